@@ -2,19 +2,31 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/jmoiron/sqlx"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/api"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/miniapp"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/monitoring"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/web"
 	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/telegram"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/Nemizar/coin_tamer_bot/cmd"
 	"github.com/Nemizar/coin_tamer_bot/configs"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
 )
 
 const (
@@ -23,6 +35,7 @@ const (
 	setConnMaxLifetime = 10 * time.Minute
 	setConnMaxIdleTime = 10 * time.Minute
 	shutdownTimeout    = 30 * time.Second
+	readHeaderTimeout  = 10 * time.Second
 )
 
 func main() {
@@ -34,7 +47,7 @@ func main() {
 
 	logger := compositionRoot.Logger()
 
-	logger.Info("bot starting", "env", cfg.ENV)
+	logger.Info("bot starting", "env", cfg.ENV, "update_mode", cfg.UpdateMode)
 
 	ctx, stop := signal.NotifyContext(
 		context.Background(),
@@ -43,15 +56,109 @@ func main() {
 	)
 	defer stop()
 
-	errCh := make(chan error, 1)
+	bot, err := newBot(compositionRoot, cfg)
+	if err != nil {
+		logger.Error("bot stopped with error", "err", err)
+
+		return
+	}
+
+	errCh := make(chan error, 4)
 	done := make(chan struct{})
 
-	go func() {
-		defer close(done)
+	var (
+		wg            sync.WaitGroup
+		server        *http.Server
+		metricsServer *http.Server
+		webhook       *telegram.Webhook
+	)
+
+	if cfg.MetricsEnabled {
+		metricsServer, err = startHTTPServer(cfg.MetricsListenAddr, newMonitoring(compositionRoot, cfg, bot), logger, errCh)
+		if err != nil {
+			logger.Error("bot stopped with error", "err", err)
+
+			return
+		}
+	}
+
+	mux := http.NewServeMux()
+
+	if cfg.IsWebhookMode() {
+		webhook, err = mountWebhook(mux, cfg, logger)
+		if err != nil {
+			logger.Error("bot stopped with error", "err", err)
+
+			return
+		}
+	}
+
+	if cfg.APIEnabled {
+		mux.Handle(api.BasePath, compositionRoot.NewAPI())
+		logger.Info("http api enabled", "path", api.BasePath)
+	}
+
+	if cfg.WebEnabled {
+		mux.Handle(web.BasePath, compositionRoot.NewDashboard())
+		logger.Info("web dashboard enabled", "path", web.BasePath)
+	}
+
+	if cfg.MiniAppEnabled {
+		mux.Handle(miniapp.BasePath, compositionRoot.NewMiniApp())
+		logger.Info("mini app enabled", "path", miniapp.BasePath)
 
-		if err := startBot(ctx, compositionRoot, cfg); err != nil {
+		// Без кнопки меню приложение все равно открывается по прямой ссылке, поэтому бот продолжает работу.
+		if err := bot.SetMiniAppMenuButton(cfg.MiniAppURL); err != nil {
+			logger.Error("set mini app menu button failed", "err", err)
+		}
+	}
+
+	if cfg.IsHTTPServerEnabled() {
+		server, err = startHTTPServer(cfg.HTTPListenAddr, mux, logger, errCh)
+		if err != nil {
+			logger.Error("bot stopped with error", "err", err)
+
+			return
+		}
+	}
+
+	var updates tgbotapi.UpdatesChannel
+
+	if cfg.IsWebhookMode() {
+		// Адрес уже слушается, поэтому Telegram не получит ошибок на первых запросах.
+		if err := bot.SetWebhook(cfg.WebhookURL, cfg.WebhookSecretToken); err != nil {
+			_ = server.Close()
+			logger.Error("bot stopped with error", "err", fmt.Errorf("set webhook: %w", err))
+
+			return
+		}
+
+		updates = webhook.Updates()
+	} else {
+		updates = bot.PollUpdates()
+	}
+
+	dispatcher := compositionRoot.NewUpdateDispatcher(bot)
+
+	wg.Go(func() {
+		dispatcher.Run(ctx, updates)
+	})
+
+	wg.Go(func() {
+		if err := startScheduler(ctx, compositionRoot, bot); err != nil {
 			errCh <- err
 		}
+	})
+
+	wg.Go(func() {
+		if err := compositionRoot.NewOutboxRelay().Run(ctx); err != nil {
+			errCh <- fmt.Errorf("run outbox relay: %w", err)
+		}
+	})
+
+	go func() {
+		wg.Wait()
+		close(done)
 	}()
 
 	select {
@@ -61,37 +168,167 @@ func main() {
 		logger.Error("bot stopped with error", "err", err)
 	}
 
-	shutdownTimer := time.NewTimer(shutdownTimeout)
-	defer shutdownTimer.Stop()
+	// Останавливаем оставшиеся компоненты, если один из них завершился с ошибкой.
+	// Диспетчер продолжает читать обновления, пока источник не закроет канал.
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if !cfg.IsWebhookMode() {
+		bot.StopPolling()
+	}
+
+	// HTTP-сервер дожидается уже принятых запросов в пределах того же времени на остановку,
+	// в том числе запросов webhook, которые передают обновления диспетчеру.
+	if server != nil {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error("http server shutdown failed", "err", err)
+		}
+	}
+
+	if webhook != nil {
+		webhook.Close()
+	}
+
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("metrics server shutdown failed", "err", err)
+		}
+	}
 
 	select {
 	case <-done:
 		logger.Info("bot stopped gracefully")
-	case <-shutdownTimer.C:
+	case <-shutdownCtx.Done():
 		logger.Error("shutdown timeout exceeded")
 	}
 }
 
-func startBot(
-	ctx context.Context,
-	compositionRoot *cmd.CompositionRoot,
-	cfg configs.Config,
-) error {
+// mountWebhook подключает к mux обработчик обновлений Telegram по пути из WEBHOOK_URL.
+func mountWebhook(mux *http.ServeMux, cfg configs.Config, logger ports.Logger) (*telegram.Webhook, error) {
+	webhook, err := telegram.NewWebhook(logger, cfg.WebhookSecretToken)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	webhookURL, err := url.Parse(cfg.WebhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook url: %w", err)
+	}
+
+	pattern := webhookURL.Path
+	if pattern == "" {
+		pattern = "/"
+	}
+
+	mux.Handle(pattern, webhook)
+	logger.Info("webhook mounted", "path", pattern)
+
+	return webhook, nil
+}
+
+// startHTTPServer начинает обслуживать handler по адресу addr.
+// Адрес слушается до возврата, поэтому после него можно регистрировать webhook.
+// Ошибка сервера после запуска передается в errCh.
+func startHTTPServer(
+	addr string,
+	handler http.Handler,
+	logger ports.Logger,
+	errCh chan<- error,
+) (*http.Server, error) {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	ln, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen http: %w", err)
+	}
+
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("serve http: %w", err)
+		}
+	}()
+
+	logger.Info("http server started", "addr", addr)
+
+	return server, nil
+}
+
+func newBot(compositionRoot *cmd.CompositionRoot, cfg configs.Config) (*telegram.Bot, error) {
 	bot, err := telegram.NewBot(
 		compositionRoot.Logger(),
+		compositionRoot.Metrics(),
 		cfg.TelegramBotToken,
 		cfg.AllowedChatIDs,
+		webBaseURL(cfg),
 		compositionRoot.NewUserRegistrationCommandHandler(),
 		compositionRoot.NewCreateDefaultCategoryCommandHandler(),
 		compositionRoot.NewCreateTransactionCommandHandler(),
+		compositionRoot.NewUpdateSettingsCommandHandler(),
+		compositionRoot.NewImportTransactionsCommandHandler(),
+		compositionRoot.NewRestoreBackupCommandHandler(),
+		compositionRoot.NewAttachFileCommandHandler(),
+		compositionRoot.NewUpdateTransactionCommandHandler(),
+		compositionRoot.NewDeleteTransactionCommandHandler(),
+		compositionRoot.NewSplitTransactionCommandHandler(),
+		compositionRoot.NewCreateRefundCommandHandler(),
+		compositionRoot.NewIssueAPITokenCommandHandler(),
+		compositionRoot.NewRevokeAPITokenCommandHandler(),
+		compositionRoot.NewIssueWebLoginCommandHandler(),
 		compositionRoot.NewGetCategoriesByTypeQueryHandler(),
 		compositionRoot.NewGetUserQueryHandler(),
+		compositionRoot.NewGetUserSettingsQueryHandler(),
+		compositionRoot.NewExportTransactionsQueryHandler(),
+		compositionRoot.NewPrepareStatementImportQueryHandler(),
+		compositionRoot.NewExportBackupQueryHandler(),
+		compositionRoot.NewReadBackupQueryHandler(),
+		compositionRoot.NewReadReceiptQueryHandler(),
+		compositionRoot.NewFindReturnedPurchaseQueryHandler(),
+		compositionRoot.NewGetTransactionDetailsQueryHandler(),
+		compositionRoot.NewGetTransactionHistoryQueryHandler(),
+		compositionRoot.NewSearchTransactionsQueryHandler(),
+		compositionRoot.NewGetUserTagsQueryHandler(),
+		compositionRoot.NewGetTagSummaryQueryHandler(),
+		compositionRoot.NewGetUserAPITokensQueryHandler(),
 	)
 	if err != nil {
-		return fmt.Errorf("create bot: %w", err)
+		return nil, fmt.Errorf("create bot: %w", err)
 	}
 
-	bot.HandleUpdates(ctx)
+	return bot, nil
+}
+
+// newMonitoring создает обработчик метрик и проверок состояния. В режиме long polling
+// готовность зависит еще и от того, получает ли бот обновления.
+func newMonitoring(compositionRoot *cmd.CompositionRoot, cfg configs.Config, bot *telegram.Bot) http.Handler {
+	checks := map[string]monitoring.Check{}
+	if !cfg.IsWebhookMode() {
+		checks["polling"] = bot.CheckPolling
+	}
+
+	return compositionRoot.NewMonitoring(checks)
+}
+
+// webBaseURL возвращает адрес веб-панели для ссылок входа или пустую строку, если панель выключена.
+func webBaseURL(cfg configs.Config) string {
+	if !cfg.WebEnabled {
+		return ""
+	}
+
+	return cfg.WebBaseURL
+}
+
+func startScheduler(ctx context.Context, compositionRoot *cmd.CompositionRoot, notifier ports.Notifier) error {
+	s := compositionRoot.NewScheduler(notifier)
+
+	if err := s.Run(ctx); err != nil {
+		return fmt.Errorf("run scheduler: %w", err)
+	}
 
 	return nil
 }
@@ -8,10 +8,12 @@ func (cr *CompositionRoot) RegisterCloser(c Closer) {
 	cr.closers = append(cr.closers, c)
 }
 
+// CloseAll закрывает ресурсы в порядке, обратном регистрации,
+// чтобы зависимые ресурсы освобождались раньше тех, от которых они зависят.
 func (cr *CompositionRoot) CloseAll() {
-	for _, closer := range cr.closers {
-		if err := closer.Close(); err != nil {
-			cr.logger.Error("error closing resource: %v", err)
+	for i := len(cr.closers) - 1; i >= 0; i-- {
+		if err := cr.closers[i].Close(); err != nil {
+			cr.logger.Error("error closing resource", "err", err)
 		}
 	}
 }
@@ -3,11 +3,36 @@ package cmd
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/api"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/miniapp"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/monitoring"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/web"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/relay"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/scheduler"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/telegram"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/backup/jsonbackup"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/blobstore/fsblob"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/events/jsonevents"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/csvexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/ofxexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/qifexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/xlsxexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer/csvimporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer/ofximporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer/qifimporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/metrics"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/qrdecoder"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
 
 	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/sl"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
@@ -19,18 +44,28 @@ import (
 	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
 )
 
+// schedulerLeaderLockKey идентификатор advisory-блокировки Postgres для выбора ведущего планировщика.
+const schedulerLeaderLockKey int64 = 7_301_026
+
 type CompositionRoot struct {
 	config  configs.Config
 	db      *sqlx.DB
 	logger  ports.Logger
+	metrics *metrics.Metrics
 	closers []Closer
 }
 
 func NewCompositionRoot(config configs.Config, db *sqlx.DB) *CompositionRoot {
+	m, err := metrics.New(db.DB)
+	if err != nil {
+		panic(fmt.Sprintf("can not create Metrics: %v", err))
+	}
+
 	cr := &CompositionRoot{
 		config:  config,
 		db:      db,
 		logger:  setupLogger(config),
+		metrics: m,
 		closers: make([]Closer, 0),
 	}
 
@@ -39,17 +74,8 @@ func NewCompositionRoot(config configs.Config, db *sqlx.DB) *CompositionRoot {
 	return cr
 }
 
-func (cr *CompositionRoot) NewUnitOfWork() ports.UnitOfWork {
-	unitOfWork, err := postgres.NewUnitOfWork(cr.db, cr.NewMediatrWithSubscriptions(), cr.Logger())
-	if err != nil {
-		panic(fmt.Sprintf("cannot create UnitOfWork: %v", err))
-	}
-
-	return unitOfWork
-}
-
 func (cr *CompositionRoot) NewUnitOfWorkFactory() ports.UnitOfWorkFactory {
-	unitOfWorkFactory, err := postgres.NewUnitOfWorkFactory(cr.db, cr.NewMediatrWithSubscriptions(), cr.Logger())
+	unitOfWorkFactory, err := postgres.NewUnitOfWorkFactory(cr.db, cr.NewEventCodec(), cr.Logger())
 	if err != nil {
 		panic(fmt.Sprintf("cannot create UnitOfWorkFactory: %v", err))
 	}
@@ -58,7 +84,7 @@ func (cr *CompositionRoot) NewUnitOfWorkFactory() ports.UnitOfWorkFactory {
 }
 
 func (cr *CompositionRoot) NewUserRegistrationCommandHandler() commands.UserRegistrationCommandHandler {
-	handler, err := commands.NewUserRegistrationCommandHandler(cr.logger, cr.NewUnitOfWork())
+	handler, err := commands.NewUserRegistrationCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
 	if err != nil {
 		panic(fmt.Sprintf("cannot create UserRegistrationCommandHandler: %v", err))
 	}
@@ -67,7 +93,7 @@ func (cr *CompositionRoot) NewUserRegistrationCommandHandler() commands.UserRegi
 }
 
 func (cr *CompositionRoot) NewCreateDefaultCategoryCommandHandler() commands.CreateDefaultCategoryCommandHandler {
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(cr.logger, cr.NewUnitOfWork())
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
 	if err != nil {
 		panic(fmt.Sprintf("can not create CreateDefaultCategoryCommandHandler: %v", err))
 	}
@@ -75,8 +101,26 @@ func (cr *CompositionRoot) NewCreateDefaultCategoryCommandHandler() commands.Cre
 	return handler
 }
 
+func (cr *CompositionRoot) NewCreateCategoryCommandHandler() commands.CreateCategoryCommandHandler {
+	handler, err := commands.NewCreateCategoryCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create CreateCategoryCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewRenameCategoryCommandHandler() commands.RenameCategoryCommandHandler {
+	handler, err := commands.NewRenameCategoryCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create RenameCategoryCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
 func (cr *CompositionRoot) NewCreateTransactionCommandHandler() commands.CreateTransactionCommandHandler {
-	handler, err := commands.NewCreateTransactionCommandHandler(cr.logger, cr.NewUnitOfWork())
+	handler, err := commands.NewCreateTransactionCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
 	if err != nil {
 		panic(fmt.Sprintf("can not create CreateTransactionCommandHandler: %v", err))
 	}
@@ -84,8 +128,157 @@ func (cr *CompositionRoot) NewCreateTransactionCommandHandler() commands.CreateT
 	return handler
 }
 
+func (cr *CompositionRoot) NewUpdateSettingsCommandHandler() commands.UpdateSettingsCommandHandler {
+	handler, err := commands.NewUpdateSettingsCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create UpdateSettingsCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewImportTransactionsCommandHandler() commands.ImportTransactionsCommandHandler {
+	handler, err := commands.NewImportTransactionsCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create ImportTransactionsCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewRestoreBackupCommandHandler() commands.RestoreBackupCommandHandler {
+	handler, err := commands.NewRestoreBackupCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create RestoreBackupCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewAttachFileCommandHandler() commands.AttachFileCommandHandler {
+	handler, err := commands.NewAttachFileCommandHandler(cr.logger, cr.NewUnitOfWorkFactory(), cr.NewBlobStore())
+	if err != nil {
+		panic(fmt.Sprintf("can not create AttachFileCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewUpdateTransactionCommandHandler() commands.UpdateTransactionCommandHandler {
+	handler, err := commands.NewUpdateTransactionCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create UpdateTransactionCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewDeleteTransactionCommandHandler() commands.DeleteTransactionCommandHandler {
+	handler, err := commands.NewDeleteTransactionCommandHandler(cr.logger, cr.NewUnitOfWorkFactory(), cr.NewBlobStore())
+	if err != nil {
+		panic(fmt.Sprintf("can not create DeleteTransactionCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewSplitTransactionCommandHandler() commands.SplitTransactionCommandHandler {
+	handler, err := commands.NewSplitTransactionCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create SplitTransactionCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewCreateRefundCommandHandler() commands.CreateRefundCommandHandler {
+	handler, err := commands.NewCreateRefundCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create CreateRefundCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewIssueAPITokenCommandHandler() commands.IssueAPITokenCommandHandler {
+	handler, err := commands.NewIssueAPITokenCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create IssueAPITokenCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewRevokeAPITokenCommandHandler() commands.RevokeAPITokenCommandHandler {
+	handler, err := commands.NewRevokeAPITokenCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create RevokeAPITokenCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewIssueWebLoginCommandHandler() commands.IssueWebLoginCommandHandler {
+	handler, err := commands.NewIssueWebLoginCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create IssueWebLoginCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewStartWebSessionCommandHandler() commands.StartWebSessionCommandHandler {
+	handler, err := commands.NewStartWebSessionCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create StartWebSessionCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewEndWebSessionCommandHandler() commands.EndWebSessionCommandHandler {
+	handler, err := commands.NewEndWebSessionCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create EndWebSessionCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+// NewBlobStore возвращает хранилище копий вложений или nil, если каталог для копий не задан.
+func (cr *CompositionRoot) NewBlobStore() ports.BlobStore {
+	if cr.config.AttachmentsDir == "" {
+		return nil
+	}
+
+	store, err := fsblob.NewStore(cr.config.AttachmentsDir)
+	if err != nil {
+		panic(fmt.Sprintf("can not create BlobStore: %v", err))
+	}
+
+	return store
+}
+
+func (cr *CompositionRoot) NewSendDigestsCommandHandler(notifier ports.Notifier) commands.SendDigestsCommandHandler {
+	handler, err := commands.NewSendDigestsCommandHandler(cr.logger, cr.NewUnitOfWorkFactory(), notifier)
+	if err != nil {
+		panic(fmt.Sprintf("can not create SendDigestsCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewSendRemindersCommandHandler(notifier ports.Notifier) commands.SendRemindersCommandHandler {
+	handler, err := commands.NewSendRemindersCommandHandler(cr.logger, cr.NewUnitOfWorkFactory(), notifier)
+	if err != nil {
+		panic(fmt.Sprintf("can not create SendRemindersCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
 func (cr *CompositionRoot) NewGetUserQueryHandler() queries.GetUserQueryHandler {
-	handler, err := queries.NewGetUserQueryHandler(cr.NewUnitOfWork())
+	handler, err := queries.NewGetUserQueryHandler(cr.NewUnitOfWorkFactory())
 	if err != nil {
 		panic(fmt.Sprintf("can not create UserQueryHandler: %v", err))
 	}
@@ -94,7 +287,7 @@ func (cr *CompositionRoot) NewGetUserQueryHandler() queries.GetUserQueryHandler
 }
 
 func (cr *CompositionRoot) NewGetCategoriesByTypeQueryHandler() queries.GetUserCategoriesByTypeQueryHandler {
-	handler, err := queries.NewGetUserCategoriesByTypeQueryHandler(cr.NewUnitOfWork())
+	handler, err := queries.NewGetUserCategoriesByTypeQueryHandler(cr.NewUnitOfWorkFactory())
 	if err != nil {
 		panic(fmt.Sprintf("can not create UserCategoriesByTypeQueryHandler: %v", err))
 	}
@@ -102,8 +295,386 @@ func (cr *CompositionRoot) NewGetCategoriesByTypeQueryHandler() queries.GetUserC
 	return handler
 }
 
+func (cr *CompositionRoot) NewGetUserCategoriesQueryHandler() queries.GetUserCategoriesQueryHandler {
+	handler, err := queries.NewGetUserCategoriesQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetUserCategoriesQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewGetUserSettingsQueryHandler() queries.GetUserSettingsQueryHandler {
+	handler, err := queries.NewGetUserSettingsQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetUserSettingsQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewExportTransactionsQueryHandler() queries.ExportTransactionsQueryHandler {
+	handler, err := queries.NewExportTransactionsQueryHandler(
+		cr.NewUnitOfWorkFactory(),
+		csvexporter.NewExporter(),
+		xlsxexporter.NewExporter(),
+		ofxexporter.NewExporter(),
+		qifexporter.NewExporter(),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create ExportTransactionsQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewPrepareStatementImportQueryHandler() queries.PrepareStatementImportQueryHandler {
+	handler, err := queries.NewPrepareStatementImportQueryHandler(
+		cr.NewUnitOfWorkFactory(),
+		csvimporter.NewReader(),
+		ofximporter.NewReader(),
+		qifimporter.NewReader(),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create PrepareStatementImportQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewExportBackupQueryHandler() queries.ExportBackupQueryHandler {
+	handler, err := queries.NewExportBackupQueryHandler(cr.NewUnitOfWorkFactory(), jsonbackup.NewCodec())
+	if err != nil {
+		panic(fmt.Sprintf("can not create ExportBackupQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewReadBackupQueryHandler() queries.ReadBackupQueryHandler {
+	handler, err := queries.NewReadBackupQueryHandler(cr.NewUnitOfWorkFactory(), jsonbackup.NewCodec())
+	if err != nil {
+		panic(fmt.Sprintf("can not create ReadBackupQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewGetTransactionDetailsQueryHandler() queries.GetTransactionDetailsQueryHandler {
+	handler, err := queries.NewGetTransactionDetailsQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetTransactionDetailsQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewGetTransactionHistoryQueryHandler() queries.GetTransactionHistoryQueryHandler {
+	handler, err := queries.NewGetTransactionHistoryQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetTransactionHistoryQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewSearchTransactionsQueryHandler() queries.SearchTransactionsQueryHandler {
+	handler, err := queries.NewSearchTransactionsQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create SearchTransactionsQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewGetUserTagsQueryHandler() queries.GetUserTagsQueryHandler {
+	handler, err := queries.NewGetUserTagsQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetUserTagsQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewGetTagSummaryQueryHandler() queries.GetTagSummaryQueryHandler {
+	handler, err := queries.NewGetTagSummaryQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetTagSummaryQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewGetSummaryQueryHandler() queries.GetSummaryQueryHandler {
+	handler, err := queries.NewGetSummaryQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetSummaryQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewGetUserAPITokensQueryHandler() queries.GetUserAPITokensQueryHandler {
+	handler, err := queries.NewGetUserAPITokensQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create GetUserAPITokensQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewAuthenticateAPITokenQueryHandler() queries.AuthenticateAPITokenQueryHandler {
+	handler, err := queries.NewAuthenticateAPITokenQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create AuthenticateAPITokenQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewAuthenticateWebSessionQueryHandler() queries.AuthenticateWebSessionQueryHandler {
+	handler, err := queries.NewAuthenticateWebSessionQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create AuthenticateWebSessionQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewReadReceiptQueryHandler() queries.ReadReceiptQueryHandler {
+	handler, err := queries.NewReadReceiptQueryHandler(cr.NewUnitOfWorkFactory(), qrdecoder.NewDecoder())
+	if err != nil {
+		panic(fmt.Sprintf("can not create ReadReceiptQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewFindReturnedPurchaseQueryHandler() queries.FindReturnedPurchaseQueryHandler {
+	handler, err := queries.NewFindReturnedPurchaseQueryHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create FindReturnedPurchaseQueryHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewUpdateDispatcher(handler telegram.UpdateHandler) *telegram.Dispatcher {
+	d, err := telegram.NewDispatcher(
+		cr.logger,
+		handler,
+		telegram.DispatcherOptions{
+			Workers:   cr.config.UpdateWorkers,
+			QueueSize: cr.config.UpdateQueueSize,
+		},
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create Dispatcher: %v", err))
+	}
+
+	if err := cr.metrics.RegisterUpdateQueue(d.QueueDepth, d.InFlight); err != nil {
+		panic(fmt.Sprintf("can not register update queue metrics: %v", err))
+	}
+
+	return d
+}
+
+func (cr *CompositionRoot) NewAPI() *api.API {
+	a, err := api.NewAPI(
+		cr.logger,
+		cr.NewAuthenticateAPITokenQueryHandler(),
+		cr.NewCreateDefaultCategoryCommandHandler(),
+		cr.NewCreateTransactionCommandHandler(),
+		cr.NewDeleteTransactionCommandHandler(),
+		cr.NewGetUserSettingsQueryHandler(),
+		cr.NewGetCategoriesByTypeQueryHandler(),
+		cr.NewGetTransactionDetailsQueryHandler(),
+		cr.NewGetTransactionHistoryQueryHandler(),
+		cr.NewGetSummaryQueryHandler(),
+		cr.NewGetTagSummaryQueryHandler(),
+		cr.NewExportTransactionsQueryHandler(),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create API: %v", err))
+	}
+
+	return a
+}
+
+func (cr *CompositionRoot) NewDashboard() *web.Dashboard {
+	d, err := web.NewDashboard(
+		cr.logger,
+		strings.HasPrefix(cr.config.WebBaseURL, "https://"),
+		cr.NewStartWebSessionCommandHandler(),
+		cr.NewEndWebSessionCommandHandler(),
+		cr.NewAuthenticateWebSessionQueryHandler(),
+		cr.NewGetUserSettingsQueryHandler(),
+		cr.NewGetUserCategoriesQueryHandler(),
+		cr.NewGetSummaryQueryHandler(),
+		cr.NewGetTransactionHistoryQueryHandler(),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create Dashboard: %v", err))
+	}
+
+	return d
+}
+
+func (cr *CompositionRoot) NewMiniApp() *miniapp.App {
+	app, err := miniapp.NewApp(
+		cr.logger,
+		cr.config.TelegramBotToken,
+		cr.NewCreateTransactionCommandHandler(),
+		cr.NewCreateCategoryCommandHandler(),
+		cr.NewRenameCategoryCommandHandler(),
+		cr.NewGetUserQueryHandler(),
+		cr.NewGetUserSettingsQueryHandler(),
+		cr.NewGetUserCategoriesQueryHandler(),
+		cr.NewGetTransactionHistoryQueryHandler(),
+		cr.NewGetSummaryQueryHandler(),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create MiniApp: %v", err))
+	}
+
+	return app
+}
+
+// NewMonitoring создает обработчик метрик и проверок состояния. К проверке готовности
+// всегда добавляется доступность базы данных.
+func (cr *CompositionRoot) NewMonitoring(checks map[string]monitoring.Check) *monitoring.Handler {
+	all := map[string]monitoring.Check{"db": cr.db.PingContext}
+	maps.Copy(all, checks)
+
+	h, err := monitoring.NewHandler(cr.logger, cr.metrics.Handler(), all)
+	if err != nil {
+		panic(fmt.Sprintf("can not create Monitoring: %v", err))
+	}
+
+	return h
+}
+
+func (cr *CompositionRoot) NewScheduler(notifier ports.Notifier) *scheduler.Scheduler {
+	elector, err := postgres.NewAdvisoryLockLeaderElector(cr.db, schedulerLeaderLockKey, cr.logger)
+	if err != nil {
+		panic(fmt.Sprintf("can not create LeaderElector: %v", err))
+	}
+
+	cr.RegisterCloser(elector)
+
+	workerID := cr.config.SchedulerWorkerID
+	if workerID == "" {
+		workerID, err = os.Hostname()
+		if err != nil {
+			panic(fmt.Sprintf("can not resolve scheduler worker id: %v", err))
+		}
+	}
+
+	s, err := scheduler.NewScheduler(
+		cr.logger,
+		cr.NewUnitOfWorkFactory(),
+		elector,
+		scheduler.Options{
+			WorkerID:     workerID,
+			Workers:      cr.config.SchedulerWorkers,
+			PollInterval: cr.config.SchedulerPollInterval,
+			JobTimeout:   cr.config.SchedulerJobTimeout,
+		},
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create Scheduler: %v", err))
+	}
+
+	s.Every(
+		scheduler.KindSendDigests,
+		scheduler.SendDigestsInterval,
+		scheduler.NewSendDigestsJob(cr.NewSendDigestsCommandHandler(notifier)),
+	)
+	s.Every(
+		scheduler.KindSendReminders,
+		scheduler.SendRemindersInterval,
+		scheduler.NewSendRemindersJob(cr.NewSendRemindersCommandHandler(notifier)),
+	)
+	s.Every(
+		scheduler.KindPurgeOutbox,
+		scheduler.PurgeOutboxInterval,
+		scheduler.NewPurgeOutboxJob(
+			cr.NewPurgeOutboxCommandHandler(),
+			cr.config.OutboxDeliveredRetention,
+			cr.config.OutboxDeadRetention,
+		),
+	)
+
+	return s
+}
+
+// NewEventCodec создает кодек событий outbox. Каждое новое доменное событие нужно зарегистрировать здесь,
+// иначе транзакция, породившая его, не будет зафиксирована.
+func (cr *CompositionRoot) NewEventCodec() ports.EventCodec {
+	codec, err := jsonevents.NewCodec(
+		user.UserRegistered{},
+		category.CategoryCreated{},
+		category.CategoryRenamed{},
+		transaction.TransactionCreated{},
+		refund.RefundCreated{},
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create EventCodec: %v", err))
+	}
+
+	return codec
+}
+
+func (cr *CompositionRoot) NewRelayOutboxCommandHandler() commands.RelayOutboxCommandHandler {
+	handler, err := commands.NewRelayOutboxCommandHandler(
+		cr.logger,
+		cr.NewUnitOfWorkFactory(),
+		cr.NewEventCodec(),
+		cr.NewMediatrWithSubscriptions(),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("can not create RelayOutboxCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewPurgeOutboxCommandHandler() commands.PurgeOutboxCommandHandler {
+	handler, err := commands.NewPurgeOutboxCommandHandler(cr.logger, cr.NewUnitOfWorkFactory())
+	if err != nil {
+		panic(fmt.Sprintf("can not create PurgeOutboxCommandHandler: %v", err))
+	}
+
+	return handler
+}
+
+func (cr *CompositionRoot) NewOutboxRelay() *relay.Relay {
+	r, err := relay.NewRelay(cr.logger, cr.NewRelayOutboxCommandHandler(), relay.Options{
+		PollInterval: cr.config.OutboxPollInterval,
+		BatchSize:    cr.config.OutboxBatchSize,
+		LockTimeout:  cr.config.OutboxLockTimeout,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("can not create OutboxRelay: %v", err))
+	}
+
+	return r
+}
+
 func (cr *CompositionRoot) NewMediatrWithSubscriptions() ddd.Mediatr {
-	mediatr := ddd.NewMediatr()
+	policy, err := ddd.ParseErrorPolicy(cr.config.EventErrorPolicy)
+	if err != nil {
+		panic(fmt.Sprintf("can not create Mediatr: %v", err))
+	}
+
+	mediatr, err := ddd.NewMediatrWithOptions(ddd.MediatrOptions{
+		ErrorPolicy: policy,
+		Workers:     cr.config.EventHandlerWorkers,
+		Logger:      cr.logger,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("can not create Mediatr: %v", err))
+	}
 
 	return mediatr
 }
@@ -112,6 +683,10 @@ func (cr *CompositionRoot) Logger() ports.Logger {
 	return cr.logger
 }
 
+func (cr *CompositionRoot) Metrics() ports.Metrics {
+	return cr.metrics
+}
+
 func setupLogger(c configs.Config) ports.Logger {
 	var (
 		slogger *slog.Logger
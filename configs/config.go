@@ -1,6 +1,19 @@
 package configs
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+var webhookSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// Способы получения обновлений Telegram.
+const (
+	UpdateModePolling = "polling"
+	UpdateModeWebhook = "webhook"
+)
 
 type Config struct {
 	ENV string `envconfig:"ENV" default:"dev"`
@@ -15,6 +28,74 @@ type Config struct {
 	TelegramBotToken string `envconfig:"TELEGRAM_BOT_TOKEN"`
 
 	AllowedChatIDs []int64 `envconfig:"ALLOWED_CHAT_IDS"`
+
+	// UpdateMode способ получения обновлений: long polling или webhook за обратным прокси.
+	UpdateMode string `envconfig:"UPDATE_MODE" default:"polling"`
+
+	// UpdateWorkers количество одновременно обрабатываемых обновлений. Обновления одного чата
+	// всегда обрабатываются по порядку.
+	UpdateWorkers int `envconfig:"UPDATE_WORKERS" default:"4"`
+
+	// UpdateQueueSize размер очереди обновлений каждого обработчика.
+	UpdateQueueSize int `envconfig:"UPDATE_QUEUE_SIZE" default:"100"`
+
+	// WebhookURL публичный адрес, на который Telegram отправляет обновления в режиме webhook.
+	WebhookURL string `envconfig:"WEBHOOK_URL"`
+
+	// HTTPListenAddr адрес HTTP-сервера, на котором бот принимает обновления от обратного прокси
+	// в режиме webhook, запросы к HTTP API, к веб-панели и к мини-приложению.
+	HTTPListenAddr string `envconfig:"HTTP_LISTEN_ADDR" default:":8080"`
+
+	// WebhookSecretToken секрет, который Telegram передает в заголовке X-Telegram-Bot-Api-Secret-Token.
+	// Допустимы латинские буквы, цифры, _ и -, не больше 256 символов.
+	WebhookSecretToken string `envconfig:"WEBHOOK_SECRET_TOKEN"`
+
+	// APIEnabled включает HTTP API по адресу /api/v1/. Токены доступа пользователи выпускают командой /token.
+	APIEnabled bool `envconfig:"API_ENABLED" default:"false"`
+
+	// WebEnabled включает веб-панель по адресу /web/. Ссылку для входа пользователи получают командой /web.
+	WebEnabled bool `envconfig:"WEB_ENABLED" default:"false"`
+
+	// WebBaseURL публичный адрес веб-панели, например https://bot.example.com/web/. Из него
+	// собираются ссылки для входа. Если адрес https, cookie сеанса передается только по HTTPS.
+	WebBaseURL string `envconfig:"WEB_BASE_URL"`
+
+	// MiniAppEnabled включает мини-приложение Telegram по адресу /app/ и кнопку меню бота, которая его открывает.
+	MiniAppEnabled bool `envconfig:"MINIAPP_ENABLED" default:"false"`
+
+	// MiniAppURL публичный адрес мини-приложения, например https://bot.example.com/app/.
+	// Telegram открывает мини-приложения только по HTTPS.
+	MiniAppURL string `envconfig:"MINIAPP_URL"`
+
+	// MetricsEnabled включает отдельный HTTP-сервер с метриками Prometheus (/metrics)
+	// и проверками /healthz и /readyz.
+	MetricsEnabled bool `envconfig:"METRICS_ENABLED" default:"false"`
+
+	// MetricsListenAddr адрес сервера метрик. Он не должен быть доступен снаружи.
+	MetricsListenAddr string `envconfig:"METRICS_LISTEN_ADDR" default:":9090"`
+
+	// AttachmentsDir каталог для копий вложений транзакций. Если не задан, копии не сохраняются.
+	AttachmentsDir string `envconfig:"ATTACHMENTS_DIR"`
+
+	SchedulerWorkerID     string        `envconfig:"SCHEDULER_WORKER_ID"`
+	SchedulerWorkers      int           `envconfig:"SCHEDULER_WORKERS" default:"1"`
+	SchedulerPollInterval time.Duration `envconfig:"SCHEDULER_POLL_INTERVAL" default:"5s"`
+	SchedulerJobTimeout   time.Duration `envconfig:"SCHEDULER_JOB_TIMEOUT" default:"20s"`
+
+	OutboxPollInterval time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+	OutboxBatchSize    int           `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+	// OutboxLockTimeout время на доставку порции событий, после которого они доставляются повторно.
+	OutboxLockTimeout time.Duration `envconfig:"OUTBOX_LOCK_TIMEOUT" default:"1m"`
+	// OutboxDeliveredRetention срок хранения доставленных событий, после которого они удаляются.
+	OutboxDeliveredRetention time.Duration `envconfig:"OUTBOX_DELIVERED_RETENTION" default:"168h"`
+	// OutboxDeadRetention срок хранения событий, которые не удалось доставить. Они нужны для разбора ошибок.
+	OutboxDeadRetention time.Duration `envconfig:"OUTBOX_DEAD_RETENTION" default:"720h"`
+
+	// EventErrorPolicy поведение при ошибках подписчиков доменных событий: fail-fast, collect-all или log-and-continue.
+	// При fail-fast и collect-all событие с ошибкой доставляется повторно всем подписчикам.
+	EventErrorPolicy string `envconfig:"EVENT_ERROR_POLICY" default:"collect-all"`
+	// EventHandlerWorkers количество подписчиков, выполняемых одновременно. Ноль — по очереди.
+	EventHandlerWorkers int `envconfig:"EVENT_HANDLER_WORKERS" default:"4"`
 }
 
 func (c Config) IsProd() bool {
@@ -25,6 +106,103 @@ func (c Config) IsDev() bool {
 	return !c.IsProd()
 }
 
+func (c Config) IsWebhookMode() bool {
+	return c.UpdateMode == UpdateModeWebhook
+}
+
+// IsHTTPServerEnabled сообщает, что боту нужен HTTP-сервер: для webhook, HTTP API, веб-панели или мини-приложения.
+func (c Config) IsHTTPServerEnabled() bool {
+	return c.IsWebhookMode() || c.APIEnabled || c.WebEnabled || c.MiniAppEnabled
+}
+
+// Validate проверяет, что заданы все настройки выбранного способа получения обновлений и HTTP-сервера.
+func (c Config) Validate() error {
+	if err := c.validateUpdateMode(); err != nil {
+		return err
+	}
+
+	if c.IsHTTPServerEnabled() && c.HTTPListenAddr == "" {
+		return fmt.Errorf("HTTP_LISTEN_ADDR is required in webhook mode, with API_ENABLED, WEB_ENABLED or MINIAPP_ENABLED")
+	}
+
+	if err := c.validateWeb(); err != nil {
+		return err
+	}
+
+	if err := c.validateMiniApp(); err != nil {
+		return err
+	}
+
+	if err := c.validateMetrics(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c Config) validateWeb() error {
+	if !c.WebEnabled {
+		return nil
+	}
+
+	u, err := url.Parse(c.WebBaseURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("WEB_BASE_URL must be an absolute http or https URL with WEB_ENABLED")
+	}
+
+	return nil
+}
+
+func (c Config) validateMiniApp() error {
+	if !c.MiniAppEnabled {
+		return nil
+	}
+
+	u, err := url.Parse(c.MiniAppURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("MINIAPP_URL must be an absolute https URL with MINIAPP_ENABLED")
+	}
+
+	return nil
+}
+
+func (c Config) validateMetrics() error {
+	if !c.MetricsEnabled {
+		return nil
+	}
+
+	if c.MetricsListenAddr == "" {
+		return fmt.Errorf("METRICS_LISTEN_ADDR is required with METRICS_ENABLED")
+	}
+
+	if c.IsHTTPServerEnabled() && c.MetricsListenAddr == c.HTTPListenAddr {
+		return fmt.Errorf("METRICS_LISTEN_ADDR must differ from HTTP_LISTEN_ADDR")
+	}
+
+	return nil
+}
+
+func (c Config) validateUpdateMode() error {
+	switch c.UpdateMode {
+	case UpdateModePolling:
+		return nil
+	case UpdateModeWebhook:
+	default:
+		return fmt.Errorf("UPDATE_MODE must be %q or %q, got %q", UpdateModePolling, UpdateModeWebhook, c.UpdateMode)
+	}
+
+	u, err := url.Parse(c.WebhookURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("WEBHOOK_URL must be an absolute https URL in webhook mode")
+	}
+
+	if !webhookSecretPattern.MatchString(c.WebhookSecretToken) {
+		return fmt.Errorf("WEBHOOK_SECRET_TOKEN must be 1-256 characters A-Z, a-z, 0-9, _ or - in webhook mode")
+	}
+
+	return nil
+}
+
 func (c Config) DBDSNString() string {
 	if c.SSLMode != "disable" {
 		c.SSLMode = "enable"
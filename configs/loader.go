@@ -22,5 +22,9 @@ func MustLoad() Config {
 		log.Fatal(err)
 	}
 
+	if err := cnf.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
 	return cnf
 }
@@ -0,0 +1,140 @@
+// Package api реализует версионированный HTTP JSON API поверх сценариев приложения.
+// Описание API в формате OpenAPI отдается по адресу /api/v1/openapi.yaml.
+package api
+
+import (
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// BasePath префикс адресов API, под которым его нужно подключать к серверу.
+const BasePath = "/api/v1/"
+
+// API обрабатывает запросы к HTTP API. Все методы, кроме описания API, требуют персональный
+// токен пользователя в заголовке Authorization: Bearer <token>. Токены выпускаются командой /token в боте.
+type API struct {
+	logger ports.Logger
+	mux    *http.ServeMux
+
+	authenticateAPITokenQueryHandler    queries.AuthenticateAPITokenQueryHandler
+	createDefaultCategoryCommandHandler commands.CreateDefaultCategoryCommandHandler
+	createTransactionCommandHandler     commands.CreateTransactionCommandHandler
+	deleteTransactionCommandHandler     commands.DeleteTransactionCommandHandler
+	getUserSettingsQueryHandler         queries.GetUserSettingsQueryHandler
+	getUserCategoriesByTypeQueryHandler queries.GetUserCategoriesByTypeQueryHandler
+	getTransactionDetailsQueryHandler   queries.GetTransactionDetailsQueryHandler
+	getTransactionHistoryQueryHandler   queries.GetTransactionHistoryQueryHandler
+	getSummaryQueryHandler              queries.GetSummaryQueryHandler
+	getTagSummaryQueryHandler           queries.GetTagSummaryQueryHandler
+	exportTransactionsQueryHandler      queries.ExportTransactionsQueryHandler
+}
+
+func NewAPI(
+	logger ports.Logger,
+	authenticateAPITokenQueryHandler queries.AuthenticateAPITokenQueryHandler,
+	createDefaultCategoryCommandHandler commands.CreateDefaultCategoryCommandHandler,
+	createTransactionCommandHandler commands.CreateTransactionCommandHandler,
+	deleteTransactionCommandHandler commands.DeleteTransactionCommandHandler,
+	getUserSettingsQueryHandler queries.GetUserSettingsQueryHandler,
+	getUserCategoriesByTypeQueryHandler queries.GetUserCategoriesByTypeQueryHandler,
+	getTransactionDetailsQueryHandler queries.GetTransactionDetailsQueryHandler,
+	getTransactionHistoryQueryHandler queries.GetTransactionHistoryQueryHandler,
+	getSummaryQueryHandler queries.GetSummaryQueryHandler,
+	getTagSummaryQueryHandler queries.GetTagSummaryQueryHandler,
+	exportTransactionsQueryHandler queries.ExportTransactionsQueryHandler,
+) (*API, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if authenticateAPITokenQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("authenticateAPITokenQueryHandler")
+	}
+
+	if createDefaultCategoryCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createDefaultCategoryCommandHandler")
+	}
+
+	if createTransactionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createTransactionCommandHandler")
+	}
+
+	if deleteTransactionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("deleteTransactionCommandHandler")
+	}
+
+	if getUserSettingsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserSettingsQueryHandler")
+	}
+
+	if getUserCategoriesByTypeQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserCategoriesByTypeQueryHandler")
+	}
+
+	if getTransactionDetailsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTransactionDetailsQueryHandler")
+	}
+
+	if getTransactionHistoryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTransactionHistoryQueryHandler")
+	}
+
+	if getSummaryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getSummaryQueryHandler")
+	}
+
+	if getTagSummaryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTagSummaryQueryHandler")
+	}
+
+	if exportTransactionsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("exportTransactionsQueryHandler")
+	}
+
+	a := &API{
+		logger:                              logger,
+		mux:                                 http.NewServeMux(),
+		authenticateAPITokenQueryHandler:    authenticateAPITokenQueryHandler,
+		createDefaultCategoryCommandHandler: createDefaultCategoryCommandHandler,
+		createTransactionCommandHandler:     createTransactionCommandHandler,
+		deleteTransactionCommandHandler:     deleteTransactionCommandHandler,
+		getUserSettingsQueryHandler:         getUserSettingsQueryHandler,
+		getUserCategoriesByTypeQueryHandler: getUserCategoriesByTypeQueryHandler,
+		getTransactionDetailsQueryHandler:   getTransactionDetailsQueryHandler,
+		getTransactionHistoryQueryHandler:   getTransactionHistoryQueryHandler,
+		getSummaryQueryHandler:              getSummaryQueryHandler,
+		getTagSummaryQueryHandler:           getTagSummaryQueryHandler,
+		exportTransactionsQueryHandler:      exportTransactionsQueryHandler,
+	}
+
+	a.routes()
+
+	return a, nil
+}
+
+func (a *API) routes() {
+	a.mux.HandleFunc("GET "+BasePath+"openapi.yaml", serveSpec)
+
+	a.handle("GET "+BasePath+"me", apitoken.ScopeRead, a.me)
+
+	a.handle("GET "+BasePath+"categories", apitoken.ScopeRead, a.listCategories)
+	a.handle("POST "+BasePath+"categories/defaults", apitoken.ScopeWrite, a.createDefaultCategories)
+
+	a.handle("GET "+BasePath+"transactions", apitoken.ScopeRead, a.listTransactions)
+	a.handle("POST "+BasePath+"transactions", apitoken.ScopeWrite, a.createTransaction)
+	a.handle("GET "+BasePath+"transactions/{id}", apitoken.ScopeRead, a.getTransaction)
+	a.handle("DELETE "+BasePath+"transactions/{id}", apitoken.ScopeWrite, a.deleteTransaction)
+
+	a.handle("GET "+BasePath+"reports/summary", apitoken.ScopeRead, a.summary)
+	a.handle("GET "+BasePath+"reports/tags/{name}", apitoken.ScopeRead, a.tagSummary)
+	a.handle("GET "+BasePath+"reports/export", apitoken.ScopeRead, a.export)
+}
+
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mux.ServeHTTP(w, r)
+}
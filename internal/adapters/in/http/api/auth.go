@@ -0,0 +1,72 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+const bearerPrefix = "Bearer "
+
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len(bearerPrefix):])
+}
+
+// handlerFunc обрабатывает запрос владельца токена. Возвращенная ошибка превращается в ответ
+// с подходящим кодом статуса.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, u *user.User) error
+
+// handle регистрирует обработчик, доступный по токену с правом scope.
+func (a *API) handle(pattern string, scope apitoken.Scope, h handlerFunc) {
+	a.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		owner, err := a.authenticate(r)
+		if errors.Is(err, apitoken.ErrInvalidSecret) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+			a.fail(w, r, errUnauthorized)
+
+			return
+		}
+
+		if err != nil {
+			a.fail(w, r, err)
+			return
+		}
+
+		if !owner.Token.Allows(scope) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="insufficient_scope", scope="`+scope.String()+`"`)
+			a.fail(w, r, forbidden(scope))
+
+			return
+		}
+
+		if err := h(w, r, owner.User); err != nil {
+			a.fail(w, r, err)
+		}
+	})
+}
+
+// authenticate находит владельца токена из заголовка Authorization. Отсутствующий токен
+// считается недействительным.
+func (a *API) authenticate(r *http.Request) (*queries.APITokenOwner, error) {
+	secret := bearerToken(r)
+	if secret == "" {
+		return nil, apitoken.ErrInvalidSecret
+	}
+
+	query, err := queries.NewAuthenticateAPITokenQuery(secret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return a.authenticateAPITokenQueryHandler.Handle(r.Context(), query)
+}
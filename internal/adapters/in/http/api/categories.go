@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// categoryTypes типы категорий в порядке выдачи.
+var categoryTypes = []category.Type{category.TypeExpense, category.TypeIncome}
+
+// listCategories возвращает категории пользователя одного типа или, без параметра type, всех типов.
+func (a *API) listCategories(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	types := categoryTypes
+
+	if value := r.URL.Query().Get("type"); value != "" {
+		t, err := category.ParseType(value)
+		if err != nil {
+			return httpjson.BadRequest("type must be one of income, expense")
+		}
+
+		types = []category.Type{t}
+	}
+
+	resp := make([]categoryResponse, 0)
+
+	for _, t := range types {
+		categories, err := a.categories(r.Context(), u.ID(), t)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range categories {
+			resp = append(resp, newCategoryResponse(c))
+		}
+	}
+
+	httpjson.Write(w, http.StatusOK, resp)
+
+	return nil
+}
+
+// createDefaultCategories создает набор категорий по умолчанию так же, как команда
+// /create_default_categories в боте.
+func (a *API) createDefaultCategories(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	cmd, err := commands.NewCreateDefaultCategoryCommandForUser(u.ID())
+	if err != nil {
+		return err
+	}
+
+	if err := a.createDefaultCategoryCommandHandler.Handle(r.Context(), cmd); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func (a *API) categories(ctx context.Context, userID shared.ID, t category.Type) ([]*category.Category, error) {
+	return a.getUserCategoriesByTypeQueryHandler.Handle(ctx, queries.NewGetUserCategoriesByType(userID, t))
+}
+
+// ownCategory проверяет, что категория принадлежит пользователю.
+func (a *API) ownCategory(ctx context.Context, userID shared.ID, categoryID shared.ID) error {
+	for _, t := range categoryTypes {
+		categories, err := a.categories(ctx, userID, t)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range categories {
+			if c.ID() == categoryID {
+				return nil
+			}
+		}
+	}
+
+	return errs.NewObjectNotFoundError("category", categoryID.String())
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+// Суммы передаются строками, чтобы клиенты не теряли точность на числах с плавающей точкой.
+
+type userResponse struct {
+	ID        shared.ID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newUserResponse(u *user.User) userResponse {
+	return userResponse{ID: u.ID(), Name: u.Name(), CreatedAt: u.CreatedAt()}
+}
+
+type categoryResponse struct {
+	ID       shared.ID     `json:"id"`
+	Name     string        `json:"name"`
+	Type     category.Type `json:"type"`
+	ParentID *shared.ID    `json:"parent_id,omitempty"`
+}
+
+func newCategoryResponse(c *category.Category) categoryResponse {
+	resp := categoryResponse{ID: c.ID(), Name: c.Name(), Type: c.Type()}
+
+	if parentID := c.ParentID(); !parentID.IsZero() {
+		resp.ParentID = &parentID
+	}
+
+	return resp
+}
+
+type transactionResponse struct {
+	ID         shared.ID       `json:"id"`
+	CategoryID shared.ID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Note       string          `json:"note"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func newTransactionResponse(t *transaction.Transaction) transactionResponse {
+	return transactionResponse{
+		ID:         t.ID(),
+		CategoryID: t.CategoryID(),
+		Amount:     t.Amount().Value(),
+		Note:       t.Note(),
+		OccurredAt: t.OccurredAt(),
+		CreatedAt:  t.CreatedAt(),
+	}
+}
+
+type transactionLineResponse struct {
+	ID                 shared.ID       `json:"id"`
+	OccurredAt         time.Time       `json:"occurred_at"`
+	Amount             decimal.Decimal `json:"amount"`
+	CategoryType       category.Type   `json:"category_type"`
+	CategoryName       string          `json:"category_name"`
+	ParentCategoryName string          `json:"parent_category_name,omitempty"`
+	Note               string          `json:"note"`
+}
+
+func newTransactionLineResponse(l report.TransactionLine) transactionLineResponse {
+	return transactionLineResponse{
+		ID:                 l.ID(),
+		OccurredAt:         l.OccurredAt(),
+		Amount:             l.Amount(),
+		CategoryType:       l.CategoryType(),
+		CategoryName:       l.CategoryName(),
+		ParentCategoryName: l.ParentName(),
+		Note:               l.Note(),
+	}
+}
+
+type historyResponse struct {
+	Items []transactionLineResponse `json:"items"`
+
+	// NextCursor передается в параметре cursor для получения следующей страницы.
+	// Пуст на последней странице.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func newHistoryResponse(page *queries.HistoryPage) historyResponse {
+	resp := historyResponse{Items: make([]transactionLineResponse, 0, len(page.Lines))}
+
+	for _, l := range page.Lines {
+		resp.Items = append(resp.Items, newTransactionLineResponse(l))
+	}
+
+	if page.HasMore {
+		resp.NextCursor = encodeCursor(page.Next())
+	}
+
+	return resp
+}
+
+type categoryTotalResponse struct {
+	CategoryID shared.ID       `json:"category_id"`
+	Name       string          `json:"name"`
+	ParentName string          `json:"parent_name,omitempty"`
+	Type       category.Type   `json:"type"`
+	Amount     decimal.Decimal `json:"amount"`
+}
+
+func newCategoryTotalResponses(totals []report.CategoryTotal) []categoryTotalResponse {
+	resp := make([]categoryTotalResponse, 0, len(totals))
+
+	for _, t := range totals {
+		resp = append(resp, categoryTotalResponse{
+			CategoryID: t.CategoryID(),
+			Name:       t.Name(),
+			ParentName: t.ParentName(),
+			Type:       t.Type(),
+			Amount:     t.Amount(),
+		})
+	}
+
+	return resp
+}
+
+type refundResponse struct {
+	ID         shared.ID       `json:"id"`
+	CategoryID shared.ID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Note       string          `json:"note"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+type refundableResponse struct {
+	CategoryID shared.ID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+}
+
+type attachmentResponse struct {
+	ID       shared.ID       `json:"id"`
+	Kind     attachment.Kind `json:"kind"`
+	FileName string          `json:"file_name"`
+	MimeType string          `json:"mime_type"`
+	Size     int64           `json:"size"`
+}
+
+type transactionDetailsResponse struct {
+	transactionLineResponse
+
+	Parts       []categoryTotalResponse `json:"parts"`
+	Refunds     []refundResponse        `json:"refunds"`
+	Refundable  []refundableResponse    `json:"refundable"`
+	Attachments []attachmentResponse    `json:"attachments"`
+}
+
+func newTransactionDetailsResponse(d *queries.TransactionDetails) transactionDetailsResponse {
+	resp := transactionDetailsResponse{
+		transactionLineResponse: newTransactionLineResponse(d.Line),
+		Parts:                   newCategoryTotalResponses(d.Parts),
+		Refunds:                 make([]refundResponse, 0, len(d.Refunds)),
+		Refundable:              make([]refundableResponse, 0, len(d.Refundable)),
+		Attachments:             make([]attachmentResponse, 0, len(d.Attachments)),
+	}
+
+	for _, r := range d.Refunds {
+		resp.Refunds = append(resp.Refunds, newRefundResponse(r))
+	}
+
+	for categoryID, amount := range d.Refundable {
+		resp.Refundable = append(resp.Refundable, refundableResponse{CategoryID: categoryID, Amount: amount})
+	}
+
+	slices.SortFunc(resp.Refundable, func(a, b refundableResponse) int {
+		return strings.Compare(a.CategoryID.String(), b.CategoryID.String())
+	})
+
+	for _, a := range d.Attachments {
+		resp.Attachments = append(resp.Attachments, attachmentResponse{
+			ID:       a.ID(),
+			Kind:     a.Kind(),
+			FileName: a.FileName(),
+			MimeType: a.MimeType(),
+			Size:     a.Size(),
+		})
+	}
+
+	return resp
+}
+
+func newRefundResponse(r *refund.Refund) refundResponse {
+	return refundResponse{
+		ID:         r.ID(),
+		CategoryID: r.CategoryID(),
+		Amount:     r.Amount().Value(),
+		Note:       r.Note(),
+		OccurredAt: r.OccurredAt(),
+	}
+}
+
+type summaryResponse struct {
+	From         *time.Time              `json:"from,omitempty"`
+	To           *time.Time              `json:"to,omitempty"`
+	TotalIncome  decimal.Decimal         `json:"total_income"`
+	TotalExpense decimal.Decimal         `json:"total_expense"`
+	Incomes      []categoryTotalResponse `json:"incomes"`
+	Expenses     []categoryTotalResponse `json:"expenses"`
+}
+
+func newSummaryResponse(s *report.Summary) summaryResponse {
+	resp := summaryResponse{
+		TotalIncome:  s.TotalIncome(),
+		TotalExpense: s.TotalExpense(),
+		Incomes:      newCategoryTotalResponses(s.Incomes()),
+		Expenses:     newCategoryTotalResponses(s.Expenses()),
+	}
+
+	// Итоги по метке считаются за все время и не имеют границ периода.
+	if from, to := s.From(), s.To(); !from.IsZero() && !to.IsZero() {
+		resp.From, resp.To = &from, &to
+	}
+
+	return resp
+}
+
+type tagSummaryResponse struct {
+	Tag string `json:"tag"`
+
+	summaryResponse
+}
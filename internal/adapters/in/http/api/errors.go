@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+)
+
+// maxBodySize наибольший размер тела запроса.
+const maxBodySize = 1 << 20
+
+var errUnauthorized = httpjson.NewRequestError(http.StatusUnauthorized, httpjson.CodeUnauthorized, "missing or invalid bearer token")
+
+// forbidden сообщает, что токену не хватает права scope.
+func forbidden(scope apitoken.Scope) error {
+	return httpjson.NewRequestError(http.StatusForbidden, httpjson.CodeForbidden, "token lacks "+scope.String()+" scope")
+}
+
+// invalidValueErrors ошибки проверки доменных моделей, которые вызваны значениями из запроса.
+var invalidValueErrors = []error{
+	transaction.ErrInvalidAmount,
+	transaction.ErrInvalidCategoryID,
+	transaction.ErrTooLongNote,
+	tag.ErrInvalidName,
+	tag.ErrTooMany,
+	report.ErrInvalidPeriod,
+	report.ErrInvalidAmountRange,
+}
+
+// fail отвечает на запрос ошибкой err.
+func (a *API) fail(w http.ResponseWriter, r *http.Request, err error) {
+	httpjson.Fail(w, r, a.logger, "api request failed", err, invalidValueErrors)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// encodeCursor упаковывает позицию в истории в непрозрачную строку для следующего запроса.
+func encodeCursor(c report.HistoryCursor) string {
+	if c.IsZero() {
+		return ""
+	}
+
+	raw := strconv.FormatInt(c.OccurredAt().UnixNano(), 10) + "_" + c.ID().String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (report.HistoryCursor, error) {
+	if value == "" {
+		return report.HistoryCursor{}, nil
+	}
+
+	invalid := httpjson.BadRequest("cursor is invalid")
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return report.HistoryCursor{}, invalid
+	}
+
+	nanosText, idText, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return report.HistoryCursor{}, invalid
+	}
+
+	nanos, err := strconv.ParseInt(nanosText, 10, 64)
+	if err != nil {
+		return report.HistoryCursor{}, invalid
+	}
+
+	id, err := shared.NewIDFromString(idText)
+	if err != nil {
+		return report.HistoryCursor{}, invalid
+	}
+
+	return report.RestoreHistoryCursor(time.Unix(0, nanos).UTC(), id), nil
+}
+
+// location возвращает часовой пояс пользователя, в котором разбираются даты из запроса.
+func (a *API) location(ctx context.Context, userID shared.ID) (*time.Location, error) {
+	s, err := a.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Location(), nil
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+// exportContentTypes типы содержимого файлов выгрузки.
+var exportContentTypes = map[report.ExportFormat]string{
+	report.ExportFormatCsv:  "text/csv; charset=utf-8",
+	report.ExportFormatXlsx: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	report.ExportFormatOfx:  "application/x-ofx",
+	report.ExportFormatQif:  "application/qif",
+}
+
+// summary возвращает доходы и расходы по категориям за период [from, to).
+func (a *API) summary(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	from, to, err := a.period(r, u)
+	if err != nil {
+		return err
+	}
+
+	query, err := queries.NewGetSummaryQuery(u.ID(), from, to)
+	if err != nil {
+		return err
+	}
+
+	s, err := a.getSummaryQueryHandler.Handle(r.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusOK, newSummaryResponse(s))
+
+	return nil
+}
+
+// tagSummary возвращает суммы транзакций с меткой по категориям за все время.
+func (a *API) tagSummary(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	query, err := queries.NewGetTagSummaryQuery(u.ID(), r.PathValue("name"))
+	if err != nil {
+		return err
+	}
+
+	s, err := a.getTagSummaryQueryHandler.Handle(r.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusOK, tagSummaryResponse{Tag: s.Tag.Name(), summaryResponse: newSummaryResponse(s.Summary)})
+
+	return nil
+}
+
+// export отдает файл выгрузки транзакций за период [from, to). Без периода выгружаются все транзакции.
+func (a *API) export(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	format, err := report.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		return httpjson.BadRequest("format must be one of csv, xlsx, ofx, qif")
+	}
+
+	var from, to time.Time
+	if r.URL.Query().Has("from") || r.URL.Query().Has("to") {
+		if from, to, err = a.period(r, u); err != nil {
+			return err
+		}
+	}
+
+	query, err := queries.NewExportTransactionsQuery(u.ID(), from, to, format, shared.ID{})
+	if err != nil {
+		return err
+	}
+
+	// Выгрузка пишется прямо в ответ, поэтому ошибку после начала записи клиенту уже не передать:
+	// ответ обрывается, и ошибка только записывается в журнал.
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="transactions.%s"`, format))
+
+	rw := &trackingWriter{ResponseWriter: w}
+	if err := a.exportTransactionsQueryHandler.Handle(r.Context(), query, rw); err != nil {
+		if !rw.written {
+			w.Header().Del("Content-Disposition")
+
+			return err
+		}
+
+		a.logger.ErrorContext(r.Context(), "api export failed", "user_id", u.ID().String(), "err", err)
+	}
+
+	return nil
+}
+
+// period разбирает границы периода from и to. Даты без времени относятся к часовому поясу пользователя.
+func (a *API) period(r *http.Request, u *user.User) (time.Time, time.Time, error) {
+	loc, err := a.location(r.Context(), u.ID())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	from, err := httpjson.QueryTime(r, "from", loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	to, err := httpjson.QueryTime(r, "to", loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return from, to, nil
+}
+
+// trackingWriter запоминает, начата ли запись ответа.
+type trackingWriter struct {
+	http.ResponseWriter
+
+	written bool
+}
+
+func (w *trackingWriter) Write(p []byte) (int, error) {
+	w.written = true
+
+	return w.ResponseWriter.Write(p)
+}
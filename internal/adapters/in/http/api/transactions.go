@@ -0,0 +1,188 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+// defaultHistoryPageSize размер страницы истории, если клиент его не указал.
+const defaultHistoryPageSize = 20
+
+// listTransactions возвращает страницу истории транзакций, от новых к старым.
+func (a *API) listTransactions(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	filter, err := a.historyFilter(r, u)
+	if err != nil {
+		return err
+	}
+
+	after, err := decodeCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return err
+	}
+
+	limit, err := httpjson.QueryInt(r, "limit", defaultHistoryPageSize, 1, queries.MaxHistoryPageSize)
+	if err != nil {
+		return err
+	}
+
+	query, err := queries.NewGetTransactionHistoryQuery(u.ID(), filter, after, limit)
+	if err != nil {
+		return err
+	}
+
+	page, err := a.getTransactionHistoryQueryHandler.Handle(r.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusOK, newHistoryResponse(page))
+
+	return nil
+}
+
+func (a *API) historyFilter(r *http.Request, u *user.User) (report.HistoryFilter, error) {
+	var filter report.HistoryFilter
+
+	q := r.URL.Query()
+
+	if value := q.Get("type"); value != "" {
+		t, err := category.ParseType(value)
+		if err != nil {
+			return filter, httpjson.BadRequest("type must be one of income, expense")
+		}
+
+		if filter, err = filter.WithType(t); err != nil {
+			return filter, err
+		}
+	}
+
+	categoryID, err := httpjson.QueryID(r, "category_id")
+	if err != nil {
+		return filter, err
+	}
+
+	if !categoryID.IsZero() {
+		filter = filter.WithCategory(categoryID)
+	}
+
+	tagID, err := httpjson.QueryID(r, "tag_id")
+	if err != nil {
+		return filter, err
+	}
+
+	if !tagID.IsZero() {
+		filter = filter.WithTag(tagID)
+	}
+
+	if q.Has("min_amount") || q.Has("max_amount") {
+		minAmount, err := httpjson.QueryAmount(r, "min_amount")
+		if err != nil {
+			return filter, err
+		}
+
+		maxAmount, err := httpjson.QueryAmount(r, "max_amount")
+		if err != nil {
+			return filter, err
+		}
+
+		if filter, err = filter.WithAmountRange(minAmount, maxAmount); err != nil {
+			return filter, err
+		}
+	}
+
+	if q.Has("from") || q.Has("to") {
+		from, to, err := a.period(r, u)
+		if err != nil {
+			return filter, err
+		}
+
+		if filter, err = filter.WithPeriod(from, to); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+type createTransactionRequest struct {
+	CategoryID shared.ID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Note       string          `json:"note"`
+	Tags       []string        `json:"tags"`
+}
+
+func (a *API) createTransaction(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	var req createTransactionRequest
+	if err := httpjson.Decode(w, r, &req, maxBodySize); err != nil {
+		return err
+	}
+
+	amount, err := transaction.NewAmount(req.Amount)
+	if err != nil {
+		return err
+	}
+
+	if err := a.ownCategory(r.Context(), u.ID(), req.CategoryID); err != nil {
+		return err
+	}
+
+	cmd, err := commands.NewCreateTransactionCommand(u.ID(), amount, req.CategoryID, req.Note, req.Tags...)
+	if err != nil {
+		return err
+	}
+
+	t, err := a.createTransactionCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusCreated, newTransactionResponse(t))
+
+	return nil
+}
+
+func (a *API) getTransaction(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	id, err := httpjson.PathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	details, err := a.getTransactionDetailsQueryHandler.Handle(r.Context(), queries.NewGetTransactionDetailsQuery(u.ID(), id))
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusOK, newTransactionDetailsResponse(details))
+
+	return nil
+}
+
+func (a *API) deleteTransaction(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	id, err := httpjson.PathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	cmd, err := commands.NewDeleteTransactionCommand(u.ID(), id)
+	if err != nil {
+		return err
+	}
+
+	if err := a.deleteTransactionCommandHandler.Handle(r.Context(), cmd); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+func (a *API) me(w http.ResponseWriter, _ *http.Request, u *user.User) error {
+	httpjson.Write(w, http.StatusOK, newUserResponse(u))
+
+	return nil
+}
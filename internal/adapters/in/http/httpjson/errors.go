@@ -0,0 +1,91 @@
+package httpjson
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// Коды ошибок в ответах.
+const (
+	CodeBadRequest    = "bad_request"
+	CodeUnauthorized  = "unauthorized"
+	CodeForbidden     = "forbidden"
+	CodeNotFound      = "not_found"
+	CodeAlreadyExists = "already_exists"
+	CodeInvalidValue  = "invalid_value"
+	CodeInternal      = "internal"
+)
+
+// RequestError ошибка в самом запросе: его нельзя разобрать или к нему нет доступа.
+type RequestError struct {
+	status  int
+	code    string
+	message string
+}
+
+// NewRequestError создает ошибку запроса, на которую отвечают кодом статуса status.
+func NewRequestError(status int, code, message string) *RequestError {
+	return &RequestError{status: status, code: code, message: message}
+}
+
+func (e *RequestError) Error() string {
+	return e.message
+}
+
+// BadRequest сообщает, что параметр запроса не удалось разобрать.
+func BadRequest(message string) error {
+	return NewRequestError(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Fail отвечает на запрос ошибкой err. Неразобранный запрос получает 400, нарушение проверок
+// сценария или ошибка из invalidValues 422, отсутствующий объект 404, дубликат 409. Остальные
+// ошибки записываются в журнал с сообщением logMessage и возвращаются клиенту без подробностей.
+func Fail(w http.ResponseWriter, r *http.Request, logger ports.Logger, logMessage string, err error, invalidValues []error) {
+	status, code := Classify(err, invalidValues)
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		logger.ErrorContext(r.Context(), logMessage, "method", r.Method, "path", r.URL.Path, "err", err)
+		message = http.StatusText(status)
+	}
+
+	Write(w, status, errorResponse{Error: errorBody{Code: code, Message: message}})
+}
+
+// Classify возвращает код статуса и код ошибки для ответа на ошибку err. Ошибки из invalidValues
+// вызваны значениями из запроса и считаются нарушением проверок.
+func Classify(err error, invalidValues []error) (int, string) {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.status, requestErr.code
+	}
+
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return http.StatusNotFound, CodeNotFound
+	case errors.Is(err, errs.ErrEntityAlreadyExists):
+		return http.StatusConflict, CodeAlreadyExists
+	case errors.Is(err, errs.ErrValueIsInvalid), errors.Is(err, errs.ErrValueIsRequired):
+		return http.StatusUnprocessableEntity, CodeInvalidValue
+	}
+
+	for _, target := range invalidValues {
+		if errors.Is(err, target) {
+			return http.StatusUnprocessableEntity, CodeInvalidValue
+		}
+	}
+
+	return http.StatusInternalServerError, CodeInternal
+}
@@ -0,0 +1,87 @@
+package httpjson_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestClassify(t *testing.T) {
+	errDomain := errors.New("domain rule")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "request error", err: fmt.Errorf("wrapped: %w", httpjson.BadRequest("bad")), wantStatus: http.StatusBadRequest, wantCode: httpjson.CodeBadRequest},
+		{name: "not found", err: errs.NewObjectNotFoundError("id", "1"), wantStatus: http.StatusNotFound, wantCode: httpjson.CodeNotFound},
+		{name: "already exists", err: errs.NewEntityAlreadyExistsError("tag", "name", "отпуск"), wantStatus: http.StatusConflict, wantCode: httpjson.CodeAlreadyExists},
+		{name: "invalid value", err: errs.NewValueIsInvalidError("amount"), wantStatus: http.StatusUnprocessableEntity, wantCode: httpjson.CodeInvalidValue},
+		{name: "listed domain error", err: fmt.Errorf("wrapped: %w", errDomain), wantStatus: http.StatusUnprocessableEntity, wantCode: httpjson.CodeInvalidValue},
+		{name: "unknown", err: errors.New("db is down"), wantStatus: http.StatusInternalServerError, wantCode: httpjson.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code := httpjson.Classify(tt.err, []error{errDomain})
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, code)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	type body struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "unknown field", body: `{"nmae":"x"}`, wantStatus: http.StatusBadRequest},
+		{name: "two objects", body: `{"name":"x"}{"name":"y"}`, wantStatus: http.StatusBadRequest},
+		{name: "too large", body: `{"name":"` + strings.Repeat("x", 64) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			var dst body
+			err := httpjson.Decode(httptest.NewRecorder(), r, &dst, 32)
+
+			status, _ := httpjson.Classify(err, nil)
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"x"}`))
+
+	var dst body
+	require.NoError(t, httpjson.Decode(httptest.NewRecorder(), r, &dst, 32))
+	assert.Equal(t, "x", dst.Name)
+}
+
+func TestQueryInt(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?months=30", nil)
+
+	_, err := httpjson.QueryInt(r, "months", 6, 1, 24)
+	require.ErrorContains(t, err, "months must be an integer from 1 to 24")
+
+	n, err := httpjson.QueryInt(r, "limit", 20, 1, 50)
+	require.NoError(t, err)
+	assert.Equal(t, 20, n)
+}
@@ -0,0 +1,41 @@
+// Package httpjson содержит общие части JSON-адаптеров HTTP: запись ответов, разбор тела
+// и параметров запроса, ответы с ошибками в едином формате.
+package httpjson
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Write отвечает телом body в формате JSON с кодом статуса status.
+func Write(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	// Заголовок уже отправлен, поэтому ошибку записи клиенту сообщить нельзя.
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Decode разбирает тело запроса размером не больше maxBodySize байт в dst. Неизвестные поля
+// считаются ошибкой, чтобы опечатка в имени поля не проходила незамеченной.
+func Decode(w http.ResponseWriter, r *http.Request, dst any, maxBodySize int64) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return NewRequestError(http.StatusRequestEntityTooLarge, CodeBadRequest, "request body is too large")
+		}
+
+		return BadRequest("invalid JSON body: " + err.Error())
+	}
+
+	if dec.Decode(&struct{}{}) != io.EOF {
+		return BadRequest("request body must contain a single JSON object")
+	}
+
+	return nil
+}
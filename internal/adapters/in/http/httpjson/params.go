@@ -0,0 +1,115 @@
+package httpjson
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// MonthLayout формат месяца в параметрах запросов и ответах, например 2026-10.
+const MonthLayout = "2006-01"
+
+// PathID разбирает идентификатор из пути запроса. Неверный идентификатор не может принадлежать
+// ни одному объекту, поэтому такой запрос получает 404.
+func PathID(r *http.Request, name string) (shared.ID, error) {
+	value := r.PathValue(name)
+
+	id, err := shared.NewIDFromString(value)
+	if err != nil {
+		return shared.ID{}, errs.NewObjectNotFoundError(name, value)
+	}
+
+	return id, nil
+}
+
+// QueryID разбирает необязательный идентификатор из строки запроса.
+func QueryID(r *http.Request, name string) (shared.ID, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return shared.ID{}, nil
+	}
+
+	id, err := shared.NewIDFromString(value)
+	if err != nil {
+		return shared.ID{}, BadRequest(fmt.Sprintf("%s must be a UUID", name))
+	}
+
+	return id, nil
+}
+
+// QueryTime разбирает необязательный момент времени в формате RFC 3339 или дату ГГГГ-ММ-ДД,
+// которая означает начало дня в часовом поясе loc.
+func QueryTime(r *http.Request, name string, loc *time.Location) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, loc)
+	if err != nil {
+		return time.Time{}, BadRequest(fmt.Sprintf("%s must be an RFC 3339 time or a YYYY-MM-DD date", name))
+	}
+
+	return t, nil
+}
+
+// QueryMonth разбирает месяц из параметра month и возвращает его начало в часовом поясе now.
+// Без параметра возвращается текущий месяц.
+func QueryMonth(r *http.Request, now time.Time) (time.Time, error) {
+	value := r.URL.Query().Get("month")
+	if value == "" {
+		return StartOfMonth(now), nil
+	}
+
+	month, err := time.ParseInLocation(MonthLayout, value, now.Location())
+	if err != nil {
+		return time.Time{}, BadRequest("month must be YYYY-MM")
+	}
+
+	return month, nil
+}
+
+// StartOfMonth возвращает начало месяца, в который попадает t.
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// QueryAmount разбирает необязательную сумму.
+func QueryAmount(r *http.Request, name string) (decimal.Decimal, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return decimal.Decimal{}, nil
+	}
+
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Decimal{}, BadRequest(fmt.Sprintf("%s must be a decimal number", name))
+	}
+
+	return amount, nil
+}
+
+// QueryInt разбирает необязательное целое число из диапазона [minValue, maxValue].
+func QueryInt(r *http.Request, name string, defaultValue, minValue, maxValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < minValue || n > maxValue {
+		return 0, BadRequest(fmt.Sprintf("%s must be an integer from %d to %d", name, minValue, maxValue))
+	}
+
+	return n, nil
+}
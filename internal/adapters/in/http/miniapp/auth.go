@@ -0,0 +1,74 @@
+package miniapp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const authPrefix = "tma "
+
+func initData(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if len(header) < len(authPrefix) || !strings.EqualFold(header[:len(authPrefix)], authPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len(authPrefix):])
+}
+
+// handlerFunc обрабатывает запрос зарегистрированного пользователя. Возвращенная ошибка
+// превращается в ответ с подходящим кодом статуса.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, u *user.User) error
+
+// handle регистрирует метод, доступный пользователю, открывшему мини-приложение.
+func (a *App) handle(pattern string, h handlerFunc) {
+	a.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		// Ответы содержат данные пользователя и не должны оставаться в кеше WebView.
+		w.Header().Set("Cache-Control", "no-store")
+
+		u, err := a.authenticate(r)
+		if errors.Is(err, errInvalidInitData) {
+			w.Header().Set("WWW-Authenticate", `tma realm="app"`)
+			a.fail(w, r, errUnauthorized)
+
+			return
+		}
+
+		if err != nil {
+			a.fail(w, r, err)
+			return
+		}
+
+		if err := h(w, r, u); err != nil {
+			a.fail(w, r, err)
+		}
+	})
+}
+
+// authenticate проверяет данные запуска и находит пользователя бота по его идентификатору в Telegram.
+// Пользователь, не зарегистрированный в боте, получает errNotRegistered.
+func (a *App) authenticate(r *http.Request) (*user.User, error) {
+	tgUser, err := validateInitData(initData(r), a.botToken, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := queries.NewGetUserQuery(strconv.FormatInt(tgUser.ID, 10), user.ProviderTelegram)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := a.getUserQueryHandler.Handle(r.Context(), query)
+	if errors.Is(err, errs.ErrObjectNotFound) || (err == nil && u == nil) {
+		return nil, errNotRegistered
+	}
+
+	return u, err
+}
@@ -0,0 +1,124 @@
+package miniapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var errCategoryIsGroup = errors.New("category is a group, choose one of its categories")
+
+// isGroup сообщает, что категория является группой расходов. Транзакции записываются
+// только в категории внутри групп, как и в боте.
+func isGroup(c *category.Category) bool {
+	return c.Type() == category.TypeExpense && c.ParentID().IsZero()
+}
+
+func (a *App) categories(ctx context.Context, userID shared.ID) ([]*category.Category, error) {
+	return a.getUserCategoriesQueryHandler.Handle(ctx, queries.NewGetUserCategoriesQuery(userID))
+}
+
+// listCategories возвращает все категории пользователя: группы расходов, категории расходов
+// внутри групп и категории доходов.
+func (a *App) listCategories(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	categories, err := a.categories(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	resp := make([]categoryResponse, 0, len(categories))
+	for _, c := range categories {
+		resp = append(resp, newCategoryResponse(c))
+	}
+
+	httpjson.Write(w, http.StatusOK, resp)
+
+	return nil
+}
+
+type createCategoryRequest struct {
+	Name     string        `json:"name"`
+	Type     category.Type `json:"type"`
+	ParentID shared.ID     `json:"parent_id"`
+}
+
+func (a *App) createCategory(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	var req createCategoryRequest
+	if err := httpjson.Decode(w, r, &req, maxBodySize); err != nil {
+		return err
+	}
+
+	cmd, err := commands.NewCreateCategoryCommand(u.ID(), req.Name, req.Type, req.ParentID)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.createCategoryCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusCreated, newCategoryResponse(c))
+
+	return nil
+}
+
+type renameCategoryRequest struct {
+	Name string `json:"name"`
+}
+
+func (a *App) renameCategory(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	id, err := httpjson.PathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	var req renameCategoryRequest
+	if err := httpjson.Decode(w, r, &req, maxBodySize); err != nil {
+		return err
+	}
+
+	cmd, err := commands.NewRenameCategoryCommand(u.ID(), id, req.Name)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.renameCategoryCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusOK, newCategoryResponse(c))
+
+	return nil
+}
+
+// entryCategory проверяет, что в категорию пользователя можно записать транзакцию.
+func (a *App) entryCategory(ctx context.Context, userID shared.ID, categoryID shared.ID) error {
+	categories, err := a.categories(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range categories {
+		if c.ID() != categoryID {
+			continue
+		}
+
+		if isGroup(c) {
+			return errCategoryIsGroup
+		}
+
+		return nil
+	}
+
+	return errs.NewObjectNotFoundError("category", categoryID.String())
+}
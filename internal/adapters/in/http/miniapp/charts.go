@@ -0,0 +1,111 @@
+package miniapp
+
+import (
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+const (
+	defaultChartMonths = 6
+	maxChartMonths     = 24
+)
+
+// categoriesChart возвращает суммы по категориям одного типа за месяц для диаграммы.
+// По умолчанию показываются расходы за текущий месяц.
+func (a *App) categoriesChart(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	now, err := a.now(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	month, err := httpjson.QueryMonth(r, now)
+	if err != nil {
+		return err
+	}
+
+	t := category.TypeExpense
+	if value := r.URL.Query().Get("type"); value != "" {
+		if t, err = category.ParseType(value); err != nil {
+			return httpjson.BadRequest("type must be one of income, expense")
+		}
+	}
+
+	query, err := queries.NewGetSummaryQuery(u.ID(), month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return err
+	}
+
+	summary, err := a.getSummaryQueryHandler.Handle(r.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	totals, total := summary.Expenses(), summary.TotalExpense()
+	if t == category.TypeIncome {
+		totals, total = summary.Incomes(), summary.TotalIncome()
+	}
+
+	resp := categoriesChartResponse{
+		Month: month.Format(httpjson.MonthLayout),
+		Type:  t,
+		Total: total,
+		Items: make([]categoryTotalResponse, 0, len(totals)),
+	}
+
+	for _, ct := range totals {
+		resp.Items = append(resp.Items, categoryTotalResponse{
+			CategoryID: ct.CategoryID(),
+			Name:       ct.Name(),
+			ParentName: ct.ParentName(),
+			Amount:     ct.Amount(),
+		})
+	}
+
+	httpjson.Write(w, http.StatusOK, resp)
+
+	return nil
+}
+
+// monthsChart возвращает доходы и расходы по месяцам, от старых к новым, включая текущий месяц.
+func (a *App) monthsChart(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	months, err := httpjson.QueryInt(r, "months", defaultChartMonths, 1, maxChartMonths)
+	if err != nil {
+		return err
+	}
+
+	now, err := a.now(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	first := httpjson.StartOfMonth(now).AddDate(0, 1-months, 0)
+	resp := make([]monthTotalsResponse, 0, months)
+
+	for i := range months {
+		month := first.AddDate(0, i, 0)
+
+		query, err := queries.NewGetSummaryQuery(u.ID(), month, month.AddDate(0, 1, 0))
+		if err != nil {
+			return err
+		}
+
+		summary, err := a.getSummaryQueryHandler.Handle(r.Context(), query)
+		if err != nil {
+			return err
+		}
+
+		resp = append(resp, monthTotalsResponse{
+			Month:   month.Format(httpjson.MonthLayout),
+			Income:  summary.TotalIncome(),
+			Expense: summary.TotalExpense(),
+		})
+	}
+
+	httpjson.Write(w, http.StatusOK, resp)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package miniapp
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// Суммы передаются строками, чтобы приложение не теряло точность на числах с плавающей точкой.
+
+type categoryResponse struct {
+	ID       shared.ID     `json:"id"`
+	Name     string        `json:"name"`
+	Type     category.Type `json:"type"`
+	ParentID *shared.ID    `json:"parent_id,omitempty"`
+
+	// Group сообщает, что категория объединяет категории расходов и сама транзакций не принимает.
+	Group bool `json:"group"`
+}
+
+func newCategoryResponse(c *category.Category) categoryResponse {
+	resp := categoryResponse{ID: c.ID(), Name: c.Name(), Type: c.Type(), Group: isGroup(c)}
+
+	if parentID := c.ParentID(); !parentID.IsZero() {
+		resp.ParentID = &parentID
+	}
+
+	return resp
+}
+
+type transactionLineResponse struct {
+	ID                 shared.ID       `json:"id"`
+	OccurredAt         time.Time       `json:"occurred_at"`
+	Amount             decimal.Decimal `json:"amount"`
+	CategoryType       category.Type   `json:"category_type"`
+	CategoryName       string          `json:"category_name"`
+	ParentCategoryName string          `json:"parent_category_name,omitempty"`
+	Note               string          `json:"note"`
+}
+
+func newTransactionLineResponses(lines []report.TransactionLine) []transactionLineResponse {
+	resp := make([]transactionLineResponse, 0, len(lines))
+
+	for _, l := range lines {
+		resp = append(resp, transactionLineResponse{
+			ID:                 l.ID(),
+			OccurredAt:         l.OccurredAt(),
+			Amount:             l.Amount(),
+			CategoryType:       l.CategoryType(),
+			CategoryName:       l.CategoryName(),
+			ParentCategoryName: l.ParentName(),
+			Note:               l.Note(),
+		})
+	}
+
+	return resp
+}
+
+type categoryTotalResponse struct {
+	CategoryID shared.ID       `json:"category_id"`
+	Name       string          `json:"name"`
+	ParentName string          `json:"parent_name,omitempty"`
+	Amount     decimal.Decimal `json:"amount"`
+}
+
+type categoriesChartResponse struct {
+	Month string                  `json:"month"`
+	Type  category.Type           `json:"type"`
+	Total decimal.Decimal         `json:"total"`
+	Items []categoryTotalResponse `json:"items"`
+}
+
+type monthTotalsResponse struct {
+	Month   string          `json:"month"`
+	Income  decimal.Decimal `json:"income"`
+	Expense decimal.Decimal `json:"expense"`
+}
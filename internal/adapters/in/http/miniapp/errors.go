@@ -0,0 +1,40 @@
+package miniapp
+
+import (
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+)
+
+// maxBodySize наибольший размер тела запроса. Мини-приложение отправляет только короткие формы.
+const maxBodySize = 16 << 10
+
+// codeNotRegistered код ошибки для пользователя Telegram, который не зарегистрирован в боте.
+const codeNotRegistered = "not_registered"
+
+var errUnauthorized = httpjson.NewRequestError(http.StatusUnauthorized, httpjson.CodeUnauthorized, "missing or invalid init data")
+
+var errNotRegistered = httpjson.NewRequestError(
+	http.StatusForbidden,
+	codeNotRegistered,
+	"user is not registered, send /start to the bot",
+)
+
+// invalidValueErrors ошибки проверки доменных моделей, которые вызваны значениями из запроса.
+var invalidValueErrors = []error{
+	category.ErrEmptyName,
+	category.ErrInvalidParent,
+	transaction.ErrInvalidAmount,
+	transaction.ErrInvalidCategoryID,
+	transaction.ErrTooLongNote,
+	report.ErrInvalidPeriod,
+	errCategoryIsGroup,
+}
+
+// fail отвечает на запрос ошибкой err.
+func (a *App) fail(w http.ResponseWriter, r *http.Request, err error) {
+	httpjson.Fail(w, r, a.logger, "mini app request failed", err, invalidValueErrors)
+}
@@ -0,0 +1,93 @@
+package miniapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// initDataMaxAge срок, в течение которого принимаются данные запуска мини-приложения.
+	// Telegram выдает новые данные при каждом открытии приложения.
+	initDataMaxAge = 24 * time.Hour
+
+	// initDataClockSkew допустимое расхождение часов с Telegram. Данные, выданные позже,
+	// отклоняются: иначе подписанные данные с датой в будущем действовали бы дольше initDataMaxAge.
+	initDataClockSkew = time.Minute
+)
+
+var errInvalidInitData = errors.New("invalid init data")
+
+// telegramUser пользователь Telegram, открывший мини-приложение.
+type telegramUser struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+}
+
+// validateInitData проверяет подпись данных запуска мини-приложения и возвращает пользователя,
+// который его открыл. Подпись считается по правилам Telegram: ключом HMAC-SHA256 служит
+// HMAC-SHA256 токена бота с ключом "WebAppData", подписываются все поля, кроме hash,
+// в виде строк key=value, упорядоченных по ключу и разделенных переводом строки.
+func validateInitData(raw string, botToken string, now time.Time) (telegramUser, error) {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return telegramUser{}, errInvalidInitData
+	}
+
+	hash, err := hex.DecodeString(values.Get("hash"))
+	if err != nil || len(hash) == 0 {
+		return telegramUser{}, errInvalidInitData
+	}
+
+	if !hmac.Equal(hash, signInitData(values, botToken)) {
+		return telegramUser{}, errInvalidInitData
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return telegramUser{}, errInvalidInitData
+	}
+
+	age := now.Sub(time.Unix(authDate, 0))
+	if age > initDataMaxAge || age < -initDataClockSkew {
+		return telegramUser{}, errInvalidInitData
+	}
+
+	var u telegramUser
+	if err := json.Unmarshal([]byte(values.Get("user")), &u); err != nil || u.ID == 0 {
+		return telegramUser{}, errInvalidInitData
+	}
+
+	return u, nil
+}
+
+func signInitData(values url.Values, botToken string) []byte {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+"="+values.Get(key))
+	}
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(botToken))
+
+	mac := hmac.New(sha256.New, secret.Sum(nil))
+	mac.Write([]byte(strings.Join(lines, "\n")))
+
+	return mac.Sum(nil)
+}
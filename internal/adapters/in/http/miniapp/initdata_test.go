@@ -0,0 +1,96 @@
+package miniapp
+
+import (
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBotToken = "7342037359:AAHtestTokenForInitDataVector_0123"
+
+// knownInitData данные запуска, подписанные токеном testBotToken независимо от signInitData.
+const knownInitData = "auth_date=1760875200&query_id=AAHdF6IQAAAAAN0XohDhrOrc" +
+	"&user=%7B%22id%22%3A279058397%2C%22first_name%22%3A%22Vladislav%22%2C%22username%22%3A%22vdkfrost%22%2C%22language_code%22%3A%22ru%22%7D" +
+	"&hash=b419dcda4d405c5965aa763bfcb17da76ae0ade3dddc28b0b988f0ed85d935e5"
+
+// knownAuthDate значение auth_date в knownInitData.
+var knownAuthDate = time.Unix(1760875200, 0)
+
+// signed подписывает values токеном testBotToken и возвращает их в виде строки запроса.
+func signed(values url.Values) string {
+	values.Set("hash", hex.EncodeToString(signInitData(values, testBotToken)))
+
+	return values.Encode()
+}
+
+func initDataValues(authDate time.Time) url.Values {
+	return url.Values{
+		"auth_date": {strconv.FormatInt(authDate.Unix(), 10)},
+		"query_id":  {"AAHdF6IQAAAAAN0XohDhrOrc"},
+		"user":      {`{"id":279058397,"first_name":"Vladislav"}`},
+	}
+}
+
+func TestValidateInitData_KnownVector(t *testing.T) {
+	u, err := validateInitData(knownInitData, testBotToken, knownAuthDate.Add(time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(279058397), u.ID)
+	assert.Equal(t, "Vladislav", u.FirstName)
+}
+
+func TestValidateInitData_Invalid(t *testing.T) {
+	now := knownAuthDate.Add(time.Hour)
+
+	tampered, err := url.ParseQuery(knownInitData)
+	require.NoError(t, err)
+	tampered.Set("user", `{"id":1,"first_name":"Vladislav"}`)
+
+	withoutHash, err := url.ParseQuery(knownInitData)
+	require.NoError(t, err)
+	withoutHash.Del("hash")
+
+	badHash, err := url.ParseQuery(knownInitData)
+	require.NoError(t, err)
+	badHash.Set("hash", "not-hex")
+
+	withoutUser := initDataValues(now)
+	withoutUser.Del("user")
+
+	tests := []struct {
+		name     string
+		raw      string
+		botToken string
+	}{
+		{name: "tampered field", raw: tampered.Encode(), botToken: testBotToken},
+		{name: "missing hash", raw: withoutHash.Encode(), botToken: testBotToken},
+		{name: "bad hash", raw: badHash.Encode(), botToken: testBotToken},
+		{name: "other bot token", raw: knownInitData, botToken: "1:other"},
+		{name: "malformed query", raw: "auth_date=%zz", botToken: testBotToken},
+		{name: "expired auth_date", raw: signed(initDataValues(now.Add(-initDataMaxAge - time.Second))), botToken: testBotToken},
+		{name: "future auth_date", raw: signed(initDataValues(now.Add(initDataClockSkew + time.Second))), botToken: testBotToken},
+		{name: "missing user", raw: signed(withoutUser), botToken: testBotToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateInitData(tt.raw, tt.botToken, now)
+			require.ErrorIs(t, err, errInvalidInitData)
+		})
+	}
+}
+
+func TestValidateInitData_ClockSkew(t *testing.T) {
+	now := time.Now()
+
+	_, err := validateInitData(signed(initDataValues(now.Add(initDataClockSkew/2))), testBotToken, now)
+	require.NoError(t, err)
+
+	_, err = validateInitData(signed(initDataValues(now.Add(-initDataMaxAge+time.Minute))), testBotToken, now)
+	require.NoError(t, err)
+}
@@ -0,0 +1,149 @@
+// Package miniapp реализует мини-приложение Telegram: страницу, которая открывается кнопкой меню
+// бота, и JSON-методы для быстрой записи транзакций, управления категориями и графиков.
+// Пользователь определяется по подписанным данным запуска, которые Telegram передает приложению.
+package miniapp
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// BasePath префикс адресов мини-приложения, под которым его нужно подключать к серверу.
+const BasePath = "/app/"
+
+// contentSecurityPolicy разрешает странице только собственные скрипты, стили и запросы,
+// а также скрипт Telegram, через который приложение получает данные запуска.
+const contentSecurityPolicy = "default-src 'none'; script-src 'self' https://telegram.org; style-src 'self'; " +
+	"connect-src 'self'; img-src 'self'; base-uri 'none'; form-action 'none'"
+
+//go:embed static
+var assets embed.FS
+
+// App обрабатывает запросы мини-приложения. Методы под BasePath+"api/" требуют данные запуска
+// в заголовке Authorization: tma <initData>.
+type App struct {
+	logger ports.Logger
+	mux    *http.ServeMux
+
+	// botToken токен бота, которым Telegram подписывает данные запуска.
+	botToken string
+
+	createTransactionCommandHandler   commands.CreateTransactionCommandHandler
+	createCategoryCommandHandler      commands.CreateCategoryCommandHandler
+	renameCategoryCommandHandler      commands.RenameCategoryCommandHandler
+	getUserQueryHandler               queries.GetUserQueryHandler
+	getUserSettingsQueryHandler       queries.GetUserSettingsQueryHandler
+	getUserCategoriesQueryHandler     queries.GetUserCategoriesQueryHandler
+	getTransactionHistoryQueryHandler queries.GetTransactionHistoryQueryHandler
+	getSummaryQueryHandler            queries.GetSummaryQueryHandler
+}
+
+func NewApp(
+	logger ports.Logger,
+	botToken string,
+	createTransactionCommandHandler commands.CreateTransactionCommandHandler,
+	createCategoryCommandHandler commands.CreateCategoryCommandHandler,
+	renameCategoryCommandHandler commands.RenameCategoryCommandHandler,
+	getUserQueryHandler queries.GetUserQueryHandler,
+	getUserSettingsQueryHandler queries.GetUserSettingsQueryHandler,
+	getUserCategoriesQueryHandler queries.GetUserCategoriesQueryHandler,
+	getTransactionHistoryQueryHandler queries.GetTransactionHistoryQueryHandler,
+	getSummaryQueryHandler queries.GetSummaryQueryHandler,
+) (*App, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if botToken == "" {
+		return nil, errs.NewValueIsRequiredError("botToken")
+	}
+
+	if createTransactionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createTransactionCommandHandler")
+	}
+
+	if createCategoryCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createCategoryCommandHandler")
+	}
+
+	if renameCategoryCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("renameCategoryCommandHandler")
+	}
+
+	if getUserQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserQueryHandler")
+	}
+
+	if getUserSettingsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserSettingsQueryHandler")
+	}
+
+	if getUserCategoriesQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserCategoriesQueryHandler")
+	}
+
+	if getTransactionHistoryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTransactionHistoryQueryHandler")
+	}
+
+	if getSummaryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getSummaryQueryHandler")
+	}
+
+	a := &App{
+		logger:                            logger,
+		mux:                               http.NewServeMux(),
+		botToken:                          botToken,
+		createTransactionCommandHandler:   createTransactionCommandHandler,
+		createCategoryCommandHandler:      createCategoryCommandHandler,
+		renameCategoryCommandHandler:      renameCategoryCommandHandler,
+		getUserQueryHandler:               getUserQueryHandler,
+		getUserSettingsQueryHandler:       getUserSettingsQueryHandler,
+		getUserCategoriesQueryHandler:     getUserCategoriesQueryHandler,
+		getTransactionHistoryQueryHandler: getTransactionHistoryQueryHandler,
+		getSummaryQueryHandler:            getSummaryQueryHandler,
+	}
+
+	if err := a.routes(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *App) routes() error {
+	static, err := fs.Sub(assets, "static")
+	if err != nil {
+		return err
+	}
+
+	// Страница и ее файлы открыты: данных пользователя в них нет.
+	a.mux.Handle("GET "+BasePath, http.StripPrefix(BasePath, http.FileServerFS(static)))
+
+	a.handle("GET "+BasePath+"api/categories", a.listCategories)
+	a.handle("POST "+BasePath+"api/categories", a.createCategory)
+	a.handle("PATCH "+BasePath+"api/categories/{id}", a.renameCategory)
+
+	a.handle("GET "+BasePath+"api/transactions", a.recentTransactions)
+	a.handle("POST "+BasePath+"api/transactions", a.createTransaction)
+
+	a.handle("GET "+BasePath+"api/charts/categories", a.categoriesChart)
+	a.handle("GET "+BasePath+"api/charts/months", a.monthsChart)
+
+	return nil
+}
+
+func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Set("Content-Security-Policy", contentSecurityPolicy)
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Referrer-Policy", "no-referrer")
+
+	a.mux.ServeHTTP(w, r)
+}
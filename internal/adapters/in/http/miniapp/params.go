@@ -0,0 +1,19 @@
+package miniapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// now возвращает текущее время в часовом поясе пользователя.
+func (a *App) now(ctx context.Context, userID shared.ID) (time.Time, error) {
+	s, err := a.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(userID))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Now().In(s.Location()), nil
+}
@@ -0,0 +1,375 @@
+'use strict';
+
+// Приложение работает внутри Telegram: initData подтверждает пользователя на каждом запросе.
+const tg = window.Telegram.WebApp;
+
+const money = new Intl.NumberFormat('ru-RU', { minimumFractionDigits: 0, maximumFractionDigits: 2 });
+const monthNames = new Intl.DateTimeFormat('ru-RU', { month: 'long', year: 'numeric' });
+const shortMonths = new Intl.DateTimeFormat('ru-RU', { month: 'short' });
+
+const state = {
+  categories: [],
+  entryType: 'expense',
+  categoryType: 'expense',
+  reportType: 'expense',
+  month: currentMonth(),
+};
+
+function $(id) {
+  return document.getElementById(id);
+}
+
+function el(tag, props, ...children) {
+  const node = document.createElement(tag);
+  Object.assign(node, props);
+  node.append(...children);
+  return node;
+}
+
+async function api(method, path, body) {
+  const options = {
+    method,
+    headers: { Authorization: 'tma ' + tg.initData },
+  };
+
+  if (body !== undefined) {
+    options.headers['Content-Type'] = 'application/json';
+    options.body = JSON.stringify(body);
+  }
+
+  const resp = await fetch('api/' + path, options);
+  const data = await resp.json().catch(() => null);
+
+  if (!resp.ok) {
+    throw new Error(data && data.error ? data.error.message : 'Ошибка ' + resp.status);
+  }
+
+  return data;
+}
+
+function showError(err) {
+  const node = $('error');
+  node.textContent = err ? err.message : '';
+  node.hidden = !err;
+  if (err) {
+    tg.HapticFeedback.notificationOccurred('error');
+  }
+}
+
+// run выполняет действие, показывает его ошибку и блокирует кнопку, пока запрос не завершится.
+async function run(button, action) {
+  if (button) {
+    button.disabled = true;
+  }
+  try {
+    showError(null);
+    await action();
+  } catch (err) {
+    showError(err);
+  } finally {
+    if (button) {
+      button.disabled = false;
+    }
+  }
+}
+
+function currentMonth() {
+  const now = new Date();
+  return new Date(now.getFullYear(), now.getMonth(), 1);
+}
+
+function monthParam(date) {
+  return date.getFullYear() + '-' + String(date.getMonth() + 1).padStart(2, '0');
+}
+
+function parseMonth(value) {
+  const [year, month] = value.split('-').map(Number);
+  return new Date(year, month - 1, 1);
+}
+
+function formatAmount(value) {
+  return money.format(Number(value));
+}
+
+function setToggle(id, value) {
+  for (const button of $(id).querySelectorAll('button')) {
+    button.classList.toggle('active', button.dataset.type === value);
+  }
+}
+
+function onToggle(id, handler) {
+  $(id).addEventListener('click', (event) => {
+    const type = event.target.dataset.type;
+    if (type) {
+      setToggle(id, type);
+      handler(type);
+    }
+  });
+}
+
+// Вкладки
+
+function openTab(name) {
+  for (const button of document.querySelectorAll('.tabs button')) {
+    button.classList.toggle('active', button.dataset.tab === name);
+  }
+  for (const section of document.querySelectorAll('.tab')) {
+    section.hidden = section.id !== name;
+  }
+  showError(null);
+
+  if (name === 'reports') {
+    run(null, loadReports);
+  }
+}
+
+// Категории
+
+async function loadCategories() {
+  state.categories = await api('GET', 'categories');
+  renderEntryCategories();
+  renderCategoryParents();
+  renderCategoryList();
+}
+
+function byName(a, b) {
+  return a.name.localeCompare(b.name, 'ru');
+}
+
+function groups() {
+  return state.categories.filter((c) => c.group).sort(byName);
+}
+
+function children(parentID) {
+  return state.categories.filter((c) => c.parent_id === parentID).sort(byName);
+}
+
+function incomes() {
+  return state.categories.filter((c) => c.type === 'income').sort(byName);
+}
+
+function renderEntryCategories() {
+  const select = $('entry-category');
+  const selected = select.value;
+  select.replaceChildren();
+
+  if (state.entryType === 'income') {
+    for (const c of incomes()) {
+      select.append(el('option', { value: c.id, textContent: c.name }));
+    }
+  } else {
+    for (const group of groups()) {
+      const items = children(group.id);
+      if (items.length === 0) {
+        continue;
+      }
+      const optgroup = el('optgroup', { label: group.name });
+      for (const c of items) {
+        optgroup.append(el('option', { value: c.id, textContent: c.name }));
+      }
+      select.append(optgroup);
+    }
+  }
+
+  if (select.options.length === 0) {
+    select.append(el('option', { value: '', textContent: 'Сначала добавьте категорию', disabled: true, selected: true }));
+  } else if (selected) {
+    select.value = selected;
+  }
+}
+
+function renderCategoryParents() {
+  const select = $('category-parent');
+  select.hidden = state.categoryType !== 'expense';
+  select.replaceChildren(el('option', { value: '', textContent: 'Новая группа расходов' }));
+
+  for (const group of groups()) {
+    select.append(el('option', { value: group.id, textContent: 'В группу «' + group.name + '»' }));
+  }
+}
+
+function categoryRow(c) {
+  const name = el('span', { textContent: c.name });
+  const rename = el('button', { type: 'button', className: 'link', textContent: 'Изменить' });
+  const row = el('div', { className: 'category' }, name, rename);
+
+  rename.addEventListener('click', () => {
+    const input = el('input', { value: c.name, maxLength: 100 });
+    const save = el('button', { type: 'button', className: 'link', textContent: 'Сохранить' });
+
+    save.addEventListener('click', () => run(save, async () => {
+      await api('PATCH', 'categories/' + c.id, { name: input.value });
+      await loadCategories();
+    }));
+
+    row.replaceChildren(input, save);
+    input.focus();
+  });
+
+  return row;
+}
+
+function renderCategoryList() {
+  const list = $('category-list');
+  list.replaceChildren(el('h2', { textContent: 'Расходы' }));
+
+  for (const group of groups()) {
+    const nested = el('div', { className: 'children' }, ...children(group.id).map(categoryRow));
+    list.append(el('div', { className: 'group' }, categoryRow(group), nested));
+  }
+
+  list.append(el('h2', { textContent: 'Доходы' }), ...incomes().map(categoryRow));
+}
+
+async function createCategory(event) {
+  event.preventDefault();
+  const form = event.target;
+
+  await run(form.querySelector('[type=submit]'), async () => {
+    const body = { name: $('category-name').value, type: state.categoryType };
+    const parentID = $('category-parent').value;
+    if (state.categoryType === 'expense' && parentID) {
+      body.parent_id = parentID;
+    }
+
+    await api('POST', 'categories', body);
+    $('category-name').value = '';
+    tg.HapticFeedback.notificationOccurred('success');
+    await loadCategories();
+  });
+}
+
+// Запись транзакции
+
+async function loadRecent() {
+  const lines = await api('GET', 'transactions');
+  const list = $('recent');
+
+  list.replaceChildren(...lines.map((l) => {
+    const title = l.parent_category_name ? l.parent_category_name + ' › ' + l.category_name : l.category_name;
+    const sign = l.category_type === 'income' ? '+' : '−';
+    const info = el('div', {},
+      el('div', { textContent: title }),
+      el('div', { className: 'note', textContent: new Date(l.occurred_at).toLocaleDateString('ru-RU') + (l.note ? ' · ' + l.note : '') }));
+    const amount = el('span', {
+      className: 'amount' + (l.category_type === 'income' ? ' income' : ''),
+      textContent: sign + formatAmount(l.amount),
+    });
+    return el('li', {}, info, amount);
+  }));
+
+  if (lines.length === 0) {
+    list.append(el('li', { className: 'hint', textContent: 'Записей пока нет' }));
+  }
+}
+
+async function createTransaction(event) {
+  event.preventDefault();
+  const form = event.target;
+
+  await run(form.querySelector('[type=submit]'), async () => {
+    await api('POST', 'transactions', {
+      category_id: $('entry-category').value,
+      amount: $('entry-amount').value,
+      note: $('entry-note').value,
+    });
+
+    $('entry-amount').value = '';
+    $('entry-note').value = '';
+    tg.HapticFeedback.notificationOccurred('success');
+    await loadRecent();
+  });
+}
+
+// Отчеты
+
+async function loadReports() {
+  $('month-title').textContent = monthNames.format(state.month);
+  $('month-next').disabled = state.month >= currentMonth();
+
+  const [chart, months] = await Promise.all([
+    api('GET', 'charts/categories?month=' + monthParam(state.month) + '&type=' + state.reportType),
+    api('GET', 'charts/months?months=6'),
+  ]);
+
+  renderCategoriesChart(chart);
+  renderMonthsChart(months);
+}
+
+function renderCategoriesChart(chart) {
+  $('report-total').textContent = formatAmount(chart.total);
+
+  // Расходы удобнее сравнивать по группам, поэтому суммы дочерних категорий складываются.
+  const totals = new Map();
+  for (const item of chart.items) {
+    const name = item.parent_name || item.name;
+    totals.set(name, (totals.get(name) || 0) + Number(item.amount));
+  }
+
+  const items = [...totals].sort((a, b) => b[1] - a[1]);
+  const max = items.length ? items[0][1] : 0;
+
+  $('report-items').replaceChildren(...items.map(([name, amount]) => {
+    const bar = el('div', { className: 'bar' });
+    bar.style.width = (max ? (amount / max) * 100 : 0) + '%';
+    return el('li', {},
+      el('div', { className: 'label' }, el('span', { textContent: name }), el('span', { className: 'amount', textContent: formatAmount(amount) })),
+      bar);
+  }));
+
+  if (items.length === 0) {
+    $('report-items').append(el('li', { className: 'hint', textContent: 'За этот месяц записей нет' }));
+  }
+}
+
+function renderMonthsChart(months) {
+  const max = Math.max(0, ...months.flatMap((m) => [Number(m.income), Number(m.expense)]));
+
+  $('months-chart').replaceChildren(...months.map((m) => {
+    const income = el('span', { className: 'income', title: formatAmount(m.income) });
+    const expense = el('span', { className: 'expense', title: formatAmount(m.expense) });
+    income.style.height = (max ? (Number(m.income) / max) * 100 : 0) + '%';
+    expense.style.height = (max ? (Number(m.expense) / max) * 100 : 0) + '%';
+    return el('div', { className: 'column' }, income, expense, el('small', { textContent: shortMonths.format(parseMonth(m.month)) }));
+  }));
+}
+
+function shiftMonth(delta) {
+  state.month = new Date(state.month.getFullYear(), state.month.getMonth() + delta, 1);
+  run(null, loadReports);
+}
+
+// Запуск
+
+function init() {
+  tg.ready();
+  tg.expand();
+
+  document.querySelector('.tabs').addEventListener('click', (event) => {
+    if (event.target.dataset.tab) {
+      openTab(event.target.dataset.tab);
+    }
+  });
+
+  onToggle('entry-type', (type) => {
+    state.entryType = type;
+    renderEntryCategories();
+  });
+  onToggle('category-type', (type) => {
+    state.categoryType = type;
+    renderCategoryParents();
+  });
+  onToggle('report-type', (type) => {
+    state.reportType = type;
+    run(null, loadReports);
+  });
+
+  $('entry-form').addEventListener('submit', createTransaction);
+  $('category-form').addEventListener('submit', createCategory);
+  $('month-prev').addEventListener('click', () => shiftMonth(-1));
+  $('month-next').addEventListener('click', () => shiftMonth(1));
+
+  run(null, () => Promise.all([loadCategories(), loadRecent()]));
+}
+
+init();
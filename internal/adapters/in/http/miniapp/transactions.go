@@ -0,0 +1,93 @@
+package miniapp
+
+import (
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/http/httpjson"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+// recentLimit число последних транзакций, которые приложение показывает под формой записи.
+const recentLimit = 10
+
+func (a *App) recentTransactions(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	query, err := queries.NewGetTransactionHistoryQuery(u.ID(), report.HistoryFilter{}, report.HistoryCursor{}, recentLimit)
+	if err != nil {
+		return err
+	}
+
+	page, err := a.getTransactionHistoryQueryHandler.Handle(r.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	httpjson.Write(w, http.StatusOK, newTransactionLineResponses(page.Lines))
+
+	return nil
+}
+
+// createTransactionRequest быстрая запись транзакции. Сумма передается строкой и может
+// содержать запятую. Метки берутся из комментария, как в сообщениях боту: #отпуск.
+type createTransactionRequest struct {
+	CategoryID shared.ID `json:"category_id"`
+	Amount     string    `json:"amount"`
+	Note       string    `json:"note"`
+}
+
+type createTransactionResponse struct {
+	ID         shared.ID       `json:"id"`
+	CategoryID shared.ID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Note       string          `json:"note"`
+	Tags       []string        `json:"tags"`
+}
+
+func (a *App) createTransaction(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	var req createTransactionRequest
+	if err := httpjson.Decode(w, r, &req, maxBodySize); err != nil {
+		return err
+	}
+
+	amount, err := transaction.NewAmountFromString(req.Amount)
+	if err != nil {
+		return err
+	}
+
+	if err := a.entryCategory(r.Context(), u.ID(), req.CategoryID); err != nil {
+		return err
+	}
+
+	tags, note := tag.ExtractHashtags(req.Note)
+
+	cmd, err := commands.NewCreateTransactionCommand(u.ID(), amount, req.CategoryID, note, tags...)
+	if err != nil {
+		return err
+	}
+
+	t, err := a.createTransactionCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		return err
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	httpjson.Write(w, http.StatusCreated, createTransactionResponse{
+		ID:         t.ID(),
+		CategoryID: t.CategoryID(),
+		Amount:     t.Amount().Value(),
+		Note:       t.Note(),
+		Tags:       tags,
+	})
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Package monitoring отдает метрики Prometheus и состояние бота для оркестратора контейнеров.
+// Обработчик слушает отдельный адрес, который не нужно публиковать наружу.
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// checkTimeout время на все проверки готовности одного запроса /readyz.
+const checkTimeout = 3 * time.Second
+
+// Check проверяет, что компонент может обслуживать запросы.
+type Check func(ctx context.Context) error
+
+// Handler отвечает на запросы:
+//   - /metrics — метрики Prometheus;
+//   - /healthz — процесс жив и отвечает;
+//   - /readyz — все проверки готовности прошли, иначе 503. Подробности ошибок пишутся
+//     только в журнал: в них могут быть адреса и имена пользователей базы данных.
+type Handler struct {
+	logger  ports.Logger
+	metrics http.Handler
+	checks  map[string]Check
+	mux     *http.ServeMux
+}
+
+// NewHandler создает обработчик. checks проверки готовности по названиям, например db.
+func NewHandler(logger ports.Logger, metrics http.Handler, checks map[string]Check) (*Handler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if metrics == nil {
+		return nil, errs.NewValueIsRequiredError("metrics")
+	}
+
+	for name, check := range checks {
+		if check == nil {
+			return nil, errs.NewValueIsRequiredError("checks." + name)
+		}
+	}
+
+	h := &Handler{
+		logger:  logger,
+		metrics: metrics,
+		checks:  checks,
+		mux:     http.NewServeMux(),
+	}
+
+	h.mux.Handle("GET /metrics", metrics)
+	h.mux.HandleFunc("GET /healthz", h.healthz)
+	h.mux.HandleFunc("GET /readyz", h.readyz)
+
+	return h, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) healthz(w http.ResponseWriter, _ *http.Request) {
+	writeText(w, http.StatusOK, "ok\n")
+}
+
+// readyz выполняет проверки по очереди в порядке названий и перечисляет результат каждой.
+func (h *Handler) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var (
+		b      strings.Builder
+		status = http.StatusOK
+	)
+
+	for _, name := range names {
+		if err := h.checks[name](ctx); err != nil {
+			status = http.StatusServiceUnavailable
+			h.logger.Error("readiness check failed", "check", name, "err", err)
+			fmt.Fprintf(&b, "%s: failed\n", name)
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s: ok\n", name)
+	}
+
+	writeText(w, status, b.String())
+}
+
+func writeText(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
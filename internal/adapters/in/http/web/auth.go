@@ -0,0 +1,133 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	sessionCookie = "ctb_session"
+
+	// maxFormSize наибольший размер формы входа и выхода.
+	maxFormSize = 4 << 10
+)
+
+// pageFunc обрабатывает запрос пользователя, вошедшего в панель. Возвращенная ошибка
+// превращается в страницу ошибки с подходящим кодом статуса.
+type pageFunc func(w http.ResponseWriter, r *http.Request, u *user.User) error
+
+// handle регистрирует страницу, доступную только после входа.
+func (d *Dashboard) handle(pattern string, h pageFunc) {
+	d.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		u, err := d.authenticate(r)
+		if errors.Is(err, websession.ErrInvalidSession) {
+			d.render(w, r, http.StatusUnauthorized, "login", loginView{})
+			return
+		}
+
+		if err != nil {
+			d.fail(w, r, err)
+			return
+		}
+
+		if err := h(w, r, u); err != nil {
+			d.fail(w, r, err)
+		}
+	})
+}
+
+// authenticate находит пользователя по cookie сеанса. Отсутствующий сеанс считается недействительным.
+func (d *Dashboard) authenticate(r *http.Request) (*user.User, error) {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil || cookie.Value == "" {
+		return nil, websession.ErrInvalidSession
+	}
+
+	query, err := queries.NewAuthenticateWebSessionQuery(cookie.Value, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return d.authenticateWebSessionQueryHandler.Handle(r.Context(), query)
+}
+
+// loginView данные страницы входа.
+type loginView struct {
+	Token string
+	Error string
+}
+
+// loginForm показывает кнопку входа по ссылке из бота. Вход выполняется только отправкой формы:
+// ссылку могут открыть программы предпросмотра, и она не должна при этом срабатывать.
+func (d *Dashboard) loginForm(w http.ResponseWriter, r *http.Request) {
+	d.render(w, r, http.StatusOK, "login", loginView{Token: r.URL.Query().Get("token")})
+}
+
+func (d *Dashboard) login(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFormSize)
+
+	cmd, err := commands.NewStartWebSessionCommand(r.PostFormValue("token"), time.Now())
+	if err != nil {
+		d.render(w, r, http.StatusUnauthorized, "login", loginView{Error: "Ссылка для входа не указана"})
+		return
+	}
+
+	started, err := d.startWebSessionCommandHandler.Handle(r.Context(), cmd)
+
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound), errors.Is(err, websession.ErrLinkUsed):
+		d.render(w, r, http.StatusUnauthorized, "login", loginView{Error: "Ссылка для входа уже использована или неверна"})
+		return
+	case errors.Is(err, websession.ErrLinkExpired):
+		d.render(w, r, http.StatusUnauthorized, "login", loginView{Error: "Срок действия ссылки для входа истек"})
+		return
+	case err != nil:
+		d.fail(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    started.Secret,
+		Path:     BasePath,
+		Expires:  started.ExpiresAt,
+		HttpOnly: true,
+		Secure:   d.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, BasePath, http.StatusSeeOther)
+}
+
+func (d *Dashboard) logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookie); err == nil && cookie.Value != "" {
+		cmd, err := commands.NewEndWebSessionCommand(cookie.Value)
+		if err != nil {
+			d.fail(w, r, err)
+			return
+		}
+
+		if err := d.endWebSessionCommandHandler.Handle(r.Context(), cmd); err != nil {
+			d.fail(w, r, err)
+			return
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Path:     BasePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   d.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, BasePath+"login", http.StatusSeeOther)
+}
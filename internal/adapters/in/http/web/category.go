@@ -0,0 +1,140 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type categoryView struct {
+	ID       string
+	Name     string
+	ParentID string
+	Parent   string
+	Income   bool
+	Nav      monthNav
+	Total    string
+	Children categoryList
+	Lines    []lineRow
+	Next     string
+}
+
+// category показывает сумму по категории за месяц, суммы ее подкатегорий и транзакции.
+func (d *Dashboard) category(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	id, err := pathID(r, "id")
+	if err != nil {
+		return err
+	}
+
+	tree, err := d.categoryTree(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	c, ok := tree[id]
+	if !ok {
+		return errs.NewObjectNotFoundError("category", id.String())
+	}
+
+	now, err := d.now(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	month, err := queryMonth(r, now)
+	if err != nil {
+		return err
+	}
+
+	after, err := decodeCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return err
+	}
+
+	from, to := month, month.AddDate(0, 1, 0)
+
+	summaryQuery, err := queries.NewGetSummaryQuery(u.ID(), from, to)
+	if err != nil {
+		return err
+	}
+
+	summary, err := d.getSummaryQueryHandler.Handle(r.Context(), summaryQuery)
+	if err != nil {
+		return err
+	}
+
+	filter, err := report.HistoryFilter{}.WithCategory(id).WithPeriod(from, to)
+	if err != nil {
+		return err
+	}
+
+	historyQuery, err := queries.NewGetTransactionHistoryQuery(u.ID(), filter, after, historyPageSize)
+	if err != nil {
+		return err
+	}
+
+	page, err := d.getTransactionHistoryQueryHandler.Handle(r.Context(), historyQuery)
+	if err != nil {
+		return err
+	}
+
+	view := categoryView{
+		ID:     id.String(),
+		Name:   c.Name(),
+		Income: c.Type() == category.TypeIncome,
+		Nav:    newMonthNav(month, now),
+		Lines:  newLineRows(page.Lines, page.Location),
+	}
+
+	if parent, ok := tree[c.ParentID()]; ok {
+		view.ParentID = parent.ID().String()
+		view.Parent = parent.Name()
+	}
+
+	if page.HasMore {
+		view.Next = encodeCursor(page.Next())
+	}
+
+	total, children := categoryTotals(tree, id, summary)
+	view.Total = report.FormatMoney(total)
+	view.Children = categoryList{Month: view.Nav.Month, Rows: children}
+
+	d.render(w, r, http.StatusOK, "category", view)
+
+	return nil
+}
+
+// categoryTotals возвращает сумму по категории id вместе с подкатегориями и суммы ее прямых
+// подкатегорий. Транзакции, записанные в саму категорию, попадают только в общую сумму.
+func categoryTotals(tree categoryTree, id shared.ID, summary *report.Summary) (decimal.Decimal, []categoryRow) {
+	totals := summary.Expenses()
+	if c := tree[id]; c.Type() == category.TypeIncome {
+		totals = summary.Incomes()
+	}
+
+	var (
+		total    decimal.Decimal
+		children []report.CategoryTotal
+	)
+
+	for _, ct := range totals {
+		if !tree.within(ct.CategoryID(), id) {
+			continue
+		}
+
+		total = total.Add(ct.Amount())
+
+		if ct.CategoryID() != id {
+			children = append(children, ct)
+		}
+	}
+
+	return total, tree.rollUp(children, id, total)
+}
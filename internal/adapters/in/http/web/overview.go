@@ -0,0 +1,63 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+type overviewView struct {
+	Nav      monthNav
+	Income   string
+	Expense  string
+	Balance  string
+	Deficit  bool
+	Expenses categoryList
+	Incomes  categoryList
+}
+
+// overview показывает доходы и расходы за месяц по корневым категориям.
+func (d *Dashboard) overview(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	now, err := d.now(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	month, err := queryMonth(r, now)
+	if err != nil {
+		return err
+	}
+
+	query, err := queries.NewGetSummaryQuery(u.ID(), month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return err
+	}
+
+	summary, err := d.getSummaryQueryHandler.Handle(r.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	tree, err := d.categoryTree(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	balance := summary.TotalIncome().Sub(summary.TotalExpense())
+	nav := newMonthNav(month, now)
+
+	d.render(w, r, http.StatusOK, "overview", overviewView{
+		Nav:      nav,
+		Income:   report.FormatMoney(summary.TotalIncome()),
+		Expense:  report.FormatMoney(summary.TotalExpense()),
+		Balance:  report.FormatMoney(balance),
+		Deficit:  balance.IsNegative(),
+		Expenses: categoryList{Month: nav.Month, Rows: tree.rollUp(summary.Expenses(), shared.ID{}, summary.TotalExpense())},
+		Incomes:  categoryList{Month: nav.Month, Rows: tree.rollUp(summary.Incomes(), shared.ID{}, summary.TotalIncome())},
+	})
+
+	return nil
+}
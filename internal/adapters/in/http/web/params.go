@@ -0,0 +1,102 @@
+package web
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// monthLayout формат месяца в адресах страниц, например 2026-10.
+const monthLayout = "2006-01"
+
+// pathID разбирает идентификатор из пути. Неверный идентификатор не может принадлежать
+// ни одному объекту, поэтому такой запрос получает 404.
+func pathID(r *http.Request, name string) (shared.ID, error) {
+	value := r.PathValue(name)
+
+	id, err := shared.NewIDFromString(value)
+	if err != nil {
+		return shared.ID{}, errs.NewObjectNotFoundError(name, value)
+	}
+
+	return id, nil
+}
+
+// queryMonth разбирает месяц из параметра month и возвращает его начало в часовом поясе now.
+// Без параметра возвращается текущий месяц.
+func queryMonth(r *http.Request, now time.Time) (time.Time, error) {
+	value := r.URL.Query().Get("month")
+	if value == "" {
+		return startOfMonth(now), nil
+	}
+
+	month, err := time.ParseInLocation(monthLayout, value, now.Location())
+	if err != nil {
+		return time.Time{}, badRequest("Месяц нужно указать в виде ГГГГ-ММ")
+	}
+
+	return month, nil
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// encodeCursor упаковывает позицию в истории в строку для ссылки на следующую страницу.
+func encodeCursor(c report.HistoryCursor) string {
+	if c.IsZero() {
+		return ""
+	}
+
+	raw := strconv.FormatInt(c.OccurredAt().UnixNano(), 10) + "_" + c.ID().String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (report.HistoryCursor, error) {
+	if value == "" {
+		return report.HistoryCursor{}, nil
+	}
+
+	invalid := badRequest("Неверная ссылка на страницу")
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return report.HistoryCursor{}, invalid
+	}
+
+	nanosText, idText, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return report.HistoryCursor{}, invalid
+	}
+
+	nanos, err := strconv.ParseInt(nanosText, 10, 64)
+	if err != nil {
+		return report.HistoryCursor{}, invalid
+	}
+
+	id, err := shared.NewIDFromString(idText)
+	if err != nil {
+		return report.HistoryCursor{}, invalid
+	}
+
+	return report.RestoreHistoryCursor(time.Unix(0, nanos).UTC(), id), nil
+}
+
+// now возвращает текущее время в часовом поясе пользователя: в нем считаются границы месяцев.
+func (d *Dashboard) now(ctx context.Context, userID shared.ID) (time.Time, error) {
+	s, err := d.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(userID))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Now().In(s.Location()), nil
+}
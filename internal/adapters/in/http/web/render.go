@@ -0,0 +1,83 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// pageNames страницы панели. Каждая страница определяет шаблоны title и content
+// и выводится внутри общего шаблона layout.
+var pageNames = []string{"login", "error", "overview", "category", "trends", "transactions"}
+
+func parsePages() (map[string]*template.Template, error) {
+	pages := make(map[string]*template.Template, len(pageNames))
+
+	for _, name := range pageNames {
+		t, err := template.ParseFS(assets, "templates/layout.html", "templates/"+name+".html")
+		if err != nil {
+			return nil, err
+		}
+
+		pages[name] = t
+	}
+
+	return pages, nil
+}
+
+// badRequestError параметр запроса не удалось разобрать.
+type badRequestError struct {
+	message string
+}
+
+func (e *badRequestError) Error() string {
+	return e.message
+}
+
+func badRequest(message string) error {
+	return &badRequestError{message: message}
+}
+
+// errorView данные страницы ошибки.
+type errorView struct {
+	Message string
+}
+
+// render выводит страницу name. Страница собирается целиком до отправки, чтобы ошибка
+// в шаблоне не оставила клиента с половиной страницы и кодом 200.
+func (d *Dashboard) render(w http.ResponseWriter, r *http.Request, status int, name string, data any) {
+	var buf bytes.Buffer
+
+	if err := d.pages[name].ExecuteTemplate(&buf, "layout", data); err != nil {
+		d.logger.Error("web dashboard: render failed", "page", name, "path", r.URL.Path, "err", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	// Заголовок уже отправлен, поэтому ошибку записи клиенту сообщить нельзя.
+	_, _ = buf.WriteTo(w)
+}
+
+// fail показывает страницу ошибки err. Неразобранный запрос получает 400, отсутствующий объект 404.
+// Остальные ошибки записываются в журнал и показываются без подробностей.
+func (d *Dashboard) fail(w http.ResponseWriter, r *http.Request, err error) {
+	var badRequestErr *badRequestError
+
+	switch {
+	case errors.As(err, &badRequestErr):
+		d.render(w, r, http.StatusBadRequest, "error", errorView{Message: badRequestErr.message})
+	case errors.Is(err, errs.ErrObjectNotFound):
+		d.render(w, r, http.StatusNotFound, "error", errorView{Message: "Страница не найдена"})
+	default:
+		d.logger.Error("web dashboard: request failed", "method", r.Method, "path", r.URL.Path, "err", err)
+		d.render(w, r, http.StatusInternalServerError, "error", errorView{Message: "Что-то пошло не так. Попробуйте позже"})
+	}
+}
@@ -0,0 +1,77 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+type transactionsView struct {
+	Nav   monthNav
+	Type  string
+	Lines []lineRow
+	Next  string
+}
+
+// transactions показывает транзакции за месяц, новые сверху. Параметр type оставляет только
+// доходы или только расходы.
+func (d *Dashboard) transactions(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	now, err := d.now(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	month, err := queryMonth(r, now)
+	if err != nil {
+		return err
+	}
+
+	after, err := decodeCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return err
+	}
+
+	filter, err := report.HistoryFilter{}.WithPeriod(month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return err
+	}
+
+	typeText := r.URL.Query().Get("type")
+	if typeText != "" {
+		t, err := category.ParseType(typeText)
+		if err != nil {
+			return badRequest("Неверный тип транзакций")
+		}
+
+		if filter, err = filter.WithType(t); err != nil {
+			return err
+		}
+	}
+
+	query, err := queries.NewGetTransactionHistoryQuery(u.ID(), filter, after, historyPageSize)
+	if err != nil {
+		return err
+	}
+
+	page, err := d.getTransactionHistoryQueryHandler.Handle(r.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	view := transactionsView{
+		Nav:   newMonthNav(month, now),
+		Type:  typeText,
+		Lines: newLineRows(page.Lines, page.Location),
+	}
+
+	if page.HasMore {
+		view.Next = encodeCursor(page.Next())
+	}
+
+	d.render(w, r, http.StatusOK, "transactions", view)
+
+	return nil
+}
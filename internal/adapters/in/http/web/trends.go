@@ -0,0 +1,144 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+// Размеры столбчатой диаграммы в единицах SVG.
+const (
+	chartHeight   = 160
+	chartColumn   = 48
+	chartBarWidth = 16
+	chartBarGap   = 2
+	chartLabelGap = 16
+)
+
+// trendRanges число месяцев, за которое можно посмотреть динамику.
+var trendRanges = []int{6, 12, 24}
+
+const defaultTrendRange = 12
+
+type trendsView struct {
+	Months  int
+	Ranges  []int
+	Width   int
+	Height  int
+	Columns []trendColumn
+}
+
+// trendColumn доходы и расходы за месяц со столбцами диаграммы.
+type trendColumn struct {
+	Month   string
+	Label   string
+	Title   string
+	Income  string
+	Expense string
+	Balance string
+	Deficit bool
+
+	LabelX     int
+	IncomeBar  chartBar
+	ExpenseBar chartBar
+}
+
+type chartBar struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+type monthTotals struct {
+	income  decimal.Decimal
+	expense decimal.Decimal
+}
+
+// trends показывает доходы и расходы по месяцам за последние месяцы, включая текущий.
+func (d *Dashboard) trends(w http.ResponseWriter, r *http.Request, u *user.User) error {
+	months := defaultTrendRange
+	if value := r.URL.Query().Get("months"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || !slices.Contains(trendRanges, n) {
+			return badRequest("Неверный период")
+		}
+
+		months = n
+	}
+
+	now, err := d.now(r.Context(), u.ID())
+	if err != nil {
+		return err
+	}
+
+	first := startOfMonth(now).AddDate(0, 1-months, 0)
+
+	totals := make([]monthTotals, 0, months)
+	maxAmount := decimal.Zero
+
+	for i := range months {
+		from := first.AddDate(0, i, 0)
+
+		query, err := queries.NewGetSummaryQuery(u.ID(), from, from.AddDate(0, 1, 0))
+		if err != nil {
+			return err
+		}
+
+		summary, err := d.getSummaryQueryHandler.Handle(r.Context(), query)
+		if err != nil {
+			return err
+		}
+
+		t := monthTotals{income: summary.TotalIncome(), expense: summary.TotalExpense()}
+		totals = append(totals, t)
+		maxAmount = decimal.Max(maxAmount, t.income, t.expense)
+	}
+
+	view := trendsView{
+		Months:  months,
+		Ranges:  trendRanges,
+		Width:   months * chartColumn,
+		Height:  chartHeight + chartLabelGap,
+		Columns: make([]trendColumn, 0, months),
+	}
+
+	for i, t := range totals {
+		month := first.AddDate(0, i, 0)
+		balance := t.income.Sub(t.expense)
+		x := i * chartColumn
+
+		view.Columns = append(view.Columns, trendColumn{
+			Month:      month.Format(monthLayout),
+			Label:      shortMonthNames[month.Month()-1],
+			Title:      monthTitle(month),
+			Income:     report.FormatMoney(t.income),
+			Expense:    report.FormatMoney(t.expense),
+			Balance:    report.FormatMoney(balance),
+			Deficit:    balance.IsNegative(),
+			LabelX:     x + chartColumn/2,
+			IncomeBar:  newChartBar(x+chartColumn/2-chartBarWidth-chartBarGap/2, t.income, maxAmount),
+			ExpenseBar: newChartBar(x+chartColumn/2+chartBarGap/2, t.expense, maxAmount),
+		})
+	}
+
+	d.render(w, r, http.StatusOK, "trends", view)
+
+	return nil
+}
+
+// newChartBar возвращает столбец высотой, пропорциональной amount, стоящий на оси диаграммы.
+func newChartBar(x int, amount decimal.Decimal, maxAmount decimal.Decimal) chartBar {
+	height := 0
+	if maxAmount.IsPositive() {
+		height = int(amount.Mul(decimal.NewFromInt(chartHeight)).Div(maxAmount).Round(0).IntPart())
+	}
+
+	return chartBar{X: x, Y: chartHeight - height, Width: chartBarWidth, Height: height}
+}
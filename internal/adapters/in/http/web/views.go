@@ -0,0 +1,203 @@
+package web
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// historyPageSize число транзакций на странице списка.
+const historyPageSize = queries.MaxHistoryPageSize
+
+var monthNames = [...]string{
+	"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь",
+	"Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь",
+}
+
+var shortMonthNames = [...]string{
+	"янв", "фев", "мар", "апр", "май", "июн",
+	"июл", "авг", "сен", "окт", "ноя", "дек",
+}
+
+// monthNav заголовок месяца со ссылками на соседние месяцы. Next пуст для текущего месяца:
+// будущих транзакций не бывает.
+type monthNav struct {
+	Title string
+	Month string
+	Prev  string
+	Next  string
+}
+
+func newMonthNav(month time.Time, now time.Time) monthNav {
+	nav := monthNav{
+		Title: monthTitle(month),
+		Month: month.Format(monthLayout),
+		Prev:  month.AddDate(0, -1, 0).Format(monthLayout),
+	}
+
+	if next := month.AddDate(0, 1, 0); !next.After(now) {
+		nav.Next = next.Format(monthLayout)
+	}
+
+	return nav
+}
+
+func monthTitle(month time.Time) string {
+	return monthNames[month.Month()-1] + " " + month.Format("2006")
+}
+
+// categoryRow строка суммы по категории. Share доля суммы от итога в процентах.
+// ID пуст, если категория не найдена, и тогда строка не ссылается на категорию.
+type categoryRow struct {
+	ID     string
+	Name   string
+	Amount string
+	Share  int
+}
+
+// categoryList суммы по категориям за месяц Month, на который ссылаются строки.
+type categoryList struct {
+	Month string
+	Rows  []categoryRow
+}
+
+// lineRow строка списка транзакций.
+type lineRow struct {
+	Date     string
+	Category string
+	Note     string
+	Amount   string
+	Income   bool
+}
+
+func newLineRows(lines []report.TransactionLine, loc *time.Location) []lineRow {
+	rows := make([]lineRow, 0, len(lines))
+
+	for _, l := range lines {
+		name := l.CategoryName()
+		if l.ParentName() != "" {
+			name = l.ParentName() + " › " + name
+		}
+
+		rows = append(rows, lineRow{
+			Date:     l.OccurredAt().In(loc).Format("02.01.2006 15:04"),
+			Category: name,
+			Note:     l.Note(),
+			Amount:   report.FormatMoney(l.Amount()),
+			Income:   l.CategoryType() == category.TypeIncome,
+		})
+	}
+
+	return rows
+}
+
+// share возвращает долю part от total в целых процентах.
+func share(part, total decimal.Decimal) int {
+	if !total.IsPositive() {
+		return 0
+	}
+
+	return int(part.Mul(decimal.NewFromInt(100)).Div(total).Round(0).IntPart())
+}
+
+// categoryTree категории пользователя обоих типов по идентификаторам.
+type categoryTree map[shared.ID]*category.Category
+
+func (d *Dashboard) categoryTree(ctx context.Context, userID shared.ID) (categoryTree, error) {
+	categories, err := d.getUserCategoriesQueryHandler.Handle(ctx, queries.NewGetUserCategoriesQuery(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	tree := make(categoryTree, len(categories))
+	for _, c := range categories {
+		tree[c.ID()] = c
+	}
+
+	return tree, nil
+}
+
+// childOf возвращает категорию, которая непосредственно входит в parent и содержит категорию id.
+// Нулевой parent означает корневые категории.
+func (t categoryTree) childOf(id shared.ID, parent shared.ID) shared.ID {
+	for {
+		c, ok := t[id]
+		if !ok || c.ParentID() == parent {
+			return id
+		}
+
+		id = c.ParentID()
+	}
+}
+
+// within сообщает, что категория id совпадает с ancestor или входит в нее.
+func (t categoryTree) within(id shared.ID, ancestor shared.ID) bool {
+	for {
+		if id == ancestor {
+			return true
+		}
+
+		c, ok := t[id]
+		if !ok || c.ParentID().IsZero() {
+			return false
+		}
+
+		id = c.ParentID()
+	}
+}
+
+// rollUp складывает суммы по категориям в суммы категорий, непосредственно входящих в parent,
+// и упорядочивает их по убыванию. Нулевой parent означает корневые категории.
+func (t categoryTree) rollUp(totals []report.CategoryTotal, parent shared.ID, total decimal.Decimal) []categoryRow {
+	type childSum struct {
+		id     shared.ID
+		amount decimal.Decimal
+	}
+
+	var sums []childSum
+
+	for _, ct := range totals {
+		id := t.childOf(ct.CategoryID(), parent)
+
+		i := slices.IndexFunc(sums, func(s childSum) bool { return s.id == id })
+		if i < 0 {
+			sums = append(sums, childSum{id: id})
+			i = len(sums) - 1
+		}
+
+		sums[i].amount = sums[i].amount.Add(ct.Amount())
+	}
+
+	slices.SortStableFunc(sums, func(a, b childSum) int {
+		return b.amount.Cmp(a.amount)
+	})
+
+	rows := make([]categoryRow, 0, len(sums))
+	for _, s := range sums {
+		rows = append(rows, t.row(s.id, s.amount, total))
+	}
+
+	return rows
+}
+
+func (t categoryTree) row(id shared.ID, amount decimal.Decimal, total decimal.Decimal) categoryRow {
+	row := categoryRow{
+		Name:   "Без категории",
+		Amount: report.FormatMoney(amount),
+		Share:  share(amount, total),
+	}
+
+	if c, ok := t[id]; ok {
+		row.ID = id.String()
+		row.Name = c.Name()
+	}
+
+	return row
+}
@@ -0,0 +1,146 @@
+// Package web реализует веб-панель только для чтения: обзор месяца, расходы по категориям,
+// динамику по месяцам и список транзакций. Страницы собираются на сервере из шаблонов,
+// встроенных в исполняемый файл. Вход выполняется по одноразовой ссылке из команды /web в боте.
+package web
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+	"net/http"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// BasePath префикс адресов веб-панели, под которым ее нужно подключать к серверу.
+const BasePath = "/web/"
+
+// contentSecurityPolicy запрещает странице все, кроме собственных стилей и отправки форм
+// на тот же сервер. Скриптов в панели нет.
+const contentSecurityPolicy = "default-src 'none'; style-src 'self'; img-src 'self'; " +
+	"form-action 'self'; frame-ancestors 'none'; base-uri 'none'"
+
+//go:embed templates static
+var assets embed.FS
+
+// Dashboard обрабатывает запросы к веб-панели.
+type Dashboard struct {
+	logger ports.Logger
+	mux    *http.ServeMux
+	pages  map[string]*template.Template
+
+	// secureCookie требует передавать cookie сеанса только по HTTPS.
+	secureCookie bool
+
+	startWebSessionCommandHandler      commands.StartWebSessionCommandHandler
+	endWebSessionCommandHandler        commands.EndWebSessionCommandHandler
+	authenticateWebSessionQueryHandler queries.AuthenticateWebSessionQueryHandler
+	getUserSettingsQueryHandler        queries.GetUserSettingsQueryHandler
+	getUserCategoriesQueryHandler      queries.GetUserCategoriesQueryHandler
+	getSummaryQueryHandler             queries.GetSummaryQueryHandler
+	getTransactionHistoryQueryHandler  queries.GetTransactionHistoryQueryHandler
+}
+
+// NewDashboard создает веб-панель. secureCookie нужно включать, когда панель открывается по HTTPS.
+func NewDashboard(
+	logger ports.Logger,
+	secureCookie bool,
+	startWebSessionCommandHandler commands.StartWebSessionCommandHandler,
+	endWebSessionCommandHandler commands.EndWebSessionCommandHandler,
+	authenticateWebSessionQueryHandler queries.AuthenticateWebSessionQueryHandler,
+	getUserSettingsQueryHandler queries.GetUserSettingsQueryHandler,
+	getUserCategoriesQueryHandler queries.GetUserCategoriesQueryHandler,
+	getSummaryQueryHandler queries.GetSummaryQueryHandler,
+	getTransactionHistoryQueryHandler queries.GetTransactionHistoryQueryHandler,
+) (*Dashboard, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if startWebSessionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("startWebSessionCommandHandler")
+	}
+
+	if endWebSessionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("endWebSessionCommandHandler")
+	}
+
+	if authenticateWebSessionQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("authenticateWebSessionQueryHandler")
+	}
+
+	if getUserSettingsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserSettingsQueryHandler")
+	}
+
+	if getUserCategoriesQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserCategoriesQueryHandler")
+	}
+
+	if getSummaryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getSummaryQueryHandler")
+	}
+
+	if getTransactionHistoryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTransactionHistoryQueryHandler")
+	}
+
+	pages, err := parsePages()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dashboard{
+		logger:                             logger,
+		mux:                                http.NewServeMux(),
+		pages:                              pages,
+		secureCookie:                       secureCookie,
+		startWebSessionCommandHandler:      startWebSessionCommandHandler,
+		endWebSessionCommandHandler:        endWebSessionCommandHandler,
+		authenticateWebSessionQueryHandler: authenticateWebSessionQueryHandler,
+		getUserSettingsQueryHandler:        getUserSettingsQueryHandler,
+		getUserCategoriesQueryHandler:      getUserCategoriesQueryHandler,
+		getSummaryQueryHandler:             getSummaryQueryHandler,
+		getTransactionHistoryQueryHandler:  getTransactionHistoryQueryHandler,
+	}
+
+	if err := d.routes(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *Dashboard) routes() error {
+	static, err := fs.Sub(assets, "static")
+	if err != nil {
+		return err
+	}
+
+	d.mux.Handle("GET "+BasePath+"static/", http.StripPrefix(BasePath+"static/", http.FileServerFS(static)))
+
+	d.mux.HandleFunc("GET "+BasePath+"login", d.loginForm)
+	d.mux.HandleFunc("POST "+BasePath+"login", d.login)
+	d.mux.HandleFunc("POST "+BasePath+"logout", d.logout)
+
+	d.handle("GET "+BasePath+"{$}", d.overview)
+	d.handle("GET "+BasePath+"categories/{id}", d.category)
+	d.handle("GET "+BasePath+"trends", d.trends)
+	d.handle("GET "+BasePath+"transactions", d.transactions)
+
+	return nil
+}
+
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Set("Content-Security-Policy", contentSecurityPolicy)
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("X-Frame-Options", "DENY")
+	// Адрес страницы входа содержит секрет ссылки, поэтому он не должен уходить в Referer.
+	h.Set("Referrer-Policy", "no-referrer")
+
+	d.mux.ServeHTTP(w, r)
+}
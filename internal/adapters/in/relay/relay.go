@@ -0,0 +1,101 @@
+// Package relay доставляет подписчикам доменные события, сохраненные в outbox.
+// Несколько экземпляров бота могут работать с одной базой: сообщения захватываются
+// с пропуском заблокированных строк, поэтому каждое сообщение доставляет один экземпляр.
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type Options struct {
+	// PollInterval период опроса outbox на наличие событий к доставке.
+	PollInterval time.Duration
+	// BatchSize количество сообщений, захватываемых за раз.
+	BatchSize int
+	// LockTimeout время, на которое захватывается порция сообщений. Не доставленные за это время
+	// сообщения доставляются повторно, поэтому оно должно быть больше времени работы подписчиков.
+	LockTimeout time.Duration
+}
+
+type Relay struct {
+	logger  ports.Logger
+	handler commands.RelayOutboxCommandHandler
+	opts    Options
+}
+
+func NewRelay(logger ports.Logger, handler commands.RelayOutboxCommandHandler, opts Options) (*Relay, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if handler == nil {
+		return nil, errs.NewValueIsRequiredError("handler")
+	}
+
+	if opts.PollInterval <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.PollInterval")
+	}
+
+	if opts.BatchSize <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.BatchSize")
+	}
+
+	if opts.LockTimeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.LockTimeout")
+	}
+
+	return &Relay{logger: logger, handler: handler, opts: opts}, nil
+}
+
+// Run доставляет события до отмены ctx. Начатая порция после отмены дорабатывает
+// в пределах LockTimeout, после чего Run возвращает управление.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	r.logger.Info("outbox relay started", "batch_size", r.opts.BatchSize)
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("outbox relay stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain доставляет порции сообщений, пока outbox не опустеет или не будет отменен ctx.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := r.relayBatch(ctx)
+		if err != nil {
+			r.logger.Error("outbox relay", "err", err)
+			return
+		}
+
+		if claimed < r.opts.BatchSize {
+			return
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	// Отмена не прерывает доставку захваченной порции: иначе подписчики получат события повторно.
+	batchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.opts.LockTimeout)
+	defer cancel()
+
+	cmd, err := commands.NewRelayOutboxCommand(time.Now(), r.opts.LockTimeout, r.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.handler.Handle(batchCtx, cmd)
+}
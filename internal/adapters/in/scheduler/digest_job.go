@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+)
+
+const (
+	// KindSendDigests тип задачи рассылки сводок расходов.
+	KindSendDigests = "digest.send"
+
+	// SendDigestsInterval период проверки, не пора ли отправить сводки.
+	SendDigestsInterval = 5 * time.Minute
+)
+
+// NewSendDigestsJob возвращает обработчик задачи рассылки сводок.
+func NewSendDigestsJob(handler commands.SendDigestsCommandHandler) Handler {
+	return func(ctx context.Context, _ *job.Job) error {
+		cmd, err := commands.NewSendDigestsCommand(time.Now())
+		if err != nil {
+			return err
+		}
+
+		return handler.Handle(ctx, cmd)
+	}
+}
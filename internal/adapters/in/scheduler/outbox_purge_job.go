@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+)
+
+const (
+	// KindPurgeOutbox тип задачи удаления из outbox сообщений, срок хранения которых истек.
+	KindPurgeOutbox = "outbox.purge"
+
+	// PurgeOutboxInterval период удаления старых сообщений outbox.
+	PurgeOutboxInterval = time.Hour
+)
+
+// NewPurgeOutboxJob возвращает обработчик задачи удаления старых сообщений outbox. Доставленные
+// сообщения хранятся deliveredRetention, недоставленные — deadRetention.
+func NewPurgeOutboxJob(handler commands.PurgeOutboxCommandHandler, deliveredRetention, deadRetention time.Duration) Handler {
+	return func(ctx context.Context, _ *job.Job) error {
+		cmd, err := commands.NewPurgeOutboxCommand(time.Now(), deliveredRetention, deadRetention)
+		if err != nil {
+			return err
+		}
+
+		return handler.Handle(ctx, cmd)
+	}
+}
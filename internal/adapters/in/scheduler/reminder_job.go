@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+)
+
+const (
+	// KindSendReminders тип задачи рассылки напоминаний о бездействии.
+	KindSendReminders = "reminder.send"
+
+	// SendRemindersInterval период проверки пользователей, которые давно ничего не записывали.
+	SendRemindersInterval = 15 * time.Minute
+)
+
+// NewSendRemindersJob возвращает обработчик задачи рассылки напоминаний.
+func NewSendRemindersJob(handler commands.SendRemindersCommandHandler) Handler {
+	return func(ctx context.Context, _ *job.Job) error {
+		cmd, err := commands.NewSendRemindersCommand(time.Now())
+		if err != nil {
+			return err
+		}
+
+		return handler.Handle(ctx, cmd)
+	}
+}
@@ -0,0 +1,343 @@
+// Package scheduler выполняет фоновые задачи, сохраненные в хранилище задач.
+// Несколько экземпляров бота могут работать с одной базой: задачи захватываются
+// с пропуском заблокированных строк, поэтому каждая задача выполняется одним экземпляром.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// Handler выполняет задачу определенного типа.
+// Возвращенная ошибка приводит к повтору задачи с экспоненциальной задержкой.
+type Handler func(ctx context.Context, j *job.Job) error
+
+type Options struct {
+	// WorkerID идентифицирует экземпляр, захвативший задачу.
+	WorkerID string
+	// Workers количество одновременно выполняемых задач.
+	Workers int
+	// PollInterval период опроса хранилища на наличие задач к выполнению.
+	PollInterval time.Duration
+	// JobTimeout максимальное время выполнения одной задачи.
+	// Блокировка задачи действует чуть дольше, после чего ведущий экземпляр возвращает её в очередь.
+	JobTimeout time.Duration
+}
+
+type recurringJob struct {
+	kind     string
+	interval time.Duration
+}
+
+type Scheduler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+	elector    ports.LeaderElector
+	opts       Options
+
+	handlers  map[string]Handler
+	recurring []recurringJob
+
+	busy             atomic.Int32
+	isLeader         bool
+	recurringEnsured bool
+}
+
+func NewScheduler(
+	logger ports.Logger,
+	uowFactory ports.UnitOfWorkFactory,
+	elector ports.LeaderElector,
+	opts Options,
+) (*Scheduler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if elector == nil {
+		return nil, errs.NewValueIsRequiredError("elector")
+	}
+
+	if opts.WorkerID == "" {
+		return nil, errs.NewValueIsRequiredError("opts.WorkerID")
+	}
+
+	if opts.Workers <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.Workers")
+	}
+
+	if opts.PollInterval <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.PollInterval")
+	}
+
+	if opts.JobTimeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.JobTimeout")
+	}
+
+	return &Scheduler{
+		logger:     logger,
+		uowFactory: uowFactory,
+		elector:    elector,
+		opts:       opts,
+		handlers:   make(map[string]Handler),
+	}, nil
+}
+
+// Register регистрирует обработчик задач типа kind.
+// Регистрация должна выполняться до вызова Run.
+func (s *Scheduler) Register(kind string, handler Handler) {
+	s.handlers[kind] = handler
+}
+
+// Every регистрирует обработчик периодической задачи, выполняемой раз в interval.
+// Задача создается в хранилище ведущим экземпляром, если её там еще нет.
+func (s *Scheduler) Every(kind string, interval time.Duration, handler Handler) {
+	s.Register(kind, handler)
+	s.recurring = append(s.recurring, recurringJob{kind: kind, interval: interval})
+}
+
+// Run опрашивает хранилище и выполняет задачи до отмены ctx.
+// После отмены новые задачи не захватываются, а уже начатые дорабатывают
+// в пределах JobTimeout, после чего Run возвращает управление.
+func (s *Scheduler) Run(ctx context.Context) error {
+	jobs := make(chan *job.Job)
+
+	var wg sync.WaitGroup
+	for range s.opts.Workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := range jobs {
+				s.execute(ctx, j)
+				s.busy.Add(-1)
+			}
+		}()
+	}
+
+	defer func() {
+		close(jobs)
+		wg.Wait()
+		s.releaseLeadership()
+		s.logger.Info("scheduler stopped")
+	}()
+
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("scheduler started", "worker_id", s.opts.WorkerID, "workers", s.opts.Workers)
+
+	for {
+		s.tick(ctx, jobs)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context, jobs chan<- *job.Job) {
+	s.leaderDuties(ctx)
+
+	// Захватываем не больше задач, чем есть свободных обработчиков,
+	// чтобы блокировка не истекла, пока задача ждет своей очереди.
+	idle := s.opts.Workers - int(s.busy.Load())
+	if idle <= 0 {
+		return
+	}
+
+	claimed, err := s.claim(ctx, idle)
+	if err != nil {
+		s.logger.Error("scheduler claim jobs", "err", err)
+		return
+	}
+
+	for i, j := range claimed {
+		s.busy.Add(1)
+
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			s.busy.Add(-1)
+			// Незапущенные задачи вернутся в очередь после истечения блокировки.
+			s.logger.Info("scheduler stopping, claimed jobs left to expire", "count", len(claimed)-i)
+			return
+		}
+	}
+}
+
+func (s *Scheduler) leaderDuties(ctx context.Context) {
+	leader, err := s.elector.TryAcquire(ctx)
+	if err != nil {
+		s.logger.Error("scheduler leader election", "err", err)
+	}
+
+	if leader != s.isLeader {
+		s.logger.Info("scheduler leadership changed", "leader", leader, "worker_id", s.opts.WorkerID)
+		s.isLeader = leader
+		s.recurringEnsured = false
+	}
+
+	if !leader {
+		return
+	}
+
+	err = s.inTx(ctx, func(uow ports.UnitOfWork) error {
+		now := time.Now()
+
+		released, err := uow.JobRepository().ReleaseExpired(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		if released > 0 {
+			s.logger.Info("scheduler released expired jobs", "count", released)
+		}
+
+		if s.recurringEnsured {
+			return nil
+		}
+
+		for _, r := range s.recurring {
+			j, err := job.NewRecurring(r.kind, r.interval, now)
+			if err != nil {
+				return err
+			}
+
+			if err := uow.JobRepository().Enqueue(ctx, j); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("scheduler leader duties", "err", err)
+		return
+	}
+
+	s.recurringEnsured = true
+}
+
+func (s *Scheduler) claim(ctx context.Context, limit int) ([]*job.Job, error) {
+	var claimed []*job.Job
+
+	err := s.inTx(ctx, func(uow ports.UnitOfWork) error {
+		now := time.Now()
+		lockedUntil := now.Add(s.lease())
+
+		var err error
+		claimed, err = uow.JobRepository().ClaimDue(ctx, s.opts.WorkerID, now, lockedUntil, limit)
+
+		return err
+	})
+
+	return claimed, err
+}
+
+func (s *Scheduler) execute(ctx context.Context, j *job.Job) {
+	// Начатая задача доводится до конца даже при остановке бота,
+	// но не дольше JobTimeout.
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.opts.JobTimeout)
+	defer cancel()
+
+	err := s.safeHandle(jobCtx, j)
+	if err != nil {
+		s.logger.Error(
+			"scheduler job failed",
+			"job_id", j.ID().String(),
+			"kind", j.Kind(),
+			"attempt", j.Attempts(),
+			"err", err,
+		)
+
+		j.Fail(err, time.Now())
+	} else {
+		j.Complete(time.Now())
+	}
+
+	err = s.inTx(jobCtx, func(uow ports.UnitOfWork) error {
+		return uow.JobRepository().Save(jobCtx, s.opts.WorkerID, j)
+	})
+
+	switch {
+	case errors.Is(err, job.ErrLeaseLost):
+		s.logger.Info("scheduler job lease lost, result discarded", "job_id", j.ID().String(), "kind", j.Kind())
+	case err != nil:
+		s.logger.Error("scheduler save job", "job_id", j.ID().String(), "err", err)
+	}
+}
+
+func (s *Scheduler) safeHandle(ctx context.Context, j *job.Job) (err error) {
+	handler, ok := s.handlers[j.Kind()]
+	if !ok {
+		return fmt.Errorf("no handler registered for job kind %q", j.Kind())
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while handling job: %v", r)
+		}
+	}()
+
+	return handler(ctx, j)
+}
+
+func (s *Scheduler) inTx(ctx context.Context, fn func(uow ports.UnitOfWork) error) error {
+	uow, err := s.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			s.logger.Error("scheduler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+
+	if err := fn(uow); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
+
+func (s *Scheduler) releaseLeadership() {
+	if !s.isLeader {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.PollInterval)
+	defer cancel()
+
+	if err := s.elector.Release(ctx); err != nil {
+		s.logger.Error("scheduler release leadership", "err", err)
+	}
+
+	s.isLeader = false
+}
+
+// lease возвращает срок блокировки захваченной задачи.
+// Запас сверх JobTimeout покрывает сохранение результата.
+func (s *Scheduler) lease() time.Duration {
+	return s.opts.JobTimeout + time.Minute
+}
@@ -0,0 +1,393 @@
+package scheduler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/scheduler"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+const (
+	testWorkerID   = "worker-1"
+	testJobKind    = "export"
+	testJobTimeout = time.Second
+	testWait       = 2 * time.Second
+)
+
+// syncBuffer журнал, который можно читать, пока планировщик пишет в него из обработчиков.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+type schedulerMocks struct {
+	uow     *portsmocks.UnitOfWorkMock
+	jobs    *portsmocks.JobRepositoryMock
+	elector *portsmocks.LeaderElectorMock
+	log     *syncBuffer
+}
+
+func newSchedulerMocks(t *testing.T) schedulerMocks {
+	t.Helper()
+
+	m := schedulerMocks{
+		uow:     portsmocks.NewUnitOfWorkMock(t),
+		jobs:    portsmocks.NewJobRepositoryMock(t),
+		elector: portsmocks.NewLeaderElectorMock(t),
+		log:     &syncBuffer{},
+	}
+
+	m.uow.EXPECT().Begin(mock.Anything).Return(nil).Maybe()
+	m.uow.EXPECT().Commit(mock.Anything).Return(nil).Maybe()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Maybe()
+	m.uow.EXPECT().JobRepository().Return(m.jobs).Maybe()
+
+	return m
+}
+
+// follower делает экземпляр ведомым: он только захватывает и выполняет задачи.
+func (m schedulerMocks) follower() {
+	m.elector.EXPECT().TryAcquire(mock.Anything).Return(false, nil).Maybe()
+}
+
+// claimOnce отдает задачи при первом захвате, а дальше сообщает, что задач нет.
+func (m schedulerMocks) claimOnce(jobs ...*job.Job) {
+	m.jobs.EXPECT().ClaimDue(mock.Anything, testWorkerID, mock.Anything, mock.Anything, mock.Anything).Return(jobs, nil).Once()
+	m.jobs.EXPECT().ClaimDue(mock.Anything, testWorkerID, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+}
+
+// saved снимок задачи в момент сохранения результата.
+type saved struct {
+	status    job.Status
+	attempts  int
+	runAt     time.Time
+	lastError string
+}
+
+// expectSave сообщает в канал о каждом сохранении и возвращает err.
+func (m schedulerMocks) expectSave(err error) <-chan saved {
+	ch := make(chan saved, 1)
+
+	m.jobs.EXPECT().Save(mock.Anything, testWorkerID, mock.Anything).
+		Run(func(_ context.Context, _ string, j *job.Job) {
+			ch <- saved{status: j.Status(), attempts: j.Attempts(), runAt: j.RunAt(), lastError: j.LastError()}
+		}).
+		Return(err).
+		Once()
+
+	return ch
+}
+
+func newScheduler(t *testing.T, m schedulerMocks, workers int) *scheduler.Scheduler {
+	t.Helper()
+
+	factory := portsmocks.NewUnitOfWorkFactoryMock(t)
+	factory.EXPECT().New().Return(m.uow, nil).Maybe()
+
+	s, err := scheduler.NewScheduler(slog.New(slog.NewTextHandler(m.log, nil)), factory, m.elector, scheduler.Options{
+		WorkerID:     testWorkerID,
+		Workers:      workers,
+		PollInterval: 10 * time.Millisecond,
+		JobTimeout:   testJobTimeout,
+	})
+	require.NoError(t, err)
+
+	return s
+}
+
+// run запускает планировщик и возвращает функцию, которая останавливает его и ждет завершения Run.
+func run(t *testing.T, s *scheduler.Scheduler) (stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- s.Run(ctx) }()
+
+	var once sync.Once
+
+	stop = func() {
+		once.Do(func() {
+			cancel()
+
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(testWait):
+				// stop может вызываться не из горутины теста, поэтому без t.Fatal.
+				t.Error("scheduler did not stop")
+			}
+		})
+	}
+	t.Cleanup(stop)
+
+	return stop
+}
+
+func waitSaved(t *testing.T, ch <-chan saved) saved {
+	t.Helper()
+
+	select {
+	case s := <-ch:
+		return s
+	case <-time.After(testWait):
+		t.Fatal("job result was not saved")
+		return saved{}
+	}
+}
+
+// claimedJob задача в том виде, в каком её возвращает захват: running, с учтенной попыткой.
+func claimedJob(attempts, maxAttempts int) *job.Job {
+	now := time.Now()
+
+	return job.Restore(shared.NewID(), testJobKind, "", nil, job.StatusRunning, attempts, maxAttempts, 0, now, "", now, now)
+}
+
+func TestScheduler_ClaimsAndCompletesJob(t *testing.T) {
+	m := newSchedulerMocks(t)
+	m.follower()
+
+	claimed := claimedJob(1, 3)
+
+	var (
+		limit   atomic.Int32
+		leaseOK atomic.Bool
+	)
+
+	m.jobs.EXPECT().ClaimDue(mock.Anything, testWorkerID, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ string, now time.Time, lockedUntil time.Time, l int) {
+			limit.Store(int32(l))
+			// Блокировка переживает таймаут задачи, чтобы успеть сохранить результат.
+			leaseOK.Store(lockedUntil.Sub(now) > testJobTimeout)
+		}).
+		Return([]*job.Job{claimed}, nil).
+		Once()
+	m.jobs.EXPECT().ClaimDue(mock.Anything, testWorkerID, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	saves := m.expectSave(nil)
+
+	s := newScheduler(t, m, 2)
+
+	var handled atomic.Pointer[job.Job]
+	s.Register(testJobKind, func(_ context.Context, j *job.Job) error {
+		handled.Store(j)
+		return nil
+	})
+
+	run(t, s)
+
+	got := waitSaved(t, saves)
+	assert.Equal(t, job.StatusDone, got.status)
+	assert.Empty(t, got.lastError)
+	assert.Same(t, claimed, handled.Load())
+	assert.Equal(t, int32(2), limit.Load(), "claims no more jobs than idle workers")
+	assert.True(t, leaseOK.Load())
+}
+
+func TestScheduler_FailedJobIsRetriedWithBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler scheduler.Handler
+		wantErr string
+	}{
+		{
+			name:    "error",
+			handler: func(context.Context, *job.Job) error { return errors.New("smtp unavailable") },
+			wantErr: "smtp unavailable",
+		},
+		{
+			name:    "panic",
+			handler: func(context.Context, *job.Job) error { panic("nil map") },
+			wantErr: "panic while handling job: nil map",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newSchedulerMocks(t)
+			m.follower()
+			m.claimOnce(claimedJob(2, 5))
+			saves := m.expectSave(nil)
+
+			s := newScheduler(t, m, 1)
+			s.Register(testJobKind, tt.handler)
+
+			before := time.Now()
+			run(t, s)
+
+			got := waitSaved(t, saves)
+			assert.Equal(t, job.StatusPending, got.status)
+			assert.Equal(t, tt.wantErr, got.lastError)
+			assert.Equal(t, 2, got.attempts)
+			assert.WithinRange(t, got.runAt, before.Add(job.RetryDelay(2)), time.Now().Add(job.RetryDelay(2)))
+		})
+	}
+}
+
+func TestScheduler_FailedJobStopsAfterMaxAttempts(t *testing.T) {
+	m := newSchedulerMocks(t)
+	m.follower()
+	m.claimOnce(claimedJob(3, 3))
+	saves := m.expectSave(nil)
+
+	s := newScheduler(t, m, 1)
+	s.Register(testJobKind, func(context.Context, *job.Job) error { return errors.New("boom") })
+
+	run(t, s)
+
+	assert.Equal(t, job.StatusFailed, waitSaved(t, saves).status)
+}
+
+func TestScheduler_UnknownKindFails(t *testing.T) {
+	m := newSchedulerMocks(t)
+	m.follower()
+	m.claimOnce(claimedJob(1, 3))
+	saves := m.expectSave(nil)
+
+	run(t, newScheduler(t, m, 1))
+
+	got := waitSaved(t, saves)
+	assert.Equal(t, job.StatusPending, got.status)
+	assert.Contains(t, got.lastError, "no handler registered")
+}
+
+func TestScheduler_LeaseLostDiscardsResult(t *testing.T) {
+	m := newSchedulerMocks(t)
+	m.follower()
+	m.claimOnce(claimedJob(1, 3))
+	saves := m.expectSave(job.ErrLeaseLost)
+
+	s := newScheduler(t, m, 1)
+	s.Register(testJobKind, func(context.Context, *job.Job) error { return nil })
+
+	stop := run(t, s)
+	waitSaved(t, saves)
+	stop()
+
+	assert.Contains(t, m.log.String(), "scheduler job lease lost, result discarded")
+	assert.NotContains(t, m.log.String(), "scheduler save job")
+}
+
+func TestScheduler_GracefulShutdown(t *testing.T) {
+	m := newSchedulerMocks(t)
+
+	// Ведущий экземпляр при остановке отказывается от лидерства.
+	m.elector.EXPECT().TryAcquire(mock.Anything).Return(true, nil).Maybe()
+	m.elector.EXPECT().Release(mock.Anything).Return(nil).Once()
+	m.jobs.EXPECT().ReleaseExpired(mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
+
+	var claims atomic.Int32
+	m.jobs.EXPECT().ClaimDue(mock.Anything, testWorkerID, mock.Anything, mock.Anything, 1).
+		Run(func(context.Context, string, time.Time, time.Time, int) { claims.Add(1) }).
+		Return([]*job.Job{claimedJob(1, 3)}, nil).
+		Once()
+	saves := m.expectSave(nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var canceled atomic.Bool
+	s := newScheduler(t, m, 1)
+	s.Register(testJobKind, func(ctx context.Context, _ *job.Job) error {
+		close(started)
+		<-release
+
+		canceled.Store(ctx.Err() != nil)
+
+		return nil
+	})
+
+	stop := run(t, s)
+
+	select {
+	case <-started:
+	case <-time.After(testWait):
+		t.Fatal("job was not started")
+	}
+
+	// Пока единственный обработчик занят, новые задачи не захватываются.
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), claims.Load())
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("scheduler stopped before the running job finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case <-stopped:
+	case <-time.After(testWait):
+		t.Fatal("scheduler did not stop")
+	}
+
+	assert.Equal(t, job.StatusDone, waitSaved(t, saves).status)
+	assert.False(t, canceled.Load(), "running job must not see the shutdown")
+}
+
+func TestScheduler_LeaderEnsuresRecurringJobsOnce(t *testing.T) {
+	m := newSchedulerMocks(t)
+	m.elector.EXPECT().TryAcquire(mock.Anything).Return(true, nil).Maybe()
+	m.elector.EXPECT().Release(mock.Anything).Return(nil).Once()
+
+	var ticks atomic.Int32
+	m.jobs.EXPECT().ReleaseExpired(mock.Anything, mock.Anything).
+		Run(func(context.Context, time.Time) { ticks.Add(1) }).
+		Return(int64(0), nil)
+	m.jobs.EXPECT().ClaimDue(mock.Anything, testWorkerID, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	enqueued := make(chan *job.Job, 2)
+	m.jobs.EXPECT().Enqueue(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, j *job.Job) { enqueued <- j }).
+		Return(nil).
+		Once()
+
+	s := newScheduler(t, m, 1)
+	s.Every("digest", time.Hour, func(context.Context, *job.Job) error { return nil })
+
+	stop := run(t, s)
+	require.Eventually(t, func() bool { return ticks.Load() >= 3 }, testWait, time.Millisecond)
+	stop()
+
+	require.Len(t, enqueued, 1)
+
+	j := <-enqueued
+	assert.Equal(t, "digest", j.Kind())
+	assert.Equal(t, "digest", j.Key())
+	assert.Equal(t, time.Hour, j.Interval())
+}
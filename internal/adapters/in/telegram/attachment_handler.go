@@ -0,0 +1,258 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// maxAttachmentCopySize наибольший размер файла, копия которого сохраняется в хранилище.
+// Файлы больше Bot API не отдает, они прикрепляются только ссылкой.
+const maxAttachmentCopySize = 20 << 20
+
+// attachedFile файл из сообщения пользователя, который прикрепляется к транзакции.
+type attachedFile struct {
+	kind     attachment.Kind
+	fileID   string
+	uniqueID string
+	name     string
+	mimeType string
+	size     int
+}
+
+// repliedTransactionID возвращает транзакцию, на подтверждение записи которой ответил пользователь
+// файлом или суммой возврата.
+func (b *Bot) repliedTransactionID(msg *tgbotapi.Message) (shared.ID, bool) {
+	reply := msg.ReplyToMessage
+	if reply == nil || reply.From == nil || reply.From.ID != b.bot.Self.ID || reply.ReplyMarkup == nil {
+		return shared.ID{}, false
+	}
+
+	for _, row := range reply.ReplyMarkup.InlineKeyboard {
+		for _, button := range row {
+			if button.CallbackData == nil || !strings.HasPrefix(*button.CallbackData, transactionCbDetails) {
+				continue
+			}
+
+			id, err := uuid.Parse(strings.TrimPrefix(*button.CallbackData, transactionCbDetails))
+			if err != nil {
+				return shared.ID{}, false
+			}
+
+			return shared.RestoreID(id), true
+		}
+	}
+
+	return shared.ID{}, false
+}
+
+// handleAttachment прикрепляет файл к транзакции, на подтверждение которой ответил пользователь.
+func (b *Bot) handleAttachment(ctx context.Context, chatID int64, userID shared.ID, transactionID shared.ID, file attachedFile) error {
+	var open commands.FileOpener
+	if file.size <= maxAttachmentCopySize {
+		open = func(ctx context.Context) (io.ReadCloser, error) {
+			content, err := b.downloadFile(ctx, file.fileID, maxAttachmentCopySize)
+			if err != nil {
+				return nil, err
+			}
+
+			return io.NopCloser(bytes.NewReader(content)), nil
+		}
+	}
+
+	cmd, err := commands.NewAttachFileCommand(
+		userID,
+		transactionID,
+		file.kind,
+		file.fileID,
+		file.uniqueID,
+		file.name,
+		file.mimeType,
+		int64(file.size),
+		open,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.attachFileCommandHandler.Handle(ctx, cmd)
+
+	switch {
+	case errors.Is(err, attachment.ErrAlreadyAttached):
+		return b.sendMsg(chatID, "Этот файл уже прикреплен к транзакции")
+	case errors.Is(err, attachment.ErrTooMany):
+		return b.sendMsg(chatID, fmt.Sprintf("К транзакции можно прикрепить не больше %d файлов", attachment.MaxPerTransaction))
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return b.sendMsg(chatID, "Транзакция не найдена. Возможно, она удалена")
+	case err != nil:
+		if err2 := b.sendMsg(chatID, "Не удалось прикрепить файл. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о вложении", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	return b.sendMsg(chatID, "📎 Файл прикреплен к транзакции")
+}
+
+func (b *Bot) handleTransactionCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	if isSplitCb(cb.Data) {
+		return b.handleSplitCb(ctx, cb, u.ID())
+	}
+
+	if isRefundCb(cb.Data) {
+		return b.handleRefundCb(ctx, cb, u.ID())
+	}
+
+	var data string
+	switch {
+	case strings.HasPrefix(cb.Data, transactionCbDetails):
+		data = strings.TrimPrefix(cb.Data, transactionCbDetails)
+	case strings.HasPrefix(cb.Data, transactionCbAttachments):
+		data = strings.TrimPrefix(cb.Data, transactionCbAttachments)
+	default:
+		return errs.NewValueIsInvalidError("transaction callback " + cb.Data)
+	}
+
+	id, err := uuid.Parse(data)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("transaction callback "+cb.Data, err)
+	}
+
+	query := queries.NewGetTransactionDetailsQuery(u.ID(), shared.RestoreID(id))
+
+	details, err := b.getTransactionDetailsQueryHandler.Handle(ctx, query)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return b.sendMsg(chatID, "Транзакция не найдена. Возможно, она удалена")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(cb.Data, transactionCbAttachments) {
+		return b.sendAttachments(chatID, details.Attachments)
+	}
+
+	keyboard := newTransactionDetailsInlineKeyboard(details.Line.ID(), len(details.Attachments), len(details.Parts) > 0, details.CanRefund())
+
+	return b.sendReplyMarkup(chatID, composeTransactionDetails(details), &keyboard)
+}
+
+// sendAttachments повторно отправляет прикрепленные файлы по их идентификаторам в Telegram.
+func (b *Bot) sendAttachments(chatID int64, attachments []*attachment.Attachment) error {
+	if len(attachments) == 0 {
+		return b.sendMsg(chatID, "К транзакции не прикреплено файлов")
+	}
+
+	for _, a := range attachments {
+		var msg tgbotapi.Chattable
+
+		switch a.Kind() {
+		case attachment.KindPhoto:
+			msg = tgbotapi.NewPhoto(chatID, tgbotapi.FileID(a.FileID()))
+		default:
+			msg = tgbotapi.NewDocument(chatID, tgbotapi.FileID(a.FileID()))
+		}
+
+		if _, err := b.bot.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// composeTransactionDetails описывает транзакцию: время, сумму, категорию или части разделенной транзакции,
+// комментарий, возвраты и число вложений.
+func composeTransactionDetails(details *queries.TransactionDetails) string {
+	line := details.Line
+
+	sign := "−"
+	if line.CategoryType() == category.TypeIncome {
+		sign = "+"
+	}
+
+	name := composeCategoryPath(line.ParentName(), line.CategoryName())
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🧾 %s%s\n", sign, report.FormatMoney(line.Amount()))
+	fmt.Fprintf(&sb, "Дата: %s\n", line.OccurredAt().In(details.Location).Format("02.01.2006 15:04"))
+	if len(details.Parts) == 0 {
+		fmt.Fprintf(&sb, "Категория: %s", name)
+	} else {
+		sb.WriteString("Разделена по категориям:")
+
+		for _, part := range details.Parts {
+			fmt.Fprintf(&sb, "\n• %s: %s", composeCategoryPath(part.ParentName(), part.Name()), report.FormatAmount(part.Amount()))
+		}
+	}
+
+	if line.Note() != "" {
+		fmt.Fprintf(&sb, "\nКомментарий: %s", line.Note())
+	}
+
+	if len(details.Refunds) > 0 {
+		sb.WriteString("\nВозвраты:")
+
+		for _, r := range details.Refunds {
+			fmt.Fprintf(&sb, "\n• %s %s", r.OccurredAt().In(details.Location).Format("02.01.2006"), report.FormatMoney(r.Amount().Value()))
+
+			if len(details.Parts) > 0 {
+				fmt.Fprintf(&sb, " — %s", composePartName(details.Parts, r.CategoryID()))
+			}
+
+			if r.Note() != "" {
+				fmt.Fprintf(&sb, ", %s", r.Note())
+			}
+		}
+	}
+
+	if len(details.Attachments) > 0 {
+		fmt.Fprintf(&sb, "\nВложений: %d", len(details.Attachments))
+	}
+
+	return sb.String()
+}
+
+// composePartName возвращает название категории части разделенной транзакции.
+func composePartName(parts []report.CategoryTotal, categoryID shared.ID) string {
+	for _, p := range parts {
+		if p.CategoryID() == categoryID {
+			return p.Name()
+		}
+	}
+
+	return "другая категория"
+}
+
+// composeCategoryPath возвращает название категории вместе с родительской: «Еда › Кафе».
+func composeCategoryPath(parentName string, name string) string {
+	if parentName == "" {
+		return name
+	}
+
+	return parentName + " › " + name
+}
@@ -0,0 +1,181 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// maxBackupSize наибольший размер файла резервной копии, совпадает с ограничением Bot API на скачивание.
+	maxBackupSize = 20 << 20
+
+	// pendingRestoreTTL время, за которое пользователь должен подтвердить восстановление.
+	pendingRestoreTTL = 15 * time.Minute
+)
+
+func (b *Bot) handleBackupCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	name := fmt.Sprintf("backup_%s.json", time.Now().Format("2006-01-02"))
+	query := queries.NewExportBackupQuery(u.ID())
+
+	err = b.sendStream(chatID, name, func(w io.Writer) error {
+		return b.exportBackupQueryHandler.Handle(ctx, query, w)
+	})
+	if err != nil {
+		if err2 := b.sendMsg(chatID, "Не удалось создать резервную копию. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о резервной копии", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (b *Bot) handleRestoreCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	b.cache.Set(userStateKey(chatID), UserStateWaitingForBackup, cache.DefaultExpiration)
+
+	return b.sendMsg(chatID, "Отправьте файл резервной копии, полученный командой /backup.\n"+
+		"Копию можно восстановить только в аккаунт без категорий и транзакций")
+}
+
+// handleBackupDocument читает присланную резервную копию и просит подтвердить восстановление.
+func (b *Bot) handleBackupDocument(ctx context.Context, chatID int64, userID shared.ID, doc *tgbotapi.Document) error {
+	b.cache.Delete(userStateKey(chatID))
+
+	if doc.FileSize > maxBackupSize {
+		return b.sendMsg(chatID, fmt.Sprintf("Файл слишком большой: резервная копия должна быть не больше %d МБ", maxBackupSize>>20))
+	}
+
+	content, err := b.downloadFile(ctx, doc.FileID, maxBackupSize)
+	if err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			return b.sendMsg(chatID, fmt.Sprintf("Файл слишком большой: резервная копия должна быть не больше %d МБ", maxBackupSize>>20))
+		}
+
+		b.sendRestoreError(chatID)
+		return err
+	}
+
+	snapshot, err := b.readBackupQueryHandler.Handle(ctx, queries.NewReadBackupQuery(userID), bytes.NewReader(content))
+
+	switch {
+	case errors.Is(err, backup.ErrUnsupportedVersion):
+		return b.sendMsg(chatID, "Эта версия резервной копии не поддерживается")
+	case errors.Is(err, backup.ErrInvalidBackup):
+		return b.sendMsg(chatID, "Файл не похож на резервную копию или поврежден")
+	case errors.Is(err, backup.ErrAccountNotEmpty):
+		return b.sendMsg(chatID, "В аккаунте уже есть категории или транзакции. Копию можно восстановить только в пустой аккаунт")
+	case err != nil:
+		b.sendRestoreError(chatID)
+		return err
+	}
+
+	b.cache.Set(pendingRestoreKey(chatID), snapshot, pendingRestoreTTL)
+
+	keyboard := newRestoreConfirmInlineKeyboard()
+
+	return b.sendReplyMarkup(chatID, composeRestorePreview(snapshot), &keyboard)
+}
+
+func (b *Bot) handleBackupCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+	msgID := cb.Message.MessageID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	switch cb.Data {
+	case backupCbCancel:
+		b.cache.Delete(pendingRestoreKey(chatID))
+		return b.editMessage(chatID, msgID, "Восстановление отменено", nil)
+	case backupCbConfirm:
+		return b.confirmRestore(ctx, chatID, msgID, u.ID())
+	}
+
+	return errs.NewValueIsInvalidError("backup callback " + cb.Data)
+}
+
+func (b *Bot) confirmRestore(ctx context.Context, chatID int64, msgID int, userID shared.ID) error {
+	res, ok := b.cache.Get(pendingRestoreKey(chatID))
+	snapshot, _ := res.(*backup.Snapshot)
+
+	if !ok || snapshot == nil {
+		return b.editMessage(chatID, msgID, "Время ожидания истекло. Отправьте /restore еще раз", nil)
+	}
+
+	// Удаляем копию до восстановления, чтобы повторное нажатие кнопки не запустило его второй раз.
+	b.cache.Delete(pendingRestoreKey(chatID))
+
+	if err := b.editMessage(chatID, msgID, composeRestorePreview(snapshot), nil); err != nil {
+		b.logger.Error("Ошибка изменения сообщения восстановления", "err", err.Error())
+	}
+
+	cmd, err := commands.NewRestoreBackupCommand(userID, snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = b.restoreBackupCommandHandler.Handle(ctx, cmd)
+	switch {
+	case errors.Is(err, backup.ErrAccountNotEmpty):
+		return b.sendMsg(chatID, "В аккаунте уже есть категории или транзакции. Копию можно восстановить только в пустой аккаунт")
+	case err != nil:
+		b.sendRestoreError(chatID)
+		return err
+	}
+
+	return b.sendMsg(chatID, fmt.Sprintf(
+		"✅ Данные восстановлены\nКатегорий: %d\nТранзакций: %d",
+		len(snapshot.Categories()),
+		len(snapshot.Transactions()),
+	))
+}
+
+func (b *Bot) sendRestoreError(chatID int64) {
+	if err := b.sendMsg(chatID, "Не удалось восстановить данные. Попробуйте позже"); err != nil {
+		b.logger.Error("Ошибка отправки сообщения о восстановлении", "err", err.Error())
+	}
+}
+
+// composeRestorePreview описывает содержимое резервной копии перед восстановлением.
+func composeRestorePreview(snapshot *backup.Snapshot) string {
+	return fmt.Sprintf(
+		"💾 Резервная копия от %s\nКатегорий: %d\nТранзакций: %d\n\nВосстановить данные из копии?",
+		snapshot.CreatedAt().In(snapshot.Settings().Location()).Format("02.01.2006 15:04"),
+		len(snapshot.Categories()),
+		len(snapshot.Transactions()),
+	)
+}
+
+func pendingRestoreKey(chatID int64) string {
+	return fmt.Sprintf("pending-restore-%d", chatID)
+}
@@ -2,6 +2,9 @@ package telegram
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -14,8 +17,14 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// pollTimeout время ожидания обновлений в одном запросе getUpdates в секундах. После StopPolling
+// канал обновлений закрывается только по завершении текущего запроса, поэтому время заметно
+// меньше отведенного на остановку.
+const pollTimeout = 10
+
 type Bot struct {
-	logger ports.Logger
+	logger  ports.Logger
+	metrics ports.Metrics
 
 	bot *tgbotapi.BotAPI
 
@@ -24,27 +33,87 @@ type Bot struct {
 	userRegistrationCommandHandler        commands.UserRegistrationCommandHandler
 	createDefaultCategoriesCommandHandler commands.CreateDefaultCategoryCommandHandler
 	createTransactionCommandHandler       commands.CreateTransactionCommandHandler
+	updateSettingsCommandHandler          commands.UpdateSettingsCommandHandler
+	importTransactionsCommandHandler      commands.ImportTransactionsCommandHandler
+	restoreBackupCommandHandler           commands.RestoreBackupCommandHandler
+	attachFileCommandHandler              commands.AttachFileCommandHandler
+	updateTransactionCommandHandler       commands.UpdateTransactionCommandHandler
+	deleteTransactionCommandHandler       commands.DeleteTransactionCommandHandler
+	splitTransactionCommandHandler        commands.SplitTransactionCommandHandler
+	createRefundCommandHandler            commands.CreateRefundCommandHandler
+	issueAPITokenCommandHandler           commands.IssueAPITokenCommandHandler
+	revokeAPITokenCommandHandler          commands.RevokeAPITokenCommandHandler
+	issueWebLoginCommandHandler           commands.IssueWebLoginCommandHandler
 
 	getUserQueryHandler                 queries.GetUserQueryHandler
 	getUserCategoriesByTypeQueryHandler queries.GetUserCategoriesByTypeQueryHandler
+	getUserSettingsQueryHandler         queries.GetUserSettingsQueryHandler
+	exportTransactionsQueryHandler      queries.ExportTransactionsQueryHandler
+	prepareStatementImportQueryHandler  queries.PrepareStatementImportQueryHandler
+	exportBackupQueryHandler            queries.ExportBackupQueryHandler
+	readBackupQueryHandler              queries.ReadBackupQueryHandler
+	readReceiptQueryHandler             queries.ReadReceiptQueryHandler
+	findReturnedPurchaseQueryHandler    queries.FindReturnedPurchaseQueryHandler
+	getTransactionDetailsQueryHandler   queries.GetTransactionDetailsQueryHandler
+	getTransactionHistoryQueryHandler   queries.GetTransactionHistoryQueryHandler
+	searchTransactionsQueryHandler      queries.SearchTransactionsQueryHandler
+	getUserTagsQueryHandler             queries.GetUserTagsQueryHandler
+	getTagSummaryQueryHandler           queries.GetTagSummaryQueryHandler
+	getUserAPITokensQueryHandler        queries.GetUserAPITokensQueryHandler
 
 	allowedChatIDs map[int64]bool
+
+	// webBaseURL публичный адрес веб-панели. Пуст, если панель выключена.
+	webBaseURL string
+
+	// lastPolledAt время последнего успешного ответа на getUpdates в наносекундах Unix.
+	lastPolledAt atomic.Int64
 }
 
 func NewBot(
 	logger ports.Logger,
+	metrics ports.Metrics,
 	telegramBotToken string,
 	allowedChatIDs []int64,
+	webBaseURL string,
 	userRegistrationHandler commands.UserRegistrationCommandHandler,
 	createDefaultCategoriesCommandHandler commands.CreateDefaultCategoryCommandHandler,
 	createTransactionCommandHandler commands.CreateTransactionCommandHandler,
+	updateSettingsCommandHandler commands.UpdateSettingsCommandHandler,
+	importTransactionsCommandHandler commands.ImportTransactionsCommandHandler,
+	restoreBackupCommandHandler commands.RestoreBackupCommandHandler,
+	attachFileCommandHandler commands.AttachFileCommandHandler,
+	updateTransactionCommandHandler commands.UpdateTransactionCommandHandler,
+	deleteTransactionCommandHandler commands.DeleteTransactionCommandHandler,
+	splitTransactionCommandHandler commands.SplitTransactionCommandHandler,
+	createRefundCommandHandler commands.CreateRefundCommandHandler,
+	issueAPITokenCommandHandler commands.IssueAPITokenCommandHandler,
+	revokeAPITokenCommandHandler commands.RevokeAPITokenCommandHandler,
+	issueWebLoginCommandHandler commands.IssueWebLoginCommandHandler,
 	getUserCategoriesByTypeQueryHandler queries.GetUserCategoriesByTypeQueryHandler,
 	getUserQueryHandler queries.GetUserQueryHandler,
+	getUserSettingsQueryHandler queries.GetUserSettingsQueryHandler,
+	exportTransactionsQueryHandler queries.ExportTransactionsQueryHandler,
+	prepareStatementImportQueryHandler queries.PrepareStatementImportQueryHandler,
+	exportBackupQueryHandler queries.ExportBackupQueryHandler,
+	readBackupQueryHandler queries.ReadBackupQueryHandler,
+	readReceiptQueryHandler queries.ReadReceiptQueryHandler,
+	findReturnedPurchaseQueryHandler queries.FindReturnedPurchaseQueryHandler,
+	getTransactionDetailsQueryHandler queries.GetTransactionDetailsQueryHandler,
+	getTransactionHistoryQueryHandler queries.GetTransactionHistoryQueryHandler,
+	searchTransactionsQueryHandler queries.SearchTransactionsQueryHandler,
+	getUserTagsQueryHandler queries.GetUserTagsQueryHandler,
+	getTagSummaryQueryHandler queries.GetTagSummaryQueryHandler,
+	getUserAPITokensQueryHandler queries.GetUserAPITokensQueryHandler,
 ) (*Bot, error) {
 	if logger == nil {
 		return nil, errs.NewValueIsRequiredError("logger")
 	}
 
+	if metrics == nil {
+		return nil, errs.NewValueIsRequiredError("metrics")
+	}
+
 	if userRegistrationHandler == nil {
 		return nil, errs.NewValueIsRequiredError("userRegistrationHandler")
 	}
@@ -57,6 +126,50 @@ func NewBot(
 		return nil, errs.NewValueIsRequiredError("createTransactionCommandHandler")
 	}
 
+	if updateSettingsCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("updateSettingsCommandHandler")
+	}
+
+	if importTransactionsCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("importTransactionsCommandHandler")
+	}
+
+	if restoreBackupCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("restoreBackupCommandHandler")
+	}
+
+	if attachFileCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("attachFileCommandHandler")
+	}
+
+	if updateTransactionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("updateTransactionCommandHandler")
+	}
+
+	if deleteTransactionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("deleteTransactionCommandHandler")
+	}
+
+	if splitTransactionCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("splitTransactionCommandHandler")
+	}
+
+	if createRefundCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("createRefundCommandHandler")
+	}
+
+	if issueAPITokenCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("issueAPITokenCommandHandler")
+	}
+
+	if revokeAPITokenCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("revokeAPITokenCommandHandler")
+	}
+
+	if issueWebLoginCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("issueWebLoginCommandHandler")
+	}
+
 	if getUserCategoriesByTypeQueryHandler == nil {
 		return nil, errs.NewValueIsRequiredError("getUserCategoriesByTypeQueryHandler")
 	}
@@ -65,13 +178,60 @@ func NewBot(
 		return nil, errs.NewValueIsRequiredError("getUserQueryHandler")
 	}
 
-	if telegramBotToken == "" {
-		return nil, errs.NewValueIsRequiredError("telegramBotToken")
+	if getUserSettingsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserSettingsQueryHandler")
 	}
 
-	bot, err := tgbotapi.NewBotAPI(telegramBotToken)
-	if err != nil {
-		return nil, err
+	if exportTransactionsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("exportTransactionsQueryHandler")
+	}
+
+	if prepareStatementImportQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("prepareStatementImportQueryHandler")
+	}
+
+	if exportBackupQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("exportBackupQueryHandler")
+	}
+
+	if readBackupQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("readBackupQueryHandler")
+	}
+
+	if readReceiptQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("readReceiptQueryHandler")
+	}
+
+	if findReturnedPurchaseQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("findReturnedPurchaseQueryHandler")
+	}
+
+	if getTransactionDetailsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTransactionDetailsQueryHandler")
+	}
+
+	if getTransactionHistoryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTransactionHistoryQueryHandler")
+	}
+
+	if searchTransactionsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("searchTransactionsQueryHandler")
+	}
+
+	if getUserTagsQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserTagsQueryHandler")
+	}
+
+	if getTagSummaryQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getTagSummaryQueryHandler")
+	}
+
+	if getUserAPITokensQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getUserAPITokensQueryHandler")
+	}
+
+	if telegramBotToken == "" {
+		return nil, errs.NewValueIsRequiredError("telegramBotToken")
 	}
 
 	chatIDsMap := make(map[int64]bool, len(allowedChatIDs))
@@ -80,63 +240,101 @@ func NewBot(
 	}
 
 	tgBot := &Bot{
-		bot:                                   bot,
 		logger:                                logger,
+		metrics:                               metrics,
 		userRegistrationCommandHandler:        userRegistrationHandler,
 		createDefaultCategoriesCommandHandler: createDefaultCategoriesCommandHandler,
 		createTransactionCommandHandler:       createTransactionCommandHandler,
+		updateSettingsCommandHandler:          updateSettingsCommandHandler,
+		importTransactionsCommandHandler:      importTransactionsCommandHandler,
+		restoreBackupCommandHandler:           restoreBackupCommandHandler,
+		attachFileCommandHandler:              attachFileCommandHandler,
+		updateTransactionCommandHandler:       updateTransactionCommandHandler,
+		deleteTransactionCommandHandler:       deleteTransactionCommandHandler,
+		splitTransactionCommandHandler:        splitTransactionCommandHandler,
+		createRefundCommandHandler:            createRefundCommandHandler,
+		issueAPITokenCommandHandler:           issueAPITokenCommandHandler,
+		revokeAPITokenCommandHandler:          revokeAPITokenCommandHandler,
+		issueWebLoginCommandHandler:           issueWebLoginCommandHandler,
 		getUserCategoriesByTypeQueryHandler:   getUserCategoriesByTypeQueryHandler,
 		getUserQueryHandler:                   getUserQueryHandler,
+		getUserSettingsQueryHandler:           getUserSettingsQueryHandler,
+		exportTransactionsQueryHandler:        exportTransactionsQueryHandler,
+		prepareStatementImportQueryHandler:    prepareStatementImportQueryHandler,
+		exportBackupQueryHandler:              exportBackupQueryHandler,
+		readBackupQueryHandler:                readBackupQueryHandler,
+		readReceiptQueryHandler:               readReceiptQueryHandler,
+		findReturnedPurchaseQueryHandler:      findReturnedPurchaseQueryHandler,
+		getTransactionDetailsQueryHandler:     getTransactionDetailsQueryHandler,
+		getTransactionHistoryQueryHandler:     getTransactionHistoryQueryHandler,
+		searchTransactionsQueryHandler:        searchTransactionsQueryHandler,
+		getUserTagsQueryHandler:               getUserTagsQueryHandler,
+		getTagSummaryQueryHandler:             getTagSummaryQueryHandler,
+		getUserAPITokensQueryHandler:          getUserAPITokensQueryHandler,
 		cache:                                 cache.New(5*time.Minute, 10*time.Minute),
 		allowedChatIDs:                        chatIDsMap,
+		webBaseURL:                            webBaseURL,
+	}
+
+	client := &http.Client{
+		Transport: &apiTransport{next: http.DefaultTransport, metrics: metrics, polled: tgBot.markPolled},
+	}
+
+	bot, err := tgbotapi.NewBotAPIWithClient(telegramBotToken, tgbotapi.APIEndpoint, client)
+	if err != nil {
+		return nil, err
 	}
 
+	tgBot.bot = bot
+
 	return tgBot, nil
 }
 
-func (b *Bot) HandleUpdates(ctx context.Context) {
+// PollUpdates начинает получать обновления через long polling. Зарегистрированный webhook
+// удаляется: пока он есть, Telegram не отдает обновления через getUpdates.
+func (b *Bot) PollUpdates() tgbotapi.UpdatesChannel {
+	if _, err := b.bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		b.logger.Error("failed to delete webhook", "err", err.Error())
+	}
+
 	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 30
-
-	updates := b.bot.GetUpdatesChan(u)
-
-	for {
-		select {
-		case <-ctx.Done():
-			b.logger.Info("context cancelled, stopping bot updates handler")
-			return
-		case update, ok := <-updates:
-			if !ok {
-				b.logger.Info("updates channel closed, stopping bot updates handler")
-				return
-			}
-
-			b.logger.Info("handle update", "update_id", update.UpdateID)
-
-			if err := b.safeHandleUpdate(ctx, update); err != nil {
-				b.logger.Error(
-					"failed to handle update",
-					"update_id", update.UpdateID,
-					"err", err.Error(),
-				)
-			}
-		}
+	u.Timeout = pollTimeout
+
+	// Отсчет для проверки готовности начинается с запуска, а не с первого ответа Telegram.
+	b.markPolled(time.Now())
+
+	return b.bot.GetUpdatesChan(u)
+}
+
+// StopPolling прекращает получение обновлений через long polling. Канал PollUpdates закрывается,
+// когда завершится текущий запрос getUpdates и полученные им обновления будут переданы в канал.
+func (b *Bot) StopPolling() {
+	b.bot.StopReceivingUpdates()
+}
+
+// HandleUpdate обрабатывает одно обновление. Ошибки обработчиков записываются в журнал
+// и вместе со временем обработки учитываются в метриках.
+func (b *Bot) HandleUpdate(ctx context.Context, update tgbotapi.Update) {
+	b.logger.Info("handle update", "update_id", update.UpdateID)
+
+	start := time.Now()
+	err := b.safeHandleUpdate(ctx, update)
+
+	b.metrics.ObserveUpdate(updateType(update), time.Since(start))
+
+	if err != nil {
+		b.metrics.IncError(errorKind(err))
+		b.logger.Error(
+			"failed to handle update",
+			"update_id", update.UpdateID,
+			"err", err.Error(),
+		)
 	}
 }
 
 func (b *Bot) safeHandleUpdate(ctx context.Context, update tgbotapi.Update) (err error) {
-	var chatID int64
-
-	switch {
-	case update.Message != nil:
-		chatID = update.Message.Chat.ID
-	case update.CallbackQuery != nil:
-		if update.CallbackQuery.Message != nil {
-			chatID = update.CallbackQuery.Message.Chat.ID
-		} else {
-			chatID = update.CallbackQuery.From.ID
-		}
-	default:
+	chatID, ok := updateChatID(update)
+	if !ok {
 		return nil
 	}
 
@@ -153,8 +351,26 @@ func (b *Bot) safeHandleUpdate(ctx context.Context, update tgbotapi.Update) (err
 				"panic", r,
 				"update_id", update.UpdateID,
 			)
+
+			err = fmt.Errorf("%w: %v", errUpdatePanicked, r)
 		}
 	}()
 
 	return b.handleUpdate(ctx, update)
 }
+
+// updateChatID возвращает чат, к которому относится обновление. Обновления других типов бот не обрабатывает.
+func updateChatID(update tgbotapi.Update) (int64, bool) {
+	switch {
+	case update.Message != nil:
+		return update.Message.Chat.ID, true
+	case update.CallbackQuery != nil:
+		if update.CallbackQuery.Message != nil {
+			return update.CallbackQuery.Message.Chat.ID, true
+		}
+
+		return update.CallbackQuery.From.ID, true
+	default:
+		return 0, false
+	}
+}
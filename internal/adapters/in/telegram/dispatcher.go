@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// dispatcherStatsInterval период записи размера очереди в журнал, пока в ней есть обновления.
+const dispatcherStatsInterval = time.Minute
+
+// UpdateHandler обрабатывает одно обновление Telegram.
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, update tgbotapi.Update)
+}
+
+type DispatcherOptions struct {
+	// Workers количество одновременно обрабатываемых обновлений.
+	Workers int
+	// QueueSize размер очереди каждого обработчика. Когда очередь заполнена, чтение новых обновлений
+	// приостанавливается до её освобождения.
+	QueueSize int
+}
+
+// Dispatcher распределяет обновления между обработчиками по чатам: обновления одного чата
+// всегда попадают к одному обработчику и выполняются по порядку, обновления разных чатов — параллельно.
+type Dispatcher struct {
+	logger  ports.Logger
+	handler UpdateHandler
+	opts    DispatcherOptions
+
+	queues   []chan tgbotapi.Update
+	inFlight atomic.Int32
+}
+
+func NewDispatcher(logger ports.Logger, handler UpdateHandler, opts DispatcherOptions) (*Dispatcher, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if handler == nil {
+		return nil, errs.NewValueIsRequiredError("handler")
+	}
+
+	if opts.Workers <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.Workers")
+	}
+
+	if opts.QueueSize <= 0 {
+		return nil, errs.NewValueIsInvalidError("opts.QueueSize")
+	}
+
+	queues := make([]chan tgbotapi.Update, opts.Workers)
+	for i := range queues {
+		queues[i] = make(chan tgbotapi.Update, opts.QueueSize)
+	}
+
+	return &Dispatcher{
+		logger:  logger,
+		handler: handler,
+		opts:    opts,
+		queues:  queues,
+	}, nil
+}
+
+// Run читает обновления из updates и распределяет их по обработчикам, пока канал не закрыт.
+// Отмена ctx не останавливает чтение: обновления, которые источник уже принял, должны быть
+// обработаны, поэтому при остановке сначала останавливается источник, а он закрывает канал.
+// Обработчики получают ctx без отмены. Run возвращает управление, когда все принятые
+// обновления обработаны.
+func (d *Dispatcher) Run(ctx context.Context, updates tgbotapi.UpdatesChannel) {
+	var wg sync.WaitGroup
+	for _, queue := range d.queues {
+		wg.Go(func() {
+			for update := range queue {
+				d.inFlight.Add(1)
+				d.handler.HandleUpdate(context.WithoutCancel(ctx), update)
+				d.inFlight.Add(-1)
+			}
+		})
+	}
+
+	defer func() {
+		for _, queue := range d.queues {
+			close(queue)
+		}
+
+		if depth := d.QueueDepth(); depth > 0 {
+			d.logger.Info("draining update queue", "queue_depth", depth)
+		}
+
+		wg.Wait()
+		d.logger.Info("update dispatcher stopped")
+	}()
+
+	ticker := time.NewTicker(dispatcherStatsInterval)
+	defer ticker.Stop()
+
+	d.logger.Info("update dispatcher started", "workers", d.opts.Workers, "queue_size", d.opts.QueueSize)
+
+	for {
+		select {
+		case <-ticker.C:
+			if depth := d.QueueDepth(); depth > 0 {
+				d.logger.Info("update queue", "queue_depth", depth, "in_flight", d.InFlight())
+			}
+		case update, ok := <-updates:
+			if !ok {
+				d.logger.Info("updates channel closed, stopping update dispatcher")
+				return
+			}
+
+			d.dispatch(update)
+		}
+	}
+}
+
+// dispatch ставит обновление в очередь обработчика его чата. Если очередь заполнена,
+// ждет её освобождения: так медленная обработка притормаживает получение обновлений.
+func (d *Dispatcher) dispatch(update tgbotapi.Update) {
+	queue := d.queues[d.shard(update)]
+
+	if len(queue) == cap(queue) {
+		d.logger.Info("update queue is full, waiting", "update_id", update.UpdateID, "queue_depth", d.QueueDepth())
+	}
+
+	queue <- update
+}
+
+// shard возвращает номер обработчика для обновления. Обновления без чата обрабатывает первый обработчик.
+func (d *Dispatcher) shard(update tgbotapi.Update) int {
+	chatID, ok := updateChatID(update)
+	if !ok {
+		return 0
+	}
+
+	shard := chatID % int64(len(d.queues))
+	if shard < 0 {
+		shard = -shard
+	}
+
+	return int(shard)
+}
+
+// QueueDepth возвращает число принятых обновлений, обработка которых еще не началась.
+func (d *Dispatcher) QueueDepth() int {
+	depth := 0
+	for _, queue := range d.queues {
+		depth += len(queue)
+	}
+
+	return depth
+}
+
+// InFlight возвращает число обновлений, которые обрабатываются прямо сейчас.
+func (d *Dispatcher) InFlight() int {
+	return int(d.inFlight.Load())
+}
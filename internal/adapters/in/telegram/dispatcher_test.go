@@ -0,0 +1,322 @@
+package telegram_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/telegram"
+)
+
+const dispatcherWait = 2 * time.Second
+
+// recordingHandler запоминает обработанные обновления. Если заданы started и release, сообщает
+// о начале обработки и ждет разрешения её завершить.
+type recordingHandler struct {
+	started chan tgbotapi.Update
+	release chan struct{}
+	jitter  bool
+
+	mu      sync.Mutex
+	handled []tgbotapi.Update
+	ctxErrs []error
+}
+
+func (h *recordingHandler) HandleUpdate(ctx context.Context, update tgbotapi.Update) {
+	if h.started != nil {
+		h.started <- update
+	}
+
+	if h.release != nil {
+		<-h.release
+	}
+
+	if h.jitter {
+		time.Sleep(time.Duration(rand.IntN(200)) * time.Microsecond)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handled = append(h.handled, update)
+	h.ctxErrs = append(h.ctxErrs, ctx.Err())
+}
+
+func (h *recordingHandler) Handled() []tgbotapi.Update {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]tgbotapi.Update(nil), h.handled...)
+}
+
+func newDispatcher(t *testing.T, handler telegram.UpdateHandler, workers, queueSize int) *telegram.Dispatcher {
+	t.Helper()
+
+	d, err := telegram.NewDispatcher(slog.New(slog.NewTextHandler(io.Discard, nil)), handler, telegram.DispatcherOptions{
+		Workers:   workers,
+		QueueSize: queueSize,
+	})
+	require.NoError(t, err)
+
+	return d
+}
+
+// runDispatcher запускает Run и возвращает канал, который закрывается после его завершения.
+func runDispatcher(ctx context.Context, d *telegram.Dispatcher, updates chan tgbotapi.Update) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.Run(ctx, updates)
+	}()
+
+	return done
+}
+
+func waitStopped(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(dispatcherWait):
+		t.Fatal("dispatcher did not stop")
+	}
+}
+
+func waitStarted(t *testing.T, started <-chan tgbotapi.Update) tgbotapi.Update {
+	t.Helper()
+
+	select {
+	case update := <-started:
+		return update
+	case <-time.After(dispatcherWait):
+		t.Fatal("update handling did not start")
+		return tgbotapi.Update{}
+	}
+}
+
+func chatUpdate(updateID int, chatID int64) tgbotapi.Update {
+	return tgbotapi.Update{
+		UpdateID: updateID,
+		Message:  &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: chatID}},
+	}
+}
+
+func TestNewDispatcher_InvalidOptions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := &recordingHandler{}
+
+	tests := []struct {
+		name string
+		opts telegram.DispatcherOptions
+	}{
+		{name: "no workers", opts: telegram.DispatcherOptions{Workers: 0, QueueSize: 1}},
+		{name: "no queue", opts: telegram.DispatcherOptions{Workers: 1, QueueSize: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := telegram.NewDispatcher(logger, handler, tt.opts)
+
+			require.Error(t, err)
+			assert.Nil(t, d)
+		})
+	}
+}
+
+func TestDispatcher_KeepsChatOrder(t *testing.T) {
+	const perChat = 100
+
+	chats := []int64{1, 2, 3, -4, 5, 6}
+	handler := &recordingHandler{jitter: true}
+	d := newDispatcher(t, handler, 4, 2)
+
+	updates := make(chan tgbotapi.Update)
+	done := runDispatcher(t.Context(), d, updates)
+
+	updateID := 0
+	for range perChat {
+		for _, chatID := range chats {
+			updateID++
+			updates <- chatUpdate(updateID, chatID)
+		}
+	}
+
+	close(updates)
+	waitStopped(t, done)
+
+	handled := handler.Handled()
+	require.Len(t, handled, perChat*len(chats))
+
+	lastByChat := map[int64]int{}
+	for _, update := range handled {
+		chatID := update.Message.Chat.ID
+		assert.Greater(t, update.UpdateID, lastByChat[chatID], "chat %d handled out of order", chatID)
+		lastByChat[chatID] = update.UpdateID
+	}
+
+	assert.Zero(t, d.QueueDepth())
+	assert.Zero(t, d.InFlight())
+}
+
+func TestDispatcher_RunsChatsInParallel(t *testing.T) {
+	handler := &recordingHandler{
+		started: make(chan tgbotapi.Update),
+		release: make(chan struct{}),
+	}
+	d := newDispatcher(t, handler, 2, 1)
+
+	updates := make(chan tgbotapi.Update)
+	done := runDispatcher(t.Context(), d, updates)
+
+	updates <- chatUpdate(1, 10)
+	updates <- chatUpdate(2, 11)
+
+	// Обе обработки начинаются, хотя ни одна не завершена.
+	first := waitStarted(t, handler.started)
+	second := waitStarted(t, handler.started)
+	assert.ElementsMatch(t, []int{1, 2}, []int{first.UpdateID, second.UpdateID})
+	assert.Equal(t, 2, d.InFlight())
+
+	close(handler.release)
+	close(updates)
+	waitStopped(t, done)
+
+	assert.Len(t, handler.Handled(), 2)
+	assert.Zero(t, d.InFlight())
+}
+
+func TestDispatcher_SameChatWaitsForPreviousUpdate(t *testing.T) {
+	handler := &recordingHandler{
+		started: make(chan tgbotapi.Update, 2),
+		release: make(chan struct{}),
+	}
+	d := newDispatcher(t, handler, 2, 2)
+
+	updates := make(chan tgbotapi.Update)
+	done := runDispatcher(t.Context(), d, updates)
+
+	updates <- chatUpdate(1, 10)
+	updates <- chatUpdate(2, 10)
+
+	assert.Equal(t, 1, waitStarted(t, handler.started).UpdateID)
+	require.Eventually(t, func() bool { return d.QueueDepth() == 1 }, dispatcherWait, time.Millisecond)
+	assert.Empty(t, handler.started)
+	assert.Equal(t, 1, d.InFlight())
+
+	close(handler.release)
+	assert.Equal(t, 2, waitStarted(t, handler.started).UpdateID)
+
+	close(updates)
+	waitStopped(t, done)
+}
+
+func TestDispatcher_Backpressure(t *testing.T) {
+	handler := &recordingHandler{
+		started: make(chan tgbotapi.Update, 3),
+		release: make(chan struct{}),
+	}
+	d := newDispatcher(t, handler, 1, 1)
+
+	updates := make(chan tgbotapi.Update)
+	done := runDispatcher(t.Context(), d, updates)
+
+	// Первое обновление занимает обработчик, второе — очередь, третье Run принимает и ждет места в очереди.
+	updates <- chatUpdate(1, 10)
+	waitStarted(t, handler.started)
+
+	updates <- chatUpdate(2, 10)
+	require.Eventually(t, func() bool { return d.QueueDepth() == 1 }, dispatcherWait, time.Millisecond)
+
+	updates <- chatUpdate(3, 10)
+
+	select {
+	case updates <- chatUpdate(4, 10):
+		t.Fatal("dispatcher accepted an update while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.Equal(t, 1, d.QueueDepth())
+	assert.Equal(t, 1, d.InFlight())
+
+	close(handler.release)
+
+	select {
+	case updates <- chatUpdate(4, 10):
+	case <-time.After(dispatcherWait):
+		t.Fatal("dispatcher did not resume reading updates")
+	}
+
+	close(updates)
+	waitStopped(t, done)
+
+	ids := make([]int, 0, 4)
+	for _, update := range handler.Handled() {
+		ids = append(ids, update.UpdateID)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, ids)
+}
+
+func TestDispatcher_DrainsQueueOnClose(t *testing.T) {
+	handler := &recordingHandler{jitter: true}
+	d := newDispatcher(t, handler, 2, 10)
+
+	updates := make(chan tgbotapi.Update, 10)
+	for i := range 10 {
+		updates <- chatUpdate(i+1, int64(i%3))
+	}
+
+	close(updates)
+
+	waitStopped(t, runDispatcher(t.Context(), d, updates))
+
+	assert.Len(t, handler.Handled(), 10)
+	assert.Zero(t, d.QueueDepth())
+	assert.Zero(t, d.InFlight())
+}
+
+func TestDispatcher_CancelDoesNotDropUpdates(t *testing.T) {
+	handler := &recordingHandler{
+		started: make(chan tgbotapi.Update, 3),
+		release: make(chan struct{}),
+	}
+	d := newDispatcher(t, handler, 1, 5)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	updates := make(chan tgbotapi.Update)
+	done := runDispatcher(ctx, d, updates)
+
+	updates <- chatUpdate(1, 10)
+	updates <- chatUpdate(2, 10)
+	updates <- chatUpdate(3, 11)
+	waitStarted(t, handler.started)
+
+	cancel()
+
+	// Отмена не останавливает Run: он ждет, пока источник закроет канал.
+	select {
+	case <-done:
+		t.Fatal("dispatcher stopped on context cancel")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(handler.release)
+	close(updates)
+	waitStopped(t, done)
+
+	assert.Len(t, handler.Handled(), 3)
+	for _, err := range handler.ctxErrs {
+		assert.NoError(t, err, "handler context must not be canceled")
+	}
+
+	assert.Zero(t, d.QueueDepth())
+	assert.Zero(t, d.InFlight())
+}
@@ -0,0 +1,214 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func (b *Bot) handleExportCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	text := "В каком формате выгрузить транзакции?"
+	tagID := shared.ID{}
+
+	if args := update.Message.CommandArguments(); args != "" {
+		t, err := b.findUserTag(ctx, u.ID(), args)
+		if err != nil {
+			return err
+		}
+
+		if t == nil {
+			return b.sendMsg(chatID, fmt.Sprintf("Метки %s нет. Метки ставятся словами с # в комментарии транзакции", args))
+		}
+
+		text = fmt.Sprintf("В каком формате выгрузить транзакции с меткой %s?", t)
+		tagID = t.ID()
+	}
+
+	keyboard := newExportFormatInlineKeyboard(tagID)
+
+	return b.sendReplyMarkup(chatID, text, &keyboard)
+}
+
+// findUserTag возвращает метку пользователя по названию со знаком # или без него.
+// Возвращает nil, если такой метки нет или название недопустимо.
+func (b *Bot) findUserTag(ctx context.Context, userID shared.ID, name string) (*tag.Tag, error) {
+	name, err := tag.NormalizeName(name)
+	if err != nil {
+		return nil, nil
+	}
+
+	tags, err := b.getUserTagsQueryHandler.Handle(ctx, queries.NewGetUserTagsQuery(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tags {
+		if t.Name() == name {
+			return t, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (b *Bot) handleExportCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	if strings.HasPrefix(cb.Data, exportCbFormat) {
+		formatText, tagText, _ := strings.Cut(strings.TrimPrefix(cb.Data, exportCbFormat), ":")
+
+		format, err := report.ParseExportFormat(formatText)
+		if err != nil {
+			return errs.NewValueIsInvalidErrorWithCause("export format", err)
+		}
+
+		tagID, err := parseExportTagID(tagText)
+		if err != nil {
+			return err
+		}
+
+		keyboard := newExportPeriodInlineKeyboard(format, tagID)
+
+		return b.editMessage(chatID, cb.Message.MessageID, "За какой период выгрузить транзакции?", &keyboard)
+	}
+
+	formatText, periodText, _ := strings.Cut(strings.TrimPrefix(cb.Data, exportCbPrefix), ":")
+	periodText, tagText, _ := strings.Cut(periodText, ":")
+
+	format, err := report.ParseExportFormat(formatText)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("export format", err)
+	}
+
+	tagID, err := parseExportTagID(tagText)
+	if err != nil {
+		return err
+	}
+
+	period := exportPeriod(periodText)
+
+	title, ok := exportPeriodTitles[period]
+	if !ok {
+		return errs.NewValueIsInvalidError("export period " + periodText)
+	}
+
+	s, err := b.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(u.ID()))
+	if err != nil {
+		return err
+	}
+
+	from, to := period.bounds(time.Now().In(s.Location()))
+
+	query, err := queries.NewExportTransactionsQuery(u.ID(), from, to, format, tagID)
+	if err != nil {
+		return err
+	}
+
+	if err := b.editMessage(chatID, cb.Message.MessageID, "📤 Выгрузка: "+strings.ToLower(title), nil); err != nil {
+		b.logger.Error("Ошибка изменения сообщения о выгрузке", "err", err.Error())
+	}
+
+	name := fmt.Sprintf("transactions_%s.%s", period.fileSuffix(from), query.Format())
+
+	err = b.sendStream(chatID, name, func(w io.Writer) error {
+		return b.exportTransactionsQueryHandler.Handle(ctx, query, w)
+	})
+	if err != nil {
+		if err2 := b.sendMsg(chatID, "Не удалось выгрузить транзакции. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о выгрузке", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// parseExportTagID разбирает необязательную метку из данных кнопки выгрузки.
+func parseExportTagID(s string) (shared.ID, error) {
+	if s == "" {
+		return shared.ID{}, nil
+	}
+
+	tagID, err := shared.NewIDFromString(s)
+	if err != nil {
+		return shared.ID{}, errs.NewValueIsInvalidErrorWithCause("export tag", err)
+	}
+
+	return tagID, nil
+}
+
+// sendStream передает файл в Telegram по мере того, как write его формирует, не собирая файл в памяти.
+func (b *Bot) sendStream(chatID int64, name string, write func(w io.Writer) error) error {
+	pr, pw := io.Pipe()
+	exportErr := make(chan error, 1)
+
+	go func() {
+		err := write(pw)
+		pw.CloseWithError(err)
+		exportErr <- err
+	}()
+
+	sendErr := b.sendDocument(chatID, name, pr, "")
+
+	// Если отправка прервалась раньше, закрытие канала освобождает горутину выгрузки.
+	_ = pr.Close()
+
+	if err := <-exportErr; err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
+// bounds возвращает границы периода [from, to) для текущего момента now.
+// Для выгрузки за все время границы нулевые.
+func (p exportPeriod) bounds(now time.Time) (from time.Time, to time.Time) {
+	year, month, _ := now.Date()
+	startOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+
+	switch p {
+	case exportPeriodMonth:
+		return startOfMonth, startOfMonth.AddDate(0, 1, 0)
+	case exportPeriodPrevMonth:
+		return startOfMonth.AddDate(0, -1, 0), startOfMonth
+	case exportPeriodYear:
+		startOfYear := time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())
+		return startOfYear, startOfYear.AddDate(1, 0, 0)
+	}
+
+	return time.Time{}, time.Time{}
+}
+
+func (p exportPeriod) fileSuffix(from time.Time) string {
+	switch p {
+	case exportPeriodMonth, exportPeriodPrevMonth:
+		return from.Format("2006-01")
+	case exportPeriodYear:
+		return from.Format("2006")
+	}
+
+	return "all"
+}
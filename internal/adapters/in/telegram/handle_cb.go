@@ -2,15 +2,51 @@ package telegram
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/google/uuid"
 
 	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
 )
 
 func (b *Bot) handleCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	if strings.HasPrefix(cb.Data, settingsCbPrefix) {
+		return b.handleSettingsCb(ctx, cb)
+	}
+
+	if strings.HasPrefix(cb.Data, exportCbPrefix) {
+		return b.handleExportCb(ctx, cb)
+	}
+
+	if strings.HasPrefix(cb.Data, quickEntryCbPrefix) {
+		return b.handleQuickEntryCb(ctx, cb)
+	}
+
+	if strings.HasPrefix(cb.Data, importCbPrefix) {
+		return b.handleImportCb(ctx, cb)
+	}
+
+	if strings.HasPrefix(cb.Data, backupCbPrefix) {
+		return b.handleBackupCb(ctx, cb)
+	}
+
+	if strings.HasPrefix(cb.Data, transactionCbPrefix) {
+		return b.handleTransactionCb(ctx, cb)
+	}
+
+	if strings.HasPrefix(cb.Data, historyCbPrefix) {
+		return b.handleHistoryCb(ctx, cb)
+	}
+
+	if strings.HasPrefix(cb.Data, tokenCbPrefix) {
+		return b.handleTokenCb(ctx, cb)
+	}
+
 	chatID := cb.Message.Chat.ID
 	prevMsgID := cb.Message.MessageID
 
@@ -38,13 +74,29 @@ func (b *Bot) handleCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 		return err
 	}
 
-	cmd, err := commands.NewCreateTransactionCommand(u.ID(), pt.Amount, shared.RestoreID(cID))
+	var cmd commands.CreateTransactionCommand
+	if pt.Receipt != nil {
+		cmd, err = commands.NewCreateTransactionFromReceiptCommand(u.ID(), *pt.Receipt, shared.RestoreID(cID), pt.Note, pt.Tags...)
+	} else {
+		cmd, err = commands.NewCreateTransactionCommand(u.ID(), pt.Amount, shared.RestoreID(cID), pt.Note, pt.Tags...)
+	}
+
 	if err != nil {
 		return err
 	}
 
-	err = b.createTransactionCommandHandler.Handle(ctx, cmd)
-	if err != nil {
+	created, err := b.createTransactionCommandHandler.Handle(ctx, cmd)
+	if errors.Is(err, receipt.ErrAlreadyRecorded) {
+		err = b.sendMessageAndDeleteInlineKeyboard(chatID, prevMsgID, "Этот чек уже записан")
+		if err != nil {
+			b.logger.Error(err.Error())
+		}
+	} else if errors.Is(err, tag.ErrTooMany) || errors.Is(err, tag.ErrInvalidName) {
+		err = b.sendMessageAndDeleteInlineKeyboard(chatID, prevMsgID, "Не удалось сохранить метки транзакции. Проверьте их и попробуйте еще раз")
+		if err != nil {
+			b.logger.Error(err.Error())
+		}
+	} else if err != nil {
 		b.logger.Error(err.Error())
 
 		err = b.sendMessageAndDeleteInlineKeyboard(chatID, prevMsgID, "Ошибка при сохранении расхода. Попробуйте еще раз")
@@ -52,7 +104,7 @@ func (b *Bot) handleCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 			b.logger.Error(err.Error())
 		}
 	} else {
-		err = b.sendMessageAndDeleteInlineKeyboard(chatID, prevMsgID, "✅ Транзакция записана!")
+		err = b.sendTransactionCreated(chatID, prevMsgID, created.ID())
 		if err != nil {
 			b.logger.Error(err.Error())
 		}
@@ -63,3 +115,16 @@ func (b *Bot) handleCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
 
 	return nil
 }
+
+// sendTransactionCreated подтверждает запись транзакции и убирает клавиатуру выбора категории.
+// Ответом на подтверждение можно прикрепить к транзакции фото или файл либо записать возврат.
+func (b *Bot) sendTransactionCreated(chatID int64, prevMsgID int, transactionID shared.ID) error {
+	keyboard := newTransactionInlineKeyboard(transactionID)
+
+	err := b.sendReplyMarkup(chatID, "✅ Транзакция записана!\nОтветьте на это сообщение фото или файлом, чтобы прикрепить чек, или суммой, чтобы записать возврат", &keyboard)
+	if err != nil {
+		return err
+	}
+
+	return b.deleteMessage(chatID, prevMsgID)
+}
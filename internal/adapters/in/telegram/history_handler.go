@@ -0,0 +1,743 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// historyPageSize число транзакций на странице истории.
+	historyPageSize = 10
+
+	// historySessionTTL время, в течение которого работают кнопки сообщения с историей.
+	historySessionTTL = 30 * time.Minute
+
+	historyDateLayout = "02.01.2006"
+)
+
+// historySession состояние просмотра истории: фильтр, просмотренные страницы и открытая транзакция.
+// Хранится в кэше и относится к одному сообщению с историей.
+type historySession struct {
+	messageID int
+
+	filter report.HistoryFilter
+
+	// categoryName название категории из фильтра для подписи над списком.
+	categoryName string
+
+	// tagName метка из фильтра для подписи над списком.
+	tagName string
+
+	// pages позиции начала просмотренных страниц, последняя — начало текущей.
+	// Стек позволяет вернуться к более новым транзакциям без обратного запроса.
+	pages []report.HistoryCursor
+	page  *queries.HistoryPage
+
+	// transactionID транзакция, открытая для просмотра и исправления.
+	transactionID shared.ID
+}
+
+func (s *historySession) resetPages() {
+	s.pages = []report.HistoryCursor{{}}
+}
+
+func (b *Bot) handleHistoryCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	if us, _ := b.getUserState(chatID); isHistoryInputState(us) {
+		b.cache.Delete(userStateKey(chatID))
+	}
+
+	s := &historySession{}
+	s.resetPages()
+
+	if err := b.loadHistoryPage(ctx, u.ID(), s); err != nil {
+		if err2 := b.sendMsg(chatID, "Не удалось загрузить историю. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения об истории", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	keyboard := newHistoryPageInlineKeyboard(s)
+	msg := tgbotapi.NewMessage(chatID, composeHistoryPage(s))
+	msg.ReplyMarkup = keyboard
+
+	sent, err := b.bot.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	s.messageID = sent.MessageID
+	b.saveHistorySession(chatID, s)
+
+	return nil
+}
+
+func (b *Bot) handleHistoryCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+	msgID := cb.Message.MessageID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	s, ok := b.getHistorySession(chatID)
+	if !ok || s.messageID != msgID {
+		return b.editMessage(chatID, msgID, "Просмотр истории устарел. Отправьте /history еще раз", nil)
+	}
+
+	defer b.saveHistorySession(chatID, s)
+
+	switch data := cb.Data; {
+	case data == historyCbPage:
+		return b.reloadHistoryPage(ctx, chatID, u.ID(), s)
+	case data == historyCbNext:
+		if s.page.HasMore {
+			s.pages = append(s.pages, s.page.Next())
+		}
+
+		return b.reloadHistoryPage(ctx, chatID, u.ID(), s)
+	case data == historyCbPrev:
+		if len(s.pages) > 1 {
+			s.pages = s.pages[:len(s.pages)-1]
+		}
+
+		return b.reloadHistoryPage(ctx, chatID, u.ID(), s)
+	case data == historyCbFilters:
+		keyboard := newHistoryFiltersInlineKeyboard(s.filter)
+		return b.editMessage(chatID, msgID, "🔍 Фильтры истории\n\n"+composeHistoryFilter(s), &keyboard)
+	case data == historyCbFilterCategories:
+		return b.showHistoryFilterCategories(ctx, chatID, u.ID(), s)
+	case data == historyCbFilterTags:
+		return b.showHistoryFilterTags(ctx, chatID, u.ID(), s)
+	case data == historyCbFilterAmount:
+		b.cache.Set(userStateKey(chatID), UserStateWaitingForHistoryAmountRange, historySessionTTL)
+		return b.sendMsg(chatID, "Введите диапазон сумм, например 100-500, 100- или -500")
+	case data == historyCbFilterReset:
+		s.filter = report.HistoryFilter{}
+		s.categoryName = ""
+		s.tagName = ""
+		s.resetPages()
+
+		return b.reloadHistoryPage(ctx, chatID, u.ID(), s)
+	case strings.HasPrefix(data, historyCbFilterType):
+		return b.applyHistoryFilterType(ctx, chatID, u.ID(), s, category.Type(strings.TrimPrefix(data, historyCbFilterType)))
+	case strings.HasPrefix(data, historyCbFilterCategory):
+		return b.applyHistoryFilterCategory(ctx, chatID, u.ID(), s, strings.TrimPrefix(data, historyCbFilterCategory))
+	case strings.HasPrefix(data, historyCbFilterTag):
+		return b.applyHistoryFilterTag(ctx, chatID, u.ID(), s, strings.TrimPrefix(data, historyCbFilterTag))
+	case strings.HasPrefix(data, historyCbFilterPeriod):
+		return b.applyHistoryFilterPeriod(ctx, chatID, u.ID(), s, exportPeriod(strings.TrimPrefix(data, historyCbFilterPeriod)))
+	}
+
+	prefix, id, err := parseHistoryIDCb(cb.Data)
+	if err != nil {
+		return err
+	}
+
+	// Кнопки выбора категории несут идентификатор категории, транзакция берется из просмотра.
+	if prefix == historyCbCategory {
+		cmd, err := commands.NewSetTransactionCategoryCommand(u.ID(), s.transactionID, id)
+		if err != nil {
+			return err
+		}
+
+		return b.updateHistoryTransaction(ctx, chatID, u.ID(), s, cmd)
+	}
+
+	s.transactionID = id
+
+	switch prefix {
+	case historyCbOpen:
+		return b.showHistoryDetails(ctx, chatID, u.ID(), s)
+	case historyCbAmount:
+		b.cache.Set(userStateKey(chatID), UserStateWaitingForHistoryAmount, historySessionTTL)
+		return b.sendMsg(chatID, "Введите новую сумму")
+	case historyCbNote:
+		b.cache.Set(userStateKey(chatID), UserStateWaitingForHistoryNote, historySessionTTL)
+		return b.sendMsg(chatID, "Введите новый комментарий или «-», чтобы удалить его")
+	case historyCbCategories:
+		return b.showHistoryCategories(ctx, chatID, u.ID(), s)
+	case historyCbDelete:
+		keyboard := newHistoryDeleteInlineKeyboard(id)
+		return b.editMessage(chatID, msgID, "Удалить транзакцию вместе с вложениями?", &keyboard)
+	case historyCbDeleteConfirm:
+		return b.deleteHistoryTransaction(ctx, chatID, u.ID(), s)
+	}
+
+	return errs.NewValueIsInvalidError("history callback " + cb.Data)
+}
+
+// handleHistoryInput принимает значение, которое пользователь ввел по кнопке истории.
+func (b *Bot) handleHistoryInput(ctx context.Context, chatID int64, userID shared.ID, us UserState, text string) error {
+	s, ok := b.getHistorySession(chatID)
+	if !ok {
+		b.cache.Delete(userStateKey(chatID))
+		return b.sendMsg(chatID, "Просмотр истории устарел. Отправьте /history еще раз")
+	}
+
+	defer b.saveHistorySession(chatID, s)
+
+	switch us {
+	case UserStateWaitingForHistoryAmount:
+		amount, err := transaction.NewAmountFromString(text)
+		if err != nil {
+			return b.sendMsg(chatID, "Не удалось распознать сумму. Введите число, например 350 или 99.90")
+		}
+
+		cmd, err := commands.NewSetTransactionAmountCommand(userID, s.transactionID, amount)
+		if err != nil {
+			return err
+		}
+
+		b.cache.Delete(userStateKey(chatID))
+
+		return b.updateHistoryTransaction(ctx, chatID, userID, s, cmd)
+	case UserStateWaitingForHistoryNote:
+		if text == "-" {
+			text = ""
+		}
+
+		cmd, err := commands.NewSetTransactionNoteCommand(userID, s.transactionID, text)
+		if err != nil {
+			return err
+		}
+
+		b.cache.Delete(userStateKey(chatID))
+
+		return b.updateHistoryTransaction(ctx, chatID, userID, s, cmd)
+	case UserStateWaitingForHistoryPeriod:
+		from, to, err := parseHistoryPeriod(text, s.page.Location)
+		if err != nil {
+			return b.sendMsg(chatID, "Не удалось распознать период. Введите даты, например 01.02.2026-28.02.2026")
+		}
+
+		filter, err := s.filter.WithPeriod(from, to)
+		if err != nil {
+			return b.sendMsg(chatID, "Начало периода должно быть раньше конца")
+		}
+
+		b.cache.Delete(userStateKey(chatID))
+
+		return b.applyHistoryFilter(ctx, chatID, userID, s, filter)
+	case UserStateWaitingForHistoryAmountRange:
+		minAmount, maxAmount, err := parseHistoryAmountRange(text)
+		if err != nil {
+			return b.sendMsg(chatID, "Не удалось распознать диапазон. Введите суммы, например 100-500, 100- или -500")
+		}
+
+		filter, err := s.filter.WithAmountRange(minAmount, maxAmount)
+		if err != nil {
+			return b.sendMsg(chatID, "Нижняя граница суммы должна быть не больше верхней")
+		}
+
+		b.cache.Delete(userStateKey(chatID))
+
+		return b.applyHistoryFilter(ctx, chatID, userID, s, filter)
+	}
+
+	return errs.NewValueIsInvalidError("history input state " + string(us))
+}
+
+func isHistoryInputState(us UserState) bool {
+	switch us {
+	case UserStateWaitingForHistoryAmount,
+		UserStateWaitingForHistoryNote,
+		UserStateWaitingForHistoryPeriod,
+		UserStateWaitingForHistoryAmountRange:
+		return true
+	}
+
+	return false
+}
+
+func (b *Bot) loadHistoryPage(ctx context.Context, userID shared.ID, s *historySession) error {
+	query, err := queries.NewGetTransactionHistoryQuery(userID, s.filter, s.pages[len(s.pages)-1], historyPageSize)
+	if err != nil {
+		return err
+	}
+
+	page, err := b.getTransactionHistoryQueryHandler.Handle(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	s.page = page
+
+	return nil
+}
+
+// reloadHistoryPage заново читает текущую страницу и показывает её в сообщении с историей.
+func (b *Bot) reloadHistoryPage(ctx context.Context, chatID int64, userID shared.ID, s *historySession) error {
+	if err := b.loadHistoryPage(ctx, userID, s); err != nil {
+		return err
+	}
+
+	// Транзакции текущей страницы могли быть удалены, тогда возвращаемся к предыдущей.
+	for len(s.page.Lines) == 0 && len(s.pages) > 1 {
+		s.pages = s.pages[:len(s.pages)-1]
+
+		if err := b.loadHistoryPage(ctx, userID, s); err != nil {
+			return err
+		}
+	}
+
+	keyboard := newHistoryPageInlineKeyboard(s)
+
+	return b.editMessage(chatID, s.messageID, composeHistoryPage(s), &keyboard)
+}
+
+func (b *Bot) showHistoryDetails(ctx context.Context, chatID int64, userID shared.ID, s *historySession) error {
+	details, err := b.getTransactionDetailsQueryHandler.Handle(ctx, queries.NewGetTransactionDetailsQuery(userID, s.transactionID))
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		if err := b.sendMsg(chatID, "Транзакция не найдена. Возможно, она удалена"); err != nil {
+			b.logger.Error("Ошибка отправки сообщения об истории", "err", err.Error())
+		}
+
+		return b.reloadHistoryPage(ctx, chatID, userID, s)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	keyboard := newHistoryDetailsInlineKeyboard(details.Line.ID(), len(details.Attachments), len(details.Parts) > 0, details.CanRefund())
+
+	return b.editMessage(chatID, s.messageID, composeTransactionDetails(details), &keyboard)
+}
+
+// showHistoryCategories предлагает перенести открытую транзакцию в другую категорию того же типа.
+func (b *Bot) showHistoryCategories(ctx context.Context, chatID int64, userID shared.ID, s *historySession) error {
+	details, err := b.getTransactionDetailsQueryHandler.Handle(ctx, queries.NewGetTransactionDetailsQuery(userID, s.transactionID))
+	if err != nil {
+		return err
+	}
+
+	categories, err := b.getUserCategories(ctx, userID, details.Line.CategoryType())
+	if err != nil {
+		return err
+	}
+
+	keyboard := newHistoryCategoriesInlineKeyboard(categories, historyCbCategory, "", historyCbOpen+s.transactionID.String())
+
+	return b.editMessage(chatID, s.messageID, "Выберите новую категорию:", &keyboard)
+}
+
+func (b *Bot) updateHistoryTransaction(
+	ctx context.Context,
+	chatID int64,
+	userID shared.ID,
+	s *historySession,
+	cmd commands.UpdateTransactionCommand,
+) error {
+	_, err := b.updateTransactionCommandHandler.Handle(ctx, cmd)
+
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return b.sendMsg(chatID, "Транзакция не найдена. Возможно, она удалена")
+	case errors.Is(err, commands.ErrCategoryTypeMismatch):
+		return b.sendMsg(chatID, "Нельзя перенести доход в категорию расходов и наоборот")
+	case errors.Is(err, transaction.ErrTooLongNote):
+		return b.sendMsg(chatID, fmt.Sprintf("Комментарий должен быть не длиннее %d символов", transaction.MaxNoteLength))
+	case errors.Is(err, transaction.ErrInvalidAmount):
+		return b.sendMsg(chatID, "Сумма должна быть больше нуля")
+	case errors.Is(err, transaction.ErrSplit):
+		return b.sendMsg(chatID, "Транзакция разделена по категориям. Сначала отмените разделение")
+	case errors.Is(err, refund.ErrBelowRefunded):
+		return b.sendMsg(chatID, "По транзакции оформлены возвраты. Сумма не может быть меньше возвращенной, а категория возврата должна остаться")
+	case err != nil:
+		if err2 := b.sendMsg(chatID, "Не удалось изменить транзакцию. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения об истории", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	return b.showHistoryDetails(ctx, chatID, userID, s)
+}
+
+func (b *Bot) deleteHistoryTransaction(ctx context.Context, chatID int64, userID shared.ID, s *historySession) error {
+	cmd, err := commands.NewDeleteTransactionCommand(userID, s.transactionID)
+	if err != nil {
+		return err
+	}
+
+	err = b.deleteTransactionCommandHandler.Handle(ctx, cmd)
+	if err != nil && !errors.Is(err, errs.ErrObjectNotFound) {
+		if err2 := b.sendMsg(chatID, "Не удалось удалить транзакцию. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения об истории", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	s.transactionID = shared.ID{}
+
+	return b.reloadHistoryPage(ctx, chatID, userID, s)
+}
+
+// applyHistoryFilter применяет фильтр и показывает первую страницу отобранных транзакций.
+func (b *Bot) applyHistoryFilter(ctx context.Context, chatID int64, userID shared.ID, s *historySession, filter report.HistoryFilter) error {
+	s.filter = filter
+	s.resetPages()
+
+	return b.reloadHistoryPage(ctx, chatID, userID, s)
+}
+
+func (b *Bot) applyHistoryFilterType(ctx context.Context, chatID int64, userID shared.ID, s *historySession, t category.Type) error {
+	filter, err := s.filter.WithType(t)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("history filter type", err)
+	}
+
+	// Категория другого типа исключила бы все транзакции, поэтому условие по ней снимается.
+	if t != "" && !filter.CategoryID().IsZero() {
+		filter = filter.WithCategory(shared.ID{})
+		s.categoryName = ""
+	}
+
+	return b.applyHistoryFilter(ctx, chatID, userID, s, filter)
+}
+
+func (b *Bot) showHistoryFilterCategories(ctx context.Context, chatID int64, userID shared.ID, s *historySession) error {
+	categories, err := b.historyFilterCategories(ctx, userID, s.filter.CategoryType())
+	if err != nil {
+		return err
+	}
+
+	keyboard := newHistoryCategoriesInlineKeyboard(categories, historyCbFilterCategory, "Все категории", historyCbFilters)
+
+	return b.editMessage(chatID, s.messageID, "Транзакции какой категории показать?", &keyboard)
+}
+
+func (b *Bot) applyHistoryFilterCategory(ctx context.Context, chatID int64, userID shared.ID, s *historySession, data string) error {
+	if data == "" {
+		s.categoryName = ""
+		return b.applyHistoryFilter(ctx, chatID, userID, s, s.filter.WithCategory(shared.ID{}))
+	}
+
+	id, err := uuid.Parse(data)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("history filter category", err)
+	}
+
+	categories, err := b.historyFilterCategories(ctx, userID, "")
+	if err != nil {
+		return err
+	}
+
+	for _, c := range categories {
+		if c.ID() == shared.RestoreID(id) {
+			s.categoryName = c.Name()
+			return b.applyHistoryFilter(ctx, chatID, userID, s, s.filter.WithCategory(c.ID()))
+		}
+	}
+
+	return errs.NewObjectNotFoundError("category", data)
+}
+
+func (b *Bot) showHistoryFilterTags(ctx context.Context, chatID int64, userID shared.ID, s *historySession) error {
+	tags, err := b.getUserTagsQueryHandler.Handle(ctx, queries.NewGetUserTagsQuery(userID))
+	if err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		keyboard := newHistoryFiltersInlineKeyboard(s.filter)
+		return b.editMessage(chatID, s.messageID, "🔍 Меток пока нет. Добавьте #метку в комментарий транзакции", &keyboard)
+	}
+
+	keyboard := newHistoryTagsInlineKeyboard(tags)
+
+	return b.editMessage(chatID, s.messageID, "Транзакции с какой меткой показать?", &keyboard)
+}
+
+func (b *Bot) applyHistoryFilterTag(ctx context.Context, chatID int64, userID shared.ID, s *historySession, data string) error {
+	if data == "" {
+		s.tagName = ""
+		return b.applyHistoryFilter(ctx, chatID, userID, s, s.filter.WithTag(shared.ID{}))
+	}
+
+	id, err := uuid.Parse(data)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("history filter tag", err)
+	}
+
+	tags, err := b.getUserTagsQueryHandler.Handle(ctx, queries.NewGetUserTagsQuery(userID))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tags {
+		if t.ID() == shared.RestoreID(id) {
+			s.tagName = t.String()
+			return b.applyHistoryFilter(ctx, chatID, userID, s, s.filter.WithTag(t.ID()))
+		}
+	}
+
+	return errs.NewObjectNotFoundError("tag", data)
+}
+
+// historyFilterCategories возвращает категории типа t или категории обоих типов, если тип не задан.
+func (b *Bot) historyFilterCategories(ctx context.Context, userID shared.ID, t category.Type) ([]*category.Category, error) {
+	if t != "" {
+		return b.getUserCategories(ctx, userID, t)
+	}
+
+	expenses, err := b.getUserCategories(ctx, userID, category.TypeExpense)
+	if err != nil {
+		return nil, err
+	}
+
+	incomes, err := b.getUserCategories(ctx, userID, category.TypeIncome)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(expenses, incomes...), nil
+}
+
+func (b *Bot) applyHistoryFilterPeriod(ctx context.Context, chatID int64, userID shared.ID, s *historySession, p exportPeriod) error {
+	if p == historyPeriodCustom {
+		b.cache.Set(userStateKey(chatID), UserStateWaitingForHistoryPeriod, historySessionTTL)
+		return b.sendMsg(chatID, "Введите период, например 01.02.2026-28.02.2026, или одну дату")
+	}
+
+	if _, ok := exportPeriodTitles[p]; !ok {
+		return errs.NewValueIsInvalidError("history period " + string(p))
+	}
+
+	from, to := p.bounds(time.Now().In(s.page.Location))
+
+	filter, err := s.filter.WithPeriod(from, to)
+	if err != nil {
+		return err
+	}
+
+	return b.applyHistoryFilter(ctx, chatID, userID, s, filter)
+}
+
+func (b *Bot) getHistorySession(chatID int64) (*historySession, bool) {
+	res, ok := b.cache.Get(historySessionKey(chatID))
+	if !ok {
+		return nil, false
+	}
+
+	s, ok := res.(*historySession)
+
+	return s, ok
+}
+
+// saveHistorySession сохраняет просмотр истории и продлевает срок его жизни.
+func (b *Bot) saveHistorySession(chatID int64, s *historySession) {
+	b.cache.Set(historySessionKey(chatID), s, historySessionTTL)
+}
+
+func historySessionKey(chatID int64) string {
+	return fmt.Sprintf("history-%d", chatID)
+}
+
+// parseHistoryIDCb разбирает данные кнопки вида hist:<действие>:<идентификатор>.
+func parseHistoryIDCb(data string) (string, shared.ID, error) {
+	for _, prefix := range []string{
+		historyCbOpen,
+		historyCbAmount,
+		historyCbNote,
+		historyCbCategories,
+		historyCbCategory,
+		historyCbDelete,
+		historyCbDeleteConfirm,
+	} {
+		if !strings.HasPrefix(data, prefix) {
+			continue
+		}
+
+		id, err := uuid.Parse(strings.TrimPrefix(data, prefix))
+		if err != nil {
+			return "", shared.ID{}, errs.NewValueIsInvalidErrorWithCause("history callback "+data, err)
+		}
+
+		return prefix, shared.RestoreID(id), nil
+	}
+
+	return "", shared.ID{}, errs.NewValueIsInvalidError("history callback " + data)
+}
+
+// parseHistoryPeriod разбирает период вида "01.02.2026-28.02.2026" или одну дату.
+// Последний день входит в период.
+func parseHistoryPeriod(text string, loc *time.Location) (time.Time, time.Time, error) {
+	fromText, toText, ok := strings.Cut(text, "-")
+	if !ok {
+		toText = fromText
+	}
+
+	from, err := time.ParseInLocation(historyDateLayout, strings.TrimSpace(fromText), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	to, err := time.ParseInLocation(historyDateLayout, strings.TrimSpace(toText), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// parseHistoryAmountRange разбирает диапазон сумм вида "100-500", "100-" или "-500".
+func parseHistoryAmountRange(text string) (decimal.Decimal, decimal.Decimal, error) {
+	minText, maxText, ok := strings.Cut(text, "-")
+	if !ok {
+		return decimal.Zero, decimal.Zero, errs.NewValueIsInvalidError("amount range")
+	}
+
+	parse := func(s string) (decimal.Decimal, error) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return decimal.Zero, nil
+		}
+
+		amount, err := transaction.NewAmountFromString(s)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		return amount.Value(), nil
+	}
+
+	minAmount, err := parse(minText)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+
+	maxAmount, err := parse(maxText)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+
+	if minAmount.IsZero() && maxAmount.IsZero() {
+		return decimal.Zero, decimal.Zero, errs.NewValueIsInvalidError("amount range")
+	}
+
+	return minAmount, maxAmount, nil
+}
+
+// composeHistoryPage описывает страницу истории: условия отбора и транзакции от новых к старым.
+func composeHistoryPage(s *historySession) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "📜 История, страница %d", len(s.pages))
+
+	if !s.filter.IsEmpty() {
+		sb.WriteString("\n" + composeHistoryFilter(s))
+	}
+
+	if len(s.page.Lines) == 0 {
+		sb.WriteString("\n\nТранзакций не найдено")
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+
+	for i, line := range s.page.Lines {
+		fmt.Fprintf(&sb, "\n%d. %s", i+1, composeTransactionLine(line, s.page.Location))
+	}
+
+	return sb.String()
+}
+
+// composeTransactionLine описывает транзакцию одной строкой: время, сумму, категорию и комментарий.
+func composeTransactionLine(line report.TransactionLine, loc *time.Location) string {
+	sign := "−"
+	if line.CategoryType() == category.TypeIncome {
+		sign = "+"
+	}
+
+	text := fmt.Sprintf(
+		"%s  %s%s  %s",
+		line.OccurredAt().In(loc).Format("02.01.06 15:04"),
+		sign,
+		report.FormatMoney(line.Amount()),
+		line.CategoryName(),
+	)
+
+	if line.Note() != "" {
+		text += " · " + line.Note()
+	}
+
+	return text
+}
+
+// composeHistoryFilter перечисляет условия отбора истории.
+func composeHistoryFilter(s *historySession) string {
+	f := s.filter
+	if f.IsEmpty() {
+		return "Фильтр: все транзакции"
+	}
+
+	parts := make([]string, 0, 5)
+
+	switch f.CategoryType() {
+	case category.TypeExpense:
+		parts = append(parts, "расходы")
+	case category.TypeIncome:
+		parts = append(parts, "доходы")
+	}
+
+	if s.categoryName != "" {
+		parts = append(parts, s.categoryName)
+	}
+
+	if s.tagName != "" {
+		parts = append(parts, s.tagName)
+	}
+
+	if !f.From().IsZero() || !f.To().IsZero() {
+		parts = append(parts, composeHistoryPeriod(f.From(), f.To(), s.page.Location))
+	}
+
+	switch {
+	case !f.MinAmount().IsZero() && !f.MaxAmount().IsZero():
+		parts = append(parts, report.FormatAmount(f.MinAmount())+"–"+report.FormatMoney(f.MaxAmount()))
+	case !f.MinAmount().IsZero():
+		parts = append(parts, "от "+report.FormatMoney(f.MinAmount()))
+	case !f.MaxAmount().IsZero():
+		parts = append(parts, "до "+report.FormatMoney(f.MaxAmount()))
+	}
+
+	return "Фильтр: " + strings.Join(parts, " · ")
+}
+
+func composeHistoryPeriod(from time.Time, to time.Time, loc *time.Location) string {
+	switch {
+	case from.IsZero():
+		return "до " + to.In(loc).AddDate(0, 0, -1).Format(historyDateLayout)
+	case to.IsZero():
+		return "с " + from.In(loc).Format(historyDateLayout)
+	}
+
+	return from.In(loc).Format(historyDateLayout) + "–" + to.In(loc).AddDate(0, 0, -1).Format(historyDateLayout)
+}
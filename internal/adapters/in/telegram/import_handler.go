@@ -0,0 +1,377 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// maxStatementSize наибольший размер файла выписки. Выписка за год обычно занимает несколько сотен килобайт.
+	maxStatementSize = 2 << 20
+
+	// pendingImportTTL время, за которое пользователь должен разметить столбцы и подтвердить импорт.
+	pendingImportTTL = 15 * time.Minute
+
+	// previewSize число операций, показываемых в предпросмотре.
+	previewSize = 10
+)
+
+var errFileTooLarge = errors.New("file is too large")
+
+// mappingSteps вопросы для ручной разметки столбцов по порядку: дата, сумма, описание.
+var mappingSteps = []string{
+	"В каком столбце дата операции?",
+	"В каком столбце сумма операции?",
+	"В каком столбце описание операции?",
+}
+
+// statementFormats форматы импорта по расширению файла.
+var statementFormats = map[string]statement.FileFormat{
+	".csv": statement.FileFormatCsv,
+	".txt": statement.FileFormatCsv,
+	".ofx": statement.FileFormatOfx,
+	".qfx": statement.FileFormatOfx,
+	".qif": statement.FileFormatQif,
+}
+
+// PendingImport выписка, загруженная пользователем и ожидающая разметки или подтверждения.
+type PendingImport struct {
+	Content []byte
+	Format  statement.FileFormat
+	Header  []string
+	Columns []int
+	Plan    *statement.ImportPlan
+}
+
+// handleDocument принимает выписку в CSV или файл обмена OFX/QIF и показывает предпросмотр импорта.
+// Документ, отправленный ответом на подтверждение записи транзакции, прикрепляется к ней.
+// После команды /restore документ считается резервной копией, изображение — фотографией чека.
+func (b *Bot) handleDocument(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+	doc := update.Message.Document
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	if transactionID, ok := b.repliedTransactionID(update.Message); ok {
+		return b.handleAttachment(ctx, chatID, u.ID(), transactionID, attachedFile{
+			kind:     attachment.KindDocument,
+			fileID:   doc.FileID,
+			uniqueID: doc.FileUniqueID,
+			name:     doc.FileName,
+			mimeType: doc.MimeType,
+			size:     doc.FileSize,
+		})
+	}
+
+	if us, _ := b.getUserState(chatID); us == UserStateWaitingForBackup {
+		return b.handleBackupDocument(ctx, chatID, u.ID(), doc)
+	}
+
+	if strings.HasPrefix(doc.MimeType, "image/") {
+		return b.handleReceiptImage(ctx, chatID, u.ID(), doc.FileID, doc.FileSize)
+	}
+
+	format, ok := statementFormats[strings.ToLower(path.Ext(doc.FileName))]
+	if !ok {
+		return b.sendMsg(chatID, "Для импорта отправьте выписку из банка в формате CSV, OFX или QIF")
+	}
+
+	if doc.FileSize > maxStatementSize {
+		return b.sendMsg(chatID, fmt.Sprintf("Файл слишком большой: выписка должна быть не больше %d МБ", maxStatementSize>>20))
+	}
+
+	content, err := b.downloadFile(ctx, doc.FileID, maxStatementSize)
+	if err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			return b.sendMsg(chatID, fmt.Sprintf("Файл слишком большой: выписка должна быть не больше %d МБ", maxStatementSize>>20))
+		}
+
+		b.sendImportError(chatID)
+		return err
+	}
+
+	return b.prepareImport(ctx, chatID, u.ID(), PendingImport{Content: content, Format: format}, nil)
+}
+
+func (b *Bot) handleImportCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+	msgID := cb.Message.MessageID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	if cb.Data == importCbCancel {
+		b.cache.Delete(pendingImportKey(chatID))
+		return b.editMessage(chatID, msgID, "Импорт отменен", nil)
+	}
+
+	pi, err := b.getPendingImport(chatID)
+	if err != nil {
+		if err2 := b.editMessage(chatID, msgID, "Время ожидания истекло. Отправьте выписку еще раз", nil); err2 != nil {
+			b.logger.Error("Ошибка изменения сообщения импорта", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	switch {
+	case cb.Data == importCbConfirm:
+		return b.confirmImport(ctx, chatID, msgID, u.ID(), pi)
+	case strings.HasPrefix(cb.Data, importCbColumn):
+		column, err := strconv.Atoi(strings.TrimPrefix(cb.Data, importCbColumn))
+		if err != nil || column < statement.NoColumn || column >= len(pi.Header) {
+			return errs.NewValueIsInvalidError("import column " + cb.Data)
+		}
+
+		pi.Columns = append(pi.Columns, column)
+
+		if err := b.deleteMessage(chatID, msgID); err != nil {
+			b.logger.Error("Ошибка удаления сообщения импорта", "err", err.Error())
+		}
+
+		if len(pi.Columns) < len(mappingSteps) {
+			return b.askImportColumn(chatID, pi)
+		}
+
+		mapping, err := statement.NewMapping(pi.Columns[0], pi.Columns[1], pi.Columns[2])
+		if err != nil {
+			b.cache.Delete(pendingImportKey(chatID))
+			return b.sendMsg(chatID, "Один столбец выбран дважды. Отправьте выписку еще раз и разметьте столбцы заново")
+		}
+
+		return b.prepareImport(ctx, chatID, u.ID(), pi, &mapping)
+	}
+
+	return errs.NewValueIsInvalidError("import callback " + cb.Data)
+}
+
+// prepareImport разбирает выписку и показывает предпросмотр. Если формат выписки не распознан,
+// предлагает разметить столбцы вручную.
+func (b *Bot) prepareImport(ctx context.Context, chatID int64, userID shared.ID, pi PendingImport, mapping *statement.Mapping) error {
+	query, err := queries.NewPrepareStatementImportQuery(userID, pi.Format, mapping)
+	if err != nil {
+		return err
+	}
+
+	plan, err := b.prepareStatementImportQueryHandler.Handle(ctx, query, bytes.NewReader(pi.Content))
+
+	var unknownFormat *statement.UnknownFormatError
+	switch {
+	case errors.As(err, &unknownFormat):
+		pi.Header = unknownFormat.Header
+		pi.Columns = nil
+
+		if err := b.sendMsg(chatID, "Не удалось определить формат выписки. Укажите, где какие данные"); err != nil {
+			return err
+		}
+
+		return b.askImportColumn(chatID, pi)
+	case errors.Is(err, statement.ErrEmptyStatement):
+		return b.sendMsg(chatID, "В выписке нет операций")
+	case err != nil:
+		b.sendImportError(chatID)
+		return err
+	}
+
+	if len(plan.Importable()) == 0 {
+		b.cache.Delete(pendingImportKey(chatID))
+		return b.sendMsg(chatID, composeImportPreview(plan))
+	}
+
+	pi.Plan = plan
+	b.cache.Set(pendingImportKey(chatID), pi, pendingImportTTL)
+
+	keyboard := newImportConfirmInlineKeyboard()
+
+	return b.sendReplyMarkup(chatID, composeImportPreview(plan), &keyboard)
+}
+
+func (b *Bot) askImportColumn(chatID int64, pi PendingImport) error {
+	b.cache.Set(pendingImportKey(chatID), pi, pendingImportTTL)
+
+	step := len(pi.Columns)
+	keyboard := newImportColumnInlineKeyboard(pi.Header, step == len(mappingSteps)-1)
+
+	return b.sendReplyMarkup(chatID, mappingSteps[step], &keyboard)
+}
+
+func (b *Bot) confirmImport(ctx context.Context, chatID int64, msgID int, userID shared.ID, pi PendingImport) error {
+	if pi.Plan == nil {
+		return errs.NewValueIsRequiredError("import plan")
+	}
+
+	// Удаляем выписку до импорта, чтобы повторное нажатие кнопки не запустило его второй раз.
+	b.cache.Delete(pendingImportKey(chatID))
+
+	if err := b.editMessage(chatID, msgID, composeImportPreview(pi.Plan), nil); err != nil {
+		b.logger.Error("Ошибка изменения сообщения импорта", "err", err.Error())
+	}
+
+	cmd, err := commands.NewImportTransactionsCommand(userID, pi.Plan.Importable())
+	if err != nil {
+		return err
+	}
+
+	imported, err := b.importTransactionsCommandHandler.Handle(ctx, cmd)
+	if errors.Is(err, statement.ErrAlreadyImported) {
+		// Выписку импортировал параллельный запрос, например второе нажатие на кнопку.
+		return b.sendMsg(chatID, "Эти операции уже импортированы")
+	}
+
+	if err != nil {
+		b.sendImportError(chatID)
+		return err
+	}
+
+	skipped := len(pi.Plan.Items()) - imported
+
+	return b.sendMsg(chatID, fmt.Sprintf("✅ Импортировано операций: %d\nПропущено: %d", imported, skipped))
+}
+
+// downloadFile скачивает файл, отправленный пользователем, не более maxSize байт.
+func (b *Bot) downloadFile(ctx context.Context, fileID string, maxSize int) ([]byte, error) {
+	url, err := b.bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, fmt.Errorf("get file url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("download file: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download file: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			b.logger.Error("Ошибка закрытия файла", "err", err.Error())
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download file: unexpected status %s", resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("download file: %w", err)
+	}
+
+	if len(content) > maxSize {
+		return nil, errFileTooLarge
+	}
+
+	return content, nil
+}
+
+func (b *Bot) getPendingImport(chatID int64) (PendingImport, error) {
+	res, ok := b.cache.Get(pendingImportKey(chatID))
+	if !ok {
+		return PendingImport{}, fmt.Errorf("not found pending import")
+	}
+
+	if pi, ok := res.(PendingImport); ok {
+		return pi, nil
+	}
+
+	return PendingImport{}, fmt.Errorf("pending import is incorrect")
+}
+
+func (b *Bot) sendImportError(chatID int64) {
+	if err := b.sendMsg(chatID, "Не удалось импортировать выписку. Попробуйте позже"); err != nil {
+		b.logger.Error("Ошибка отправки сообщения об импорте", "err", err.Error())
+	}
+}
+
+// composeImportPreview описывает, что будет импортировано из выписки.
+func composeImportPreview(plan *statement.ImportPlan) string {
+	var b strings.Builder
+
+	if preset := plan.Mapping().Preset(); preset != "" {
+		fmt.Fprintf(&b, "📄 Выписка: %s\n", preset)
+	} else {
+		b.WriteString("📄 Выписка\n")
+	}
+
+	importable := plan.Importable()
+
+	fmt.Fprintf(&b, "Операций в файле: %d\n", len(plan.Items())+len(plan.RowErrors()))
+	fmt.Fprintf(&b, "Будет импортировано: %d\n", len(importable))
+
+	if n := plan.DuplicateCount(); n > 0 {
+		fmt.Fprintf(&b, "Уже импортированы ранее: %d\n", n)
+	}
+
+	if n := plan.UncategorizedCount(); n > 0 {
+		fmt.Fprintf(&b, "Нет подходящей категории: %d\n", n)
+	}
+
+	if rowErrors := plan.RowErrors(); len(rowErrors) > 0 {
+		lines := make([]string, 0, previewSize)
+		for i, e := range rowErrors {
+			if i == previewSize {
+				lines = append(lines, "…")
+				break
+			}
+
+			lines = append(lines, strconv.Itoa(e.Line))
+		}
+
+		fmt.Fprintf(&b, "Не удалось разобрать строки: %s\n", strings.Join(lines, ", "))
+	}
+
+	if len(importable) == 0 {
+		b.WriteString("\nНовых операций для импорта нет")
+		return b.String()
+	}
+
+	b.WriteString("\n")
+
+	for i, item := range importable {
+		if i == previewSize {
+			fmt.Fprintf(&b, "…и еще %d\n", len(importable)-previewSize)
+			break
+		}
+
+		r := item.Record()
+		fmt.Fprintf(&b, "%s %s · %s", r.OccurredAt().Format("02.01"), report.FormatMoney(r.SignedAmount()), item.Category().Name())
+
+		if r.Description() != "" {
+			fmt.Fprintf(&b, " · %s", r.Description())
+		}
+
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func pendingImportKey(chatID int64) string {
+	return fmt.Sprintf("pending-import-%d", chatID)
+}
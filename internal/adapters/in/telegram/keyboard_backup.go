@@ -0,0 +1,20 @@
+package telegram
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	backupCbPrefix  = "backup:"
+	backupCbConfirm = backupCbPrefix + "confirm"
+	backupCbCancel  = backupCbPrefix + "cancel"
+)
+
+func newRestoreConfirmInlineKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Восстановить", backupCbConfirm),
+			tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", backupCbCancel),
+		),
+	)
+}
@@ -0,0 +1,74 @@
+package telegram
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+const (
+	exportCbPrefix = "export:"
+	exportCbFormat = exportCbPrefix + "fmt:"
+)
+
+// exportPeriod период выгрузки, выбираемый кнопкой.
+type exportPeriod string
+
+const (
+	exportPeriodMonth     exportPeriod = "month"
+	exportPeriodPrevMonth exportPeriod = "prev_month"
+	exportPeriodYear      exportPeriod = "year"
+	exportPeriodAll       exportPeriod = "all"
+)
+
+var exportPeriodTitles = map[exportPeriod]string{
+	exportPeriodMonth:     "Этот месяц",
+	exportPeriodPrevMonth: "Прошлый месяц",
+	exportPeriodYear:      "Этот год",
+	exportPeriodAll:       "Все время",
+}
+
+var exportFormatTitles = map[report.ExportFormat]string{
+	report.ExportFormatCsv:  "CSV",
+	report.ExportFormatXlsx: "Excel (XLSX)",
+	report.ExportFormatOfx:  "OFX",
+	report.ExportFormatQif:  "QIF",
+}
+
+// newExportFormatInlineKeyboard предлагает формат выгрузки. Непустая метка tagID передается
+// в данных кнопок последним полем, чтобы выгрузить только транзакции с этой меткой.
+func newExportFormatInlineKeyboard(tagID shared.ID) tgbotapi.InlineKeyboardMarkup {
+	button := func(f report.ExportFormat) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(exportFormatTitles[f], exportCbFormat+f.String()+exportTagSuffix(tagID))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(button(report.ExportFormatCsv), button(report.ExportFormatXlsx)),
+		tgbotapi.NewInlineKeyboardRow(button(report.ExportFormatOfx), button(report.ExportFormatQif)),
+	)
+}
+
+// newExportPeriodInlineKeyboard предлагает период выгрузки в формате format.
+// Данные кнопки имеют вид export:<формат>:<период>[:<метка>].
+func newExportPeriodInlineKeyboard(format report.ExportFormat, tagID shared.ID) tgbotapi.InlineKeyboardMarkup {
+	button := func(p exportPeriod) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(
+			exportPeriodTitles[p],
+			exportCbPrefix+format.String()+":"+string(p)+exportTagSuffix(tagID),
+		)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(button(exportPeriodMonth), button(exportPeriodPrevMonth)),
+		tgbotapi.NewInlineKeyboardRow(button(exportPeriodYear), button(exportPeriodAll)),
+	)
+}
+
+func exportTagSuffix(tagID shared.ID) string {
+	if tagID.IsZero() {
+		return ""
+	}
+
+	return ":" + tagID.String()
+}
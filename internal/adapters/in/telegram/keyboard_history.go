@@ -0,0 +1,218 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+)
+
+const (
+	historyCbPrefix = "hist:"
+
+	historyCbPage = historyCbPrefix + "page"
+	historyCbNext = historyCbPrefix + "next"
+	historyCbPrev = historyCbPrefix + "prev"
+	historyCbOpen = historyCbPrefix + "open:"
+
+	historyCbAmount        = historyCbPrefix + "amount:"
+	historyCbNote          = historyCbPrefix + "note:"
+	historyCbCategories    = historyCbPrefix + "cats:"
+	historyCbCategory      = historyCbPrefix + "cat:"
+	historyCbDelete        = historyCbPrefix + "del:"
+	historyCbDeleteConfirm = historyCbPrefix + "delok:"
+
+	historyCbFilters          = historyCbPrefix + "filters"
+	historyCbFilterType       = historyCbPrefix + "ftype:"
+	historyCbFilterCategories = historyCbPrefix + "fcats"
+	historyCbFilterCategory   = historyCbPrefix + "fcat:"
+	historyCbFilterTags       = historyCbPrefix + "ftags"
+	historyCbFilterTag        = historyCbPrefix + "ftag:"
+	historyCbFilterPeriod     = historyCbPrefix + "fperiod:"
+	historyCbFilterAmount     = historyCbPrefix + "famount"
+	historyCbFilterReset      = historyCbPrefix + "freset"
+)
+
+// historyPeriodCustom период истории, который пользователь вводит сообщением.
+const historyPeriodCustom exportPeriod = "custom"
+
+// newHistoryPageInlineKeyboard кнопки с номерами транзакций страницы, листание и фильтры.
+func newHistoryPageInlineKeyboard(s *historySession) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, 4)
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 5)
+
+	for i, line := range s.page.Lines {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(strconv.Itoa(i+1), historyCbOpen+line.ID().String()))
+
+		if len(row) == 5 {
+			rows = append(rows, row)
+			row = make([]tgbotapi.InlineKeyboardButton, 0, 5)
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	nav := make([]tgbotapi.InlineKeyboardButton, 0, 2)
+	if len(s.pages) > 1 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("◀️ Новее", historyCbPrev))
+	}
+
+	if s.page.HasMore {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("Старше ▶️", historyCbNext))
+	}
+
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🔍 Фильтры", historyCbFilters),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func newHistoryDetailsInlineKeyboard(transactionID shared.ID, attachments int, split bool, refundable bool) tgbotapi.InlineKeyboardMarkup {
+	id := transactionID.String()
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✏️ Сумма", historyCbAmount+id),
+			tgbotapi.NewInlineKeyboardButtonData("📝 Комментарий", historyCbNote+id),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗂 Категория", historyCbCategories+id),
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Удалить", historyCbDelete+id),
+		),
+	}
+
+	if attachments > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("📎 Показать вложения (%d)", attachments),
+				transactionCbAttachments+id,
+			),
+		))
+	}
+
+	row := tgbotapi.NewInlineKeyboardRow(newSplitButton(transactionID, split))
+	if refundable {
+		row = append(row, newRefundButton(transactionID))
+	}
+
+	rows = append(rows, row, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ К списку", historyCbPage),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func newHistoryDeleteInlineKeyboard(transactionID shared.ID) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Да, удалить", historyCbDeleteConfirm+transactionID.String()),
+			tgbotapi.NewInlineKeyboardButtonData("Отмена", historyCbOpen+transactionID.String()),
+		),
+	)
+}
+
+// newHistoryCategoriesInlineKeyboard список категорий, кнопки которых начинаются с prefix.
+// Если all не пуст, первой идет кнопка с пустым идентификатором, снимающая выбор.
+func newHistoryCategoriesInlineKeyboard(categories []*category.Category, prefix string, all string, back string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(categories)/3+3)
+
+	if all != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(all, prefix)))
+	}
+
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 3)
+
+	for _, c := range categories {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(c.Name(), prefix+c.ID().String()))
+
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = make([]tgbotapi.InlineKeyboardButton, 0, 3)
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Назад", back),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// newHistoryTagsInlineKeyboard список меток для фильтра истории. Первая кнопка снимает условие по метке.
+func newHistoryTagsInlineKeyboard(tags []*tag.Tag) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(tags)/3+3)
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Все метки", historyCbFilterTag)))
+
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 3)
+
+	for _, t := range tags {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(t.String(), historyCbFilterTag+t.ID().String()))
+
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = make([]tgbotapi.InlineKeyboardButton, 0, 3)
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Назад", historyCbFilters),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func newHistoryFiltersInlineKeyboard(filter report.HistoryFilter) tgbotapi.InlineKeyboardMarkup {
+	typeButton := func(title string, t category.Type) tgbotapi.InlineKeyboardButton {
+		if filter.CategoryType() == t {
+			title = "✅ " + title
+		}
+
+		return tgbotapi.NewInlineKeyboardButtonData(title, historyCbFilterType+t.String())
+	}
+
+	periodButton := func(p exportPeriod) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(exportPeriodTitles[p], historyCbFilterPeriod+string(p))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			typeButton("Все", ""),
+			typeButton("Расходы", category.TypeExpense),
+			typeButton("Доходы", category.TypeIncome),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗂 Категория", historyCbFilterCategories),
+			tgbotapi.NewInlineKeyboardButtonData("🏷 Метка", historyCbFilterTags),
+			tgbotapi.NewInlineKeyboardButtonData("💰 Сумма", historyCbFilterAmount),
+		),
+		tgbotapi.NewInlineKeyboardRow(periodButton(exportPeriodMonth), periodButton(exportPeriodPrevMonth)),
+		tgbotapi.NewInlineKeyboardRow(periodButton(exportPeriodYear), periodButton(exportPeriodAll)),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📅 Свой период", historyCbFilterPeriod+string(historyPeriodCustom)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("♻️ Сбросить", historyCbFilterReset),
+			tgbotapi.NewInlineKeyboardButtonData("⬅️ К списку", historyCbPage),
+		),
+	)
+}
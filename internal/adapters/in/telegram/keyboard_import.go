@@ -0,0 +1,61 @@
+package telegram
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+)
+
+const (
+	importCbPrefix  = "import:"
+	importCbColumn  = importCbPrefix + "col:"
+	importCbConfirm = importCbPrefix + "confirm"
+	importCbCancel  = importCbPrefix + "cancel"
+
+	// maxColumnTitle наибольшая длина названия столбца на кнопке.
+	maxColumnTitle = 30
+)
+
+// newImportColumnInlineKeyboard предлагает выбрать столбец выписки по заголовку header.
+// Данные кнопки имеют вид import:col:<номер столбца>. С optional добавляется кнопка «Нет такого столбца».
+func newImportColumnInlineKeyboard(header []string, optional bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	for i, title := range header {
+		if utf8.RuneCountInString(title) > maxColumnTitle {
+			title = string([]rune(title)[:maxColumnTitle-1]) + "…"
+		}
+
+		if title == "" {
+			title = "Столбец " + strconv.Itoa(i+1)
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(title, importCbColumn+strconv.Itoa(i)),
+		))
+	}
+
+	if optional {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Нет такого столбца", importCbColumn+strconv.Itoa(statement.NoColumn)),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", importCbCancel),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func newImportConfirmInlineKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Импортировать", importCbConfirm),
+			tgbotapi.NewInlineKeyboardButtonData("❌ Отмена", importCbCancel),
+		),
+	)
+}
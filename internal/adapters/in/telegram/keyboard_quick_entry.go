@@ -0,0 +1,23 @@
+package telegram
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+)
+
+const quickEntryCbPrefix = "quick:"
+
+func newQuickEntryInlineKeyboard(amounts []transaction.Amount) tgbotapi.InlineKeyboardMarkup {
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(amounts))
+
+	for _, a := range amounts {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			report.FormatMoney(a.Value()),
+			quickEntryCbPrefix+a.Value().String(),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
@@ -0,0 +1,260 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+)
+
+const (
+	settingsCbPrefix = "settings:"
+
+	settingsCbMenu   = settingsCbPrefix + "menu"
+	settingsCbDigest = settingsCbPrefix + "digest:"
+	settingsCbHours  = settingsCbPrefix + "hours"
+	settingsCbHour   = settingsCbPrefix + "hour:"
+	settingsCbZones  = settingsCbPrefix + "zones"
+	settingsCbZone   = settingsCbPrefix + "tz:"
+
+	settingsCbReminders = settingsCbPrefix + "reminders"
+	settingsCbReminder  = settingsCbPrefix + "reminder:"
+	settingsCbQuietList = settingsCbPrefix + "quiet"
+	settingsCbQuiet     = settingsCbPrefix + "quiet:"
+)
+
+// reminderOptions варианты срока бездействия перед напоминанием в часах, 0 — напоминания выключены.
+var reminderOptions = []int{0, 12, 24, 48, 72}
+
+type quietHoursOption struct {
+	from int
+	to   int
+}
+
+// quietHoursOptions варианты тихих часов, одинаковые границы означают отсутствие тихих часов.
+var quietHoursOptions = []quietHoursOption{
+	{from: 22, to: 9},
+	{from: 23, to: 8},
+	{from: 0, to: 7},
+	{from: 0, to: 0},
+}
+
+type timezoneOption struct {
+	name  string
+	title string
+}
+
+// timezoneOptions часовые пояса России, предлагаемые в настройках.
+var timezoneOptions = []timezoneOption{
+	{name: "Europe/Kaliningrad", title: "Калининград"},
+	{name: "Europe/Moscow", title: "Москва"},
+	{name: "Europe/Samara", title: "Самара"},
+	{name: "Asia/Yekaterinburg", title: "Екатеринбург"},
+	{name: "Asia/Omsk", title: "Омск"},
+	{name: "Asia/Novosibirsk", title: "Новосибирск"},
+	{name: "Asia/Irkutsk", title: "Иркутск"},
+	{name: "Asia/Yakutsk", title: "Якутск"},
+	{name: "Asia/Vladivostok", title: "Владивосток"},
+	{name: "Asia/Magadan", title: "Магадан"},
+	{name: "Asia/Kamchatka", title: "Камчатка"},
+}
+
+var digestModeTitles = map[settings.DigestMode]string{
+	settings.DigestModeOff:    "выкл",
+	settings.DigestModeDaily:  "ежедневно",
+	settings.DigestModeWeekly: "еженедельно",
+}
+
+func settingsText(s *settings.Settings) string {
+	digest := "выключена"
+	switch s.DigestMode() {
+	case settings.DigestModeDaily:
+		digest = "ежедневно в " + formatHour(s.DigestHour())
+	case settings.DigestModeWeekly:
+		digest = "по понедельникам в " + formatHour(s.DigestHour())
+	}
+
+	reminder := "выключены"
+	if s.RemindersEnabled() {
+		reminder = fmt.Sprintf("через %d ч без записей", s.ReminderAfterHours())
+		if s.HasQuietHours() {
+			reminder += ", кроме " + formatQuietHours(s.QuietFrom(), s.QuietTo())
+		}
+	}
+
+	return fmt.Sprintf(
+		"⚙️ Настройки\n\nЧасовой пояс: %s\nСводка расходов: %s\nНапоминания: %s",
+		timezoneTitle(s.Timezone(), s.Location()),
+		digest,
+		reminder,
+	)
+}
+
+func newSettingsInlineKeyboard(s *settings.Settings) tgbotapi.InlineKeyboardMarkup {
+	modes := []settings.DigestMode{settings.DigestModeOff, settings.DigestModeDaily, settings.DigestModeWeekly}
+	modeRow := make([]tgbotapi.InlineKeyboardButton, 0, len(modes))
+
+	for _, m := range modes {
+		title := digestModeTitles[m]
+		if m == s.DigestMode() {
+			title = "✅ " + title
+		}
+
+		modeRow = append(modeRow, tgbotapi.NewInlineKeyboardButtonData(title, settingsCbDigest+m.String()))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		modeRow,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🕘 Время сводки: "+formatHour(s.DigestHour()), settingsCbHours),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔔 Напоминания: "+formatReminder(s.ReminderAfterHours()), settingsCbReminders),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🌙 Тихие часы: "+formatQuietHours(s.QuietFrom(), s.QuietTo()), settingsCbQuietList),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🌍 Часовой пояс", settingsCbZones),
+		),
+	)
+}
+
+func newRemindersInlineKeyboard(current int) tgbotapi.InlineKeyboardMarkup {
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(reminderOptions))
+
+	for _, hours := range reminderOptions {
+		title := formatReminder(hours)
+		if hours == current {
+			title = "✅ " + title
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(title, settingsCbReminder+strconv.Itoa(hours)))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⬅️ Назад", settingsCbMenu),
+		),
+	)
+}
+
+func newQuietHoursInlineKeyboard(from int, to int) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(quietHoursOptions)+1)
+
+	current := formatQuietHours(from, to)
+
+	for _, o := range quietHoursOptions {
+		title := formatQuietHours(o.from, o.to)
+		if title == current {
+			title = "✅ " + title
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(title, fmt.Sprintf("%s%d-%d", settingsCbQuiet, o.from, o.to)),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Назад", settingsCbMenu),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func newHoursInlineKeyboard(current int) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, 5)
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 6)
+
+	for hour := range 24 {
+		title := formatHour(hour)
+		if hour == current {
+			title = "✅ " + title
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(title, settingsCbHour+strconv.Itoa(hour)))
+
+		if len(row) == 6 {
+			rows = append(rows, row)
+			row = make([]tgbotapi.InlineKeyboardButton, 0, 6)
+		}
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Назад", settingsCbMenu),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func newTimezonesInlineKeyboard(current string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(timezoneOptions)/2+2)
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 2)
+
+	for _, tz := range timezoneOptions {
+		loc, err := time.LoadLocation(tz.name)
+		if err != nil {
+			continue
+		}
+
+		title := timezoneTitle(tz.name, loc)
+		if tz.name == current {
+			title = "✅ " + title
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(title, settingsCbZone+tz.name))
+
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = make([]tgbotapi.InlineKeyboardButton, 0, 2)
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Назад", settingsCbMenu),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func timezoneTitle(name string, loc *time.Location) string {
+	title := name
+	for _, tz := range timezoneOptions {
+		if tz.name == name {
+			title = tz.title
+			break
+		}
+	}
+
+	_, offset := time.Now().In(loc).Zone()
+
+	return fmt.Sprintf("%s (UTC%+d)", title, offset/3600)
+}
+
+func formatReminder(hours int) string {
+	if hours == 0 {
+		return "выкл"
+	}
+
+	return fmt.Sprintf("%d ч", hours)
+}
+
+func formatQuietHours(from int, to int) string {
+	if from == to {
+		return "нет"
+	}
+
+	return formatHour(from) + "–" + formatHour(to)
+}
+
+func formatHour(hour int) string {
+	return fmt.Sprintf("%02d:00", hour)
+}
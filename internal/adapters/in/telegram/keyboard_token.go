@@ -0,0 +1,30 @@
+package telegram
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+)
+
+const (
+	tokenCbPrefix = "token:"
+	tokenCbRevoke = tokenCbPrefix + "revoke:"
+)
+
+// newTokensInlineKeyboard кнопки отзыва под списком токенов, по одной на каждый токен.
+func newTokensInlineKeyboard(tokens []*apitoken.Token) *tgbotapi.InlineKeyboardMarkup {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(tokens))
+	for _, t := range tokens {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Отозвать «"+t.Name()+"»", tokenCbRevoke+t.ID().String()),
+		))
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	return &keyboard
+}
@@ -0,0 +1,201 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+const (
+	transactionCbPrefix      = "tx:"
+	transactionCbDetails     = transactionCbPrefix + "show:"
+	transactionCbAttachments = transactionCbPrefix + "files:"
+
+	transactionCbSplit         = transactionCbPrefix + "split:"
+	transactionCbUnsplit       = transactionCbPrefix + "unsplit:"
+	transactionCbSplitCategory = transactionCbPrefix + "splitcat:"
+	transactionCbSplitRest     = transactionCbPrefix + "splitrest"
+	transactionCbSplitCancel   = transactionCbPrefix + "splitcancel"
+
+	transactionCbRefund         = transactionCbPrefix + "refund:"
+	transactionCbRefundCategory = transactionCbPrefix + "refundcat:"
+	transactionCbRefundCancel   = transactionCbPrefix + "refundcancel"
+
+	transactionCbRefundReceipt       = transactionCbPrefix + "refundrcpt:"
+	transactionCbRefundReceiptCancel = transactionCbRefundReceipt + "cancel"
+)
+
+// newTransactionInlineKeyboard кнопки подробностей и разделения под подтверждением записи транзакции.
+// По кнопке подробностей определяется транзакция, когда пользователь отвечает на подтверждение файлом.
+func newTransactionInlineKeyboard(transactionID shared.ID) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔎 Подробнее", transactionCbDetails+transactionID.String()),
+			newSplitButton(transactionID, false),
+		),
+	)
+}
+
+func newTransactionDetailsInlineKeyboard(transactionID shared.ID, attachments int, split bool, refundable bool) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, 2)
+
+	if attachments > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("📎 Показать вложения (%d)", attachments),
+				transactionCbAttachments+transactionID.String(),
+			),
+		))
+	}
+
+	row := tgbotapi.NewInlineKeyboardRow(newSplitButton(transactionID, split))
+	if refundable {
+		row = append(row, newRefundButton(transactionID))
+	}
+
+	rows = append(rows, row)
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// newRefundButton кнопка возврата по расходной транзакции.
+func newRefundButton(transactionID shared.ID) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData("💸 Возврат", transactionCbRefund+transactionID.String())
+}
+
+// newRefundPartsInlineKeyboard части разделенной транзакции, по которым еще можно оформить возврат.
+func newRefundPartsInlineKeyboard(parts []report.CategoryTotal, refundable map[shared.ID]decimal.Decimal) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(parts)+1)
+
+	for _, p := range parts {
+		available := refundable[p.CategoryID()]
+		if !available.IsPositive() {
+			continue
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s — до %s", p.Name(), report.FormatMoney(available)),
+				transactionCbRefundCategory+p.CategoryID().String(),
+			),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(newRefundCancelButton()))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func newRefundCancelInlineKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(newRefundCancelButton()))
+}
+
+func newRefundCancelButton() tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData("✖️ Отмена", transactionCbRefundCancel)
+}
+
+// newReturnedPurchaseInlineKeyboard покупки, из которых пользователь выбирает возвращенную по чеку возврата.
+func newReturnedPurchaseInlineKeyboard(purchases []report.TransactionLine, loc *time.Location) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(purchases)+1)
+
+	for _, p := range purchases {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s · %s · %s", p.OccurredAt().In(loc).Format("02.01 15:04"), p.CategoryName(), report.FormatMoney(p.Amount())),
+				transactionCbRefundReceipt+p.ID().String(),
+			),
+		))
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✖️ Отмена", transactionCbRefundReceiptCancel),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// newSplitButton кнопка разделения транзакции по категориям или отмены разделения.
+func newSplitButton(transactionID shared.ID, split bool) tgbotapi.InlineKeyboardButton {
+	if split {
+		return tgbotapi.NewInlineKeyboardButtonData("↩️ Отменить разделение", transactionCbUnsplit+transactionID.String())
+	}
+
+	return tgbotapi.NewInlineKeyboardButtonData("✂️ Разделить", transactionCbSplit+transactionID.String())
+}
+
+// newSplitCategoriesInlineKeyboard категории для очередной части транзакции. Уже выбранные категории не показываются.
+func newSplitCategoriesInlineKeyboard(categories []*category.Category, used map[shared.ID]bool) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(categories)/3+2)
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 3)
+
+	for _, c := range categories {
+		if used[c.ID()] {
+			continue
+		}
+
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(c.Name(), transactionCbSplitCategory+c.ID().String()))
+
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = make([]tgbotapi.InlineKeyboardButton, 0, 3)
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✖️ Отмена", transactionCbSplitCancel),
+	))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// newSplitAmountInlineKeyboard кнопки при вводе суммы части. Весь остаток можно отнести на категорию,
+// только если она не первая: иначе транзакция не разделится.
+func newSplitAmountInlineKeyboard(rest bool) tgbotapi.InlineKeyboardMarkup {
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 2)
+
+	if rest {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("Весь остаток", transactionCbSplitRest))
+	}
+
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData("✖️ Отмена", transactionCbSplitCancel))
+
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// newTransactionLinesInlineKeyboard кнопки с номерами строк списка, открывающие подробности транзакций.
+func newTransactionLinesInlineKeyboard(lines []report.TransactionLine) *tgbotapi.InlineKeyboardMarkup {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(lines)/5+1)
+	row := make([]tgbotapi.InlineKeyboardButton, 0, 5)
+
+	for i, line := range lines {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(strconv.Itoa(i+1), transactionCbDetails+line.ID().String()))
+
+		if len(row) == 5 {
+			rows = append(rows, row)
+			row = make([]tgbotapi.InlineKeyboardButton, 0, 5)
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	return &keyboard
+}
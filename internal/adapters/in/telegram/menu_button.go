@@ -0,0 +1,46 @@
+package telegram
+
+import (
+	"net/url"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// miniAppMenuButtonText подпись кнопки меню, которая открывает мини-приложение.
+const miniAppMenuButtonText = "Открыть"
+
+type menuButtonWebApp struct {
+	Type   string     `json:"type"`
+	Text   string     `json:"text"`
+	WebApp webAppInfo `json:"web_app"`
+}
+
+type webAppInfo struct {
+	URL string `json:"url"`
+}
+
+// SetMiniAppMenuButton заменяет кнопку меню во всех личных чатах с ботом на кнопку,
+// которая открывает мини-приложение по адресу appURL.
+func (b *Bot) SetMiniAppMenuButton(appURL string) error {
+	u, err := url.Parse(appURL)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("appURL", err)
+	}
+
+	params := tgbotapi.Params{}
+
+	err = params.AddInterface("menu_button", menuButtonWebApp{
+		Type:   "web_app",
+		Text:   miniAppMenuButtonText,
+		WebApp: webAppInfo{URL: u.String()},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.bot.MakeRequest("setChatMenuButton", params)
+
+	return err
+}
@@ -0,0 +1,124 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// pollingStallTimeout время без ответов на getUpdates, после которого long polling считается зависшим.
+// Telegram держит запрос не дольше pollTimeout, а после ошибки библиотека повторяет его через 3 секунды.
+const pollingStallTimeout = 2 * time.Minute
+
+// Типы обновлений в метриках.
+const (
+	updateTypeCommand       = "command"
+	updateTypeMessage       = "message"
+	updateTypeCallbackQuery = "callback_query"
+	updateTypeOther         = "other"
+)
+
+// Виды ошибок обработки обновлений в метриках.
+const (
+	errorKindNotFound      = "not_found"
+	errorKindInvalidValue  = "invalid_value"
+	errorKindRequiredValue = "required_value"
+	errorKindAlreadyExists = "already_exists"
+	errorKindTelegramAPI   = "telegram_api"
+	errorKindTimeout       = "timeout"
+	errorKindPanic         = "panic"
+	errorKindInternal      = "internal"
+)
+
+var errUpdatePanicked = errors.New("update handler panicked")
+
+// updateType возвращает тип обновления для метрик. Команды учитываются отдельно от остальных сообщений.
+func updateType(update tgbotapi.Update) string {
+	switch {
+	case update.Message != nil && update.Message.IsCommand():
+		return updateTypeCommand
+	case update.Message != nil:
+		return updateTypeMessage
+	case update.CallbackQuery != nil:
+		return updateTypeCallbackQuery
+	default:
+		return updateTypeOther
+	}
+}
+
+// errorKind возвращает вид ошибки обработки обновления для метрик.
+func errorKind(err error) string {
+	var apiErr *tgbotapi.Error
+
+	switch {
+	case errors.Is(err, errUpdatePanicked):
+		return errorKindPanic
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return errorKindNotFound
+	case errors.Is(err, errs.ErrValueIsInvalid):
+		return errorKindInvalidValue
+	case errors.Is(err, errs.ErrValueIsRequired):
+		return errorKindRequiredValue
+	case errors.Is(err, errs.ErrEntityAlreadyExists):
+		return errorKindAlreadyExists
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorKindTimeout
+	case errors.As(err, &apiErr):
+		return errorKindTelegramAPI
+	default:
+		return errorKindInternal
+	}
+}
+
+// apiTransport учитывает в метриках каждый запрос к Telegram Bot API и сообщает об успешных
+// ответах на getUpdates, по которым проверяется, что long polling не остановился.
+type apiTransport struct {
+	next    http.RoundTripper
+	metrics ports.Metrics
+	polled  func(time.Time)
+}
+
+func (t *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	// Путь запроса имеет вид /bot<token>/<method>: в метрики попадает только метод.
+	method := path.Base(req.URL.Path)
+	failed := err != nil || resp.StatusCode >= http.StatusBadRequest
+
+	t.metrics.ObserveTelegramRequest(method, time.Since(start), failed)
+
+	if method == "getUpdates" && !failed {
+		t.polled(time.Now())
+	}
+
+	return resp, err
+}
+
+// CheckPolling возвращает ошибку, если long polling не запущен или Telegram не отвечал
+// на getUpdates дольше pollingStallTimeout. Так же проявляется и заполненная очередь
+// обновлений: пока она не освободится, новые обновления не запрашиваются.
+func (b *Bot) CheckPolling(context.Context) error {
+	last := b.lastPolledAt.Load()
+	if last == 0 {
+		return errors.New("polling is not started")
+	}
+
+	if since := time.Since(time.Unix(0, last)); since > pollingStallTimeout {
+		return fmt.Errorf("no polling progress for %s", since.Round(time.Second))
+	}
+
+	return nil
+}
+
+func (b *Bot) markPolled(at time.Time) {
+	b.lastPolledAt.Store(at.UnixNano())
+}
@@ -2,8 +2,10 @@ package telegram
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
@@ -11,6 +13,8 @@ import (
 
 	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
 )
@@ -31,13 +35,40 @@ func (b *Bot) handleMsg(ctx context.Context, update tgbotapi.Update) error {
 		return err
 	}
 
-	amount, operationType, err := parseAmount(text)
+	us, _ := b.getUserState(chatID)
+	if isHistoryInputState(us) {
+		return b.handleHistoryInput(ctx, chatID, u.ID(), us, text)
+	}
+
+	if us == UserStateWaitingForSplitAmount {
+		return b.handleSplitAmountInput(ctx, chatID, u.ID(), text)
+	}
+
+	if us == UserStateWaitingForRefundAmount {
+		return b.handleRefundAmountInput(ctx, chatID, u.ID(), text)
+	}
+
+	// Сумма, отправленная ответом на подтверждение записи, оформляет возврат по этой транзакции.
+	if transactionID, ok := b.repliedTransactionID(update.Message); ok {
+		return b.handleRefundReply(ctx, chatID, u.ID(), transactionID, text)
+	}
+
+	if receipt.LooksLikeReceipt(text) {
+		return b.handleReceipt(ctx, chatID, queries.NewReadReceiptQueryFromText(u.ID(), text))
+	}
+
+	amount, operationType, note, err := parseTransactionText(text)
 	if err != nil {
 		b.sendValidationError(chatID)
 		return err
 	}
 
-	b.savePendingTransaction(chatID, amount)
+	tags, note := tag.ExtractHashtags(note)
+	if len(tags) > tag.MaxPerTransaction {
+		return b.sendMsg(chatID, fmt.Sprintf("У транзакции может быть не больше %d меток", tag.MaxPerTransaction))
+	}
+
+	b.savePendingTransaction(chatID, amount, note, tags)
 
 	categories, err := b.getUserCategories(ctx, u.ID(), operationType)
 	if err != nil {
@@ -71,6 +102,20 @@ func (b *Bot) getOrNotifyUser(ctx context.Context, chatID int64) (*user.User, er
 	return u, nil
 }
 
+// parseTransactionText разбирает сообщение вида "350 кофе с собой":
+// первое слово — сумма, остальное — комментарий к транзакции.
+// Метки вида #отпуск из комментария разбирает вызывающий.
+func parseTransactionText(msg string) (transaction.Amount, category.Type, string, error) {
+	amountText, note := strings.TrimSpace(msg), ""
+	if i := strings.IndexFunc(amountText, unicode.IsSpace); i >= 0 {
+		amountText, note = amountText[:i], strings.TrimSpace(amountText[i:])
+	}
+
+	amount, opType, err := parseAmount(amountText)
+
+	return amount, opType, note, err
+}
+
 func parseAmount(msg string) (transaction.Amount, category.Type, error) {
 	msg = strings.TrimSpace(msg)
 
@@ -3,6 +3,7 @@ package telegram
 import (
 	"encoding/json"
 	"errors"
+	"io"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -41,6 +42,18 @@ func (b *Bot) sendReplyMarkup(chatID int64, text string, replyMarkup *tgbotapi.I
 	return nil
 }
 
+func (b *Bot) editMessage(chatID int64, messageID int, text string, replyMarkup *tgbotapi.InlineKeyboardMarkup) error {
+	msg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	msg.ReplyMarkup = replyMarkup
+
+	_, err := b.bot.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (b *Bot) sendMessageAndDeleteInlineKeyboard(chatID int64, prevMsgID int, text string) error {
 	err := b.sendMsg(chatID, text)
 	if err != nil {
@@ -54,3 +67,15 @@ func (b *Bot) sendMessageAndDeleteInlineKeyboard(chatID int64, prevMsgID int, te
 
 	return nil
 }
+
+func (b *Bot) sendDocument(chatID int64, name string, r io.Reader, caption string) error {
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileReader{Name: name, Reader: r})
+	doc.Caption = caption
+
+	_, err := b.bot.Send(doc)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package telegram
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var _ ports.Notifier = &Bot{}
+
+// Notify отправляет уведомление в чат пользователя.
+func (b *Bot) Notify(_ context.Context, recipient *user.ExternalIdentity, notification ports.Notification) error {
+	if recipient == nil {
+		return errs.NewValueIsRequiredError("recipient")
+	}
+
+	if recipient.Provider() != user.ProviderTelegram {
+		return errs.NewValueIsInvalidError("recipient.Provider()")
+	}
+
+	chatID, err := strconv.ParseInt(recipient.ExternalID(), 10, 64)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("recipient.ExternalID()", err)
+	}
+
+	if len(notification.QuickAmounts) == 0 {
+		return b.sendMsg(chatID, notification.Text)
+	}
+
+	keyboard := newQuickEntryInlineKeyboard(notification.QuickAmounts)
+
+	return b.sendReplyMarkup(chatID, notification.Text, &keyboard)
+}
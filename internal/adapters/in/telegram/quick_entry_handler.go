@@ -0,0 +1,37 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+)
+
+// handleQuickEntryCb начинает запись расхода на сумму, выбранную кнопкой в напоминании.
+func (b *Bot) handleQuickEntryCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	amount, err := transaction.NewAmountFromString(strings.TrimPrefix(cb.Data, quickEntryCbPrefix))
+	if err != nil {
+		b.sendValidationError(chatID)
+		return err
+	}
+
+	b.savePendingTransaction(chatID, amount, "", nil)
+
+	categories, err := b.getUserCategories(ctx, u.ID(), category.TypeExpense)
+	if err != nil {
+		b.sendCategoriesError(chatID)
+		return err
+	}
+
+	return b.sendCategoriesKeyboard(chatID, categories)
+}
@@ -0,0 +1,209 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// maxReceiptImageSize наибольший размер фотографии чека. Telegram сжимает фотографии,
+// больше бывают только изображения, отправленные файлом.
+const maxReceiptImageSize = 10 << 20
+
+// returnReceiptSession чек возврата, для которого пользователь выбирает возвращенную покупку.
+type returnReceiptSession struct {
+	messageID int
+	amount    transaction.Amount
+}
+
+// handlePhoto прикрепляет фотографию к транзакции, если она отправлена ответом на подтверждение записи,
+// иначе распознает на ней QR-код чека.
+func (b *Bot) handlePhoto(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	photos := update.Message.Photo
+	if len(photos) == 0 {
+		return nil
+	}
+
+	// Размеры фотографии идут по возрастанию, на самой крупной QR-код читается надежнее.
+	photo := photos[len(photos)-1]
+
+	if transactionID, ok := b.repliedTransactionID(update.Message); ok {
+		return b.handleAttachment(ctx, chatID, u.ID(), transactionID, attachedFile{
+			kind:     attachment.KindPhoto,
+			fileID:   photo.FileID,
+			uniqueID: photo.FileUniqueID,
+			mimeType: "image/jpeg",
+			size:     photo.FileSize,
+		})
+	}
+
+	return b.handleReceiptImage(ctx, chatID, u.ID(), photo.FileID, photo.FileSize)
+}
+
+// handleReceiptImage скачивает изображение с QR-кодом чека и разбирает его.
+func (b *Bot) handleReceiptImage(ctx context.Context, chatID int64, userID shared.ID, fileID string, size int) error {
+	if size > maxReceiptImageSize {
+		return b.sendMsg(chatID, fmt.Sprintf("Изображение слишком большое: фото чека должно быть не больше %d МБ", maxReceiptImageSize>>20))
+	}
+
+	content, err := b.downloadFile(ctx, fileID, maxReceiptImageSize)
+	if err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			return b.sendMsg(chatID, fmt.Sprintf("Изображение слишком большое: фото чека должно быть не больше %d МБ", maxReceiptImageSize>>20))
+		}
+
+		b.sendReceiptError(chatID)
+		return err
+	}
+
+	return b.handleReceipt(ctx, chatID, queries.NewReadReceiptQueryFromImage(userID, content))
+}
+
+// handleReceipt разбирает чек, показывает его сумму и дату и предлагает выбрать категорию.
+func (b *Bot) handleReceipt(ctx context.Context, chatID int64, query queries.ReadReceiptQuery) error {
+	r, err := b.readReceiptQueryHandler.Handle(ctx, query)
+
+	switch {
+	case errors.Is(err, receipt.ErrCodeNotFound):
+		return b.sendMsg(chatID, "Не удалось найти QR-код на фото. Сфотографируйте код крупнее и ровнее или отправьте его содержимое текстом")
+	case errors.Is(err, receipt.ErrNotReceipt), errors.Is(err, receipt.ErrInvalidOperation):
+		return b.sendMsg(chatID, "Это не QR-код кассового чека")
+	case errors.Is(err, receipt.ErrAlreadyRecorded):
+		return b.sendMsg(chatID, "Этот чек уже записан")
+	case err != nil:
+		b.sendReceiptError(chatID)
+		return err
+	}
+
+	amount, err := transaction.NewAmount(r.Amount())
+	if err != nil {
+		return b.sendMsg(chatID, "В чеке указана некорректная сумма")
+	}
+
+	if r.IsPurchaseReturn() {
+		return b.handleReturnReceipt(ctx, chatID, query.UserID(), r, amount)
+	}
+
+	b.savePendingReceipt(chatID, amount, r)
+
+	err = b.sendMsg(chatID, fmt.Sprintf(
+		"🧾 Чек от %s на %s",
+		r.OccurredAt().Format("02.01.2006 15:04"),
+		report.FormatMoney(r.Amount()),
+	))
+	if err != nil {
+		return err
+	}
+
+	categories, err := b.getUserCategories(ctx, query.UserID(), r.Type())
+	if err != nil {
+		b.sendCategoriesError(chatID)
+		return err
+	}
+
+	return b.sendCategoriesKeyboard(chatID, categories)
+}
+
+// handleReturnReceipt записывает чек возврата покупки возвратом по исходной покупке, а не доходом.
+// Покупка ищется по фискальному накопителю и сумме; если однозначно найти её не удалось,
+// пользователь выбирает её из подходящих покупок.
+func (b *Bot) handleReturnReceipt(ctx context.Context, chatID int64, userID shared.ID, r receipt.Receipt, amount transaction.Amount) error {
+	found, err := b.findReturnedPurchaseQueryHandler.Handle(ctx, queries.NewFindReturnedPurchaseQuery(userID, r))
+	if err != nil {
+		b.sendReceiptError(chatID)
+		return err
+	}
+
+	title := fmt.Sprintf(
+		"🧾 Чек возврата от %s на %s",
+		r.OccurredAt().Format("02.01.2006 15:04"),
+		report.FormatMoney(r.Amount()),
+	)
+
+	if found.Found {
+		err := b.sendMsg(chatID, fmt.Sprintf(
+			"%s\n\nВозврат по покупке от %s в категории «%s»",
+			title,
+			found.Purchase.OccurredAt().In(r.OccurredAt().Location()).Format("02.01.2006 15:04"),
+			found.Purchase.CategoryName(),
+		))
+		if err != nil {
+			return err
+		}
+
+		return b.startRefund(ctx, chatID, userID, found.Purchase.ID(), &amount, "")
+	}
+
+	if len(found.Candidates) == 0 {
+		return b.sendMsg(chatID, title+"\n\nНе нашел покупку, которую возвращает этот чек. Откройте её в истории и нажмите «Возврат»")
+	}
+
+	msg := tgbotapi.NewMessage(chatID, title+"\n\nНе нашел покупку по этому чеку. Какую покупку вернули?")
+	msg.ReplyMarkup = newReturnedPurchaseInlineKeyboard(found.Candidates, r.OccurredAt().Location())
+
+	sent, err := b.bot.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	b.cache.Set(returnReceiptSessionKey(chatID), &returnReceiptSession{messageID: sent.MessageID, amount: amount}, historySessionTTL)
+
+	return nil
+}
+
+// chooseReturnedPurchase начинает возврат по покупке, которую пользователь выбрал для чека возврата.
+func (b *Bot) chooseReturnedPurchase(ctx context.Context, chatID int64, msgID int, userID shared.ID, data string) error {
+	res, ok := b.cache.Get(returnReceiptSessionKey(chatID))
+	s, _ := res.(*returnReceiptSession)
+
+	if !ok || s == nil || s.messageID != msgID {
+		return b.editMessage(chatID, msgID, "Чек возврата устарел. Отправьте его еще раз", nil)
+	}
+
+	if data == strings.TrimPrefix(transactionCbRefundReceiptCancel, transactionCbRefundReceipt) {
+		b.cache.Delete(returnReceiptSessionKey(chatID))
+		return b.editMessage(chatID, msgID, "Возврат отменен", nil)
+	}
+
+	id, err := uuid.Parse(data)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("return receipt callback "+data, err)
+	}
+
+	b.cache.Delete(returnReceiptSessionKey(chatID))
+
+	if err := b.editMessage(chatID, msgID, fmt.Sprintf("🧾 Возврат %s по выбранной покупке", report.FormatMoney(s.amount.Value())), nil); err != nil {
+		return err
+	}
+
+	return b.startRefund(ctx, chatID, userID, shared.RestoreID(id), &s.amount, "")
+}
+
+func returnReceiptSessionKey(chatID int64) string {
+	return fmt.Sprintf("return-receipt-%d", chatID)
+}
+
+func (b *Bot) sendReceiptError(chatID int64) {
+	if err := b.sendMsg(chatID, "Не удалось прочитать чек. Попробуйте позже"); err != nil {
+		b.logger.Error("Ошибка отправки сообщения о чеке", "err", err.Error())
+	}
+}
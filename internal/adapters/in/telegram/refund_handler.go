@@ -0,0 +1,296 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// refundSession состояние оформления возврата. Хранится в кэше и относится к одному сообщению
+// с выбором части или вводом суммы; при ответе суммой на подтверждение записи сообщения может не быть.
+type refundSession struct {
+	messageID int
+
+	details *queries.TransactionDetails
+
+	// categoryID категория возврата. Пуста, пока пользователь не выбрал часть разделенной транзакции.
+	categoryID   shared.ID
+	categoryName string
+
+	// amount и note известны заранее, если пользователь ответил суммой на подтверждение записи.
+	amount *transaction.Amount
+	note   string
+}
+
+func isRefundCb(data string) bool {
+	for _, prefix := range []string{transactionCbRefund, transactionCbRefundCategory, transactionCbRefundCancel, transactionCbRefundReceipt} {
+		if strings.HasPrefix(data, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Bot) handleRefundCb(ctx context.Context, cb *tgbotapi.CallbackQuery, userID shared.ID) error {
+	chatID := cb.Message.Chat.ID
+	msgID := cb.Message.MessageID
+
+	if data := cb.Data; strings.HasPrefix(data, transactionCbRefund) {
+		id, err := uuid.Parse(strings.TrimPrefix(data, transactionCbRefund))
+		if err != nil {
+			return errs.NewValueIsInvalidErrorWithCause("transaction callback "+data, err)
+		}
+
+		return b.startRefund(ctx, chatID, userID, shared.RestoreID(id), nil, "")
+	}
+
+	if strings.HasPrefix(cb.Data, transactionCbRefundReceipt) {
+		return b.chooseReturnedPurchase(ctx, chatID, msgID, userID, strings.TrimPrefix(cb.Data, transactionCbRefundReceipt))
+	}
+
+	s, ok := b.getRefundSession(chatID)
+	if !ok || s.messageID != msgID {
+		return b.editMessage(chatID, msgID, "Возврат устарел. Откройте транзакцию и нажмите «Возврат» еще раз", nil)
+	}
+
+	switch data := cb.Data; {
+	case data == transactionCbRefundCancel:
+		b.finishRefundSession(chatID)
+		return b.editMessage(chatID, msgID, "Возврат отменен", nil)
+	case strings.HasPrefix(data, transactionCbRefundCategory):
+		return b.chooseRefundCategory(ctx, chatID, userID, s, strings.TrimPrefix(data, transactionCbRefundCategory))
+	}
+
+	return errs.NewValueIsInvalidError("transaction callback " + cb.Data)
+}
+
+// handleRefundReply записывает возврат по транзакции, на подтверждение записи которой пользователь
+// ответил сообщением вида "1500 не подошел размер".
+func (b *Bot) handleRefundReply(ctx context.Context, chatID int64, userID shared.ID, transactionID shared.ID, text string) error {
+	amount, _, note, err := parseTransactionText(text)
+	if err != nil {
+		return b.sendMsg(chatID, "Чтобы записать возврат, ответьте на подтверждение суммой, например: 1500 не подошел размер")
+	}
+
+	return b.startRefund(ctx, chatID, userID, transactionID, &amount, note)
+}
+
+// handleRefundAmountInput принимает сумму возврата и причину, введенные сообщением.
+func (b *Bot) handleRefundAmountInput(ctx context.Context, chatID int64, userID shared.ID, text string) error {
+	s, ok := b.getRefundSession(chatID)
+	if !ok || s.categoryID.IsZero() {
+		b.cache.Delete(userStateKey(chatID))
+		return b.sendMsg(chatID, "Возврат устарел. Откройте транзакцию и нажмите «Возврат» еще раз")
+	}
+
+	amount, _, note, err := parseTransactionText(text)
+	if err != nil {
+		return b.sendMsg(chatID, "Не удалось распознать сумму. Введите число и, если нужно, причину, например: 1500 не подошел размер")
+	}
+
+	s.amount, s.note = &amount, note
+
+	return b.completeRefund(ctx, chatID, userID, s)
+}
+
+// startRefund начинает возврат по транзакции. Для разделенной транзакции сначала выбирается часть,
+// затем, если сумма еще не известна, она запрашивается сообщением.
+func (b *Bot) startRefund(
+	ctx context.Context,
+	chatID int64,
+	userID shared.ID,
+	transactionID shared.ID,
+	amount *transaction.Amount,
+	note string,
+) error {
+	details, err := b.getTransactionDetailsQueryHandler.Handle(ctx, queries.NewGetTransactionDetailsQuery(userID, transactionID))
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return b.sendMsg(chatID, "Транзакция не найдена. Возможно, она удалена")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if details.Line.CategoryType() != category.TypeExpense {
+		return b.sendMsg(chatID, "Возврат можно оформить только по расходу")
+	}
+
+	if !details.CanRefund() {
+		return b.sendMsg(chatID, "По этой транзакции уже возвращена вся сумма")
+	}
+
+	s := &refundSession{details: details, amount: amount, note: note}
+
+	if len(details.Parts) == 0 {
+		for id := range details.Refundable {
+			s.categoryID = id
+		}
+
+		s.categoryName = details.Line.CategoryName()
+
+		return b.continueRefund(ctx, chatID, userID, s)
+	}
+
+	keyboard := newRefundPartsInlineKeyboard(details.Parts, details.Refundable)
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
+		"💸 Возврат по транзакции на %s\n\nКакую часть покупки вернули?",
+		report.FormatMoney(details.Line.Amount()),
+	))
+	msg.ReplyMarkup = keyboard
+
+	sent, err := b.bot.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	s.messageID = sent.MessageID
+	b.saveRefundSession(chatID, s)
+
+	return nil
+}
+
+func (b *Bot) chooseRefundCategory(ctx context.Context, chatID int64, userID shared.ID, s *refundSession, data string) error {
+	id, err := uuid.Parse(data)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("refund category", err)
+	}
+
+	for _, p := range s.details.Parts {
+		if p.CategoryID() != shared.RestoreID(id) {
+			continue
+		}
+
+		s.categoryID = p.CategoryID()
+		s.categoryName = p.Name()
+
+		return b.continueRefund(ctx, chatID, userID, s)
+	}
+
+	return errs.NewObjectNotFoundError("category", data)
+}
+
+// continueRefund записывает возврат, если сумма известна, иначе запрашивает её.
+func (b *Bot) continueRefund(ctx context.Context, chatID int64, userID shared.ID, s *refundSession) error {
+	if s.amount != nil {
+		return b.completeRefund(ctx, chatID, userID, s)
+	}
+
+	keyboard := newRefundCancelInlineKeyboard()
+	text := fmt.Sprintf(
+		"💸 Возврат по категории «%s», можно вернуть до %s\n\nВведите сумму и, если нужно, причину, например: 1500 не подошел размер",
+		s.categoryName,
+		report.FormatMoney(s.details.Refundable[s.categoryID]),
+	)
+
+	if s.messageID != 0 {
+		if err := b.editMessage(chatID, s.messageID, text, &keyboard); err != nil {
+			return err
+		}
+	} else {
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = keyboard
+
+		sent, err := b.bot.Send(msg)
+		if err != nil {
+			return err
+		}
+
+		s.messageID = sent.MessageID
+	}
+
+	b.saveRefundSession(chatID, s)
+	b.cache.Set(userStateKey(chatID), UserStateWaitingForRefundAmount, historySessionTTL)
+
+	return nil
+}
+
+func (b *Bot) completeRefund(ctx context.Context, chatID int64, userID shared.ID, s *refundSession) error {
+	b.finishRefundSession(chatID)
+
+	cmd, err := commands.NewCreateRefundCommand(userID, s.details.Line.ID(), s.categoryID, *s.amount, s.note)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.createRefundCommandHandler.Handle(ctx, cmd)
+
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return b.sendRefundResult(chatID, s, "Транзакция не найдена. Возможно, она удалена")
+	case errors.Is(err, refund.ErrNotExpense):
+		return b.sendRefundResult(chatID, s, "Возврат можно оформить только по расходу")
+	case errors.Is(err, refund.ErrExceedsOriginal):
+		return b.sendRefundResult(chatID, s, fmt.Sprintf(
+			"Сумма возврата больше суммы покупки за вычетом прежних возвратов. Можно вернуть не больше %s",
+			report.FormatMoney(s.details.Refundable[s.categoryID]),
+		))
+	case errors.Is(err, refund.ErrUnknownCategory):
+		return b.sendRefundResult(chatID, s, "Разделение транзакции изменилось. Откройте транзакцию и оформите возврат еще раз")
+	case errors.Is(err, transaction.ErrTooLongNote):
+		return b.sendRefundResult(chatID, s, fmt.Sprintf("Причина возврата не должна быть длиннее %d символов", transaction.MaxNoteLength))
+	case err != nil:
+		if err2 := b.sendRefundResult(chatID, s, "Не удалось записать возврат. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о возврате", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	return b.sendRefundResult(chatID, s, fmt.Sprintf(
+		"✅ Возврат %s записан, расходы по категории «%s» уменьшены",
+		report.FormatMoney(s.amount.Value()),
+		s.categoryName,
+	))
+}
+
+// sendRefundResult заменяет сообщение о возврате результатом или отправляет результат новым сообщением,
+// если возврат оформлен ответом на подтверждение записи.
+func (b *Bot) sendRefundResult(chatID int64, s *refundSession, text string) error {
+	if s.messageID != 0 {
+		return b.editMessage(chatID, s.messageID, text, nil)
+	}
+
+	return b.sendMsg(chatID, text)
+}
+
+func (b *Bot) getRefundSession(chatID int64) (*refundSession, bool) {
+	res, ok := b.cache.Get(refundSessionKey(chatID))
+	if !ok {
+		return nil, false
+	}
+
+	s, ok := res.(*refundSession)
+
+	return s, ok
+}
+
+func (b *Bot) saveRefundSession(chatID int64, s *refundSession) {
+	b.cache.Set(refundSessionKey(chatID), s, historySessionTTL)
+}
+
+func (b *Bot) finishRefundSession(chatID int64) {
+	b.cache.Delete(refundSessionKey(chatID))
+
+	if us, _ := b.getUserState(chatID); us == UserStateWaitingForRefundAmount {
+		b.cache.Delete(userStateKey(chatID))
+	}
+}
+
+func refundSessionKey(chatID int64) string {
+	return fmt.Sprintf("refund-%d", chatID)
+}
@@ -18,11 +18,33 @@ func (b *Bot) handleUpdate(ctx context.Context, update tgbotapi.Update) error {
 			return b.handleStartCommand(ctx, update)
 		case "create_default_categories":
 			return b.handleCreateDefaultCategoriesCommand(ctx, update)
+		case "settings":
+			return b.handleSettingsCommand(ctx, update)
+		case "export":
+			return b.handleExportCommand(ctx, update)
+		case "backup":
+			return b.handleBackupCommand(ctx, update)
+		case "restore":
+			return b.handleRestoreCommand(ctx, update)
+		case "history":
+			return b.handleHistoryCommand(ctx, update)
+		case "search":
+			return b.handleSearchCommand(ctx, update)
+		case "tag":
+			return b.handleTagCommand(ctx, update)
+		case "token":
+			return b.handleTokenCommand(ctx, update)
+		case "web":
+			return b.handleWebCommand(ctx, update)
 		}
 
 		return errs.NewValueIsInvalidErrorWithCause("command", errs.NewValueIsInvalidError("command "+cmd))
 	case update.CallbackQuery != nil:
 		return b.handleCb(ctx, update.CallbackQuery)
+	case update.Message != nil && update.Message.Photo != nil:
+		return b.handlePhoto(ctx, update)
+	case update.Message != nil && update.Message.Document != nil:
+		return b.handleDocument(ctx, update)
 	case update.Message != nil:
 		return b.handleMsg(ctx, update)
 	}
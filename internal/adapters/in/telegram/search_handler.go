@@ -0,0 +1,87 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+)
+
+// searchLimit наибольшее число найденных транзакций в ответе, итоги считаются по всем совпадениям.
+const searchLimit = 20
+
+func (b *Bot) handleSearchCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	query, err := queries.NewSearchTransactionsQuery(u.ID(), update.Message.CommandArguments(), searchLimit)
+
+	switch {
+	case errors.Is(err, report.ErrEmptySearchText):
+		return b.sendMsg(chatID, "Напишите, что искать, например: /search страховка")
+	case errors.Is(err, report.ErrTooLongSearchText):
+		return b.sendMsg(chatID, fmt.Sprintf("Запрос должен быть не длиннее %d символов", report.MaxSearchTextLength))
+	case err != nil:
+		return err
+	}
+
+	search, err := b.searchTransactionsQueryHandler.Handle(ctx, query)
+	if err != nil {
+		if err2 := b.sendMsg(chatID, "Не удалось выполнить поиск. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о поиске", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, composeSearchResult(query.Text(), search))
+	if keyboard := newTransactionLinesInlineKeyboard(search.Result.Lines()); keyboard != nil {
+		msg.ReplyMarkup = keyboard
+	}
+
+	_, err = b.bot.Send(msg)
+
+	return err
+}
+
+// composeSearchResult описывает найденные транзакции и итоги по всем совпадениям.
+func composeSearchResult(text string, search *queries.TransactionSearch) string {
+	r := search.Result
+
+	if r.Count() == 0 {
+		return fmt.Sprintf("🔎 По запросу «%s» ничего не найдено", text)
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🔎 «%s»: найдено %d", text, r.Count())
+
+	if !r.TotalExpense().IsZero() {
+		fmt.Fprintf(&sb, "\nРасходы: %s", report.FormatMoney(r.TotalExpense()))
+	}
+
+	if !r.TotalIncome().IsZero() {
+		fmt.Fprintf(&sb, "\nДоходы: %s", report.FormatMoney(r.TotalIncome()))
+	}
+
+	sb.WriteString("\n")
+
+	for i, line := range r.Lines() {
+		fmt.Fprintf(&sb, "\n%d. %s", i+1, composeTransactionLine(line, search.Location))
+	}
+
+	if r.HasMore() {
+		fmt.Fprintf(&sb, "\n\nПоказаны последние %d", len(r.Lines()))
+	}
+
+	return sb.String()
+}
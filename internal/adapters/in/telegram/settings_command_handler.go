@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func (b *Bot) handleSettingsCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	s, err := b.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(u.ID()))
+	if err != nil {
+		if err2 := b.sendMsg(chatID, "Не удалось загрузить настройки. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о загрузке настроек", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	keyboard := newSettingsInlineKeyboard(s)
+
+	return b.sendReplyMarkup(chatID, settingsText(s), &keyboard)
+}
+
+func (b *Bot) handleSettingsCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+	msgID := cb.Message.MessageID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	s, err := b.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(u.ID()))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cb.Data == settingsCbHours:
+		keyboard := newHoursInlineKeyboard(s.DigestHour())
+		return b.editMessage(chatID, msgID, "В какое время присылать сводку?", &keyboard)
+	case cb.Data == settingsCbZones:
+		keyboard := newTimezonesInlineKeyboard(s.Timezone())
+		return b.editMessage(chatID, msgID, "Выберите часовой пояс:", &keyboard)
+	case cb.Data == settingsCbReminders:
+		keyboard := newRemindersInlineKeyboard(s.ReminderAfterHours())
+		return b.editMessage(chatID, msgID, "Напомнить записать траты, если вы ничего не записывали:", &keyboard)
+	case cb.Data == settingsCbQuietList:
+		keyboard := newQuietHoursInlineKeyboard(s.QuietFrom(), s.QuietTo())
+		return b.editMessage(chatID, msgID, "В какое время не присылать напоминания?", &keyboard)
+	case cb.Data == settingsCbMenu:
+	default:
+		cmd, err := newSettingsCommandFromCb(u.ID(), cb.Data)
+		if err != nil {
+			return err
+		}
+
+		s, err = b.updateSettingsCommandHandler.Handle(ctx, cmd)
+		if err != nil {
+			if err2 := b.sendMsg(chatID, "Не удалось сохранить настройки. Попробуйте еще раз"); err2 != nil {
+				b.logger.Error("Ошибка отправки сообщения о сохранении настроек", "err", err2.Error())
+			}
+
+			return err
+		}
+	}
+
+	keyboard := newSettingsInlineKeyboard(s)
+
+	return b.editMessage(chatID, msgID, settingsText(s), &keyboard)
+}
+
+func newSettingsCommandFromCb(userID shared.ID, data string) (commands.UpdateSettingsCommand, error) {
+	switch {
+	case strings.HasPrefix(data, settingsCbDigest):
+		mode, err := settings.ParseDigestMode(strings.TrimPrefix(data, settingsCbDigest))
+		if err != nil {
+			return nil, errs.NewValueIsInvalidErrorWithCause("digest mode", err)
+		}
+
+		return commands.NewSetDigestModeCommand(userID, mode)
+	case strings.HasPrefix(data, settingsCbHour):
+		hour, err := strconv.Atoi(strings.TrimPrefix(data, settingsCbHour))
+		if err != nil {
+			return nil, errs.NewValueIsInvalidErrorWithCause("digest hour", err)
+		}
+
+		return commands.NewSetDigestHourCommand(userID, hour)
+	case strings.HasPrefix(data, settingsCbReminder):
+		hours, err := strconv.Atoi(strings.TrimPrefix(data, settingsCbReminder))
+		if err != nil {
+			return nil, errs.NewValueIsInvalidErrorWithCause("reminder hours", err)
+		}
+
+		return commands.NewSetReminderCommand(userID, hours)
+	case strings.HasPrefix(data, settingsCbQuiet):
+		from, to, ok := strings.Cut(strings.TrimPrefix(data, settingsCbQuiet), "-")
+		if !ok {
+			return nil, errs.NewValueIsInvalidError("quiet hours " + data)
+		}
+
+		fromHour, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, errs.NewValueIsInvalidErrorWithCause("quiet hours from", err)
+		}
+
+		toHour, err := strconv.Atoi(to)
+		if err != nil {
+			return nil, errs.NewValueIsInvalidErrorWithCause("quiet hours to", err)
+		}
+
+		return commands.NewSetQuietHoursCommand(userID, fromHour, toHour)
+	case strings.HasPrefix(data, settingsCbZone):
+		return commands.NewSetTimezoneCommand(userID, strings.TrimPrefix(data, settingsCbZone))
+	}
+
+	return nil, errs.NewValueIsInvalidError("settings callback " + data)
+}
@@ -0,0 +1,373 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// splitSession состояние разделения транзакции: выбранные части и категория, для которой ждем сумму.
+// Хранится в кэше и относится к одному сообщению с выбором категорий.
+type splitSession struct {
+	messageID int
+
+	transactionID shared.ID
+	total         decimal.Decimal
+	categoryType  category.Type
+
+	parts []splitDraft
+
+	// pending категория очередной части, сумму которой пользователь вводит сообщением.
+	pending *category.Category
+}
+
+type splitDraft struct {
+	category *category.Category
+	amount   transaction.Amount
+}
+
+// remaining возвращает сумму, которую еще не отнесли ни на одну категорию.
+func (s *splitSession) remaining() decimal.Decimal {
+	rest := s.total
+	for _, p := range s.parts {
+		rest = rest.Sub(p.amount.Value())
+	}
+
+	return rest
+}
+
+func (s *splitSession) usedCategories() map[shared.ID]bool {
+	used := make(map[shared.ID]bool, len(s.parts))
+	for _, p := range s.parts {
+		used[p.category.ID()] = true
+	}
+
+	return used
+}
+
+func isSplitCb(data string) bool {
+	for _, prefix := range []string{
+		transactionCbSplit,
+		transactionCbUnsplit,
+		transactionCbSplitCategory,
+		transactionCbSplitRest,
+		transactionCbSplitCancel,
+	} {
+		if strings.HasPrefix(data, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Bot) handleSplitCb(ctx context.Context, cb *tgbotapi.CallbackQuery, userID shared.ID) error {
+	chatID := cb.Message.Chat.ID
+	msgID := cb.Message.MessageID
+
+	switch data := cb.Data; {
+	case strings.HasPrefix(data, transactionCbSplit):
+		id, err := uuid.Parse(strings.TrimPrefix(data, transactionCbSplit))
+		if err != nil {
+			return errs.NewValueIsInvalidErrorWithCause("transaction callback "+data, err)
+		}
+
+		return b.startSplit(ctx, chatID, userID, shared.RestoreID(id))
+	case strings.HasPrefix(data, transactionCbUnsplit):
+		id, err := uuid.Parse(strings.TrimPrefix(data, transactionCbUnsplit))
+		if err != nil {
+			return errs.NewValueIsInvalidErrorWithCause("transaction callback "+data, err)
+		}
+
+		return b.unsplit(ctx, chatID, userID, shared.RestoreID(id))
+	}
+
+	s, ok := b.getSplitSession(chatID)
+	if !ok || s.messageID != msgID {
+		return b.editMessage(chatID, msgID, "Разделение устарело. Откройте транзакцию и нажмите «Разделить» еще раз", nil)
+	}
+
+	switch data := cb.Data; {
+	case data == transactionCbSplitCancel:
+		b.finishSplitSession(chatID)
+		return b.editMessage(chatID, msgID, "Разделение отменено", nil)
+	case data == transactionCbSplitRest:
+		if s.pending == nil || len(s.parts) == 0 {
+			return nil
+		}
+
+		rest, err := transaction.NewAmount(s.remaining())
+		if err != nil {
+			return err
+		}
+
+		return b.addSplitPart(ctx, chatID, userID, s, rest)
+	case strings.HasPrefix(data, transactionCbSplitCategory):
+		return b.chooseSplitCategory(ctx, chatID, userID, s, strings.TrimPrefix(data, transactionCbSplitCategory))
+	}
+
+	return errs.NewValueIsInvalidError("transaction callback " + cb.Data)
+}
+
+// handleSplitAmountInput принимает сумму очередной части, введенную сообщением.
+func (b *Bot) handleSplitAmountInput(ctx context.Context, chatID int64, userID shared.ID, text string) error {
+	s, ok := b.getSplitSession(chatID)
+	if !ok || s.pending == nil {
+		b.cache.Delete(userStateKey(chatID))
+		return b.sendMsg(chatID, "Разделение устарело. Откройте транзакцию и нажмите «Разделить» еще раз")
+	}
+
+	amount, err := transaction.NewAmountFromString(text)
+	if err != nil {
+		return b.sendMsg(chatID, "Не удалось распознать сумму. Введите число, например 350 или 99.90")
+	}
+
+	return b.addSplitPart(ctx, chatID, userID, s, amount)
+}
+
+func (b *Bot) startSplit(ctx context.Context, chatID int64, userID shared.ID, transactionID shared.ID) error {
+	details, err := b.getTransactionDetailsQueryHandler.Handle(ctx, queries.NewGetTransactionDetailsQuery(userID, transactionID))
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return b.sendMsg(chatID, "Транзакция не найдена. Возможно, она удалена")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s := &splitSession{
+		transactionID: transactionID,
+		total:         details.Line.Amount(),
+		categoryType:  details.Line.CategoryType(),
+	}
+
+	categories, err := b.getUserCategories(ctx, userID, s.categoryType)
+	if err != nil {
+		b.sendCategoriesError(chatID)
+		return err
+	}
+
+	keyboard := newSplitCategoriesInlineKeyboard(categories, nil)
+	msg := tgbotapi.NewMessage(chatID, composeSplitProgress(s)+"\n\nВыберите категорию первой части")
+	msg.ReplyMarkup = keyboard
+
+	sent, err := b.bot.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	s.messageID = sent.MessageID
+	b.saveSplitSession(chatID, s)
+
+	return nil
+}
+
+func (b *Bot) chooseSplitCategory(ctx context.Context, chatID int64, userID shared.ID, s *splitSession, data string) error {
+	id, err := uuid.Parse(data)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("split category", err)
+	}
+
+	categories, err := b.getUserCategories(ctx, userID, s.categoryType)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range categories {
+		if c.ID() != shared.RestoreID(id) {
+			continue
+		}
+
+		s.pending = c
+		b.saveSplitSession(chatID, s)
+		b.cache.Set(userStateKey(chatID), UserStateWaitingForSplitAmount, historySessionTTL)
+
+		keyboard := newSplitAmountInlineKeyboard(len(s.parts) > 0)
+		text := fmt.Sprintf(
+			"%s\n\nСколько отнести на «%s»? Введите сумму",
+			composeSplitProgress(s),
+			c.Name(),
+		)
+
+		return b.editMessage(chatID, s.messageID, text, &keyboard)
+	}
+
+	return errs.NewObjectNotFoundError("category", data)
+}
+
+// addSplitPart относит amount на выбранную категорию. Когда распределена вся сумма, транзакция делится.
+func (b *Bot) addSplitPart(ctx context.Context, chatID int64, userID shared.ID, s *splitSession, amount transaction.Amount) error {
+	rest := s.remaining()
+
+	switch {
+	case amount.Value().GreaterThan(rest):
+		return b.sendMsg(chatID, "Сумма больше нераспределенного остатка "+report.FormatMoney(rest))
+	case amount.Value().Equal(rest) && len(s.parts) == 0:
+		return b.sendMsg(chatID, "На первую категорию нельзя отнести всю сумму. Введите меньшую сумму")
+	case amount.Value().LessThan(rest) && len(s.parts) == transaction.MaxSplitParts-1:
+		return b.sendMsg(chatID, fmt.Sprintf(
+			"Частей может быть не больше %d, последняя должна забрать весь остаток %s",
+			transaction.MaxSplitParts,
+			report.FormatMoney(rest),
+		))
+	}
+
+	s.parts = append(s.parts, splitDraft{category: s.pending, amount: amount})
+	s.pending = nil
+	b.cache.Delete(userStateKey(chatID))
+
+	if s.remaining().IsZero() {
+		return b.completeSplit(ctx, chatID, userID, s)
+	}
+
+	b.saveSplitSession(chatID, s)
+
+	categories, err := b.getUserCategories(ctx, userID, s.categoryType)
+	if err != nil {
+		b.sendCategoriesError(chatID)
+		return err
+	}
+
+	// Сумму вводят сообщением, поэтому выбор следующей категории отправляется новым сообщением под ним.
+	if err := b.editMessage(chatID, s.messageID, composeSplitProgress(s), nil); err != nil {
+		b.logger.Error("Ошибка изменения сообщения о разделении", "err", err.Error())
+	}
+
+	keyboard := newSplitCategoriesInlineKeyboard(categories, s.usedCategories())
+	msg := tgbotapi.NewMessage(chatID, "Выберите категорию следующей части")
+	msg.ReplyMarkup = keyboard
+
+	sent, err := b.bot.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	s.messageID = sent.MessageID
+	b.saveSplitSession(chatID, s)
+
+	return nil
+}
+
+func (b *Bot) completeSplit(ctx context.Context, chatID int64, userID shared.ID, s *splitSession) error {
+	b.finishSplitSession(chatID)
+
+	parts := make([]transaction.Split, 0, len(s.parts))
+	for _, p := range s.parts {
+		part, err := transaction.NewSplit(p.category.ID(), p.amount)
+		if err != nil {
+			return err
+		}
+
+		parts = append(parts, part)
+	}
+
+	cmd, err := commands.NewSplitTransactionCommand(userID, s.transactionID, parts)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.splitTransactionCommandHandler.Handle(ctx, cmd)
+
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return b.sendMsg(chatID, "Транзакция или категория не найдена. Возможно, они удалены")
+	case errors.Is(err, transaction.ErrSplitSumMismatch):
+		return b.sendMsg(chatID, "Части не сходятся с суммой транзакции. Возможно, сумма изменилась, разделите транзакцию еще раз")
+	case errors.Is(err, refund.ErrBelowRefunded):
+		return b.sendMsg(chatID, "По транзакции оформлены возвраты. Оставьте в частях категории возвратов с суммой не меньше возвращенной")
+	case err != nil:
+		if err2 := b.sendMsg(chatID, "Не удалось разделить транзакцию. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о разделении", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	keyboard := newTransactionDetailsInlineKeyboard(s.transactionID, 0, true, s.categoryType == category.TypeExpense)
+
+	return b.editMessage(chatID, s.messageID, composeSplitParts("✅ Транзакция на "+report.FormatMoney(s.total)+" разделена", s), &keyboard)
+}
+
+func (b *Bot) unsplit(ctx context.Context, chatID int64, userID shared.ID, transactionID shared.ID) error {
+	cmd, err := commands.NewUnsplitTransactionCommand(userID, transactionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.splitTransactionCommandHandler.Handle(ctx, cmd)
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return b.sendMsg(chatID, "Транзакция не найдена. Возможно, она удалена")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return b.sendMsg(chatID, "↩️ Разделение отменено: вся сумма отнесена на категорию первой части")
+}
+
+func (b *Bot) getSplitSession(chatID int64) (*splitSession, bool) {
+	res, ok := b.cache.Get(splitSessionKey(chatID))
+	if !ok {
+		return nil, false
+	}
+
+	s, ok := res.(*splitSession)
+
+	return s, ok
+}
+
+func (b *Bot) saveSplitSession(chatID int64, s *splitSession) {
+	b.cache.Set(splitSessionKey(chatID), s, historySessionTTL)
+}
+
+func (b *Bot) finishSplitSession(chatID int64) {
+	b.cache.Delete(splitSessionKey(chatID))
+
+	if us, _ := b.getUserState(chatID); us == UserStateWaitingForSplitAmount {
+		b.cache.Delete(userStateKey(chatID))
+	}
+}
+
+func splitSessionKey(chatID int64) string {
+	return fmt.Sprintf("split-%d", chatID)
+}
+
+// composeSplitProgress описывает распределенные части и остаток, например:
+//
+//	✂️ Разделить 3 000 ₽
+//	• Продукты: 2 200
+//	Осталось: 800 ₽
+func composeSplitProgress(s *splitSession) string {
+	return composeSplitParts("✂️ Разделить "+report.FormatMoney(s.total), s)
+}
+
+func composeSplitParts(title string, s *splitSession) string {
+	var sb strings.Builder
+
+	sb.WriteString(title)
+
+	for _, p := range s.parts {
+		fmt.Fprintf(&sb, "\n• %s: %s", p.category.Name(), report.FormatAmount(p.amount.Value()))
+	}
+
+	if rest := s.remaining(); !rest.IsZero() {
+		fmt.Fprintf(&sb, "\nОсталось: %s", report.FormatMoney(rest))
+	}
+
+	return sb.String()
+}
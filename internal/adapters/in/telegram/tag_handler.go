@@ -0,0 +1,109 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// handleTagCommand без аргументов перечисляет метки пользователя,
+// а с меткой, например /tag #отпуск2026, показывает, сколько по ней потрачено и получено.
+func (b *Bot) handleTagCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	args := update.Message.CommandArguments()
+	if args == "" {
+		tags, err := b.getUserTagsQueryHandler.Handle(ctx, queries.NewGetUserTagsQuery(u.ID()))
+		if err != nil {
+			return err
+		}
+
+		return b.sendMsg(chatID, composeTagList(tags))
+	}
+
+	query, err := queries.NewGetTagSummaryQuery(u.ID(), args)
+	if errors.Is(err, tag.ErrInvalidName) {
+		return b.sendMsg(chatID, fmt.Sprintf(
+			"Метка может состоять из букв, цифр и _ и быть не длиннее %d символов",
+			tag.MaxNameLength,
+		))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	summary, err := b.getTagSummaryQueryHandler.Handle(ctx, query)
+
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound):
+		return b.sendMsg(chatID, fmt.Sprintf("Метки #%s нет", query.TagName()))
+	case err != nil:
+		if err2 := b.sendMsg(chatID, "Не удалось посчитать итоги по метке. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения об итогах по метке", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	return b.sendMsg(chatID, composeTagSummary(summary))
+}
+
+func composeTagList(tags []*tag.Tag) string {
+	if len(tags) == 0 {
+		return "🏷 Меток пока нет. Добавьте #метку в комментарий транзакции, например: 350 кофе #отпуск2026"
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.String())
+	}
+
+	return "🏷 Ваши метки: " + strings.Join(names, " ") + "\n\nИтоги по метке: /tag название"
+}
+
+// composeTagSummary описывает итоги по метке, например:
+//
+//	🏷 #отпуск2026
+//	Потрачено: 45 300 ₽
+//	• Билеты: 30 000
+func composeTagSummary(s *queries.TagSummary) string {
+	var sb strings.Builder
+
+	sb.WriteString("🏷 " + s.Tag.String())
+
+	if s.Summary.IsEmpty() {
+		sb.WriteString("\nТранзакций с этой меткой нет")
+		return sb.String()
+	}
+
+	writeTotals := func(title string, total string, totals []report.CategoryTotal) {
+		if len(totals) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&sb, "\n\n%s: %s", title, total)
+
+		for _, t := range totals {
+			fmt.Fprintf(&sb, "\n• %s: %s", t.Name(), report.FormatAmount(t.Amount()))
+		}
+	}
+
+	writeTotals("Потрачено", report.FormatMoney(s.Summary.TotalExpense()), s.Summary.Expenses())
+	writeTotals("Получено", report.FormatMoney(s.Summary.TotalIncome()), s.Summary.Incomes())
+
+	return sb.String()
+}
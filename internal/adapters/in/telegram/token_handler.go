@@ -0,0 +1,266 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/queries"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const tokenUsage = "Выпустить токен: /token new название [read|write|read,write] [30d|31.12.2026]\n" +
+	"По умолчанию токен дает только чтение и не истекает. Право write включает read"
+
+// handleTokenCommand управляет персональными токенами доступа к HTTP API:
+// /token перечисляет токены с кнопками отзыва, /token new выпускает новый.
+func (b *Bot) handleTokenCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	args := strings.Fields(update.Message.CommandArguments())
+	if len(args) == 0 {
+		return b.sendTokenList(ctx, chatID, u)
+	}
+
+	if args[0] != "new" {
+		return b.sendMsg(chatID, tokenUsage)
+	}
+
+	s, err := b.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(u.ID()))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	name, scopes, expiresAt, err := parseTokenArgs(args[1:], now.In(s.Location()))
+	if err != nil {
+		return b.sendMsg(chatID, tokenUsage)
+	}
+
+	cmd, err := commands.NewIssueAPITokenCommand(u.ID(), name, scopes, expiresAt, now)
+	if err != nil {
+		return err
+	}
+
+	issued, err := b.issueAPITokenCommandHandler.Handle(ctx, cmd)
+
+	switch {
+	case errors.Is(err, apitoken.ErrInvalidName):
+		return b.sendMsg(chatID, fmt.Sprintf("Название токена должно быть не длиннее %d символов", apitoken.MaxNameLength))
+	case errors.Is(err, apitoken.ErrExpiresInPast):
+		return b.sendMsg(chatID, "Срок действия токена должен закончиться в будущем")
+	case errors.Is(err, apitoken.ErrTooMany):
+		return b.sendMsg(chatID, fmt.Sprintf(
+			"У вас уже %d действующих токенов. Отзовите ненужные: /token",
+			apitoken.MaxActivePerUser,
+		))
+	case err != nil:
+		if err2 := b.sendMsg(chatID, "Не удалось выпустить токен. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о выпуске токена", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	return b.sendMsg(chatID, composeIssuedToken(issued, s.Location()))
+}
+
+func (b *Bot) sendTokenList(ctx context.Context, chatID int64, u *user.User) error {
+	tokens, err := b.getUserAPITokensQueryHandler.Handle(ctx, queries.NewGetUserAPITokensQuery(u.ID()))
+	if err != nil {
+		return err
+	}
+
+	s, err := b.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(u.ID()))
+	if err != nil {
+		return err
+	}
+
+	return b.sendReplyMarkup(chatID, composeTokenList(tokens, time.Now(), s.Location()), newTokensInlineKeyboard(tokens))
+}
+
+func (b *Bot) handleTokenCb(ctx context.Context, cb *tgbotapi.CallbackQuery) error {
+	chatID := cb.Message.Chat.ID
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	if !strings.HasPrefix(cb.Data, tokenCbRevoke) {
+		return errs.NewValueIsInvalidError("token callback " + cb.Data)
+	}
+
+	id, err := uuid.Parse(strings.TrimPrefix(cb.Data, tokenCbRevoke))
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("token callback "+cb.Data, err)
+	}
+
+	cmd, err := commands.NewRevokeAPITokenCommand(u.ID(), shared.RestoreID(id), time.Now())
+	if err != nil {
+		return err
+	}
+
+	revoked, err := b.revokeAPITokenCommandHandler.Handle(ctx, cmd)
+
+	switch {
+	case errors.Is(err, errs.ErrObjectNotFound), errors.Is(err, apitoken.ErrAlreadyRevoked):
+		return b.sendMsg(chatID, "Токен уже отозван")
+	case err != nil:
+		return err
+	}
+
+	if err := b.sendMsg(chatID, fmt.Sprintf("Токен «%s» отозван и больше не принимается", revoked.Name())); err != nil {
+		return err
+	}
+
+	return b.refreshTokenList(ctx, cb, u)
+}
+
+// refreshTokenList обновляет список токенов в сообщении, из которого токен был отозван.
+func (b *Bot) refreshTokenList(ctx context.Context, cb *tgbotapi.CallbackQuery, u *user.User) error {
+	tokens, err := b.getUserAPITokensQueryHandler.Handle(ctx, queries.NewGetUserAPITokensQuery(u.ID()))
+	if err != nil {
+		return err
+	}
+
+	s, err := b.getUserSettingsQueryHandler.Handle(ctx, queries.NewGetUserSettingsQuery(u.ID()))
+	if err != nil {
+		return err
+	}
+
+	return b.editMessage(
+		cb.Message.Chat.ID,
+		cb.Message.MessageID,
+		composeTokenList(tokens, time.Now(), s.Location()),
+		newTokensInlineKeyboard(tokens),
+	)
+}
+
+// parseTokenArgs разбирает аргументы /token new. Права и срок необязательны и указываются
+// после названия, поэтому разбираются с конца; все остальное считается названием.
+func parseTokenArgs(args []string, now time.Time) (string, []apitoken.Scope, time.Time, error) {
+	scopes := []apitoken.Scope{apitoken.ScopeRead}
+
+	var expiresAt time.Time
+
+	if n := len(args); n > 1 {
+		if t, ok := parseTokenExpiry(args[n-1], now); ok {
+			expiresAt = t
+			args = args[:n-1]
+		}
+	}
+
+	if n := len(args); n > 1 {
+		if s, ok := parseTokenScopes(args[n-1]); ok {
+			scopes = s
+			args = args[:n-1]
+		}
+	}
+
+	if len(args) == 0 {
+		return "", nil, time.Time{}, errs.NewValueIsRequiredError("name")
+	}
+
+	return strings.Join(args, " "), scopes, expiresAt, nil
+}
+
+// parseTokenExpiry разбирает срок действия: число дней, например 30d, или дату, до которой токен действует включительно.
+func parseTokenExpiry(text string, now time.Time) (time.Time, bool) {
+	if days, ok := strings.CutSuffix(text, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return time.Time{}, false
+		}
+
+		return now.AddDate(0, 0, n), true
+	}
+
+	date, err := time.ParseInLocation(historyDateLayout, text, now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return date.AddDate(0, 0, 1), true
+}
+
+func parseTokenScopes(text string) ([]apitoken.Scope, bool) {
+	parts := strings.Split(text, ",")
+
+	scopes := make([]apitoken.Scope, 0, len(parts))
+	for _, p := range parts {
+		s, err := apitoken.ParseScope(p)
+		if err != nil {
+			return nil, false
+		}
+
+		scopes = append(scopes, s)
+	}
+
+	return scopes, true
+}
+
+func composeIssuedToken(issued *commands.IssuedAPIToken, loc *time.Location) string {
+	return fmt.Sprintf(
+		"🔑 Токен «%s» выпущен: %s\n\n%s\n\nСохраните его сейчас: бот хранит только хеш и показать токен повторно не сможет. "+
+			"Передавайте его в заголовке Authorization: Bearer <токен>",
+		issued.Token.Name(),
+		composeTokenTerms(issued.Token, loc),
+		issued.Secret,
+	)
+}
+
+// composeTokenList описывает токены пользователя, например:
+//
+//	🔑 Ваши токены:
+//	• cli (ctb_Ab3x…) — чтение, бессрочно
+func composeTokenList(tokens []*apitoken.Token, now time.Time, loc *time.Location) string {
+	if len(tokens) == 0 {
+		return "🔑 Токенов доступа к API пока нет\n\n" + tokenUsage
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("🔑 Ваши токены:")
+
+	for _, t := range tokens {
+		fmt.Fprintf(&sb, "\n• %s (%s…) — %s", t.Name(), t.Hint(), composeTokenTerms(t, loc))
+
+		if t.IsExpired(now) {
+			sb.WriteString(", истек")
+		}
+	}
+
+	sb.WriteString("\n\n" + tokenUsage)
+
+	return sb.String()
+}
+
+func composeTokenTerms(t *apitoken.Token, loc *time.Location) string {
+	access := "чтение"
+	if t.Allows(apitoken.ScopeWrite) {
+		access = "чтение и запись"
+	}
+
+	if t.ExpiresAt().IsZero() {
+		return access + ", бессрочно"
+	}
+
+	return access + ", до " + t.ExpiresAt().In(loc).Format("02.01.2006 15:04")
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/patrickmn/go-cache"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 )
 
@@ -12,21 +13,49 @@ type UserState string
 
 const (
 	UserStateWaitingForCategory UserState = "waiting_for_category"
+	UserStateWaitingForBackup   UserState = "waiting_for_backup"
+
+	// Состояния ввода значений для истории транзакций.
+	UserStateWaitingForHistoryAmount      UserState = "waiting_for_history_amount"
+	UserStateWaitingForHistoryNote        UserState = "waiting_for_history_note"
+	UserStateWaitingForHistoryPeriod      UserState = "waiting_for_history_period"
+	UserStateWaitingForHistoryAmountRange UserState = "waiting_for_history_amount_range"
+
+	// UserStateWaitingForSplitAmount ожидание суммы очередной части разделяемой транзакции.
+	UserStateWaitingForSplitAmount UserState = "waiting_for_split_amount"
+
+	// UserStateWaitingForRefundAmount ожидание суммы и причины возврата.
+	UserStateWaitingForRefundAmount UserState = "waiting_for_refund_amount"
 )
 
 type PendingTransaction struct {
 	Amount transaction.Amount
+	Note   string
+
+	// Tags названия меток из сообщения без знака #.
+	Tags []string
+
+	// Receipt чек, по которому записывается транзакция. Пуст для транзакций, введенных вручную.
+	Receipt *receipt.Receipt
 }
 
 func (b *Bot) savePendingTransaction(
 	chatID int64,
 	amount transaction.Amount,
+	note string,
+	tags []string,
 ) {
+	b.savePending(chatID, PendingTransaction{Amount: amount, Note: note, Tags: tags})
+}
+
+func (b *Bot) savePendingReceipt(chatID int64, amount transaction.Amount, r receipt.Receipt) {
+	b.savePending(chatID, PendingTransaction{Amount: amount, Receipt: &r})
+}
+
+func (b *Bot) savePending(chatID int64, pt PendingTransaction) {
 	b.cache.Set(
 		pendingTransactionKey(chatID),
-		PendingTransaction{
-			Amount: amount,
-		},
+		pt,
 		cache.DefaultExpiration,
 	)
 
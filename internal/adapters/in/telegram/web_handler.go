@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+)
+
+// handleWebCommand выдает одноразовую ссылку для входа в веб-панель.
+func (b *Bot) handleWebCommand(ctx context.Context, update tgbotapi.Update) error {
+	chatID := update.Message.Chat.ID
+
+	if b.webBaseURL == "" {
+		return b.sendMsg(chatID, "Веб-панель не включена")
+	}
+
+	u, err := b.getOrNotifyUser(ctx, chatID)
+	if err != nil || u == nil {
+		return err
+	}
+
+	cmd, err := commands.NewIssueWebLoginCommand(u.ID(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	issued, err := b.issueWebLoginCommandHandler.Handle(ctx, cmd)
+	if err != nil {
+		if err2 := b.sendMsg(chatID, "Не удалось создать ссылку для входа. Попробуйте позже"); err2 != nil {
+			b.logger.Error("Ошибка отправки сообщения о ссылке для входа", "err", err2.Error())
+		}
+
+		return err
+	}
+
+	link, err := webLoginLink(b.webBaseURL, issued.Secret)
+	if err != nil {
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf(
+		"🌐 Ссылка для входа в веб-панель:\n%s\n\nСсылка действует %d минут и срабатывает один раз. Не пересылайте ее",
+		link,
+		int(websession.LinkTTL.Minutes()),
+	))
+	// Предпросмотр не расходует ссылку, но ни к чему показывать секрет лишним программам.
+	msg.DisableWebPagePreview = true
+
+	_, err = b.bot.Send(msg)
+
+	return err
+}
+
+// webLoginLink возвращает адрес страницы входа в панель с секретом ссылки.
+func webLoginLink(baseURL string, secret string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse web base url: %w", err)
+	}
+
+	u = u.JoinPath("login")
+	u.RawQuery = url.Values{"token": {secret}}.Encode()
+
+	return u.String(), nil
+}
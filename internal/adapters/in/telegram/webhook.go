@@ -0,0 +1,130 @@
+package telegram
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// webhookSecretHeader заголовок, в котором Telegram передает секрет, указанный при регистрации webhook.
+	webhookSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+	// maxWebhookBodySize наибольший размер тела запроса с обновлением. Обновления с файлами
+	// содержат только идентификаторы файлов и намного меньше.
+	maxWebhookBodySize = 1 << 20
+
+	// webhookQueueSize число принятых, но еще не обработанных обновлений. Когда очередь заполнена,
+	// запрос ждет, и Telegram повторяет доставку позже.
+	webhookQueueSize = 100
+)
+
+// Webhook принимает обновления Telegram по HTTP и передает их в канал Updates в порядке поступления.
+// Запросы без верного секрета в заголовке X-Telegram-Bot-Api-Secret-Token отклоняются.
+type Webhook struct {
+	logger  ports.Logger
+	secret  []byte
+	updates chan tgbotapi.Update
+
+	// mu защищает закрытие канала updates от одновременной отправки в него.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewWebhook(logger ports.Logger, secret string) (*Webhook, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if secret == "" {
+		return nil, errs.NewValueIsRequiredError("secret")
+	}
+
+	return &Webhook{
+		logger:  logger,
+		secret:  []byte(secret),
+		updates: make(chan tgbotapi.Update, webhookQueueSize),
+	}, nil
+}
+
+// Updates возвращает канал принятых обновлений.
+func (w *Webhook) Updates() tgbotapi.UpdatesChannel {
+	return w.updates
+}
+
+// Close прекращает прием обновлений и закрывает канал Updates. Запросы, которые уже передают
+// обновление в канал, завершаются раньше закрытия, последующие получают 503, и Telegram повторяет доставку.
+func (w *Webhook) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	w.closed = true
+	close(w.updates)
+}
+
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", http.MethodPost)
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(webhookSecretHeader)), w.secret) != 1 {
+		w.logger.Info("webhook request with invalid secret token", "remote_addr", r.RemoteAddr)
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+		return
+	}
+
+	var update tgbotapi.Update
+	if err := json.NewDecoder(http.MaxBytesReader(rw, r.Body, maxWebhookBodySize)).Decode(&update); err != nil {
+		w.logger.Error("failed to decode webhook update", "err", err.Error())
+		http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+		return
+	}
+
+	select {
+	case w.updates <- update:
+		rw.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+		http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	}
+}
+
+// SetWebhook регистрирует в Telegram адрес webhookURL. Telegram будет передавать secret
+// в заголовке каждого запроса с обновлениями.
+func (b *Bot) SetWebhook(webhookURL string, secret string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause("webhookURL", err)
+	}
+
+	_, err = b.bot.MakeRequest("setWebhook", tgbotapi.Params{
+		"url":          u.String(),
+		"secret_token": secret,
+	})
+
+	return err
+}
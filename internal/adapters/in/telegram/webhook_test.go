@@ -0,0 +1,117 @@
+package telegram_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/in/telegram"
+)
+
+const (
+	testWebhookSecret = "s3cr3t"
+	testUpdate        = `{"update_id":7,"message":{"message_id":1,"date":1760875200,"chat":{"id":42,"type":"private"},"text":"350 обед"}}`
+)
+
+func newWebhook(t *testing.T) *telegram.Webhook {
+	t.Helper()
+
+	w, err := telegram.NewWebhook(slog.New(slog.NewTextHandler(io.Discard, nil)), testWebhookSecret)
+	require.NoError(t, err)
+
+	return w
+}
+
+func webhookRequest(method, secret string, body io.Reader) *http.Request {
+	r := httptest.NewRequest(method, "/telegram/webhook", body)
+	if secret != "" {
+		r.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	}
+
+	return r
+}
+
+func TestWebhook_Rejects(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    *http.Request
+		wantStatus int
+	}{
+		{name: "GET", request: webhookRequest(http.MethodGet, testWebhookSecret, nil), wantStatus: http.StatusMethodNotAllowed},
+		{name: "missing secret", request: webhookRequest(http.MethodPost, "", strings.NewReader(testUpdate)), wantStatus: http.StatusUnauthorized},
+		{name: "wrong secret", request: webhookRequest(http.MethodPost, "guess", strings.NewReader(testUpdate)), wantStatus: http.StatusUnauthorized},
+		{name: "bad JSON", request: webhookRequest(http.MethodPost, testWebhookSecret, strings.NewReader("{")), wantStatus: http.StatusBadRequest},
+		{
+			name:       "too large body",
+			request:    webhookRequest(http.MethodPost, testWebhookSecret, strings.NewReader(`{"update_id":1,"x":"`+strings.Repeat("a", 1<<20)+`"}`)),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWebhook(t)
+
+			rec := httptest.NewRecorder()
+			w.ServeHTTP(rec, tt.request)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Empty(t, w.Updates())
+		})
+	}
+}
+
+func TestWebhook_AllowHeaderOnWrongMethod(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newWebhook(t).ServeHTTP(rec, webhookRequest(http.MethodGet, testWebhookSecret, nil))
+
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+}
+
+func TestWebhook_DeliversUpdate(t *testing.T) {
+	w := newWebhook(t)
+
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, webhookRequest(http.MethodPost, testWebhookSecret, strings.NewReader(testUpdate)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	select {
+	case update := <-w.Updates():
+		assert.Equal(t, 7, update.UpdateID)
+		require.NotNil(t, update.Message)
+		assert.Equal(t, int64(42), update.Message.Chat.ID)
+		assert.Equal(t, "350 обед", update.Message.Text)
+	case <-time.After(time.Second):
+		t.Fatal("update was not delivered")
+	}
+}
+
+func TestWebhook_Close(t *testing.T) {
+	w := newWebhook(t)
+
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, webhookRequest(http.MethodPost, testWebhookSecret, strings.NewReader(testUpdate)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	w.Close()
+	w.Close()
+
+	// Принятое до закрытия обновление остается в канале, после него канал закрыт.
+	_, ok := <-w.Updates()
+	assert.True(t, ok)
+
+	_, ok = <-w.Updates()
+	assert.False(t, ok)
+
+	rec = httptest.NewRecorder()
+	w.ServeHTTP(rec, webhookRequest(http.MethodPost, testWebhookSecret, bytes.NewBufferString(testUpdate)))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
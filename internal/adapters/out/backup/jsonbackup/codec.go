@@ -0,0 +1,369 @@
+// Package jsonbackup записывает резервную копию данных пользователя в JSON и читает её обратно.
+// Формат версионируется полем version, которое проверяется при восстановлении.
+package jsonbackup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// maxBackupSize наибольший размер читаемой копии, защищает от исчерпания памяти.
+const maxBackupSize = 20 << 20
+
+type document struct {
+	Version            int                `json:"version"`
+	CreatedAt          time.Time          `json:"created_at"`
+	User               *userModel         `json:"user"`
+	ExternalIdentities []identityModel    `json:"external_identities"`
+	Categories         []categoryModel    `json:"categories"`
+	Transactions       []transactionModel `json:"transactions"`
+	Tags               []tagModel         `json:"tags"`
+	Refunds            []refundModel      `json:"refunds"`
+	Settings           *settingsModel     `json:"settings"`
+}
+
+type userModel struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type identityModel struct {
+	Provider   user.Provider `json:"provider"`
+	ExternalID string        `json:"external_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type categoryModel struct {
+	ID        uuid.UUID     `json:"id"`
+	Name      string        `json:"name"`
+	Type      category.Type `json:"type"`
+	ParentID  *uuid.UUID    `json:"parent_id,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type transactionModel struct {
+	ID          uuid.UUID       `json:"id"`
+	CategoryID  uuid.UUID       `json:"category_id"`
+	Amount      decimal.Decimal `json:"amount"`
+	Note        string          `json:"note,omitempty"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Fingerprint string          `json:"fingerprint,omitempty"`
+	Receipt     *receiptModel   `json:"receipt,omitempty"`
+	Splits      []splitModel    `json:"splits,omitempty"`
+	Tags        []uuid.UUID     `json:"tags,omitempty"`
+}
+
+type splitModel struct {
+	CategoryID uuid.UUID       `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+}
+
+type tagModel struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type refundModel struct {
+	ID            uuid.UUID       `json:"id"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	CategoryID    uuid.UUID       `json:"category_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Note          string          `json:"note,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type receiptModel struct {
+	FiscalDrive    string `json:"fn"`
+	FiscalDocument string `json:"i"`
+	FiscalSign     string `json:"fp"`
+}
+
+type settingsModel struct {
+	Timezone           string              `json:"timezone"`
+	DigestMode         settings.DigestMode `json:"digest_mode"`
+	DigestHour         int                 `json:"digest_hour"`
+	ReminderAfterHours int                 `json:"reminder_after_hours"`
+	QuietFrom          int                 `json:"quiet_from"`
+	QuietTo            int                 `json:"quiet_to"`
+}
+
+var _ ports.BackupCodec = Codec{}
+
+type Codec struct{}
+
+func NewCodec() Codec {
+	return Codec{}
+}
+
+func (Codec) Encode(w io.Writer, snapshot *backup.Snapshot) error {
+	if w == nil {
+		return errs.NewValueIsRequiredError("w")
+	}
+
+	if snapshot == nil {
+		return errs.NewValueIsRequiredError("snapshot")
+	}
+
+	u := snapshot.User()
+	s := snapshot.Settings()
+
+	doc := document{
+		Version:   snapshot.Version(),
+		CreatedAt: snapshot.CreatedAt().UTC(),
+		User: &userModel{
+			ID:        u.ID().Value(),
+			Name:      u.Name(),
+			CreatedAt: u.CreatedAt().UTC(),
+		},
+		ExternalIdentities: make([]identityModel, 0, len(snapshot.ExternalIdentities())),
+		Categories:         make([]categoryModel, 0, len(snapshot.Categories())),
+		Transactions:       make([]transactionModel, 0, len(snapshot.Transactions())),
+		Tags:               make([]tagModel, 0, len(snapshot.Tags())),
+		Refunds:            make([]refundModel, 0, len(snapshot.Refunds())),
+		Settings: &settingsModel{
+			Timezone:           s.Timezone(),
+			DigestMode:         s.DigestMode(),
+			DigestHour:         s.DigestHour(),
+			ReminderAfterHours: s.ReminderAfterHours(),
+			QuietFrom:          s.QuietFrom(),
+			QuietTo:            s.QuietTo(),
+		},
+	}
+
+	for _, ei := range snapshot.ExternalIdentities() {
+		doc.ExternalIdentities = append(doc.ExternalIdentities, identityModel{
+			Provider:   ei.Provider(),
+			ExternalID: ei.ExternalID(),
+			CreatedAt:  ei.GetCreatedAt().UTC(),
+		})
+	}
+
+	for _, c := range snapshot.Categories() {
+		m := categoryModel{
+			ID:        c.ID().Value(),
+			Name:      c.Name(),
+			Type:      c.Type(),
+			CreatedAt: c.CreatedAt().UTC(),
+		}
+
+		if !c.ParentID().IsZero() {
+			parentID := c.ParentID().Value()
+			m.ParentID = &parentID
+		}
+
+		doc.Categories = append(doc.Categories, m)
+	}
+
+	transactionTags := make(map[shared.ID][]uuid.UUID)
+	for _, l := range snapshot.TagLinks() {
+		transactionTags[l.TransactionID()] = append(transactionTags[l.TransactionID()], l.TagID().Value())
+	}
+
+	for _, t := range snapshot.Transactions() {
+		m := transactionModel{
+			ID:          t.ID().Value(),
+			CategoryID:  t.CategoryID().Value(),
+			Amount:      t.Amount().Value(),
+			Note:        t.Note(),
+			OccurredAt:  t.OccurredAt().UTC(),
+			CreatedAt:   t.CreatedAt().UTC(),
+			Fingerprint: t.Fingerprint(),
+			Tags:        transactionTags[t.ID()],
+		}
+
+		if f := t.FiscalID(); !f.IsZero() {
+			m.Receipt = &receiptModel{FiscalDrive: f.Drive(), FiscalDocument: f.Document(), FiscalSign: f.Sign()}
+		}
+
+		for _, part := range t.Splits() {
+			m.Splits = append(m.Splits, splitModel{CategoryID: part.CategoryID().Value(), Amount: part.Amount().Value()})
+		}
+
+		doc.Transactions = append(doc.Transactions, m)
+	}
+
+	for _, tg := range snapshot.Tags() {
+		doc.Tags = append(doc.Tags, tagModel{ID: tg.ID().Value(), Name: tg.Name(), CreatedAt: tg.CreatedAt().UTC()})
+	}
+
+	for _, r := range snapshot.Refunds() {
+		doc.Refunds = append(doc.Refunds, refundModel{
+			ID:            r.ID().Value(),
+			TransactionID: r.TransactionID().Value(),
+			CategoryID:    r.CategoryID().Value(),
+			Amount:        r.Amount().Value(),
+			Note:          r.Note(),
+			OccurredAt:    r.OccurredAt().UTC(),
+			CreatedAt:     r.CreatedAt().UTC(),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode backup: %w", err)
+	}
+
+	return nil
+}
+
+func (Codec) Decode(r io.Reader) (*backup.Snapshot, error) {
+	if r == nil {
+		return nil, errs.NewValueIsRequiredError("r")
+	}
+
+	var doc document
+
+	dec := json.NewDecoder(io.LimitReader(r, maxBackupSize))
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", backup.ErrInvalidBackup, err)
+	}
+
+	// Версия проверяется раньше остальных полей: копия более новой версии
+	// может не соответствовать этой схеме.
+	if doc.Version < backup.MinSchemaVersion || doc.Version > backup.SchemaVersion {
+		return backup.Restore(doc.Version, doc.CreatedAt, nil, nil, nil, nil, nil, nil, nil, nil), nil
+	}
+
+	if doc.User == nil {
+		return nil, fmt.Errorf("%w: user is missing", backup.ErrInvalidBackup)
+	}
+
+	if doc.Settings == nil {
+		return nil, fmt.Errorf("%w: settings are missing", backup.ErrInvalidBackup)
+	}
+
+	userID := shared.RestoreID(doc.User.ID)
+	u := user.Restore(userID, doc.User.Name, doc.User.CreatedAt)
+
+	identities := make([]*user.ExternalIdentity, 0, len(doc.ExternalIdentities))
+	for _, m := range doc.ExternalIdentities {
+		identities = append(identities, user.RestoreExternalIdentity(shared.NewID(), userID, m.Provider, m.ExternalID, m.CreatedAt))
+	}
+
+	categories := make([]*category.Category, 0, len(doc.Categories))
+	for _, m := range doc.Categories {
+		var parentID *shared.ID
+		if m.ParentID != nil {
+			id := shared.RestoreID(*m.ParentID)
+			parentID = &id
+		}
+
+		categories = append(categories, category.Restore(shared.RestoreID(m.ID), m.Name, userID, parentID, m.Type, m.CreatedAt))
+	}
+
+	transactions := make([]*transaction.Transaction, 0, len(doc.Transactions))
+	var links []tag.Link
+	for _, m := range doc.Transactions {
+		amount, err := transaction.NewAmount(m.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transaction %s: %w", backup.ErrInvalidBackup, m.ID, err)
+		}
+
+		var fiscalID receipt.FiscalID
+		if m.Receipt != nil {
+			fiscalID, err = receipt.NewFiscalID(m.Receipt.FiscalDrive, m.Receipt.FiscalDocument, m.Receipt.FiscalSign)
+			if err != nil {
+				return nil, fmt.Errorf("%w: transaction %s: %w", backup.ErrInvalidBackup, m.ID, err)
+			}
+		}
+
+		splits := make([]transaction.Split, 0, len(m.Splits))
+		for _, sm := range m.Splits {
+			partAmount, err := transaction.NewAmount(sm.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("%w: transaction %s: %w", backup.ErrInvalidBackup, m.ID, err)
+			}
+
+			part, err := transaction.NewSplit(shared.RestoreID(sm.CategoryID), partAmount)
+			if err != nil {
+				return nil, fmt.Errorf("%w: transaction %s: %w", backup.ErrInvalidBackup, m.ID, err)
+			}
+
+			splits = append(splits, part)
+		}
+
+		for _, tagID := range m.Tags {
+			link, err := tag.NewLink(shared.RestoreID(m.ID), shared.RestoreID(tagID))
+			if err != nil {
+				return nil, fmt.Errorf("%w: transaction %s: %w", backup.ErrInvalidBackup, m.ID, err)
+			}
+
+			links = append(links, link)
+		}
+
+		transactions = append(transactions, transaction.Restore(
+			shared.RestoreID(m.ID),
+			userID,
+			amount,
+			shared.RestoreID(m.CategoryID),
+			m.Note,
+			m.OccurredAt,
+			m.Fingerprint,
+			fiscalID,
+			m.CreatedAt,
+			splits...,
+		))
+	}
+
+	tags := make([]*tag.Tag, 0, len(doc.Tags))
+	for _, m := range doc.Tags {
+		tags = append(tags, tag.Restore(shared.RestoreID(m.ID), userID, m.Name, m.CreatedAt))
+	}
+
+	refunds := make([]*refund.Refund, 0, len(doc.Refunds))
+	for _, m := range doc.Refunds {
+		amount, err := transaction.NewAmount(m.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%w: refund %s: %w", backup.ErrInvalidBackup, m.ID, err)
+		}
+
+		refunds = append(refunds, refund.Restore(
+			shared.RestoreID(m.ID),
+			userID,
+			shared.RestoreID(m.TransactionID),
+			shared.RestoreID(m.CategoryID),
+			amount,
+			m.Note,
+			m.OccurredAt,
+			m.CreatedAt,
+		))
+	}
+
+	s := settings.Restore(
+		userID,
+		doc.Settings.Timezone,
+		doc.Settings.DigestMode,
+		doc.Settings.DigestHour,
+		time.Time{},
+		doc.Settings.ReminderAfterHours,
+		doc.Settings.QuietFrom,
+		doc.Settings.QuietTo,
+		time.Time{},
+		doc.CreatedAt,
+	)
+
+	return backup.Restore(doc.Version, doc.CreatedAt, u, identities, categories, transactions, tags, links, refunds, s), nil
+}
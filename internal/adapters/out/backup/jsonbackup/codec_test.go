@@ -0,0 +1,189 @@
+package jsonbackup_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/backup/jsonbackup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+func newAmount(t *testing.T, value string) transaction.Amount {
+	t.Helper()
+
+	amount, err := transaction.NewAmountFromString(value)
+	require.NoError(t, err)
+
+	return amount
+}
+
+func newSnapshot(t *testing.T) *backup.Snapshot {
+	t.Helper()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := user.Restore(shared.NewID(), "Иван", createdAt)
+	identity := user.RestoreExternalIdentity(shared.NewID(), u.ID(), user.ProviderTelegram, "42", createdAt)
+
+	food := category.Restore(shared.NewID(), "Еда", u.ID(), nil, category.TypeExpense, createdAt)
+	foodID := food.ID()
+	cafe := category.Restore(shared.NewID(), "Кафе", u.ID(), &foodID, category.TypeExpense, createdAt)
+
+	fiscalID, err := receipt.NewFiscalID("9999078900004312", "3522", "3961931294")
+	require.NoError(t, err)
+
+	occurredAt := time.Date(2026, 2, 3, 12, 30, 0, 0, time.UTC)
+	lunch := transaction.Restore(shared.NewID(), u.ID(), newAmount(t, "350.50"), cafe.ID(), "обед", occurredAt, "fp", fiscalID, occurredAt)
+
+	split, err := transaction.NewSplit(food.ID(), newAmount(t, "50"))
+	require.NoError(t, err)
+
+	rest, err := transaction.NewSplit(cafe.ID(), newAmount(t, "300.50"))
+	require.NoError(t, err)
+
+	require.NoError(t, lunch.SplitInto([]transaction.Split{rest, split}))
+
+	vacation := tag.Restore(shared.NewID(), u.ID(), "отпуск2026", createdAt)
+	family := tag.Restore(shared.NewID(), u.ID(), "семья", createdAt)
+
+	links := make([]tag.Link, 0, 2)
+	for _, tg := range []*tag.Tag{vacation, family} {
+		link, err := tag.NewLink(lunch.ID(), tg.ID())
+		require.NoError(t, err)
+
+		links = append(links, link)
+	}
+
+	returned := refund.Restore(shared.NewID(), u.ID(), lunch.ID(), food.ID(), newAmount(t, "20.25"), "остыл", occurredAt, occurredAt)
+
+	s, err := settings.New(u.ID())
+	require.NoError(t, err)
+	require.NoError(t, s.SetTimezone("Asia/Novosibirsk"))
+	require.NoError(t, s.SetDigestMode(settings.DigestModeWeekly))
+
+	snapshot, err := backup.NewSnapshot(
+		u,
+		[]*user.ExternalIdentity{identity},
+		[]*category.Category{food, cafe},
+		[]*transaction.Transaction{lunch},
+		[]*tag.Tag{vacation, family},
+		links,
+		[]*refund.Refund{returned},
+		s,
+	)
+	require.NoError(t, err)
+
+	return snapshot
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	codec := jsonbackup.NewCodec()
+	original := newSnapshot(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, codec.Encode(&buf, original))
+
+	decoded, err := codec.Decode(&buf)
+	require.NoError(t, err)
+	require.NoError(t, decoded.Validate())
+
+	assert.Equal(t, backup.SchemaVersion, decoded.Version())
+	assert.Equal(t, original.User().ID(), decoded.User().ID())
+	assert.Equal(t, original.User().Name(), decoded.User().Name())
+
+	require.Len(t, decoded.ExternalIdentities(), 1)
+	assert.Equal(t, "42", decoded.ExternalIdentities()[0].ExternalID())
+
+	require.Len(t, decoded.Categories(), 2)
+	for i, c := range original.Categories() {
+		assert.Equal(t, c.ID(), decoded.Categories()[i].ID())
+		assert.Equal(t, c.Name(), decoded.Categories()[i].Name())
+		assert.Equal(t, c.Type(), decoded.Categories()[i].Type())
+		assert.Equal(t, c.ParentID(), decoded.Categories()[i].ParentID())
+	}
+
+	require.Len(t, decoded.Transactions(), 1)
+	want, got := original.Transactions()[0], decoded.Transactions()[0]
+	assert.Equal(t, want.ID(), got.ID())
+	assert.True(t, want.Amount().Value().Equal(got.Amount().Value()))
+	assert.Equal(t, want.CategoryID(), got.CategoryID())
+	assert.Equal(t, want.Note(), got.Note())
+	assert.True(t, want.OccurredAt().Equal(got.OccurredAt()))
+	assert.Equal(t, want.Fingerprint(), got.Fingerprint())
+	assert.Equal(t, want.FiscalID(), got.FiscalID())
+
+	require.Len(t, got.Splits(), 2)
+	for i, part := range want.Splits() {
+		assert.Equal(t, part.CategoryID(), got.Splits()[i].CategoryID())
+		assert.True(t, part.Amount().Value().Equal(got.Splits()[i].Amount().Value()))
+	}
+
+	require.Len(t, decoded.Tags(), 2)
+	for i, tg := range original.Tags() {
+		assert.Equal(t, tg.ID(), decoded.Tags()[i].ID())
+		assert.Equal(t, tg.Name(), decoded.Tags()[i].Name())
+	}
+
+	assert.Equal(t, original.TagLinks(), decoded.TagLinks())
+
+	require.Len(t, decoded.Refunds(), 1)
+	wantRefund, gotRefund := original.Refunds()[0], decoded.Refunds()[0]
+	assert.Equal(t, wantRefund.ID(), gotRefund.ID())
+	assert.Equal(t, wantRefund.UserID(), gotRefund.UserID())
+	assert.Equal(t, wantRefund.TransactionID(), gotRefund.TransactionID())
+	assert.Equal(t, wantRefund.CategoryID(), gotRefund.CategoryID())
+	assert.True(t, wantRefund.Amount().Value().Equal(gotRefund.Amount().Value()))
+	assert.Equal(t, wantRefund.Note(), gotRefund.Note())
+	assert.True(t, wantRefund.OccurredAt().Equal(gotRefund.OccurredAt()))
+
+	assert.Equal(t, original.Settings().Timezone(), decoded.Settings().Timezone())
+	assert.Equal(t, original.Settings().DigestMode(), decoded.Settings().DigestMode())
+	assert.Equal(t, original.Settings().DigestHour(), decoded.Settings().DigestHour())
+}
+
+func TestCodec_Decode_PreviousVersion(t *testing.T) {
+	doc := `{
+		"version": 1,
+		"created_at": "2026-01-01T00:00:00Z",
+		"user": {"id": "0196a4c8-8f5e-7b6a-9d2e-3c4b5a697887", "name": "Иван", "created_at": "2026-01-01T00:00:00Z"},
+		"external_identities": [],
+		"categories": [],
+		"transactions": [],
+		"settings": {"timezone": "Europe/Moscow", "digest_mode": "off", "digest_hour": 9}
+	}`
+
+	decoded, err := jsonbackup.NewCodec().Decode(strings.NewReader(doc))
+	require.NoError(t, err)
+	require.NoError(t, decoded.Validate())
+
+	assert.Equal(t, 1, decoded.Version())
+	assert.Empty(t, decoded.Tags())
+	assert.Empty(t, decoded.TagLinks())
+	assert.Empty(t, decoded.Refunds())
+}
+
+func TestCodec_Decode_UnsupportedVersion(t *testing.T) {
+	doc := `{"version": 999, "created_at": "2026-01-01T00:00:00Z", "accounts": []}`
+
+	decoded, err := jsonbackup.NewCodec().Decode(strings.NewReader(doc))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, decoded.Validate(), backup.ErrUnsupportedVersion)
+}
+
+func TestCodec_Decode_Invalid(t *testing.T) {
+	_, err := jsonbackup.NewCodec().Decode(strings.NewReader("{"))
+	require.ErrorIs(t, err, backup.ErrInvalidBackup)
+}
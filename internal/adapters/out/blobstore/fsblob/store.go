@@ -0,0 +1,118 @@
+// Package fsblob хранит копии файлов в каталоге локальной файловой системы.
+// Ключ файла — относительный путь внутри каталога.
+package fsblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	dirPerm  = 0o750
+	filePerm = 0o640
+)
+
+var _ ports.BlobStore = Store{}
+
+type Store struct {
+	root string
+}
+
+func NewStore(root string) (Store, error) {
+	if root == "" {
+		return Store{}, errs.NewValueIsRequiredError("root")
+	}
+
+	if err := os.MkdirAll(root, dirPerm); err != nil {
+		return Store{}, fmt.Errorf("create blob store root: %w", err)
+	}
+
+	return Store{root: root}, nil
+}
+
+// Put записывает файл во временный файл рядом и переименовывает его, чтобы при сбое
+// под ключом не осталось недописанного содержимого.
+func (s Store) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return fmt.Errorf("blob store put: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("blob store put: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := io.Copy(tmp, readerWithContext{ctx: ctx, r: r}); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("blob store put: %w", err)
+	}
+
+	if err := tmp.Chmod(filePerm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("blob store put: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("blob store put: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("blob store put: %w", err)
+	}
+
+	return nil
+}
+
+func (s Store) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("blob store delete: %w", err)
+	}
+
+	return nil
+}
+
+// path переводит ключ в путь внутри корневого каталога и не дает выйти за его пределы.
+func (s Store) path(key string) (string, error) {
+	key = filepath.FromSlash(key)
+	if !filepath.IsLocal(key) {
+		return "", errs.NewValueIsInvalidError("key " + key)
+	}
+
+	return filepath.Join(s.root, key), nil
+}
+
+// readerWithContext прерывает чтение, когда контекст отменен.
+type readerWithContext struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r readerWithContext) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.r.Read(p)
+}
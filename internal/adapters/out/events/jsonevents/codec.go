@@ -0,0 +1,75 @@
+// Package jsonevents сериализует доменные события в JSON для хранения в outbox.
+// Восстановить можно только события, зарегистрированные при создании кодека.
+package jsonevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var ErrUnknownEvent = errors.New("unknown event")
+
+var _ ports.EventCodec = &Codec{}
+
+type Codec struct {
+	types map[string]reflect.Type
+}
+
+// NewCodec создает кодек для событий, переданных образцами, например user.UserRegistered{}.
+// Имена событий должны быть уникальными.
+func NewCodec(events ...ddd.DomainEvent) (*Codec, error) {
+	types := make(map[string]reflect.Type, len(events))
+
+	for _, event := range events {
+		if event == nil {
+			return nil, errs.NewValueIsRequiredError("event")
+		}
+
+		name := event.GetName()
+		if _, ok := types[name]; ok {
+			return nil, errs.NewValueIsInvalidError("event " + name)
+		}
+
+		types[name] = reflect.TypeOf(event)
+	}
+
+	return &Codec{types: types}, nil
+}
+
+func (c *Codec) Encode(event ddd.DomainEvent) ([]byte, error) {
+	if _, ok := c.types[event.GetName()]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, event.GetName())
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("encode event %s: %w", event.GetName(), err)
+	}
+
+	return payload, nil
+}
+
+func (c *Codec) Decode(name string, payload []byte) (ddd.DomainEvent, error) {
+	t, ok := c.types[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, name)
+	}
+
+	value := reflect.New(t)
+	if err := json.Unmarshal(payload, value.Interface()); err != nil {
+		return nil, fmt.Errorf("decode event %s: %w", name, err)
+	}
+
+	event, ok := value.Elem().Interface().(ddd.DomainEvent)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, name)
+	}
+
+	return event, nil
+}
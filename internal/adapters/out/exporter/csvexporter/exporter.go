@@ -0,0 +1,112 @@
+// Package csvexporter выгружает транзакции в CSV, который без дополнительной настройки открывается в Excel.
+package csvexporter
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// utf8BOM позволяет Excel распознать кодировку файла.
+	utf8BOM = "\ufeff"
+
+	// delimiter разделитель полей, который ожидает Excel с русскими региональными настройками.
+	delimiter = ';'
+
+	dateLayout = "02.01.2006 15:04"
+)
+
+var header = []string{
+	"Дата",
+	"Сумма",
+	"Валюта",
+	"Тип",
+	"Родительская категория",
+	"Категория",
+	"Комментарий",
+	"Счет",
+}
+
+var typeTitles = map[category.Type]string{
+	category.TypeExpense: "Расход",
+	category.TypeIncome:  "Доход",
+}
+
+var _ ports.Exporter = Exporter{}
+
+type Exporter struct{}
+
+func NewExporter() Exporter {
+	return Exporter{}
+}
+
+func (e Exporter) Format() report.ExportFormat {
+	return report.ExportFormatCsv
+}
+
+func (e Exporter) NewWriter(w io.Writer, loc *time.Location) (ports.ExportWriter, error) {
+	if w == nil {
+		return nil, errs.NewValueIsRequiredError("w")
+	}
+
+	if loc == nil {
+		return nil, errs.NewValueIsRequiredError("loc")
+	}
+
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+	cw.UseCRLF = true
+
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &writer{csv: cw, loc: loc}, nil
+}
+
+type writer struct {
+	csv *csv.Writer
+	loc *time.Location
+}
+
+func (w *writer) Write(line report.TransactionLine) error {
+	parentName, categoryName := line.ParentName(), line.CategoryName()
+
+	return w.csv.Write([]string{
+		line.OccurredAt().In(w.loc).Format(dateLayout),
+		strings.Replace(line.Amount().StringFixed(2), ".", ",", 1),
+		report.CurrencyCode,
+		typeTitles[line.CategoryType()],
+		escapeFormula(parentName),
+		escapeFormula(categoryName),
+		escapeFormula(line.Note()),
+		// Счета пока не поддерживаются, колонка оставлена для совместимости формата.
+		"",
+	})
+}
+
+func (w *writer) Close() error {
+	w.csv.Flush()
+
+	return w.csv.Error()
+}
+
+// escapeFormula не дает табличному редактору выполнить текст, начинающийся с символа формулы.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
@@ -0,0 +1,126 @@
+package csvexporter_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/csvexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+var wantHeader = []string{"Дата", "Сумма", "Валюта", "Тип", "Родительская категория", "Категория", "Комментарий", "Счет"}
+
+// export выгружает строки и возвращает файл целиком.
+func export(t *testing.T, loc *time.Location, lines ...report.TransactionLine) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := csvexporter.NewExporter().NewWriter(&buf, loc)
+	require.NoError(t, err)
+
+	for _, line := range lines {
+		require.NoError(t, w.Write(line))
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+// readBack разбирает выгрузку так же, как табличный редактор: без BOM и с разделителем «;».
+func readBack(t *testing.T, data []byte) [][]string {
+	t.Helper()
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.Comma = ';'
+
+	records, err := r.ReadAll()
+	require.NoError(t, err)
+
+	return records
+}
+
+func line(occurredAt time.Time, amount string, categoryType category.Type, categoryName, parentName, note string) report.TransactionLine {
+	return report.NewTransactionLine(shared.NewID(), occurredAt, decimal.RequireFromString(amount), categoryType, categoryName, parentName, note)
+}
+
+func TestExporter_NewWriter_RequiresArguments(t *testing.T) {
+	_, err := csvexporter.NewExporter().NewWriter(nil, time.UTC)
+	require.Error(t, err)
+
+	_, err = csvexporter.NewExporter().NewWriter(&bytes.Buffer{}, nil)
+	require.Error(t, err)
+}
+
+func TestExporter_EmptyExportHasBOMAndHeader(t *testing.T) {
+	data := export(t, time.UTC)
+
+	assert.True(t, bytes.HasPrefix(data, []byte("\xef\xbb\xbf")), "export must start with UTF-8 BOM")
+	assert.Equal(t, "\ufeff"+strings.Join(wantHeader, ";")+"\r\n", string(data))
+	assert.Equal(t, [][]string{wantHeader}, readBack(t, data))
+}
+
+func TestExporter_Write(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	data := export(t, moscow,
+		line(time.Date(2026, 3, 15, 11, 30, 0, 0, time.UTC), "350.5", category.TypeExpense, "Кафе", "Еда", "Обед; с коллегами"),
+		line(time.Date(2026, 3, 31, 22, 15, 0, 0, time.UTC), "50000", category.TypeIncome, "Зарплата", "", "аванс \"март\""),
+	)
+
+	assert.Equal(t, [][]string{
+		wantHeader,
+		{"15.03.2026 14:30", "350,50", report.CurrencyCode, "Расход", "Еда", "Кафе", "Обед; с коллегами", ""},
+		// Время переведено в часовой пояс пользователя, поэтому дата сменилась.
+		{"01.04.2026 01:15", "50000,00", report.CurrencyCode, "Доход", "", "Зарплата", "аванс \"март\"", ""},
+	}, readBack(t, data))
+}
+
+func TestExporter_Write_EscapesFormulas(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{text: "+79001234567", want: "'+79001234567"},
+		{text: "-2+3", want: "'-2+3"},
+		{text: "@SUM(A1:A2)", want: "'@SUM(A1:A2)"},
+		{text: "\tcmd", want: "'\tcmd"},
+		// Writer с UseCRLF отбрасывает одиночный \r, но экранирование остается в начале поля.
+		{text: "\rcmd", want: "'cmd"},
+		{text: "обед = 300", want: "обед = 300"},
+		{text: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			data := export(t, time.UTC, line(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC), "100", category.TypeExpense, tt.text, tt.text, tt.text))
+
+			records := readBack(t, data)
+			require.Len(t, records, 2)
+
+			row := records[1]
+			assert.Equal(t, tt.want, row[4], "parent category")
+			assert.Equal(t, tt.want, row[5], "category")
+			assert.Equal(t, tt.want, row[6], "note")
+		})
+	}
+}
+
+func TestExporter_Write_NegativeAmountIsNotEscaped(t *testing.T) {
+	data := export(t, time.UTC, line(time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC), "-20.25", category.TypeExpense, "Кафе", "Еда", "Возврат"))
+
+	records := readBack(t, data)
+	require.Len(t, records, 2)
+	assert.Equal(t, "-20,25", records[1][1])
+}
@@ -0,0 +1,215 @@
+// Package ofxexporter выгружает транзакции в OFX 2 (Open Financial Exchange)
+// для загрузки в GnuCash, HomeBank и другие финансовые программы.
+package ofxexporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// bankID и accountID условно обозначают единственный счет, пока в учете нет отдельных счетов.
+	bankID    = "COINTAMER"
+	accountID = "main"
+
+	// maxNameLength наибольшая длина поля NAME по спецификации OFX.
+	maxNameLength = 32
+
+	dateLayout = "20060102150405"
+)
+
+const header = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+var _ ports.Exporter = Exporter{}
+
+type Exporter struct{}
+
+func NewExporter() Exporter {
+	return Exporter{}
+}
+
+func (e Exporter) Format() report.ExportFormat {
+	return report.ExportFormatOfx
+}
+
+func (e Exporter) NewWriter(w io.Writer, loc *time.Location) (ports.ExportWriter, error) {
+	if w == nil {
+		return nil, errs.NewValueIsRequiredError("w")
+	}
+
+	if loc == nil {
+		return nil, errs.NewValueIsRequiredError("loc")
+	}
+
+	return &writer{out: w, loc: loc}, nil
+}
+
+// writer накапливает операции в памяти: границы периода выписки записываются перед операциями,
+// а известны только после чтения последней из них.
+type writer struct {
+	out     io.Writer
+	loc     *time.Location
+	trns    bytes.Buffer
+	start   time.Time
+	end     time.Time
+	balance decimal.Decimal
+
+	// lastID и part нумеруют части разделенной транзакции, которые приходят подряд с общим
+	// идентификатором: FITID должен быть уникален в пределах выписки.
+	lastID string
+	part   int
+}
+
+// Write добавляет операцию. Комментарий становится получателем (NAME),
+// путь категории «Родитель:Категория» записывается в MEMO.
+func (w *writer) Write(line report.TransactionLine) error {
+	occurredAt := line.OccurredAt().In(w.loc)
+	if w.start.IsZero() || occurredAt.Before(w.start) {
+		w.start = occurredAt
+	}
+
+	if occurredAt.After(w.end) {
+		w.end = occurredAt
+	}
+
+	amount := line.Amount()
+	if line.CategoryType() == category.TypeExpense {
+		amount = amount.Neg()
+	}
+
+	// Возврат по расходу приходит строкой расхода с отрицательной суммой и зачисляется на счет.
+	trnType := "CREDIT"
+	if amount.IsNegative() {
+		trnType = "DEBIT"
+	}
+
+	w.balance = w.balance.Add(amount)
+
+	name := line.Note()
+	if name == "" {
+		name = line.CategoryName()
+	}
+
+	fitID := line.ID().String()
+	if fitID == w.lastID {
+		w.part++
+		fitID += "-" + strconv.Itoa(w.part)
+	} else {
+		w.lastID, w.part = fitID, 0
+	}
+
+	b := &w.trns
+	b.WriteString("<STMTTRN>\n")
+	writeElement(b, "TRNTYPE", trnType)
+	writeElement(b, "DTPOSTED", formatDate(occurredAt))
+	writeElement(b, "TRNAMT", amount.StringFixed(2))
+	writeElement(b, "FITID", fitID)
+	writeElement(b, "NAME", truncate(name, maxNameLength))
+	writeElement(b, "MEMO", categoryPath(line))
+	b.WriteString("</STMTTRN>\n")
+
+	return nil
+}
+
+func (w *writer) Close() error {
+	now := time.Now().In(w.loc)
+	if w.start.IsZero() {
+		w.start, w.end = now, now
+	}
+
+	var b bytes.Buffer
+
+	b.WriteString(header)
+	b.WriteString("<OFX>\n<SIGNONMSGSRSV1>\n<SONRS>\n")
+	writeStatus(&b)
+	writeElement(&b, "DTSERVER", formatDate(now))
+	writeElement(&b, "LANGUAGE", "RUS")
+	b.WriteString("</SONRS>\n</SIGNONMSGSRSV1>\n")
+	b.WriteString("<BANKMSGSRSV1>\n<STMTTRNRS>\n")
+	writeElement(&b, "TRNUID", "0")
+	writeStatus(&b)
+	b.WriteString("<STMTRS>\n")
+	writeElement(&b, "CURDEF", report.CurrencyCode)
+	b.WriteString("<BANKACCTFROM>\n")
+	writeElement(&b, "BANKID", bankID)
+	writeElement(&b, "ACCTID", accountID)
+	writeElement(&b, "ACCTTYPE", "CHECKING")
+	b.WriteString("</BANKACCTFROM>\n<BANKTRANLIST>\n")
+	writeElement(&b, "DTSTART", formatDate(w.start))
+	writeElement(&b, "DTEND", formatDate(w.end))
+
+	if _, err := w.out.Write(b.Bytes()); err != nil {
+		return err
+	}
+
+	if _, err := w.trns.WriteTo(w.out); err != nil {
+		return err
+	}
+
+	b.Reset()
+	b.WriteString("</BANKTRANLIST>\n<LEDGERBAL>\n")
+	writeElement(&b, "BALAMT", w.balance.StringFixed(2))
+	writeElement(&b, "DTASOF", formatDate(w.end))
+	b.WriteString("</LEDGERBAL>\n</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n")
+
+	_, err := w.out.Write(b.Bytes())
+
+	return err
+}
+
+func writeStatus(b *bytes.Buffer) {
+	b.WriteString("<STATUS>\n")
+	writeElement(b, "CODE", "0")
+	writeElement(b, "SEVERITY", "INFO")
+	b.WriteString("</STATUS>\n")
+}
+
+func writeElement(b *bytes.Buffer, name, value string) {
+	b.WriteString("<" + name + ">")
+	// Запись в bytes.Buffer не возвращает ошибок.
+	_ = xml.EscapeText(b, []byte(value))
+	b.WriteString("</" + name + ">\n")
+}
+
+// formatDate форматирует время в виде OFX с указанием смещения часового пояса: 20260315143000.000[+3:MSK].
+func formatDate(t time.Time) string {
+	name, offset := t.Zone()
+	hours := strconv.FormatFloat(float64(offset)/3600, 'f', -1, 64)
+	if offset >= 0 {
+		hours = "+" + hours
+	}
+
+	return fmt.Sprintf("%s.000[%s:%s]", t.Format(dateLayout), hours, name)
+}
+
+func categoryPath(line report.TransactionLine) string {
+	name := strings.ReplaceAll(line.CategoryName(), ":", "-")
+	if line.ParentName() == "" {
+		return name
+	}
+
+	return strings.ReplaceAll(line.ParentName(), ":", "-") + ":" + name
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n])
+}
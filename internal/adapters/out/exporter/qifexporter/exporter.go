@@ -0,0 +1,104 @@
+// Package qifexporter выгружает транзакции в QIF (Quicken Interchange Format)
+// для загрузки в GnuCash, HomeBank и другие финансовые программы.
+package qifexporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// defaultAccount единственный счет, пока в учете нет отдельных счетов.
+	defaultAccount = "Основной"
+
+	// dateLayout американский порядок даты, который QIF-программы ожидают по умолчанию.
+	dateLayout = "01/02/2006"
+)
+
+// nameReplacer убирает из названий категорий разделители QIF: «:» отделяет подкатегорию, «/» — класс.
+var nameReplacer = strings.NewReplacer(":", "-", "/", "-")
+
+var _ ports.Exporter = Exporter{}
+
+type Exporter struct{}
+
+func NewExporter() Exporter {
+	return Exporter{}
+}
+
+func (e Exporter) Format() report.ExportFormat {
+	return report.ExportFormatQif
+}
+
+func (e Exporter) NewWriter(w io.Writer, loc *time.Location) (ports.ExportWriter, error) {
+	if w == nil {
+		return nil, errs.NewValueIsRequiredError("w")
+	}
+
+	if loc == nil {
+		return nil, errs.NewValueIsRequiredError("loc")
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if _, err := fmt.Fprintf(bw, "!Account\nN%s\nTBank\n^\n!Type:Bank\n", defaultAccount); err != nil {
+		return nil, err
+	}
+
+	return &writer{out: bw, loc: loc}, nil
+}
+
+type writer struct {
+	out *bufio.Writer
+	loc *time.Location
+}
+
+// Write записывает транзакцию: комментарий становится получателем (P),
+// категория записывается с родительской через двоеточие (L).
+func (w *writer) Write(line report.TransactionLine) error {
+	amount := line.Amount()
+	if line.CategoryType() == category.TypeExpense {
+		amount = amount.Neg()
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "D%s\n", line.OccurredAt().In(w.loc).Format(dateLayout))
+	fmt.Fprintf(&b, "T%s\n", amount.StringFixed(2))
+
+	if note := singleLine(line.Note()); note != "" {
+		fmt.Fprintf(&b, "P%s\n", note)
+	}
+
+	fmt.Fprintf(&b, "L%s\n^\n", categoryPath(line))
+
+	_, err := w.out.WriteString(b.String())
+
+	return err
+}
+
+func (w *writer) Close() error {
+	return w.out.Flush()
+}
+
+func categoryPath(line report.TransactionLine) string {
+	name := nameReplacer.Replace(line.CategoryName())
+	if line.ParentName() == "" {
+		return name
+	}
+
+	return nameReplacer.Replace(line.ParentName()) + ":" + name
+}
+
+// singleLine заменяет переводы строк пробелами: в QIF каждое поле занимает одну строку.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
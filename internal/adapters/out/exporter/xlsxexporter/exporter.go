@@ -0,0 +1,328 @@
+// Package xlsxexporter выгружает транзакции в книгу Excel: лист с транзакциями,
+// готовый для сводных таблиц, помесячную сводку по категориям и сводку по счетам.
+package xlsxexporter
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	transactionsSheet = "Транзакции"
+	summarySheet      = "Сводка по месяцам"
+	accountsSheet     = "Счета"
+
+	// defaultAccount единственный счет, пока в учете нет отдельных счетов.
+	defaultAccount = "Основной"
+
+	amountFormat = "#,##0.00"
+	dateFormat   = "dd.mm.yyyy hh:mm"
+	monthLayout  = "2006-01"
+)
+
+var transactionsHeader = []any{
+	"Дата",
+	"Месяц",
+	"Сумма",
+	"Валюта",
+	"Тип",
+	"Родительская категория",
+	"Категория",
+	"Комментарий",
+	"Счет",
+}
+
+var typeTitles = map[category.Type]string{
+	category.TypeExpense: "Расход",
+	category.TypeIncome:  "Доход",
+}
+
+var _ ports.Exporter = Exporter{}
+
+type Exporter struct{}
+
+func NewExporter() Exporter {
+	return Exporter{}
+}
+
+func (e Exporter) Format() report.ExportFormat {
+	return report.ExportFormatXlsx
+}
+
+func (e Exporter) NewWriter(w io.Writer, loc *time.Location) (ports.ExportWriter, error) {
+	if w == nil {
+		return nil, errs.NewValueIsRequiredError("w")
+	}
+
+	if loc == nil {
+		return nil, errs.NewValueIsRequiredError("loc")
+	}
+
+	f := excelize.NewFile()
+
+	xw := &writer{
+		out:     w,
+		loc:     loc,
+		file:    f,
+		summary: make(map[summaryKey]map[string]decimal.Decimal),
+		months:  make(map[string]struct{}),
+	}
+
+	if err := xw.init(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+type summaryKey struct {
+	categoryType category.Type
+	parentName   string
+	categoryName string
+}
+
+type writer struct {
+	out  io.Writer
+	loc  *time.Location
+	file *excelize.File
+
+	stream      *excelize.StreamWriter
+	row         int
+	amountStyle int
+	dateStyle   int
+
+	summary map[summaryKey]map[string]decimal.Decimal
+	months  map[string]struct{}
+	income  decimal.Decimal
+	expense decimal.Decimal
+}
+
+func (w *writer) init() error {
+	if err := w.file.SetSheetName("Sheet1", transactionsSheet); err != nil {
+		return err
+	}
+
+	var err error
+
+	w.amountStyle, err = w.file.NewStyle(&excelize.Style{CustomNumFmt: ptr(amountFormat)})
+	if err != nil {
+		return err
+	}
+
+	w.dateStyle, err = w.file.NewStyle(&excelize.Style{CustomNumFmt: ptr(dateFormat)})
+	if err != nil {
+		return err
+	}
+
+	w.stream, err = w.file.NewStreamWriter(transactionsSheet)
+	if err != nil {
+		return err
+	}
+
+	for col, width := range []float64{17, 9, 13, 8, 9, 24, 24, 40, 12} {
+		if err := w.stream.SetColWidth(col+1, col+1, width); err != nil {
+			return err
+		}
+	}
+
+	if err := w.stream.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+
+	w.row = 1
+
+	return w.stream.SetRow("A1", transactionsHeader)
+}
+
+func (w *writer) Write(line report.TransactionLine) error {
+	local := line.OccurredAt().In(w.loc)
+	month := local.Format(monthLayout)
+	amount, _ := line.Amount().Float64()
+
+	w.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	err = w.stream.SetRow(cell, []any{
+		excelize.Cell{StyleID: w.dateStyle, Value: wallClock(local)},
+		month,
+		excelize.Cell{StyleID: w.amountStyle, Value: amount},
+		report.CurrencyCode,
+		typeTitles[line.CategoryType()],
+		line.ParentName(),
+		line.CategoryName(),
+		line.Note(),
+		defaultAccount,
+	})
+	if err != nil {
+		return err
+	}
+
+	w.accumulate(line, month)
+
+	return nil
+}
+
+func (w *writer) accumulate(line report.TransactionLine, month string) {
+	key := summaryKey{
+		categoryType: line.CategoryType(),
+		parentName:   line.ParentName(),
+		categoryName: line.CategoryName(),
+	}
+
+	byMonth, ok := w.summary[key]
+	if !ok {
+		byMonth = make(map[string]decimal.Decimal)
+		w.summary[key] = byMonth
+	}
+
+	byMonth[month] = byMonth[month].Add(line.Amount())
+	w.months[month] = struct{}{}
+
+	if line.CategoryType() == category.TypeIncome {
+		w.income = w.income.Add(line.Amount())
+	} else {
+		w.expense = w.expense.Add(line.Amount())
+	}
+}
+
+func (w *writer) Close() error {
+	defer func() {
+		_ = w.file.Close()
+	}()
+
+	if w.row > 1 {
+		ref := fmt.Sprintf("A1:I%d", w.row)
+		if err := w.stream.AddTable(&excelize.Table{Range: ref, Name: "Transactions", StyleName: "TableStyleLight9"}); err != nil {
+			return err
+		}
+	}
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	if err := w.writeSummary(); err != nil {
+		return err
+	}
+
+	if err := w.writeAccounts(); err != nil {
+		return err
+	}
+
+	w.file.SetActiveSheet(0)
+
+	_, err := w.file.WriteTo(w.out)
+
+	return err
+}
+
+func (w *writer) writeSummary() error {
+	if _, err := w.file.NewSheet(summarySheet); err != nil {
+		return err
+	}
+
+	months := sortedKeys(w.months)
+	keys := sortedSummaryKeys(w.summary)
+
+	header := []any{"Тип", "Родительская категория", "Категория"}
+	for _, m := range months {
+		header = append(header, m)
+	}
+
+	header = append(header, "Итого")
+
+	if err := w.file.SetSheetRow(summarySheet, "A1", &header); err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		row := []any{typeTitles[key.categoryType], key.parentName, key.categoryName}
+		total := decimal.Zero
+
+		for _, m := range months {
+			amount := w.summary[key][m]
+			total = total.Add(amount)
+			row = append(row, amount.InexactFloat64())
+		}
+
+		row = append(row, total.InexactFloat64())
+
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+
+		if err := w.file.SetSheetRow(summarySheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	if len(keys) > 0 {
+		from, _ := excelize.CoordinatesToCellName(4, 2)
+		to, _ := excelize.CoordinatesToCellName(4+len(months), len(keys)+1)
+
+		if err := w.file.SetCellStyle(summarySheet, from, to, w.amountStyle); err != nil {
+			return err
+		}
+	}
+
+	return w.file.SetColWidth(summarySheet, "A", "C", 24)
+}
+
+func (w *writer) writeAccounts() error {
+	if _, err := w.file.NewSheet(accountsSheet); err != nil {
+		return err
+	}
+
+	rows := [][]any{
+		{"Счет", "Валюта", "Доходы", "Расходы", "Баланс"},
+		{
+			defaultAccount,
+			report.CurrencyCode,
+			w.income.InexactFloat64(),
+			w.expense.InexactFloat64(),
+			w.income.Sub(w.expense).InexactFloat64(),
+		},
+	}
+
+	for i, row := range rows {
+		if err := w.file.SetSheetRow(accountsSheet, fmt.Sprintf("A%d", i+1), &row); err != nil {
+			return err
+		}
+	}
+
+	if err := w.file.SetCellStyle(accountsSheet, "C2", "E2", w.amountStyle); err != nil {
+		return err
+	}
+
+	return w.file.SetColWidth(accountsSheet, "A", "E", 14)
+}
+
+// wallClock переносит местное время в UTC без сдвига: Excel не хранит часовой пояс,
+// а excelize переводит время в число от абсолютного момента.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,158 @@
+package xlsxexporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/xlsxexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// export выгружает строки и открывает получившуюся книгу.
+func export(t *testing.T, loc *time.Location, lines ...report.TransactionLine) *excelize.File {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := xlsxexporter.NewExporter().NewWriter(&buf, loc)
+	require.NoError(t, err)
+
+	for _, line := range lines {
+		require.NoError(t, w.Write(line))
+	}
+
+	require.NoError(t, w.Close())
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = f.Close() })
+
+	return f
+}
+
+func rows(t *testing.T, f *excelize.File, sheet string) [][]string {
+	t.Helper()
+
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+
+	return rows
+}
+
+func line(occurredAt time.Time, amount string, categoryType category.Type, categoryName, parentName, note string) report.TransactionLine {
+	return report.NewTransactionLine(shared.NewID(), occurredAt, decimal.RequireFromString(amount), categoryType, categoryName, parentName, note)
+}
+
+func TestExporter_NewWriter_RequiresArguments(t *testing.T) {
+	_, err := xlsxexporter.NewExporter().NewWriter(nil, time.UTC)
+	require.Error(t, err)
+
+	_, err = xlsxexporter.NewExporter().NewWriter(&bytes.Buffer{}, nil)
+	require.Error(t, err)
+}
+
+func TestExporter_Sheets(t *testing.T) {
+	f := export(t, time.UTC)
+
+	assert.Equal(t, []string{"Транзакции", "Сводка по месяцам", "Счета"}, f.GetSheetList())
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	assert.Equal(t, [][]string{
+		{"Дата", "Месяц", "Сумма", "Валюта", "Тип", "Родительская категория", "Категория", "Комментарий", "Счет"},
+	}, rows(t, f, "Транзакции"))
+}
+
+func TestExporter_Transactions(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	f := export(t, moscow,
+		line(time.Date(2026, 3, 15, 11, 30, 0, 0, time.UTC), "350.5", category.TypeExpense, "Кафе", "Еда", "Обед <с коллегами> & десерт"),
+		line(time.Date(2026, 3, 31, 22, 15, 0, 0, time.UTC), "50000", category.TypeIncome, "Зарплата", "", ""),
+	)
+
+	got := rows(t, f, "Транзакции")
+	require.Len(t, got, 3)
+
+	assert.Equal(t, []string{"Месяц", "Сумма", "Валюта", "Тип", "Родительская категория", "Категория", "Комментарий", "Счет"}, got[0][1:])
+	assert.Equal(t, []string{"2026-03", "350.5", report.CurrencyCode, "Расход", "Еда", "Кафе", "Обед <с коллегами> & десерт", "Основной"}, got[1][1:])
+	// Время переведено в часовой пояс пользователя, поэтому операция попала в апрель.
+	assert.Equal(t, []string{"2026-04", "50000", report.CurrencyCode, "Доход", "", "Зарплата", "", "Основной"}, got[2][1:])
+
+	// В ячейке даты хранится местное время без часового пояса.
+	for cell, want := range map[string]time.Time{
+		"A2": time.Date(2026, 3, 15, 14, 30, 0, 0, time.UTC),
+		"A3": time.Date(2026, 4, 1, 1, 15, 0, 0, time.UTC),
+	} {
+		raw, err := f.GetCellValue("Транзакции", cell, excelize.Options{RawCellValue: true})
+		require.NoError(t, err)
+
+		serial, err := decimal.NewFromString(raw)
+		require.NoError(t, err)
+
+		date, err := excelize.ExcelDateToTime(serial.InexactFloat64(), false)
+		require.NoError(t, err)
+		assert.WithinDuration(t, want, date, time.Second, cell)
+	}
+
+	formatted, err := f.GetCellValue("Транзакции", "C3")
+	require.NoError(t, err)
+	assert.Equal(t, "50,000.00", formatted)
+}
+
+func TestExporter_TextIsNotFormula(t *testing.T) {
+	const injection = `=HYPERLINK("http://evil","click")`
+
+	f := export(t, time.UTC, line(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC), "100", category.TypeExpense, injection, "+cmd", "@SUM(A1:A2)"))
+
+	for cell, want := range map[string]string{"F2": "+cmd", "G2": injection, "H2": "@SUM(A1:A2)"} {
+		value, err := f.GetCellValue("Транзакции", cell)
+		require.NoError(t, err)
+		assert.Equal(t, want, value, cell)
+
+		formula, err := f.GetCellFormula("Транзакции", cell)
+		require.NoError(t, err)
+		assert.Empty(t, formula, cell)
+
+		cellType, err := f.GetCellType("Транзакции", cell)
+		require.NoError(t, err)
+		assert.NotEqual(t, excelize.CellTypeFormula, cellType, cell)
+	}
+}
+
+func TestExporter_MonthlySummary(t *testing.T) {
+	f := export(t, time.UTC,
+		line(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), "100", category.TypeExpense, "Кафе", "Еда", ""),
+		line(time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC), "50.25", category.TypeExpense, "Кафе", "Еда", ""),
+		line(time.Date(2026, 4, 2, 10, 0, 0, 0, time.UTC), "200", category.TypeExpense, "Кафе", "Еда", ""),
+		line(time.Date(2026, 4, 3, 10, 0, 0, 0, time.UTC), "70", category.TypeExpense, "Метро", "Транспорт", ""),
+		line(time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC), "1000", category.TypeIncome, "Зарплата", "", ""),
+	)
+
+	assert.Equal(t, [][]string{
+		{"Тип", "Родительская категория", "Категория", "2026-03", "2026-04", "Итого"},
+		{"Расход", "Еда", "Кафе", "150.25", "200", "350.25"},
+		{"Расход", "Транспорт", "Метро", "0", "70", "70"},
+		{"Доход", "", "Зарплата", "1000", "0", "1000"},
+	}, rows(t, f, "Сводка по месяцам"))
+}
+
+func TestExporter_Accounts(t *testing.T) {
+	f := export(t, time.UTC,
+		line(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), "300", category.TypeExpense, "Кафе", "Еда", ""),
+		line(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), "-50", category.TypeExpense, "Кафе", "Еда", "Возврат"),
+		line(time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC), "1000", category.TypeIncome, "Зарплата", "", ""),
+	)
+
+	assert.Equal(t, [][]string{
+		{"Счет", "Валюта", "Доходы", "Расходы", "Баланс"},
+		{"Основной", report.CurrencyCode, "1000", "250", "750"},
+	}, rows(t, f, "Счета"))
+}
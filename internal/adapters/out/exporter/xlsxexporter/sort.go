@@ -0,0 +1,35 @@
+package xlsxexporter
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+)
+
+func sortedKeys(m map[string]struct{}) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// sortedSummaryKeys упорядочивает строки сводки: сначала расходы, затем доходы,
+// внутри типа — по родительской категории и категории.
+func sortedSummaryKeys(m map[summaryKey]map[string]decimal.Decimal) []summaryKey {
+	return slices.SortedFunc(maps.Keys(m), func(a, b summaryKey) int {
+		return cmp.Or(
+			cmp.Compare(typeOrder(a.categoryType), typeOrder(b.categoryType)),
+			cmp.Compare(a.parentName, b.parentName),
+			cmp.Compare(a.categoryName, b.categoryName),
+		)
+	})
+}
+
+func typeOrder(t category.Type) int {
+	if t == category.TypeExpense {
+		return 0
+	}
+
+	return 1
+}
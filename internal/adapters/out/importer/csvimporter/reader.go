@@ -0,0 +1,119 @@
+// Package csvimporter читает банковские выписки в CSV, выгруженные из интернет-банков.
+package csvimporter
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+)
+
+// delimiters разделители полей в порядке предпочтения при равном числе вхождений.
+var delimiters = []rune{';', ',', '\t'}
+
+var _ ports.StatementReader = Reader{}
+
+type Reader struct{}
+
+func NewReader() Reader {
+	return Reader{}
+}
+
+func (Reader) Format() statement.FileFormat {
+	return statement.FileFormatCsv
+}
+
+// Read определяет кодировку (UTF-8 или Windows-1251) и разделитель полей, после чего читает выписку.
+// Пустые строки пропускаются, первая непустая строка считается заголовком.
+func (Reader) Read(r io.Reader) (statement.Table, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return statement.Table{}, fmt.Errorf("read statement: %w", err)
+	}
+
+	text, err := importer.DecodeText(content)
+	if err != nil {
+		return statement.Table{}, err
+	}
+
+	cr := csv.NewReader(strings.NewReader(text))
+	cr.Comma = detectDelimiter(text)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	var (
+		header []string
+		rows   [][]string
+	)
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return statement.Table{}, fmt.Errorf("read statement: %w", err)
+		}
+
+		if isEmpty(record) {
+			continue
+		}
+
+		if header == nil {
+			header = record
+			continue
+		}
+
+		rows = append(rows, record)
+	}
+
+	return statement.NewTable(header, rows)
+}
+
+// detectDelimiter выбирает разделитель, чаще других встречающийся в первой строке вне кавычек.
+func detectDelimiter(text string) rune {
+	firstLine := strings.TrimSpace(text)
+	if i := strings.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	counts := make(map[rune]int, len(delimiters))
+	quoted := false
+
+	for _, r := range firstLine {
+		if r == '"' {
+			quoted = !quoted
+			continue
+		}
+
+		if !quoted {
+			counts[r]++
+		}
+	}
+
+	best := delimiters[0]
+	for _, d := range delimiters[1:] {
+		if counts[d] > counts[best] {
+			best = d
+		}
+	}
+
+	return best
+}
+
+func isEmpty(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
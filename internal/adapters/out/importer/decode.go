@@ -0,0 +1,29 @@
+// Package importer содержит общие для форматов импорта вспомогательные функции.
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// utf8BOM метка порядка байтов, которую добавляют Excel и часть банков.
+const utf8BOM = "\ufeff"
+
+// DecodeText возвращает содержимое файла в UTF-8. Файлы, не являющиеся корректным UTF-8,
+// считаются выгруженными в Windows-1251, которую до сих пор используют многие банки.
+func DecodeText(content []byte) (string, error) {
+	content = bytes.TrimPrefix(content, []byte(utf8BOM))
+	if utf8.Valid(content) {
+		return string(content), nil
+	}
+
+	decoded, err := charmap.Windows1251.NewDecoder().Bytes(content)
+	if err != nil {
+		return "", fmt.Errorf("decode statement: %w", err)
+	}
+
+	return string(decoded), nil
+}
@@ -0,0 +1,138 @@
+// Package ofximporter читает файлы OFX (Open Financial Exchange), которые выгружают банки
+// и финансовые программы вроде GnuCash и HomeBank. Поддерживаются SGML-вариант OFX 1.x
+// с незакрытыми тегами и XML-вариант OFX 2.x.
+package ofximporter
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+)
+
+// tagPattern находит открывающие и закрывающие теги вместе с текстом, идущим за тегом.
+var tagPattern = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+var _ ports.StatementReader = Reader{}
+
+type Reader struct{}
+
+func NewReader() Reader {
+	return Reader{}
+}
+
+func (Reader) Format() statement.FileFormat {
+	return statement.FileFormatOfx
+}
+
+// Read читает операции из всех выписок файла, в том числе по нескольким счетам.
+// Имя получателя (NAME или PAYEE) становится описанием операции. В MEMO выгрузки бота
+// записывают путь категории, поэтому последний его сегмент передается как категория операции;
+// в выписках банков там обычно уточнение операции, которое тоже помогает подобрать категорию.
+func (Reader) Read(r io.Reader) (statement.Table, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return statement.Table{}, fmt.Errorf("read ofx: %w", err)
+	}
+
+	text, err := importer.DecodeText(content)
+	if err != nil {
+		return statement.Table{}, err
+	}
+
+	var (
+		rows    [][]string
+		current map[string]string
+	)
+
+	for _, m := range tagPattern.FindAllStringSubmatch(text, -1) {
+		closing, tag, value := m[1] == "/", strings.ToUpper(m[2]), strings.TrimSpace(m[3])
+
+		switch {
+		case tag == "STMTTRN" && !closing:
+			current = make(map[string]string)
+		case tag == "STMTTRN" && closing:
+			if current != nil {
+				rows = append(rows, toRow(current))
+			}
+
+			current = nil
+		case current != nil && !closing && value != "":
+			current[tag] = html.UnescapeString(value)
+		}
+	}
+
+	return statement.NewExchangeTable(statement.FileFormatOfx, rows)
+}
+
+func toRow(fields map[string]string) []string {
+	description := fields["NAME"]
+	if description == "" {
+		description = fields["PAYEE"]
+	}
+
+	memo := fields["MEMO"]
+	if description == "" {
+		description, memo = memo, ""
+	}
+
+	categoryName := strings.TrimSpace(memo[strings.LastIndex(memo, ":")+1:])
+
+	// Выгрузки бота записывают название категории в NAME операций без комментария.
+	// Банки часто дублируют NAME в MEMO, такое описание сохраняется.
+	if memo != description && description == categoryName {
+		description = ""
+	}
+
+	row := make([]string, statement.ExchangeColumnCategory+1)
+	row[statement.ExchangeColumnDate] = normalizeDate(fields["DTPOSTED"])
+	row[statement.ExchangeColumnAmount] = fields["TRNAMT"]
+	row[statement.ExchangeColumnDescription] = description
+	row[statement.ExchangeColumnCategory] = categoryName
+
+	return row
+}
+
+// normalizeDate приводит дату OFX вида 20260315143000.000[+3:MSK] к RFC 3339. Дата без времени
+// или без часового пояса возвращается без смещения и будет отнесена к часовому поясу пользователя.
+// Нераспознанная дата возвращается как есть, чтобы строка попала в ошибки разбора.
+func normalizeDate(s string) string {
+	value, zone, _ := strings.Cut(strings.TrimSpace(s), "[")
+	value, _, _ = strings.Cut(value, ".")
+
+	if len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return s
+		}
+
+		return t.Format("2006-01-02")
+	}
+
+	t, err := time.Parse("20060102150405", value)
+	if err != nil {
+		return s
+	}
+
+	if zone == "" {
+		return t.Format("2006-01-02 15:04:05")
+	}
+
+	offset, _, _ := strings.Cut(strings.TrimSuffix(zone, "]"), ":")
+
+	hours, err := strconv.ParseFloat(offset, 64)
+	if err != nil {
+		return t.Format("2006-01-02 15:04:05")
+	}
+
+	loc := time.FixedZone("", int(hours*60*60))
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc).Format(time.RFC3339)
+}
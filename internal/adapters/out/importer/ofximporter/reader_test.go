@@ -0,0 +1,167 @@
+package ofximporter_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/ofxexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer/ofximporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+)
+
+func readFile(t *testing.T, name string) statement.Table {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = f.Close() })
+
+	table, err := ofximporter.NewReader().Read(f)
+	require.NoError(t, err)
+
+	return table
+}
+
+func TestReader_Read_SGML(t *testing.T) {
+	want := [][]string{
+		{"2026-03-15T14:30:00+03:00", "-350.50", "ПЯТЕРОЧКА 1234", "Супермаркеты"},
+		{"2026-03-10", "50000.00", "ООО Ромашка & Ко", ""},
+		{"2026-03-12 12:00:00", "-99.00", "Подписка", ""},
+	}
+
+	// Банки выгружают OFX 1.x и в UTF-8, и в Windows-1251.
+	for _, name := range []string{"bank_v1.ofx", "bank_v1_cp1251.ofx"} {
+		t.Run(name, func(t *testing.T) {
+			table := readFile(t, name)
+
+			assert.Equal(t, want, table.Rows())
+
+			mapping, ok := table.Mapping()
+			require.True(t, ok)
+			assert.Equal(t, "OFX", mapping.Preset())
+		})
+	}
+}
+
+func TestReader_Read_XMLWithSeveralAccounts(t *testing.T) {
+	table := readFile(t, "two_accounts_v2.ofx")
+
+	assert.Equal(t, [][]string{
+		{"2026-02-01T08:00:00-05:00", "-12.00", "Coffee", "Кафе"},
+		{"2026-02-02", "-700.00", "", "Такси"},
+		{"вчера", "-1.00", "Bad date", ""},
+	}, table.Rows())
+
+	mapping, ok := table.Mapping()
+	require.True(t, ok)
+
+	records, rowErrors := mapping.Parse(table, time.UTC)
+	require.Len(t, records, 2)
+	require.Len(t, rowErrors, 1)
+	assert.Equal(t, 4, rowErrors[0].Line)
+
+	assert.True(t, records[0].OccurredAt().Equal(time.Date(2026, 2, 1, 13, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Кафе", records[0].BankCategory())
+}
+
+func TestReader_Read_Empty(t *testing.T) {
+	_, err := ofximporter.NewReader().Read(bytes.NewBufferString("<OFX><BANKTRANLIST></BANKTRANLIST></OFX>"))
+	require.ErrorIs(t, err, statement.ErrEmptyStatement)
+}
+
+// TestRoundTrip проверяет, что выгрузка бота загружается обратно: даты со смещением, суммы,
+// описания и категории совпадают, а части разделенной транзакции остаются отдельными операциями.
+func TestRoundTrip(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	split := shared.NewID()
+
+	tests := []struct {
+		line report.TransactionLine
+		// wantDescription описание после загрузки. NAME операции без комментария совпадает с MEMO,
+		// как у банков, которые дублируют получателя, поэтому название категории остается описанием.
+		wantDescription string
+		// wantType тип после загрузки. Возврат по расходу выгружается зачислением и загружается доходом.
+		wantType category.Type
+	}{
+		{
+			line:            report.NewTransactionLine(shared.NewID(), time.Date(2026, 3, 15, 14, 30, 0, 0, loc), decimal.RequireFromString("350.5"), category.TypeExpense, "Кафе", "Еда", "Обед с коллегами"),
+			wantDescription: "Обед с коллегами",
+			wantType:        category.TypeExpense,
+		},
+		{
+			line:            report.NewTransactionLine(shared.NewID(), time.Date(2026, 3, 10, 9, 0, 0, 0, loc), decimal.RequireFromString("50000"), category.TypeIncome, "Зарплата", "", ""),
+			wantDescription: "Зарплата",
+			wantType:        category.TypeIncome,
+		},
+		{
+			line:            report.NewTransactionLine(shared.NewID(), time.Date(2026, 3, 11, 9, 0, 0, 0, loc), decimal.RequireFromString("120"), category.TypeExpense, "Метро", "Транспорт", ""),
+			wantDescription: "",
+			wantType:        category.TypeExpense,
+		},
+		{
+			line:            report.NewTransactionLine(split, time.Date(2026, 3, 14, 18, 0, 0, 0, loc), decimal.RequireFromString("400"), category.TypeExpense, "Продукты", "Еда", "Ашан"),
+			wantDescription: "Ашан",
+			wantType:        category.TypeExpense,
+		},
+		{
+			line:            report.NewTransactionLine(split, time.Date(2026, 3, 14, 18, 0, 0, 0, loc), decimal.RequireFromString("200"), category.TypeExpense, "Быт", "", "Ашан"),
+			wantDescription: "Ашан",
+			wantType:        category.TypeExpense,
+		},
+		{
+			line:            report.NewTransactionLine(shared.NewID(), time.Date(2026, 3, 16, 10, 0, 0, 0, loc), decimal.RequireFromString("-20.25"), category.TypeExpense, "Кафе", "Еда", "Возврат: остыл"),
+			wantDescription: "Возврат: остыл",
+			wantType:        category.TypeIncome,
+		},
+	}
+
+	var buf bytes.Buffer
+
+	w, err := ofxexporter.NewExporter().NewWriter(&buf, loc)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		require.NoError(t, w.Write(tt.line))
+	}
+
+	require.NoError(t, w.Close())
+
+	table, err := ofximporter.NewReader().Read(&buf)
+	require.NoError(t, err)
+
+	mapping, ok := table.Mapping()
+	require.True(t, ok)
+
+	// Часовой пояс разбора отличается от часового пояса выгрузки: смещение записано в файле.
+	records, rowErrors := mapping.Parse(table, time.UTC)
+	require.Empty(t, rowErrors)
+	require.Len(t, records, len(tests))
+
+	for i, tt := range tests {
+		r := records[i]
+
+		want := tt.line.Amount()
+		if tt.line.CategoryType() == category.TypeExpense {
+			want = want.Neg()
+		}
+
+		assert.True(t, tt.line.OccurredAt().Equal(r.OccurredAt()), "line %d occurred at", i)
+		assert.True(t, want.Equal(r.SignedAmount()), "line %d amount: want %s, got %s", i, want, r.SignedAmount())
+		assert.Equal(t, tt.wantType, r.Type(), "line %d type", i)
+		assert.Equal(t, tt.wantDescription, r.Description(), "line %d description", i)
+		assert.Equal(t, tt.line.CategoryName(), r.BankCategory(), "line %d category", i)
+	}
+}
@@ -0,0 +1,151 @@
+// Package qifimporter читает файлы QIF (Quicken Interchange Format), которые выгружают
+// GnuCash, HomeBank и другие финансовые программы.
+package qifimporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+)
+
+// transactionSections разделы QIF, содержащие операции по счетам. Списки категорий,
+// классов и инвестиционные операции пропускаются.
+var transactionSections = map[string]bool{
+	"!type:bank":   true,
+	"!type:cash":   true,
+	"!type:ccard":  true,
+	"!type:oth a":  true,
+	"!type:oth l":  true,
+	"!type:otha":   true,
+	"!type:othl":   true,
+	"!type:credit": true,
+}
+
+// dateLayouts форматы дат QIF. Американский порядок месяц/день принят в формате по умолчанию,
+// апостроф перед годом используют Quicken и GnuCash.
+var dateLayouts = []string{
+	"01/02/2006",
+	"1/2/2006",
+	"01/02'2006",
+	"1/2'2006",
+	"1/2'06",
+	"01/02/06",
+	"02.01.2006",
+	"2006-01-02",
+}
+
+var _ ports.StatementReader = Reader{}
+
+type Reader struct{}
+
+func NewReader() Reader {
+	return Reader{}
+}
+
+func (Reader) Format() statement.FileFormat {
+	return statement.FileFormatQif
+}
+
+// Read читает операции из всех счетов файла. Получатель (P) становится описанием операции,
+// а при его отсутствии — комментарий (M). Из категории (L) вида «Родитель:Категория» берется
+// последний сегмент; переводы между счетами ([Счет]) передаются без категории.
+// У разделенных операций учитывается общая сумма.
+func (Reader) Read(r io.Reader) (statement.Table, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return statement.Table{}, fmt.Errorf("read qif: %w", err)
+	}
+
+	text, err := importer.DecodeText(content)
+	if err != nil {
+		return statement.Table{}, err
+	}
+
+	var (
+		rows      [][]string
+		inSection bool
+		fields    = make(map[byte]string)
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "!"):
+			inSection = transactionSections[strings.ToLower(strings.TrimSpace(line))]
+			clear(fields)
+		case !inSection:
+			continue
+		case line[0] == '^':
+			if len(fields) > 0 {
+				rows = append(rows, toRow(fields))
+			}
+
+			clear(fields)
+		default:
+			// Поля разделенной операции (S, E, $) повторяются и в импорт не попадают.
+			if _, ok := fields[line[0]]; !ok {
+				fields[line[0]] = strings.TrimSpace(line[1:])
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return statement.Table{}, fmt.Errorf("read qif: %w", err)
+	}
+
+	return statement.NewExchangeTable(statement.FileFormatQif, rows)
+}
+
+func toRow(fields map[byte]string) []string {
+	amount := fields['T']
+	if amount == "" {
+		amount = fields['U']
+	}
+
+	description := fields['P']
+	if description == "" {
+		description = fields['M']
+	}
+
+	categoryName := fields['L']
+	if strings.HasPrefix(categoryName, "[") {
+		categoryName = ""
+	}
+
+	// Класс операции записывается после косой черты: «Категория/Класс».
+	categoryName, _, _ = strings.Cut(categoryName, "/")
+	categoryName = categoryName[strings.LastIndex(categoryName, ":")+1:]
+
+	row := make([]string, statement.ExchangeColumnCategory+1)
+	row[statement.ExchangeColumnDate] = normalizeDate(fields['D'])
+	row[statement.ExchangeColumnAmount] = amount
+	row[statement.ExchangeColumnDescription] = description
+	row[statement.ExchangeColumnCategory] = strings.TrimSpace(categoryName)
+
+	return row
+}
+
+// normalizeDate приводит дату QIF к виду 2006-01-02. Нераспознанная дата возвращается как есть,
+// чтобы строка попала в ошибки разбора, а не прервала импорт всего файла.
+func normalizeDate(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,110 @@
+package qifimporter_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/exporter/qifexporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/importer/qifimporter"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+)
+
+func TestReader_Read(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "gnucash.qif"))
+	require.NoError(t, err)
+
+	defer func() { _ = f.Close() }()
+
+	table, err := qifimporter.NewReader().Read(f)
+	require.NoError(t, err)
+
+	// Список категорий и инвестиционный счет пропускаются, перевод между счетами идет без категории,
+	// у разделенной операции берется общая сумма.
+	assert.Equal(t, [][]string{
+		{"2026-03-15", "-350.50", "Пятерочка", "Продукты"},
+		{"2026-03-10", "50,000.00", "ООО Ромашка", "Зарплата"},
+		{"2026-03-12", "-99.00", "Подписка", "Развлечения"},
+		{"2026-03-13", "-5000.00", "Перевод на карту", ""},
+		{"2026-03-14", "-600.00", "Ашан", "Еда"},
+		{"2026-03-14", "-120.00", "Метро", "Транспорт"},
+		{"2026-13-40", "-1.00", "Неверная дата", ""},
+	}, table.Rows())
+
+	mapping, ok := table.Mapping()
+	require.True(t, ok)
+	assert.Equal(t, "QIF", mapping.Preset())
+
+	_, rowErrors := mapping.Parse(table, time.UTC)
+	require.Len(t, rowErrors, 1)
+	assert.Equal(t, 8, rowErrors[0].Line)
+}
+
+func TestReader_Read_WithoutTransactions(t *testing.T) {
+	_, err := qifimporter.NewReader().Read(bytes.NewBufferString("!Type:Cat\nNЕда\nE\n^\n"))
+	require.ErrorIs(t, err, statement.ErrEmptyStatement)
+}
+
+// TestRoundTrip проверяет, что выгрузка бота загружается обратно. QIF хранит только дату,
+// поэтому время операций не сохраняется.
+func TestRoundTrip(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Novosibirsk")
+	require.NoError(t, err)
+
+	lines := []report.TransactionLine{
+		report.NewTransactionLine(shared.NewID(), time.Date(2026, 3, 15, 23, 30, 0, 0, loc), decimal.RequireFromString("350.5"), category.TypeExpense, "Кафе", "Еда", "Обед\nс коллегами"),
+		report.NewTransactionLine(shared.NewID(), time.Date(2026, 3, 10, 9, 0, 0, 0, loc), decimal.RequireFromString("50000"), category.TypeIncome, "Зарплата", "", ""),
+		report.NewTransactionLine(shared.NewID(), time.Date(2026, 3, 11, 9, 0, 0, 0, loc), decimal.RequireFromString("120"), category.TypeExpense, "Метро/автобус", "Транспорт", ""),
+	}
+
+	wantDescriptions := []string{"Обед с коллегами", "", ""}
+	wantCategories := []string{"Кафе", "Зарплата", "Метро-автобус"}
+
+	var buf bytes.Buffer
+
+	w, err := qifexporter.NewExporter().NewWriter(&buf, loc)
+	require.NoError(t, err)
+
+	for _, line := range lines {
+		require.NoError(t, w.Write(line))
+	}
+
+	require.NoError(t, w.Close())
+
+	table, err := qifimporter.NewReader().Read(&buf)
+	require.NoError(t, err)
+
+	mapping, ok := table.Mapping()
+	require.True(t, ok)
+
+	records, rowErrors := mapping.Parse(table, loc)
+	require.Empty(t, rowErrors)
+	require.Len(t, records, len(lines))
+
+	for i, line := range lines {
+		r := records[i]
+
+		occurredAt := line.OccurredAt()
+		wantDate := time.Date(occurredAt.Year(), occurredAt.Month(), occurredAt.Day(), 0, 0, 0, 0, loc)
+
+		want := line.Amount()
+		if line.CategoryType() == category.TypeExpense {
+			want = want.Neg()
+		}
+
+		assert.True(t, wantDate.Equal(r.OccurredAt()), "line %d: want %s, got %s", i, wantDate, r.OccurredAt())
+		assert.True(t, want.Equal(r.SignedAmount()), "line %d amount: want %s, got %s", i, want, r.SignedAmount())
+		assert.Equal(t, line.CategoryType(), r.Type(), "line %d type", i)
+		assert.Equal(t, wantDescriptions[i], r.Description(), "line %d description", i)
+		assert.Equal(t, wantCategories[i], r.BankCategory(), "line %d category", i)
+	}
+}
@@ -0,0 +1,146 @@
+// Package metrics собирает метрики бота в формате Prometheus.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	namespace = "coin_tamer"
+
+	// dbName значение метки db_name в метриках пула соединений.
+	dbName = "postgres"
+
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+var _ ports.Metrics = &Metrics{}
+
+// Metrics хранит метрики бота в собственном реестре, чтобы в выдачу не попадали метрики,
+// зарегистрированные библиотеками в глобальном реестре.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	updates          *prometheus.CounterVec
+	updateDuration   *prometheus.HistogramVec
+	errors           *prometheus.CounterVec
+	telegramDuration *prometheus.HistogramVec
+}
+
+// New создает метрики бота. Статистика пула соединений db снимается при каждом сборе метрик.
+func New(db *sql.DB) (*Metrics, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "updates_total",
+			Help:      "Number of processed Telegram updates by type.",
+		}, []string{"type"}),
+		updateDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "update_duration_seconds",
+			Help:      "Time spent handling a Telegram update by type.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"type"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Number of update handling errors by kind.",
+		}, []string{"kind"}),
+		telegramDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "telegram_request_duration_seconds",
+			Help:      "Telegram Bot API call latency by method and result.",
+			// getUpdates держит соединение до 10 секунд, поэтому верхние корзины шире стандартных.
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20, 30, 45},
+		}, []string{"method", "result"}),
+	}
+
+	cs := []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, dbName),
+		m.updates,
+		m.updateDuration,
+		m.errors,
+		m.telegramDuration,
+	}
+
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// RegisterUpdateQueue добавляет метрики очереди обновлений: число принятых, но еще не начатых
+// обновлений и число обновлений в обработке. Значения снимаются функциями depth и inFlight при сборе.
+func (m *Metrics) RegisterUpdateQueue(depth func() int, inFlight func() int) error {
+	if depth == nil {
+		return errs.NewValueIsRequiredError("depth")
+	}
+
+	if inFlight == nil {
+		return errs.NewValueIsRequiredError("inFlight")
+	}
+
+	cs := []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "update_queue_depth",
+			Help:      "Number of accepted Telegram updates waiting for a worker.",
+		}, func() float64 { return float64(depth()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "updates_in_flight",
+			Help:      "Number of Telegram updates being handled right now.",
+		}, func() float64 { return float64(inFlight()) }),
+	}
+
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handler отдает метрики в текстовом формате Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveUpdate(updateType string, duration time.Duration) {
+	m.updates.WithLabelValues(updateType).Inc()
+	m.updateDuration.WithLabelValues(updateType).Observe(duration.Seconds())
+}
+
+func (m *Metrics) IncError(kind string) {
+	m.errors.WithLabelValues(kind).Inc()
+}
+
+func (m *Metrics) ObserveTelegramRequest(method string, duration time.Duration, failed bool) {
+	result := resultOK
+	if failed {
+		result = resultError
+	}
+
+	m.telegramDuration.WithLabelValues(method, result).Observe(duration.Seconds())
+}
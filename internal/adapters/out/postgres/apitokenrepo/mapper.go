@@ -0,0 +1,63 @@
+package apitokenrepo
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+const scopeSeparator = ","
+
+type Model struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	TokenHash []byte
+	Hint      string
+	Scopes    string
+	ExpiresAt sql.NullTime
+	RevokedAt sql.NullTime
+	CreatedAt time.Time
+}
+
+func (m Model) toDomain() *apitoken.Token {
+	var scopes []apitoken.Scope
+	for s := range strings.SplitSeq(m.Scopes, scopeSeparator) {
+		scopes = append(scopes, apitoken.Scope(s))
+	}
+
+	return apitoken.Restore(
+		shared.RestoreID(m.ID),
+		shared.RestoreID(m.UserID),
+		m.Name,
+		m.TokenHash,
+		m.Hint,
+		scopes,
+		m.ExpiresAt.Time,
+		m.RevokedAt.Time,
+		m.CreatedAt,
+	)
+}
+
+// scanFields возвращает указатели на поля модели в порядке selectColumns.
+func (m *Model) scanFields() []any {
+	return []any{&m.ID, &m.UserID, &m.Name, &m.TokenHash, &m.Hint, &m.Scopes, &m.ExpiresAt, &m.RevokedAt, &m.CreatedAt}
+}
+
+func joinScopes(scopes []apitoken.Scope) string {
+	names := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		names = append(names, s.String())
+	}
+
+	return strings.Join(names, scopeSeparator)
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
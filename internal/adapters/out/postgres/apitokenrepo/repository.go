@@ -0,0 +1,150 @@
+package apitokenrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const selectColumns = `t.id, t.user_id, t.name, t.token_hash, t.hint, t.scopes, t.expires_at, t.revoked_at, t.created_at`
+
+type APITokenRepository struct {
+	tracker Tracker
+}
+
+func NewAPITokenRepository(tracker Tracker) (ports.APITokenRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &APITokenRepository{tracker: tracker}, nil
+}
+
+func (r APITokenRepository) Add(ctx context.Context, t *apitoken.Token) error {
+	stmt := `INSERT INTO api_tokens (id, user_id, name, token_hash, hint, scopes, expires_at, revoked_at, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		t.ID(),
+		t.UserID(),
+		t.Name(),
+		t.Hash(),
+		t.Hint(),
+		joinScopes(t.Scopes()),
+		nullTime(t.ExpiresAt()),
+		nullTime(t.RevokedAt()),
+		t.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("api token repo add: %w", err)
+	}
+
+	return nil
+}
+
+func (r APITokenRepository) Save(ctx context.Context, t *apitoken.Token) error {
+	stmt := `UPDATE api_tokens SET revoked_at = $2 WHERE id = $1`
+
+	res, err := r.tracker.Tx().ExecContext(ctx, stmt, t.ID(), nullTime(t.RevokedAt()))
+	if err != nil {
+		return fmt.Errorf("api token repo save: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("api token repo save: %w", err)
+	}
+
+	if affected == 0 {
+		return errs.NewObjectNotFoundError("api token", t.ID().String())
+	}
+
+	return nil
+}
+
+func (r APITokenRepository) Get(ctx context.Context, userID shared.ID, id shared.ID) (*apitoken.Token, error) {
+	stmt := `SELECT ` + selectColumns + `
+				FROM api_tokens t
+				WHERE t.user_id = $1 AND t.id = $2`
+
+	return r.get(ctx, "api token repo get", id.String(), stmt, userID, id)
+}
+
+func (r APITokenRepository) GetByHash(ctx context.Context, hash []byte) (*apitoken.Token, error) {
+	stmt := `SELECT ` + selectColumns + `
+				FROM api_tokens t
+				WHERE t.token_hash = $1`
+
+	return r.get(ctx, "api token repo get by hash", "hash", stmt, hash)
+}
+
+func (r APITokenRepository) FindByUserID(ctx context.Context, userID shared.ID) ([]*apitoken.Token, error) {
+	op := "api token repo find by user id"
+
+	stmt := `SELECT ` + selectColumns + `
+				FROM api_tokens t
+				WHERE t.user_id = $1 AND t.revoked_at IS NULL
+				ORDER BY t.created_at, t.id`
+
+	q := r.tracker.DB().QueryContext
+	if r.tracker.InTx() {
+		q = r.tracker.Tx().QueryContext
+	}
+
+	rows, err := q(ctx, stmt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error(op, "err", err.Error())
+		}
+	}(rows)
+
+	var result []*apitoken.Token
+	for rows.Next() {
+		var m Model
+
+		if err := rows.Scan(m.scanFields()...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, m.toDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+func (r APITokenRepository) get(ctx context.Context, op string, key string, stmt string, args ...any) (*apitoken.Token, error) {
+	q := r.tracker.DB().QueryRowContext
+	if r.tracker.InTx() {
+		q = r.tracker.Tx().QueryRowContext
+	}
+
+	var m Model
+
+	err := q(ctx, stmt, args...).Scan(m.scanFields()...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewObjectNotFoundError("api token", key)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return m.toDomain(), nil
+}
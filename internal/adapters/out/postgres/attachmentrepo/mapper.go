@@ -0,0 +1,62 @@
+package attachmentrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type Model struct {
+	ID            uuid.UUID
+	TransactionID uuid.UUID
+	UserID        uuid.UUID
+	Kind          attachment.Kind
+	FileID        string
+	FileUniqueID  string
+	FileName      string
+	MimeType      string
+	Size          int64
+	StorageKey    sql.NullString
+	CreatedAt     time.Time
+}
+
+func (m Model) toDomain() *attachment.Attachment {
+	return attachment.Restore(
+		shared.RestoreID(m.ID),
+		shared.RestoreID(m.TransactionID),
+		shared.RestoreID(m.UserID),
+		m.Kind,
+		m.FileID,
+		m.FileUniqueID,
+		m.FileName,
+		m.MimeType,
+		m.Size,
+		m.StorageKey.String,
+		m.CreatedAt,
+	)
+}
+
+// scanFields возвращает указатели на поля модели в порядке selectColumns.
+func (m *Model) scanFields() []any {
+	return []any{
+		&m.ID,
+		&m.TransactionID,
+		&m.UserID,
+		&m.Kind,
+		&m.FileID,
+		&m.FileUniqueID,
+		&m.FileName,
+		&m.MimeType,
+		&m.Size,
+		&m.StorageKey,
+		&m.CreatedAt,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,95 @@
+package attachmentrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const selectColumns = `id, transaction_id, user_id, kind, file_id, file_unique_id,
+	file_name, mime_type, size, storage_key, created_at`
+
+type AttachmentRepository struct {
+	tracker Tracker
+}
+
+func NewAttachmentRepository(tracker Tracker) (ports.AttachmentRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &AttachmentRepository{tracker: tracker}, nil
+}
+
+func (r AttachmentRepository) Add(ctx context.Context, a *attachment.Attachment) error {
+	stmt := `INSERT INTO transaction_attachments (id, transaction_id, user_id, kind, file_id, file_unique_id,
+												 file_name, mime_type, size, storage_key, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		a.ID(),
+		a.TransactionID(),
+		a.UserID(),
+		a.Kind(),
+		a.FileID(),
+		a.FileUniqueID(),
+		a.FileName(),
+		a.MimeType(),
+		a.Size(),
+		nullString(a.StorageKey()),
+		a.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("attachment repo add: %w", err)
+	}
+
+	return nil
+}
+
+func (r AttachmentRepository) FindByTransactionID(ctx context.Context, transactionID shared.ID) ([]*attachment.Attachment, error) {
+	var q sqlx.QueryerContext = r.tracker.DB()
+	if r.tracker.InTx() {
+		q = r.tracker.Tx()
+	}
+
+	stmt := `SELECT ` + selectColumns + `
+				FROM transaction_attachments
+				WHERE transaction_id = $1
+				ORDER BY created_at, id`
+	rows, err := q.QueryContext(ctx, stmt, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("attachment repo find by transaction id: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error("attachment repo find by transaction id", "err", err.Error())
+		}
+	}(rows)
+
+	var result []*attachment.Attachment
+	for rows.Next() {
+		var m Model
+
+		if err := rows.Scan(m.scanFields()...); err != nil {
+			return nil, fmt.Errorf("attachment repo find by transaction id: %w", err)
+		}
+
+		result = append(result, m.toDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("attachment repo find by transaction id: %w", err)
+	}
+
+	return result, nil
+}
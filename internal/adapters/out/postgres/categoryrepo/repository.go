@@ -3,6 +3,7 @@ package categoryrepo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -26,6 +27,8 @@ func NewCategoryRepository(tracker Tracker) (ports.CategoryRepository, error) {
 }
 
 func (c CategoryRepository) Create(ctx context.Context, cat *category.Category) error {
+	c.tracker.Track(cat)
+
 	stmt := `INSERT INTO categories (id, name, type, owner_id, parent_category_id, created_at)
 			 VALUES ($1, $2, $3, $4, $5, $6)`
 	_, err := c.tracker.Tx().ExecContext(ctx, stmt, cat.ID(), cat.Name(), cat.Type(), cat.OwnerID(), cat.ParentID(), cat.CreatedAt())
@@ -37,13 +40,13 @@ func (c CategoryRepository) Create(ctx context.Context, cat *category.Category)
 }
 
 func (c CategoryRepository) GetIncomeByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error) {
-	stmt := `SELECT * FROM categories WHERE owner_id = $1 AND type = $2 AND parent_category_id = $3`
+	stmt := `SELECT id, name, owner_id, parent_category_id, type, created_at FROM categories WHERE owner_id = $1 AND type = $2 AND parent_category_id = $3`
 
 	return c.getByUserIDAndType(ctx, stmt, userID, category.TypeIncome)
 }
 
 func (c CategoryRepository) GetExpenseByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error) {
-	stmt := `SELECT * FROM categories WHERE owner_id = $1 AND type = $2 AND parent_category_id != $3`
+	stmt := `SELECT id, name, owner_id, parent_category_id, type, created_at FROM categories WHERE owner_id = $1 AND type = $2 AND parent_category_id != $3`
 
 	return c.getByUserIDAndType(ctx, stmt, userID, category.TypeExpense)
 }
@@ -80,11 +83,107 @@ func (c CategoryRepository) getByUserIDAndType(ctx context.Context, stmt string,
 	return categories, nil
 }
 
+func (c CategoryRepository) GetAllByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error) {
+	stmt := `SELECT id, name, owner_id, parent_category_id, type, created_at
+				FROM categories
+				WHERE owner_id = $1
+				ORDER BY created_at, id`
+	rows, err := c.tracker.DB().QueryContext(ctx, stmt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("category repo get all by user id: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			c.tracker.Logger().Error("category repo get all by user id", "err", err.Error())
+		}
+	}(rows)
+
+	var categories []*category.Category
+	for rows.Next() {
+		var model Model
+
+		if err := rows.Scan(&model.ID, &model.Name, &model.OwnerID, &model.ParentID, &model.CategoryType, &model.CreatedAt); err != nil {
+			return nil, fmt.Errorf("category repo get all by user id: %w", err)
+		}
+
+		parentID := shared.ID{}
+		if model.ParentID != uuid.Nil {
+			parentID = shared.RestoreID(model.ParentID)
+		}
+
+		categories = append(categories, category.Restore(shared.RestoreID(model.ID), model.Name, shared.RestoreID(model.OwnerID), &parentID, model.CategoryType, model.CreatedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("category repo get all by user id: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (c CategoryRepository) Get(ctx context.Context, userID shared.ID, id shared.ID) (*category.Category, error) {
+	stmt := `SELECT id, name, owner_id, parent_category_id, type, created_at
+				FROM categories
+				WHERE owner_id = $1 AND id = $2`
+
+	q := c.tracker.DB().QueryRowContext
+	if c.tracker.InTx() {
+		q = c.tracker.Tx().QueryRowContext
+	}
+
+	var model Model
+
+	err := q(ctx, stmt, userID, id).Scan(&model.ID, &model.Name, &model.OwnerID, &model.ParentID, &model.CategoryType, &model.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewObjectNotFoundError("category", id.String())
+		}
+
+		return nil, fmt.Errorf("category repo get: %w", err)
+	}
+
+	parentID := shared.ID{}
+	if model.ParentID != uuid.Nil {
+		parentID = shared.RestoreID(model.ParentID)
+	}
+
+	return category.Restore(shared.RestoreID(model.ID), model.Name, shared.RestoreID(model.OwnerID), &parentID, model.CategoryType, model.CreatedAt), nil
+}
+
+func (c CategoryRepository) Save(ctx context.Context, cat *category.Category) error {
+	c.tracker.Track(cat)
+
+	stmt := `UPDATE categories SET name = $2 WHERE id = $1`
+
+	res, err := c.tracker.Tx().ExecContext(ctx, stmt, cat.ID(), cat.Name())
+	if err != nil {
+		return fmt.Errorf("category repo save: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("category repo save: %w", err)
+	}
+
+	if affected == 0 {
+		return errs.NewObjectNotFoundError("category", cat.ID().String())
+	}
+
+	return nil
+}
+
 func (c CategoryRepository) HasCategoriesByUserID(ctx context.Context, userID shared.ID) (bool, error) {
 	stmt := `SELECT EXISTS(SELECT 1 FROM categories WHERE owner_id = $1)`
 
+	q := c.tracker.DB().QueryRowContext
+	if c.tracker.InTx() {
+		q = c.tracker.Tx().QueryRowContext
+	}
+
 	var exists bool
-	err := c.tracker.DB().QueryRowContext(ctx, stmt, userID).Scan(&exists)
+	err := q(ctx, stmt, userID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("category repo has categories by user id: %w", err)
 	}
@@ -0,0 +1,47 @@
+package jobrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type Model struct {
+	ID              uuid.UUID
+	Kind            string
+	Key             sql.NullString
+	Payload         []byte
+	Status          job.Status
+	Attempts        int
+	MaxAttempts     int
+	IntervalSeconds int64
+	RunAt           time.Time
+	LastError       sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (m Model) toDomain() *job.Job {
+	return job.Restore(
+		shared.RestoreID(m.ID),
+		m.Kind,
+		m.Key.String,
+		m.Payload,
+		m.Status,
+		m.Attempts,
+		m.MaxAttempts,
+		time.Duration(m.IntervalSeconds)*time.Second,
+		m.RunAt,
+		m.LastError.String,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,161 @@
+package jobrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type JobRepository struct {
+	tracker Tracker
+}
+
+func NewJobRepository(tracker Tracker) (ports.JobRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &JobRepository{tracker: tracker}, nil
+}
+
+func (r JobRepository) Enqueue(ctx context.Context, j *job.Job) error {
+	stmt := `INSERT INTO jobs (id, kind, key, payload, status, attempts, max_attempts, interval_seconds, run_at, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			 ON CONFLICT (key) DO NOTHING`
+	_, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		j.ID(),
+		j.Kind(),
+		nullString(j.Key()),
+		j.Payload(),
+		j.Status(),
+		j.Attempts(),
+		j.MaxAttempts(),
+		int64(j.Interval()/time.Second),
+		j.RunAt(),
+		j.CreatedAt(),
+		j.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("job repo enqueue: %w", err)
+	}
+
+	return nil
+}
+
+func (r JobRepository) ClaimDue(
+	ctx context.Context,
+	workerID string,
+	now time.Time,
+	lockedUntil time.Time,
+	limit int,
+) ([]*job.Job, error) {
+	stmt := `UPDATE jobs
+			 SET status = $1, attempts = attempts + 1, locked_by = $2, locked_until = $3, updated_at = $4
+			 WHERE id IN (
+				 SELECT id FROM jobs
+				 WHERE status = $5 AND run_at <= $4
+				 ORDER BY run_at
+				 LIMIT $6
+				 FOR UPDATE SKIP LOCKED
+			 )
+			 RETURNING id, kind, key, payload, status, attempts, max_attempts, interval_seconds, run_at, last_error, created_at, updated_at`
+	rows, err := r.tracker.Tx().QueryContext(ctx, stmt, job.StatusRunning, workerID, lockedUntil, now, job.StatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("job repo claim due: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error("job repo claim due", "err", err.Error())
+		}
+	}(rows)
+
+	var jobs []*job.Job
+	for rows.Next() {
+		var m Model
+
+		err := rows.Scan(
+			&m.ID,
+			&m.Kind,
+			&m.Key,
+			&m.Payload,
+			&m.Status,
+			&m.Attempts,
+			&m.MaxAttempts,
+			&m.IntervalSeconds,
+			&m.RunAt,
+			&m.LastError,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("job repo claim due: %w", err)
+		}
+
+		jobs = append(jobs, m.toDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("job repo claim due: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (r JobRepository) Save(ctx context.Context, workerID string, j *job.Job) error {
+	stmt := `UPDATE jobs
+			 SET status = $3, attempts = $4, run_at = $5, last_error = $6, updated_at = $7,
+				 locked_by = NULL, locked_until = NULL
+			 WHERE id = $1 AND locked_by = $2`
+	res, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		j.ID(),
+		workerID,
+		j.Status(),
+		j.Attempts(),
+		j.RunAt(),
+		nullString(j.LastError()),
+		j.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("job repo save: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("job repo save: %w", err)
+	}
+
+	// Блокировку сняли как истекшую, и задача вернулась в очередь или захвачена другим экземпляром.
+	if affected == 0 {
+		return job.ErrLeaseLost
+	}
+
+	return nil
+}
+
+func (r JobRepository) ReleaseExpired(ctx context.Context, now time.Time) (int64, error) {
+	stmt := `UPDATE jobs
+			 SET status = $1, locked_by = NULL, locked_until = NULL, updated_at = $2
+			 WHERE status = $3 AND locked_until < $2`
+	res, err := r.tracker.Tx().ExecContext(ctx, stmt, job.StatusPending, now, job.StatusRunning)
+	if err != nil {
+		return 0, fmt.Errorf("job repo release expired: %w", err)
+	}
+
+	released, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("job repo release expired: %w", err)
+	}
+
+	return released, nil
+}
@@ -0,0 +1,18 @@
+package jobrepo
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+)
+
+type Tracker interface {
+	Tx() *sqlx.Tx
+	DB() *sqlx.DB
+	InTx() bool
+	Begin(ctx context.Context) error
+	Commit(ctx context.Context) error
+	Logger() ports.Logger
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var _ ports.LeaderElector = &AdvisoryLockLeaderElector{}
+
+// AdvisoryLockLeaderElector выбирает ведущий экземпляр с помощью сессионной advisory-блокировки Postgres.
+// Блокировка удерживается выделенным соединением: при его потере Postgres сам снимает блокировку,
+// и лидерство переходит к другому экземпляру.
+type AdvisoryLockLeaderElector struct {
+	db      *sqlx.DB
+	lockKey int64
+	logger  ports.Logger
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+func NewAdvisoryLockLeaderElector(db *sqlx.DB, lockKey int64, logger ports.Logger) (*AdvisoryLockLeaderElector, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	return &AdvisoryLockLeaderElector{db: db, lockKey: lockKey, logger: logger}, nil
+}
+
+func (e *AdvisoryLockLeaderElector) TryAcquire(ctx context.Context) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		// Блокировка уже наша, достаточно убедиться, что соединение живо.
+		if err := e.conn.PingContext(ctx); err != nil {
+			e.dropConn()
+			return false, fmt.Errorf("leader elector ping: %w", err)
+		}
+
+		return true, nil
+	}
+
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("leader elector conn: %w", err)
+	}
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, e.lockKey).Scan(&acquired)
+	if err != nil {
+		e.discardConn(conn)
+		return false, fmt.Errorf("leader elector try lock: %w", err)
+	}
+
+	if !acquired {
+		if err := conn.Close(); err != nil {
+			e.logger.Error("leader elector close conn", "err", err)
+		}
+
+		return false, nil
+	}
+
+	e.conn = conn
+
+	return true, nil
+}
+
+func (e *AdvisoryLockLeaderElector) Release(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+
+	_, err := e.conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, e.lockKey)
+	e.dropConn()
+
+	if err != nil {
+		return fmt.Errorf("leader elector unlock: %w", err)
+	}
+
+	return nil
+}
+
+// Close освобождает соединение, удерживающее блокировку.
+func (e *AdvisoryLockLeaderElector) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dropConn()
+
+	return nil
+}
+
+func (e *AdvisoryLockLeaderElector) dropConn() {
+	if e.conn == nil {
+		return
+	}
+
+	e.discardConn(e.conn)
+	e.conn = nil
+}
+
+func (e *AdvisoryLockLeaderElector) discardConn(conn *sql.Conn) {
+	// Соединение с сессионной блокировкой нельзя возвращать в пул:
+	// Raw + driver.ErrBadConn заставляет database/sql закрыть его физически.
+	err := conn.Raw(func(any) error { return driver.ErrBadConn })
+	if err != nil && !errors.Is(err, driver.ErrBadConn) {
+		e.logger.Error("leader elector close conn", "err", err)
+	}
+
+	_ = conn.Close()
+}
@@ -0,0 +1,43 @@
+package outboxrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type Model struct {
+	ID            uuid.UUID
+	Name          string
+	Payload       []byte
+	Status        outbox.Status
+	Attempts      int
+	MaxAttempts   int
+	NextAttemptAt time.Time
+	LastError     sql.NullString
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (m Model) toDomain() *outbox.Message {
+	return outbox.Restore(
+		shared.RestoreID(m.ID),
+		m.Name,
+		m.Payload,
+		m.Status,
+		m.Attempts,
+		m.MaxAttempts,
+		m.NextAttemptAt,
+		m.LastError.String,
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
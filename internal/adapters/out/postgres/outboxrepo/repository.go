@@ -0,0 +1,159 @@
+package outboxrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type OutboxRepository struct {
+	tracker Tracker
+}
+
+func NewOutboxRepository(tracker Tracker) (ports.OutboxRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &OutboxRepository{tracker: tracker}, nil
+}
+
+func (r OutboxRepository) Add(ctx context.Context, m *outbox.Message) error {
+	stmt := `INSERT INTO outbox (id, name, payload, status, attempts, max_attempts, next_attempt_at, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		m.ID(),
+		m.Name(),
+		m.Payload(),
+		m.Status(),
+		m.Attempts(),
+		m.MaxAttempts(),
+		m.NextAttemptAt(),
+		m.CreatedAt(),
+		m.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("outbox repo add: %w", err)
+	}
+
+	return nil
+}
+
+func (r OutboxRepository) ClaimDue(
+	ctx context.Context,
+	claim shared.ID,
+	now time.Time,
+	lockedUntil time.Time,
+	limit int,
+) ([]*outbox.Message, error) {
+	stmt := `WITH claimed AS (
+				 UPDATE outbox
+				 SET attempts = attempts + 1, next_attempt_at = $1, updated_at = $2, claim_token = $5
+				 WHERE id IN (
+					 SELECT id FROM outbox
+					 WHERE status = $3 AND next_attempt_at <= $2
+					 ORDER BY created_at, id
+					 LIMIT $4
+					 FOR UPDATE SKIP LOCKED
+				 )
+				 RETURNING id, name, payload, status, attempts, max_attempts, next_attempt_at, last_error, created_at, updated_at
+			 )
+			 SELECT * FROM claimed
+			 ORDER BY created_at, id`
+	rows, err := r.tracker.Tx().QueryContext(ctx, stmt, lockedUntil, now, outbox.StatusPending, limit, claim)
+	if err != nil {
+		return nil, fmt.Errorf("outbox repo claim due: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error("outbox repo claim due", "err", err.Error())
+		}
+	}(rows)
+
+	var messages []*outbox.Message
+	for rows.Next() {
+		var m Model
+
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&m.Payload,
+			&m.Status,
+			&m.Attempts,
+			&m.MaxAttempts,
+			&m.NextAttemptAt,
+			&m.LastError,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("outbox repo claim due: %w", err)
+		}
+
+		messages = append(messages, m.toDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("outbox repo claim due: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r OutboxRepository) Save(ctx context.Context, claim shared.ID, m *outbox.Message) error {
+	stmt := `UPDATE outbox
+			 SET status = $3, attempts = $4, next_attempt_at = $5, last_error = $6, updated_at = $7,
+				 claim_token = NULL
+			 WHERE id = $1 AND claim_token = $2`
+	res, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		m.ID(),
+		claim,
+		m.Status(),
+		m.Attempts(),
+		m.NextAttemptAt(),
+		nullString(m.LastError()),
+		m.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("outbox repo save: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("outbox repo save: %w", err)
+	}
+
+	// Захват истек: сообщение захвачено другим экземпляром или его результат уже сохранен.
+	if affected == 0 {
+		return outbox.ErrLeaseLost
+	}
+
+	return nil
+}
+
+func (r OutboxRepository) DeleteFinished(ctx context.Context, status outbox.Status, before time.Time) (int64, error) {
+	stmt := `DELETE FROM outbox WHERE status = $1 AND updated_at < $2`
+	res, err := r.tracker.Tx().ExecContext(ctx, stmt, status, before)
+	if err != nil {
+		return 0, fmt.Errorf("outbox repo delete finished: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("outbox repo delete finished: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,55 @@
+package refundrepo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+)
+
+type Model struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	TransactionID uuid.UUID
+	CategoryID    uuid.UUID
+	Amount        decimal.Decimal
+	Note          string
+	OccurredAt    time.Time
+	CreatedAt     time.Time
+}
+
+func (m Model) toDomain() (*refund.Refund, error) {
+	amount, err := transaction.NewAmount(m.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return refund.Restore(
+		shared.RestoreID(m.ID),
+		shared.RestoreID(m.UserID),
+		shared.RestoreID(m.TransactionID),
+		shared.RestoreID(m.CategoryID),
+		amount,
+		m.Note,
+		m.OccurredAt,
+		m.CreatedAt,
+	), nil
+}
+
+// scanFields возвращает указатели на поля модели в порядке selectColumns.
+func (m *Model) scanFields() []any {
+	return []any{
+		&m.ID,
+		&m.UserID,
+		&m.TransactionID,
+		&m.CategoryID,
+		&m.Amount,
+		&m.Note,
+		&m.OccurredAt,
+		&m.CreatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package refundrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const selectColumns = `id, user_id, transaction_id, category_id, amount, note, occurred_at, created_at`
+
+type RefundRepository struct {
+	tracker Tracker
+}
+
+func NewRefundRepository(tracker Tracker) (ports.RefundRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &RefundRepository{tracker: tracker}, nil
+}
+
+func (r RefundRepository) Add(ctx context.Context, rf *refund.Refund) error {
+	r.tracker.Track(rf)
+
+	stmt := `INSERT INTO refunds (id, user_id, transaction_id, category_id, amount, note, occurred_at, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		rf.ID(),
+		rf.UserID(),
+		rf.TransactionID(),
+		rf.CategoryID(),
+		rf.Amount().Value(),
+		rf.Note(),
+		rf.OccurredAt(),
+		rf.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("refund repo add: %w", err)
+	}
+
+	return nil
+}
+
+func (r RefundRepository) FindByTransactionID(ctx context.Context, transactionID shared.ID) ([]*refund.Refund, error) {
+	var q sqlx.QueryerContext = r.tracker.DB()
+	if r.tracker.InTx() {
+		q = r.tracker.Tx()
+	}
+
+	stmt := `SELECT ` + selectColumns + `
+				FROM refunds
+				WHERE transaction_id = $1
+				ORDER BY occurred_at, id`
+
+	return r.find(ctx, q, "refund repo find by transaction id", stmt, transactionID)
+}
+
+func (r RefundRepository) FindByUserID(ctx context.Context, userID shared.ID) ([]*refund.Refund, error) {
+	stmt := `SELECT ` + selectColumns + `
+				FROM refunds
+				WHERE user_id = $1
+				ORDER BY occurred_at, id`
+
+	return r.find(ctx, r.tracker.DB(), "refund repo find by user id", stmt, userID)
+}
+
+func (r RefundRepository) find(ctx context.Context, q sqlx.QueryerContext, op string, stmt string, args ...any) ([]*refund.Refund, error) {
+	rows, err := q.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error(op, "err", err.Error())
+		}
+	}(rows)
+
+	var result []*refund.Refund
+	for rows.Next() {
+		var m Model
+
+		if err := rows.Scan(m.scanFields()...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		rf, err := m.toDomain()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, rf)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package settingsrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type Model struct {
+	UserID             uuid.UUID
+	Timezone           string
+	DigestMode         settings.DigestMode
+	DigestHour         int
+	DigestSentAt       sql.NullTime
+	ReminderAfterHours int
+	QuietFrom          int
+	QuietTo            int
+	RemindedAt         sql.NullTime
+	UpdatedAt          time.Time
+}
+
+func (m Model) toDomain() *settings.Settings {
+	return settings.Restore(
+		shared.RestoreID(m.UserID),
+		m.Timezone,
+		m.DigestMode,
+		m.DigestHour,
+		m.DigestSentAt.Time,
+		m.ReminderAfterHours,
+		m.QuietFrom,
+		m.QuietTo,
+		m.RemindedAt.Time,
+		m.UpdatedAt,
+	)
+}
+
+// scanFields возвращает указатели на поля модели в порядке selectColumns.
+func (m *Model) scanFields() []any {
+	return []any{
+		&m.UserID,
+		&m.Timezone,
+		&m.DigestMode,
+		&m.DigestHour,
+		&m.DigestSentAt,
+		&m.ReminderAfterHours,
+		&m.QuietFrom,
+		&m.QuietTo,
+		&m.RemindedAt,
+		&m.UpdatedAt,
+	}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
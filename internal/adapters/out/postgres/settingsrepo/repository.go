@@ -0,0 +1,144 @@
+package settingsrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const selectColumns = `user_id, timezone, digest_mode, digest_hour, digest_sent_at,
+	reminder_after_hours, quiet_from, quiet_to, reminded_at, updated_at`
+
+type SettingsRepository struct {
+	tracker Tracker
+}
+
+func NewSettingsRepository(tracker Tracker) (ports.SettingsRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &SettingsRepository{tracker: tracker}, nil
+}
+
+// Get возвращает настройки пользователя. Внутри транзакции строка блокируется до её завершения,
+// чтобы чтение, изменение и сохранение настроек не перезаписали параллельное изменение.
+func (r SettingsRepository) Get(ctx context.Context, userID shared.ID) (*settings.Settings, error) {
+	stmt := `SELECT ` + selectColumns + ` FROM user_settings WHERE user_id = $1`
+
+	var q sqlx.QueryerContext = r.tracker.DB()
+	if r.tracker.InTx() {
+		q = r.tracker.Tx()
+		stmt += ` FOR UPDATE`
+	}
+
+	row := q.QueryRowxContext(ctx, stmt, userID)
+
+	var m Model
+	err := row.Scan(m.scanFields()...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return settings.New(userID)
+		}
+
+		return nil, fmt.Errorf("settings repo get: %w", err)
+	}
+
+	return m.toDomain(), nil
+}
+
+func (r SettingsRepository) Save(ctx context.Context, s *settings.Settings) error {
+	stmt := `INSERT INTO user_settings (user_id, timezone, digest_mode, digest_hour, digest_sent_at,
+									   reminder_after_hours, quiet_from, quiet_to, reminded_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			 ON CONFLICT (user_id) DO UPDATE
+			 SET timezone = EXCLUDED.timezone,
+				 digest_mode = EXCLUDED.digest_mode,
+				 digest_hour = EXCLUDED.digest_hour,
+				 digest_sent_at = EXCLUDED.digest_sent_at,
+				 reminder_after_hours = EXCLUDED.reminder_after_hours,
+				 quiet_from = EXCLUDED.quiet_from,
+				 quiet_to = EXCLUDED.quiet_to,
+				 reminded_at = EXCLUDED.reminded_at,
+				 updated_at = EXCLUDED.updated_at`
+	_, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		s.UserID(),
+		s.Timezone(),
+		s.DigestMode(),
+		s.DigestHour(),
+		nullTime(s.DigestSentAt()),
+		s.ReminderAfterHours(),
+		s.QuietFrom(),
+		s.QuietTo(),
+		nullTime(s.RemindedAt()),
+		s.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("settings repo save: %w", err)
+	}
+
+	return nil
+}
+
+func (r SettingsRepository) FindDigestSubscribers(ctx context.Context) ([]*settings.Settings, error) {
+	stmt := `SELECT ` + selectColumns + ` FROM user_settings WHERE digest_mode != $1`
+
+	result, err := r.find(ctx, stmt, settings.DigestModeOff)
+	if err != nil {
+		return nil, fmt.Errorf("settings repo find digest subscribers: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r SettingsRepository) FindReminderSubscribers(ctx context.Context) ([]*settings.Settings, error) {
+	stmt := `SELECT ` + selectColumns + ` FROM user_settings WHERE reminder_after_hours > 0`
+
+	result, err := r.find(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("settings repo find reminder subscribers: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r SettingsRepository) find(ctx context.Context, stmt string, args ...any) ([]*settings.Settings, error) {
+	rows, err := r.tracker.DB().QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error("settings repo find", "err", err.Error())
+		}
+	}(rows)
+
+	var result []*settings.Settings
+	for rows.Next() {
+		var m Model
+
+		if err := rows.Scan(m.scanFields()...); err != nil {
+			return nil, err
+		}
+
+		result = append(result, m.toDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,26 @@
+package tagrepo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+)
+
+type Model struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+func (m Model) toDomain() *tag.Tag {
+	return tag.Restore(shared.RestoreID(m.ID), shared.RestoreID(m.UserID), m.Name, m.CreatedAt)
+}
+
+// scanFields возвращает указатели на поля модели в порядке selectColumns.
+func (m *Model) scanFields() []any {
+	return []any{&m.ID, &m.UserID, &m.Name, &m.CreatedAt}
+}
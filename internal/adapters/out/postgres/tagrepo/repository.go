@@ -0,0 +1,183 @@
+package tagrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const selectColumns = `tg.id, tg.user_id, tg.name, tg.created_at`
+
+type TagRepository struct {
+	tracker Tracker
+}
+
+func NewTagRepository(tracker Tracker) (ports.TagRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &TagRepository{tracker: tracker}, nil
+}
+
+func (r TagRepository) Ensure(ctx context.Context, userID shared.ID, tags []*tag.Tag) ([]*tag.Tag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(tags))
+
+	for _, t := range tags {
+		stmt := `INSERT INTO tags (id, user_id, name, created_at)
+				 VALUES ($1, $2, $3, $4)
+				 ON CONFLICT (user_id, name) DO NOTHING`
+		if _, err := r.tracker.Tx().ExecContext(ctx, stmt, t.ID(), userID, t.Name(), t.CreatedAt()); err != nil {
+			return nil, fmt.Errorf("tag repo ensure: %w", err)
+		}
+
+		names = append(names, t.Name())
+	}
+
+	stmt := `SELECT ` + selectColumns + `
+				FROM tags tg
+				WHERE tg.user_id = $1 AND tg.name = ANY($2)
+				ORDER BY tg.name`
+
+	return r.find(ctx, r.tracker.Tx(), "tag repo ensure", stmt, userID, names)
+}
+
+func (r TagRepository) Link(ctx context.Context, transactionID shared.ID, tagIDs []shared.ID) error {
+	for _, id := range tagIDs {
+		stmt := `INSERT INTO transaction_tags (transaction_id, tag_id)
+				 VALUES ($1, $2)
+				 ON CONFLICT DO NOTHING`
+		if _, err := r.tracker.Tx().ExecContext(ctx, stmt, transactionID, id); err != nil {
+			return fmt.Errorf("tag repo link: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r TagRepository) FindByUserID(ctx context.Context, userID shared.ID) ([]*tag.Tag, error) {
+	stmt := `SELECT ` + selectColumns + `
+				FROM tags tg
+				WHERE tg.user_id = $1
+				ORDER BY tg.name`
+
+	return r.find(ctx, r.tracker.DB(), "tag repo find by user id", stmt, userID)
+}
+
+func (r TagRepository) FindByTransactionID(ctx context.Context, transactionID shared.ID) ([]*tag.Tag, error) {
+	var q sqlx.QueryerContext = r.tracker.DB()
+	if r.tracker.InTx() {
+		q = r.tracker.Tx()
+	}
+
+	stmt := `SELECT ` + selectColumns + `
+				FROM tags tg
+				INNER JOIN transaction_tags tt ON tt.tag_id = tg.id
+				WHERE tt.transaction_id = $1
+				ORDER BY tg.name`
+
+	return r.find(ctx, q, "tag repo find by transaction id", stmt, transactionID)
+}
+
+func (r TagRepository) FindLinksByUserID(ctx context.Context, userID shared.ID) ([]tag.Link, error) {
+	stmt := `SELECT tt.transaction_id, tt.tag_id
+				FROM transaction_tags tt
+				INNER JOIN tags tg ON tg.id = tt.tag_id
+				WHERE tg.user_id = $1
+				ORDER BY tt.transaction_id, tg.name`
+	rows, err := r.tracker.DB().QueryContext(ctx, stmt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("tag repo find links by user id: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error("tag repo find links by user id", "err", err.Error())
+		}
+	}(rows)
+
+	var result []tag.Link
+	for rows.Next() {
+		var transactionID, tagID uuid.UUID
+		if err := rows.Scan(&transactionID, &tagID); err != nil {
+			return nil, fmt.Errorf("tag repo find links by user id: %w", err)
+		}
+
+		link, err := tag.NewLink(shared.RestoreID(transactionID), shared.RestoreID(tagID))
+		if err != nil {
+			return nil, fmt.Errorf("tag repo find links by user id: %w", err)
+		}
+
+		result = append(result, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("tag repo find links by user id: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r TagRepository) GetByName(ctx context.Context, userID shared.ID, name string) (*tag.Tag, error) {
+	stmt := `SELECT ` + selectColumns + `
+				FROM tags tg
+				WHERE tg.user_id = $1 AND tg.name = $2`
+
+	var m Model
+
+	err := r.tracker.DB().QueryRowContext(ctx, stmt, userID, name).Scan(m.scanFields()...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewObjectNotFoundError("tag", name)
+		}
+
+		return nil, fmt.Errorf("tag repo get by name: %w", err)
+	}
+
+	return m.toDomain(), nil
+}
+
+func (r TagRepository) find(ctx context.Context, q sqlx.QueryerContext, op string, stmt string, args ...any) ([]*tag.Tag, error) {
+	rows, err := q.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			r.tracker.Logger().Error(op, "err", err.Error())
+		}
+	}(rows)
+
+	var result []*tag.Tag
+	for rows.Next() {
+		var m Model
+
+		if err := rows.Scan(m.scanFields()...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, m.toDomain())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
@@ -2,14 +2,46 @@ package transactionrepo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
 )
 
+const (
+	// uniqueViolation код ошибки Postgres при нарушении уникального индекса.
+	uniqueViolation = "23505"
+
+	receiptIndex     = "ux_transactions_user_receipt"
+	fingerprintIndex = "ux_transactions_user_fingerprint"
+)
+
+const selectColumns = `id, user_id, amount, category_id, note, occurred_at, COALESCE(fingerprint, ''),
+	COALESCE(fiscal_drive, ''), COALESCE(fiscal_document, ''), COALESCE(fiscal_sign, ''), created_at`
+
+// lineColumns столбцы строки транзакции с данными категории для scanLine.
+// Таблицы выбираются под псевдонимами t, c и p для родительской категории.
+const lineColumns = `t.id, t.occurred_at, t.amount, c.type, c.name, COALESCE(p.name, ''), t.note`
+
+// partLineColumns столбцы строки части транзакции из представления transaction_parts для scanLine.
+// Разделенная транзакция дает строку на каждую часть со своей категорией и суммой,
+// возврат — отдельную строку с отрицательной суммой в категории исходной транзакции.
+const partLineColumns = `t.line_id, t.occurred_at, t.amount, c.type, c.name, COALESCE(p.name, ''), t.note`
+
 type TransactionRepository struct {
 	tracker Tracker
 }
@@ -23,24 +55,733 @@ func NewTransactionRepository(tracker Tracker) (ports.TransactionRepository, err
 }
 
 func (t TransactionRepository) Add(ctx context.Context, tr *transaction.Transaction) error {
-	stmt := `INSERT INTO transactions (id, amount, category_id, created_at, user_id)
-			 VALUES ($1, $2, $3, $4, $5)`
-	_, err := t.tracker.Tx().ExecContext(ctx, stmt, tr.ID(), tr.Amount(), tr.CategoryID(), tr.CreatedAt(), tr.UserID())
+	t.tracker.Track(tr)
+
+	stmt := `INSERT INTO transactions (id, amount, category_id, note, occurred_at, fingerprint,
+										fiscal_drive, fiscal_document, fiscal_sign, created_at, user_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := t.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		tr.ID(),
+		tr.Amount(),
+		tr.CategoryID(),
+		tr.Note(),
+		tr.OccurredAt(),
+		nullString(tr.Fingerprint()),
+		nullString(tr.FiscalID().Drive()),
+		nullString(tr.FiscalID().Document()),
+		nullString(tr.FiscalID().Sign()),
+		tr.CreatedAt(),
+		tr.UserID(),
+	)
 	if err != nil {
+		return fmt.Errorf("transaction repo add: %w", duplicateError(err))
+	}
+
+	if err := t.addSplits(ctx, tr); err != nil {
 		return fmt.Errorf("transaction repo add: %w", err)
 	}
 
 	return nil
 }
 
+// duplicateError заменяет нарушение уникальности чека или отпечатка доменной ошибкой. Проверки
+// в сценариях выполняются до вставки, и параллельная запись того же чека или операции выписки
+// доходит до индекса.
+func duplicateError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case receiptIndex:
+		return fmt.Errorf("%w: %w", receipt.ErrAlreadyRecorded, err)
+	case fingerprintIndex:
+		return fmt.Errorf("%w: %w", statement.ErrAlreadyImported, err)
+	}
+
+	return err
+}
+
+// addSplits сохраняет части разделенной транзакции в порядке ввода.
+func (t TransactionRepository) addSplits(ctx context.Context, tr *transaction.Transaction) error {
+	stmt := `INSERT INTO transaction_splits (transaction_id, position, category_id, amount) VALUES ($1, $2, $3, $4)`
+
+	for i, part := range tr.Splits() {
+		if _, err := t.tracker.Tx().ExecContext(ctx, stmt, tr.ID(), i, part.CategoryID(), part.Amount().Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (t TransactionRepository) Get(ctx context.Context, id shared.ID) (*transaction.Transaction, error) {
-	panic("implement me")
+	var q sqlx.QueryerContext = t.tracker.DB()
+	if t.tracker.InTx() {
+		q = t.tracker.Tx()
+
+		// Строка блокируется до чтения частей, чтобы части и возвраты читались уже
+		// под блокировкой: параллельные возвраты и изменения одной транзакции идут по очереди.
+		if _, err := t.tracker.Tx().ExecContext(ctx, `SELECT 1 FROM transactions WHERE id = $1 FOR UPDATE`, id); err != nil {
+			return nil, fmt.Errorf("transaction repo get: %w", err)
+		}
+	}
+
+	splits, err := t.findSplits(ctx, q, `s.transaction_id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo get: %w", err)
+	}
+
+	stmt := `SELECT ` + selectColumns + ` FROM transactions WHERE id = $1`
+
+	tr, err := scanTransaction(q.QueryRowxContext(ctx, stmt, id), splits)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewObjectNotFoundError("transaction", id.String())
+		}
+
+		return nil, fmt.Errorf("transaction repo get: %w", err)
+	}
+
+	return tr, nil
 }
 
-func (t TransactionRepository) Update(ctx context.Context, transaction *transaction.Transaction) error {
-	panic("implement me")
+func (t TransactionRepository) GetLine(ctx context.Context, userID shared.ID, id shared.ID) (report.TransactionLine, error) {
+	stmt := `SELECT ` + lineColumns + `
+				FROM transactions t
+				INNER JOIN categories c ON c.id = t.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				WHERE t.user_id = $1 AND t.id = $2`
+
+	line, err := scanLine(t.tracker.DB().QueryRowContext(ctx, stmt, userID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return report.TransactionLine{}, errs.NewObjectNotFoundError("transaction", id.String())
+		}
+
+		return report.TransactionLine{}, fmt.Errorf("transaction repo get line: %w", err)
+	}
+
+	return line, nil
+}
+
+func (t TransactionRepository) Update(ctx context.Context, tr *transaction.Transaction) error {
+	stmt := `UPDATE transactions
+			 SET amount = $2, category_id = $3, note = $4, occurred_at = $5
+			 WHERE id = $1`
+	res, err := t.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		tr.ID(),
+		tr.Amount().Value(),
+		tr.CategoryID(),
+		tr.Note(),
+		tr.OccurredAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("transaction repo update: %w", err)
+	}
+
+	if err := requireAffected(res, tr.ID()); err != nil {
+		return err
+	}
+
+	_, err = t.tracker.Tx().ExecContext(ctx, `DELETE FROM transaction_splits WHERE transaction_id = $1`, tr.ID())
+	if err != nil {
+		return fmt.Errorf("transaction repo update: %w", err)
+	}
+
+	if err := t.addSplits(ctx, tr); err != nil {
+		return fmt.Errorf("transaction repo update: %w", err)
+	}
+
+	return nil
+}
+
+func (t TransactionRepository) FindParts(ctx context.Context, transactionID shared.ID) ([]report.CategoryTotal, error) {
+	stmt := `SELECT c.id, c.name, COALESCE(p.name, ''), c.type, s.amount
+				FROM transaction_splits s
+				INNER JOIN categories c ON c.id = s.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				WHERE s.transaction_id = $1
+				ORDER BY s.position`
+
+	return t.getTotals(ctx, "transaction repo find parts", stmt, transactionID)
 }
 
 func (t TransactionRepository) Delete(ctx context.Context, id shared.ID) error {
-	panic("implement me")
+	res, err := t.tracker.Tx().ExecContext(ctx, `DELETE FROM transactions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("transaction repo delete: %w", err)
+	}
+
+	return requireAffected(res, id)
+}
+
+func (t TransactionRepository) FindHistory(
+	ctx context.Context,
+	userID shared.ID,
+	filter report.HistoryFilter,
+	after report.HistoryCursor,
+	limit int,
+) ([]report.TransactionLine, error) {
+	stmt := `SELECT ` + lineColumns + `
+				FROM transactions t
+				INNER JOIN categories c ON c.id = t.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				WHERE t.user_id = $1
+				  AND ($2::uuid IS NULL OR EXISTS (
+					  SELECT 1 FROM transaction_parts tp
+					  INNER JOIN categories pc ON pc.id = tp.category_id
+					  WHERE tp.transaction_id = t.id AND (pc.id = $2 OR pc.parent_category_id = $2)))
+				  AND ($3::text IS NULL OR c.type = $3)
+				  AND ($4::numeric IS NULL OR t.amount >= $4)
+				  AND ($5::numeric IS NULL OR t.amount <= $5)
+				  AND ($6::timestamptz IS NULL OR t.occurred_at >= $6)
+				  AND ($7::timestamptz IS NULL OR t.occurred_at < $7)
+				  AND ($8::timestamptz IS NULL OR (t.occurred_at, t.id) < ($8, $9::uuid))
+				  AND ($11::uuid IS NULL OR EXISTS (
+					  SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag_id = $11))
+				ORDER BY t.occurred_at DESC, t.id DESC
+				LIMIT $10`
+	rows, err := t.tracker.DB().QueryContext(
+		ctx,
+		stmt,
+		userID,
+		nullUUID(filter.CategoryID()),
+		nullString(filter.CategoryType().String()),
+		nullDecimal(filter.MinAmount()),
+		nullDecimal(filter.MaxAmount()),
+		nullTime(filter.From()),
+		nullTime(filter.To()),
+		nullTime(after.OccurredAt()),
+		nullUUID(after.ID()),
+		limit,
+		nullUUID(filter.TagID()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo find history: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error("transaction repo find history", "err", err.Error())
+		}
+	}(rows)
+
+	lines := make([]report.TransactionLine, 0, limit)
+	for rows.Next() {
+		line, err := scanLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("transaction repo find history: %w", err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("transaction repo find history: %w", err)
+	}
+
+	return lines, nil
+}
+
+// searchFrom источник строк полнотекстового поиска: $1 — пользователь, $2 — запрос.
+// Совпадением считается запрос в комментарии, в названии категории или родительской категории.
+const searchFrom = `FROM transactions t
+				INNER JOIN categories c ON c.id = t.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				CROSS JOIN websearch_to_tsquery('russian', $2) q
+				WHERE t.user_id = $1
+				  AND (t.note_tsv @@ q OR c.name_tsv @@ q OR p.name_tsv @@ q)`
+
+func (t TransactionRepository) Search(ctx context.Context, userID shared.ID, text string, limit int) (*report.SearchResult, error) {
+	var (
+		count        int
+		totalExpense decimal.Decimal
+		totalIncome  decimal.Decimal
+	)
+
+	// Итоги считаются по частям найденных транзакций, как в отчетах: части разделенной транзакции
+	// учитываются по типам своих категорий, а возвраты уменьшают расход.
+	stmt := `WITH matched AS (SELECT t.id ` + searchFrom + `)
+				SELECT (SELECT COUNT(*) FROM matched),
+					   COALESCE(SUM(tp.amount) FILTER (WHERE pc.type = $3), 0),
+					   COALESCE(SUM(tp.amount) FILTER (WHERE pc.type = $4), 0)
+				FROM transaction_parts tp
+				INNER JOIN categories pc ON pc.id = tp.category_id
+				WHERE tp.user_id = $1 AND tp.transaction_id IN (SELECT id FROM matched)`
+	err := t.tracker.DB().QueryRowContext(ctx, stmt, userID, text, category.TypeExpense, category.TypeIncome).
+		Scan(&count, &totalExpense, &totalIncome)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo search: %w", err)
+	}
+
+	if count == 0 {
+		return report.NewSearchResult(nil, 0, totalExpense, totalIncome), nil
+	}
+
+	stmt = `SELECT ` + lineColumns + `
+				` + searchFrom + `
+				ORDER BY t.occurred_at DESC, t.id DESC
+				LIMIT $3`
+	rows, err := t.tracker.DB().QueryContext(ctx, stmt, userID, text, limit)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo search: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error("transaction repo search", "err", err.Error())
+		}
+	}(rows)
+
+	lines := make([]report.TransactionLine, 0, min(count, limit))
+	for rows.Next() {
+		line, err := scanLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("transaction repo search: %w", err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("transaction repo search: %w", err)
+	}
+
+	return report.NewSearchResult(lines, count, totalExpense, totalIncome), nil
+}
+
+func (t TransactionRepository) GetTotalsByCategory(
+	ctx context.Context,
+	userID shared.ID,
+	from time.Time,
+	to time.Time,
+) ([]report.CategoryTotal, error) {
+	stmt := `SELECT c.id, c.name, COALESCE(p.name, ''), c.type, SUM(t.amount)
+				FROM transaction_parts t
+				INNER JOIN categories c ON c.id = t.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				WHERE t.user_id = $1 AND t.occurred_at >= $2 AND t.occurred_at < $3
+				GROUP BY c.id, c.name, p.name, c.type`
+
+	return t.getTotals(ctx, "transaction repo get totals by category", stmt, userID, from, to)
+}
+
+func (t TransactionRepository) GetTotalsByTag(ctx context.Context, userID shared.ID, tagID shared.ID) ([]report.CategoryTotal, error) {
+	stmt := `SELECT c.id, c.name, COALESCE(p.name, ''), c.type, SUM(t.amount)
+				FROM transaction_parts t
+				INNER JOIN transaction_tags tt ON tt.transaction_id = t.transaction_id
+				INNER JOIN categories c ON c.id = t.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				WHERE t.user_id = $1 AND tt.tag_id = $2
+				GROUP BY c.id, c.name, p.name, c.type`
+
+	return t.getTotals(ctx, "transaction repo get totals by tag", stmt, userID, tagID)
+}
+
+// getTotals читает суммы по категориям, которые возвращает запрос stmt.
+func (t TransactionRepository) getTotals(ctx context.Context, op string, stmt string, args ...any) ([]report.CategoryTotal, error) {
+	rows, err := t.tracker.DB().QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error(op, "err", err.Error())
+		}
+	}(rows)
+
+	var totals []report.CategoryTotal
+	for rows.Next() {
+		var (
+			categoryID   uuid.UUID
+			name         string
+			parentName   string
+			categoryType category.Type
+			amount       decimal.Decimal
+		)
+
+		if err := rows.Scan(&categoryID, &name, &parentName, &categoryType, &amount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		totals = append(totals, report.NewCategoryTotal(shared.RestoreID(categoryID), name, parentName, categoryType, amount))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return totals, nil
+}
+
+func (t TransactionRepository) GetLastCreatedAt(ctx context.Context, userID shared.ID) (time.Time, error) {
+	stmt := `SELECT MAX(created_at) FROM transactions WHERE user_id = $1`
+
+	var q sqlx.QueryerContext = t.tracker.DB()
+	if t.tracker.InTx() {
+		q = t.tracker.Tx()
+	}
+
+	var lastCreatedAt sql.NullTime
+	if err := q.QueryRowxContext(ctx, stmt, userID).Scan(&lastCreatedAt); err != nil {
+		return time.Time{}, fmt.Errorf("transaction repo get last created at: %w", err)
+	}
+
+	return lastCreatedAt.Time, nil
+}
+
+func (t TransactionRepository) StreamLines(
+	ctx context.Context,
+	userID shared.ID,
+	from time.Time,
+	to time.Time,
+	tagID shared.ID,
+	fn func(line report.TransactionLine) error,
+) error {
+	stmt := `SELECT ` + partLineColumns + `
+				FROM transaction_parts t
+				INNER JOIN categories c ON c.id = t.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				WHERE t.user_id = $1
+				  AND ($2::timestamptz IS NULL OR t.occurred_at >= $2)
+				  AND ($3::timestamptz IS NULL OR t.occurred_at < $3)
+				  AND ($4::uuid IS NULL OR EXISTS (
+					  SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.transaction_id AND tt.tag_id = $4))
+				ORDER BY t.occurred_at, t.line_id, t.position`
+	rows, err := t.tracker.DB().QueryContext(ctx, stmt, userID, nullTime(from), nullTime(to), nullUUID(tagID))
+	if err != nil {
+		return fmt.Errorf("transaction repo stream lines: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error("transaction repo stream lines", "err", err.Error())
+		}
+	}(rows)
+
+	for rows.Next() {
+		line, err := scanLine(rows)
+		if err != nil {
+			return fmt.Errorf("transaction repo stream lines: %w", err)
+		}
+
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("transaction repo stream lines: %w", err)
+	}
+
+	return nil
+}
+
+func (t TransactionRepository) FindFingerprints(ctx context.Context, userID shared.ID, fingerprints []string) ([]string, error) {
+	if len(fingerprints) == 0 {
+		return nil, nil
+	}
+
+	var q sqlx.QueryerContext = t.tracker.DB()
+	if t.tracker.InTx() {
+		q = t.tracker.Tx()
+	}
+
+	stmt := `SELECT fingerprint FROM transactions WHERE user_id = $1 AND fingerprint = ANY($2)`
+	rows, err := q.QueryContext(ctx, stmt, userID, fingerprints)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo find fingerprints: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error("transaction repo find fingerprints", "err", err.Error())
+		}
+	}(rows)
+
+	var found []string
+	for rows.Next() {
+		var fingerprint string
+
+		if err := rows.Scan(&fingerprint); err != nil {
+			return nil, fmt.Errorf("transaction repo find fingerprints: %w", err)
+		}
+
+		found = append(found, fingerprint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("transaction repo find fingerprints: %w", err)
+	}
+
+	return found, nil
+}
+
+func (t TransactionRepository) HasFiscalID(ctx context.Context, userID shared.ID, fiscalID receipt.FiscalID) (bool, error) {
+	var q sqlx.QueryerContext = t.tracker.DB()
+	if t.tracker.InTx() {
+		q = t.tracker.Tx()
+	}
+
+	stmt := `SELECT EXISTS(SELECT 1 FROM transactions
+						   WHERE user_id = $1 AND fiscal_drive = $2 AND fiscal_document = $3 AND fiscal_sign = $4)`
+
+	var exists bool
+	err := q.QueryRowxContext(ctx, stmt, userID, fiscalID.Drive(), fiscalID.Document(), fiscalID.Sign()).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("transaction repo has fiscal id: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (t TransactionRepository) FindLinesByFiscalDrive(
+	ctx context.Context,
+	userID shared.ID,
+	fiscalDrive string,
+	limit int,
+) ([]report.TransactionLine, error) {
+	// Условие на fiscal_sign позволяет использовать частичный индекс ux_transactions_user_receipt.
+	stmt := `SELECT ` + lineColumns + `
+				FROM transactions t
+				INNER JOIN categories c ON c.id = t.category_id
+				LEFT JOIN categories p ON p.id = c.parent_category_id
+				WHERE t.user_id = $1 AND t.fiscal_drive = $2 AND t.fiscal_sign IS NOT NULL
+				ORDER BY t.occurred_at DESC, t.id DESC
+				LIMIT $3`
+	rows, err := t.tracker.DB().QueryContext(ctx, stmt, userID, fiscalDrive, limit)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo find lines by fiscal drive: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error("transaction repo find lines by fiscal drive", "err", err.Error())
+		}
+	}(rows)
+
+	var lines []report.TransactionLine
+	for rows.Next() {
+		line, err := scanLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("transaction repo find lines by fiscal drive: %w", err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("transaction repo find lines by fiscal drive: %w", err)
+	}
+
+	return lines, nil
+}
+
+func (t TransactionRepository) FindByUserID(ctx context.Context, userID shared.ID) ([]*transaction.Transaction, error) {
+	splits, err := t.findSplits(
+		ctx,
+		t.tracker.DB(),
+		`s.transaction_id IN (SELECT id FROM transactions WHERE user_id = $1)`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo find by user id: %w", err)
+	}
+
+	stmt := `SELECT ` + selectColumns + `
+				FROM transactions
+				WHERE user_id = $1
+				ORDER BY occurred_at, id`
+	rows, err := t.tracker.DB().QueryContext(ctx, stmt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("transaction repo find by user id: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error("transaction repo find by user id", "err", err.Error())
+		}
+	}(rows)
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		tr, err := scanTransaction(rows, splits)
+		if err != nil {
+			return nil, fmt.Errorf("transaction repo find by user id: %w", err)
+		}
+
+		transactions = append(transactions, tr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("transaction repo find by user id: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// findSplits возвращает части разделенных транзакций, отобранных условием where над transaction_splits s,
+// по идентификаторам транзакций в порядке ввода.
+func (t TransactionRepository) findSplits(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	where string,
+	args ...any,
+) (map[uuid.UUID][]transaction.Split, error) {
+	stmt := `SELECT s.transaction_id, s.category_id, s.amount
+				FROM transaction_splits s
+				WHERE ` + where + `
+				ORDER BY s.transaction_id, s.position`
+	rows, err := q.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			t.tracker.Logger().Error("transaction repo find splits", "err", err.Error())
+		}
+	}(rows)
+
+	splits := make(map[uuid.UUID][]transaction.Split)
+	for rows.Next() {
+		var (
+			transactionID uuid.UUID
+			categoryID    uuid.UUID
+			value         decimal.Decimal
+		)
+
+		if err := rows.Scan(&transactionID, &categoryID, &value); err != nil {
+			return nil, err
+		}
+
+		amount, err := transaction.NewAmount(value)
+		if err != nil {
+			return nil, err
+		}
+
+		part, err := transaction.NewSplit(shared.RestoreID(categoryID), amount)
+		if err != nil {
+			return nil, err
+		}
+
+		splits[transactionID] = append(splits[transactionID], part)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return splits, nil
+}
+
+// scanLine читает строку транзакции, выбранную со столбцами lineColumns.
+func scanLine(row interface{ Scan(dest ...any) error }) (report.TransactionLine, error) {
+	var (
+		id           uuid.UUID
+		occurredAt   time.Time
+		amount       decimal.Decimal
+		categoryType category.Type
+		categoryName string
+		parentName   string
+		note         string
+	)
+
+	if err := row.Scan(&id, &occurredAt, &amount, &categoryType, &categoryName, &parentName, &note); err != nil {
+		return report.TransactionLine{}, err
+	}
+
+	return report.NewTransactionLine(shared.RestoreID(id), occurredAt, amount, categoryType, categoryName, parentName, note), nil
+}
+
+// scanTransaction читает транзакцию из строки, выбранной со столбцами selectColumns,
+// вместе с её частями из splits.
+func scanTransaction(
+	row interface{ Scan(dest ...any) error },
+	splits map[uuid.UUID][]transaction.Split,
+) (*transaction.Transaction, error) {
+	var (
+		id          uuid.UUID
+		userID      uuid.UUID
+		value       decimal.Decimal
+		categoryID  uuid.UUID
+		note        string
+		occurredAt  time.Time
+		fingerprint string
+		drive       string
+		document    string
+		sign        string
+		createdAt   time.Time
+	)
+
+	err := row.Scan(&id, &userID, &value, &categoryID, &note, &occurredAt, &fingerprint, &drive, &document, &sign, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := transaction.NewAmount(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Реквизиты чека заданы либо все, либо ни одного.
+	fiscalID, _ := receipt.NewFiscalID(drive, document, sign)
+
+	return transaction.Restore(
+		shared.RestoreID(id),
+		shared.RestoreID(userID),
+		amount,
+		shared.RestoreID(categoryID),
+		note,
+		occurredAt,
+		fingerprint,
+		fiscalID,
+		createdAt,
+		splits[id]...,
+	), nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullUUID(id shared.ID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id.Value(), Valid: !id.IsZero()}
+}
+
+func nullDecimal(d decimal.Decimal) decimal.NullDecimal {
+	return decimal.NullDecimal{Decimal: d, Valid: !d.IsZero()}
+}
+
+// requireAffected возвращает errs.ErrObjectNotFound, если запрос не затронул ни одной строки.
+func requireAffected(res sql.Result, id shared.ID) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return errs.NewObjectNotFoundError("transaction", id.String())
+	}
+
+	return nil
 }
@@ -0,0 +1,61 @@
+package transactionrepo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+)
+
+func TestDuplicateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "receipt index",
+			err:  &pgconn.PgError{Code: uniqueViolation, ConstraintName: receiptIndex},
+			want: receipt.ErrAlreadyRecorded,
+		},
+		{
+			name: "fingerprint index",
+			err:  &pgconn.PgError{Code: uniqueViolation, ConstraintName: fingerprintIndex},
+			want: statement.ErrAlreadyImported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := duplicateError(tt.err)
+
+			assert.ErrorIs(t, got, tt.want)
+			assert.ErrorIs(t, got, tt.err)
+		})
+	}
+}
+
+func TestDuplicateError_KeepsOtherErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "other index", err: &pgconn.PgError{Code: uniqueViolation, ConstraintName: "transactions_pkey"}},
+		{name: "other code", err: &pgconn.PgError{Code: "23503", ConstraintName: receiptIndex}},
+		{name: "not a postgres error", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := duplicateError(tt.err)
+
+			assert.Equal(t, tt.err, got)
+			assert.NotErrorIs(t, got, receipt.ErrAlreadyRecorded)
+			assert.NotErrorIs(t, got, statement.ErrAlreadyImported)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
 )
 
@@ -15,4 +17,5 @@ type Tracker interface {
 	Track(agg ddd.AggregateRoot)
 	Begin(ctx context.Context) error
 	Commit(ctx context.Context) error
+	Logger() ports.Logger
 }
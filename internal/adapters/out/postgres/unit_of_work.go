@@ -5,13 +5,23 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/apitokenrepo"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/attachmentrepo"
 	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/categoryrepo"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/jobrepo"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/outboxrepo"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/refundrepo"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/settingsrepo"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/tagrepo"
 	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/transactionrepo"
 	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/userrepo"
+	"github.com/Nemizar/coin_tamer_bot/internal/adapters/out/postgres/websessionrepo"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
@@ -24,29 +34,37 @@ type UnitOfWork struct {
 	tx                *sqlx.Tx
 	committed         bool
 	trackedAggregates []ddd.AggregateRoot
-	mediatr           ddd.Mediatr
+	codec             ports.EventCodec
 	logger            ports.Logger
 
 	// Ленивая инициализация репозиториев
 	categoryRepo    ports.CategoryRepository
 	transactionRepo ports.TransactionRepository
 	userRepo        ports.UserRepository
+	jobRepo         ports.JobRepository
+	settingsRepo    ports.SettingsRepository
+	attachmentRepo  ports.AttachmentRepository
+	tagRepo         ports.TagRepository
+	refundRepo      ports.RefundRepository
+	outboxRepo      ports.OutboxRepository
+	apiTokenRepo    ports.APITokenRepository
+	webSessionRepo  ports.WebSessionRepository
 }
 
-func NewUnitOfWork(pool *sqlx.DB, mediatr ddd.Mediatr, logger ports.Logger) (ports.UnitOfWork, error) {
+func NewUnitOfWork(pool *sqlx.DB, codec ports.EventCodec, logger ports.Logger) (ports.UnitOfWork, error) {
 	if pool == nil {
 		return nil, errs.NewValueIsRequiredError("pool")
 	}
 
-	if mediatr == nil {
-		return nil, errs.NewValueIsRequiredError("mediatr")
+	if codec == nil {
+		return nil, errs.NewValueIsRequiredError("codec")
 	}
 
 	if logger == nil {
 		return nil, errs.NewValueIsRequiredError("logger")
 	}
 
-	uow := &UnitOfWork{pool: pool, mediatr: mediatr, logger: logger}
+	uow := &UnitOfWork{pool: pool, codec: codec, logger: logger}
 
 	categoryRepo, err := categoryrepo.NewCategoryRepository(uow)
 	if err != nil {
@@ -63,9 +81,57 @@ func NewUnitOfWork(pool *sqlx.DB, mediatr ddd.Mediatr, logger ports.Logger) (por
 		return nil, err
 	}
 
+	jobRepo, err := jobrepo.NewJobRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	settingsRepo, err := settingsrepo.NewSettingsRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentRepo, err := attachmentrepo.NewAttachmentRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	tagRepo, err := tagrepo.NewTagRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	refundRepo, err := refundrepo.NewRefundRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxRepo, err := outboxrepo.NewOutboxRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	apiTokenRepo, err := apitokenrepo.NewAPITokenRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	webSessionRepo, err := websessionrepo.NewWebSessionRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+
 	uow.categoryRepo = categoryRepo
 	uow.transactionRepo = transactionRepo
 	uow.userRepo = userRepo
+	uow.jobRepo = jobRepo
+	uow.settingsRepo = settingsRepo
+	uow.attachmentRepo = attachmentRepo
+	uow.tagRepo = tagRepo
+	uow.refundRepo = refundRepo
+	uow.outboxRepo = outboxRepo
+	uow.apiTokenRepo = apiTokenRepo
+	uow.webSessionRepo = webSessionRepo
 
 	return uow, nil
 }
@@ -93,7 +159,7 @@ func (u *UnitOfWork) Commit(ctx context.Context) error {
 		return fmt.Errorf("transaction already committed")
 	}
 
-	if err := u.publishDomainEvents(ctx); err != nil {
+	if err := u.saveDomainEvents(ctx); err != nil {
 		return err
 	}
 
@@ -114,6 +180,8 @@ func (u *UnitOfWork) RollbackUnlessCommitted() error {
 
 	err := u.tx.Rollback()
 	u.clearTx()
+	// События отмененных изменений не должны попасть в outbox при следующей фиксации.
+	u.trackedAggregates = nil
 
 	if err != nil && !errors.Is(err, sql.ErrTxDone) {
 		return fmt.Errorf("failed to rollback transaction: %w", err)
@@ -145,7 +213,7 @@ func (u *UnitOfWork) TrackedAggregates() []ddd.AggregateRoot {
 func (u *UnitOfWork) clearTx() {
 	u.tx = nil
 	u.committed = false
-	// НЕ трогаем trackedAggregates — их очистка должна быть после обработки событий!
+	// НЕ трогаем trackedAggregates — их очищает сохранение событий в outbox или откат.
 }
 
 func (u *UnitOfWork) CategoryRepository() ports.CategoryRepository {
@@ -160,20 +228,66 @@ func (u *UnitOfWork) TransactionRepository() ports.TransactionRepository {
 	return u.transactionRepo
 }
 
-func (u *UnitOfWork) publishDomainEvents(ctx context.Context) error {
+func (u *UnitOfWork) JobRepository() ports.JobRepository {
+	return u.jobRepo
+}
+
+func (u *UnitOfWork) SettingsRepository() ports.SettingsRepository {
+	return u.settingsRepo
+}
+
+func (u *UnitOfWork) AttachmentRepository() ports.AttachmentRepository {
+	return u.attachmentRepo
+}
+
+func (u *UnitOfWork) TagRepository() ports.TagRepository {
+	return u.tagRepo
+}
+
+func (u *UnitOfWork) RefundRepository() ports.RefundRepository {
+	return u.refundRepo
+}
+
+func (u *UnitOfWork) OutboxRepository() ports.OutboxRepository {
+	return u.outboxRepo
+}
+
+func (u *UnitOfWork) APITokenRepository() ports.APITokenRepository {
+	return u.apiTokenRepo
+}
+
+func (u *UnitOfWork) WebSessionRepository() ports.WebSessionRepository {
+	return u.webSessionRepo
+}
+
+// saveDomainEvents сохраняет события отслеживаемых агрегатов в outbox в текущей транзакции.
+// События доставляются подписчикам после фиксации транзакции, поэтому подписчики не увидят
+// событий отмененных изменений, а ошибка подписчика не отменяет сами изменения.
+func (u *UnitOfWork) saveDomainEvents(ctx context.Context) error {
+	now := time.Now()
+
 	for _, aggregate := range u.trackedAggregates {
 		for _, event := range aggregate.GetDomainEvents() {
-			err := u.mediatr.Publish(ctx, event)
+			payload, err := u.codec.Encode(event)
+			if err != nil {
+				return fmt.Errorf("encode event %s: %w", event.GetName(), err)
+			}
+
+			message, err := outbox.New(event, payload, now)
 			if err != nil {
-				u.logger.Error("publish event", "err", err)
+				return err
+			}
 
-				continue
+			if err := u.outboxRepo.Add(ctx, message); err != nil {
+				return err
 			}
 		}
 
 		aggregate.ClearDomainEvents()
 	}
 
+	u.trackedAggregates = nil
+
 	return nil
 }
 
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
 )
 
 type Model struct {
@@ -11,3 +13,11 @@ type Model struct {
 	Name      string
 	CreatedAt time.Time
 }
+
+type ExternalIdentityModel struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Provider   user.Provider
+	ExternalID string
+	CreatedAt  time.Time
+}
@@ -78,3 +78,86 @@ func (u UserRepository) FindByExternalProvider(ctx context.Context, provider use
 
 	return user.Restore(shared.RestoreID(repoModel.ID), repoModel.Name, repoModel.CreatedAt), nil
 }
+
+func (u UserRepository) Get(ctx context.Context, id shared.ID) (*user.User, error) {
+	stmt := `SELECT id, name, created_at FROM users WHERE id = $1`
+	row := u.tracker.DB().QueryRowContext(ctx, stmt, id)
+
+	var repoModel Model
+	err := row.Scan(&repoModel.ID, &repoModel.Name, &repoModel.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewObjectNotFoundError("user", id.String())
+		}
+
+		return nil, fmt.Errorf("user repo get: %w", err)
+	}
+
+	return user.Restore(shared.RestoreID(repoModel.ID), repoModel.Name, repoModel.CreatedAt), nil
+}
+
+func (u UserRepository) GetExternalIdentity(ctx context.Context, userID shared.ID, provider user.Provider) (*user.ExternalIdentity, error) {
+	stmt := `SELECT id, user_id, provider, external_id, created_at
+				FROM external_identities
+				WHERE user_id = $1 AND provider = $2`
+	row := u.tracker.DB().QueryRowContext(ctx, stmt, userID, provider)
+
+	var model ExternalIdentityModel
+	err := row.Scan(&model.ID, &model.UserID, &model.Provider, &model.ExternalID, &model.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewObjectNotFoundError("external identity", userID.String())
+		}
+
+		return nil, fmt.Errorf("user repo get external identity: %w", err)
+	}
+
+	return user.RestoreExternalIdentity(
+		shared.RestoreID(model.ID),
+		shared.RestoreID(model.UserID),
+		model.Provider,
+		model.ExternalID,
+		model.CreatedAt,
+	), nil
+}
+
+func (u UserRepository) FindExternalIdentities(ctx context.Context, userID shared.ID) ([]*user.ExternalIdentity, error) {
+	stmt := `SELECT id, user_id, provider, external_id, created_at
+				FROM external_identities
+				WHERE user_id = $1
+				ORDER BY created_at`
+	rows, err := u.tracker.DB().QueryContext(ctx, stmt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user repo find external identities: %w", err)
+	}
+
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			u.tracker.Logger().Error("user repo find external identities", "err", err.Error())
+		}
+	}(rows)
+
+	var identities []*user.ExternalIdentity
+	for rows.Next() {
+		var model ExternalIdentityModel
+
+		if err := rows.Scan(&model.ID, &model.UserID, &model.Provider, &model.ExternalID, &model.CreatedAt); err != nil {
+			return nil, fmt.Errorf("user repo find external identities: %w", err)
+		}
+
+		identities = append(identities, user.RestoreExternalIdentity(
+			shared.RestoreID(model.ID),
+			shared.RestoreID(model.UserID),
+			model.Provider,
+			model.ExternalID,
+			model.CreatedAt,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("user repo find external identities: %w", err)
+	}
+
+	return identities, nil
+}
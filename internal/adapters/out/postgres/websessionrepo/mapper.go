@@ -0,0 +1,40 @@
+package websessionrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+)
+
+type Model struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	TokenHash   []byte
+	ActivatedAt sql.NullTime
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+func (m Model) toDomain() *websession.Session {
+	return websession.Restore(
+		shared.RestoreID(m.ID),
+		shared.RestoreID(m.UserID),
+		m.TokenHash,
+		m.ActivatedAt.Time,
+		m.ExpiresAt,
+		m.CreatedAt,
+	)
+}
+
+// scanFields возвращает указатели на поля модели в порядке selectColumns.
+func (m *Model) scanFields() []any {
+	return []any{&m.ID, &m.UserID, &m.TokenHash, &m.ActivatedAt, &m.ExpiresAt, &m.CreatedAt}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
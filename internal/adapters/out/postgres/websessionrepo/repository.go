@@ -0,0 +1,115 @@
+package websessionrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const selectColumns = `s.id, s.user_id, s.token_hash, s.activated_at, s.expires_at, s.created_at`
+
+type WebSessionRepository struct {
+	tracker Tracker
+}
+
+func NewWebSessionRepository(tracker Tracker) (ports.WebSessionRepository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &WebSessionRepository{tracker: tracker}, nil
+}
+
+func (r WebSessionRepository) Add(ctx context.Context, s *websession.Session) error {
+	stmt := `INSERT INTO web_sessions (id, user_id, token_hash, activated_at, expires_at, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.tracker.Tx().ExecContext(
+		ctx,
+		stmt,
+		s.ID(),
+		s.UserID(),
+		s.Hash(),
+		nullTime(s.ActivatedAt()),
+		s.ExpiresAt(),
+		s.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("web session repo add: %w", err)
+	}
+
+	return nil
+}
+
+func (r WebSessionRepository) Save(ctx context.Context, s *websession.Session) error {
+	stmt := `UPDATE web_sessions SET token_hash = $2, activated_at = $3, expires_at = $4 WHERE id = $1`
+
+	res, err := r.tracker.Tx().ExecContext(ctx, stmt, s.ID(), s.Hash(), nullTime(s.ActivatedAt()), s.ExpiresAt())
+	if err != nil {
+		return fmt.Errorf("web session repo save: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("web session repo save: %w", err)
+	}
+
+	if affected == 0 {
+		return errs.NewObjectNotFoundError("web session", s.ID().String())
+	}
+
+	return nil
+}
+
+func (r WebSessionRepository) GetByHash(ctx context.Context, hash []byte) (*websession.Session, error) {
+	stmt := `SELECT ` + selectColumns + `
+				FROM web_sessions s
+				WHERE s.token_hash = $1`
+
+	q := r.tracker.DB().QueryRowContext
+	if r.tracker.InTx() {
+		q = r.tracker.Tx().QueryRowContext
+
+		// Вход по ссылке меняет хеш, поэтому параллельный вход по той же ссылке после ожидания
+		// блокировки уже не найдет сеанс: ссылка действует ровно один раз.
+		stmt += ` FOR UPDATE`
+	}
+
+	var m Model
+
+	err := q(ctx, stmt, hash).Scan(m.scanFields()...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewObjectNotFoundError("web session", "hash")
+		}
+
+		return nil, fmt.Errorf("web session repo get by hash: %w", err)
+	}
+
+	return m.toDomain(), nil
+}
+
+func (r WebSessionRepository) Delete(ctx context.Context, id shared.ID) error {
+	_, err := r.tracker.Tx().ExecContext(ctx, `DELETE FROM web_sessions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("web session repo delete: %w", err)
+	}
+
+	return nil
+}
+
+func (r WebSessionRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	_, err := r.tracker.Tx().ExecContext(ctx, `DELETE FROM web_sessions WHERE expires_at <= $1`, now)
+	if err != nil {
+		return fmt.Errorf("web session repo delete expired: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Package qrdecoder распознает QR-коды на фотографиях средствами gozxing, без внешних сервисов.
+package qrdecoder
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Telegram пересылает фотографии в JPEG.
+	_ "image/png"
+	"io"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var _ ports.QRDecoder = Decoder{}
+
+type Decoder struct{}
+
+func NewDecoder() Decoder {
+	return Decoder{}
+}
+
+// Decode пробует гибридный и глобальный бинаризаторы: первый лучше справляется с неравномерным
+// освещением на фото, второй — с мелкими кодами на однородном фоне.
+func (Decoder) Decode(r io.Reader) (string, error) {
+	if r == nil {
+		return "", errs.NewValueIsRequiredError("r")
+	}
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return "", fmt.Errorf("decode image: %w", err)
+	}
+
+	source := gozxing.NewLuminanceSourceFromImage(img)
+	hints := map[gozxing.DecodeHintType]any{
+		gozxing.DecodeHintType_TRY_HARDER:    true,
+		gozxing.DecodeHintType_ALSO_INVERTED: true,
+	}
+
+	binarizers := []gozxing.Binarizer{
+		gozxing.NewHybridBinarizer(source),
+		gozxing.NewGlobalHistgramBinarizer(source),
+	}
+
+	reader := qrcode.NewQRCodeReader()
+
+	for _, binarizer := range binarizers {
+		bitmap, err := gozxing.NewBinaryBitmap(binarizer)
+		if err != nil {
+			return "", fmt.Errorf("decode qr code: %w", err)
+		}
+
+		result, err := reader.Decode(bitmap, hints)
+		if err == nil {
+			return result.GetText(), nil
+		}
+
+		// Код не найден или не прочитан, пробуем следующий бинаризатор.
+		var readerErr gozxing.ReaderException
+		if !errors.As(err, &readerErr) {
+			return "", fmt.Errorf("decode qr code: %w", err)
+		}
+	}
+
+	return "", receipt.ErrCodeNotFound
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"io"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// FileOpener открывает содержимое прикрепляемого файла. Вызывается, только если нужна копия файла.
+type FileOpener func(ctx context.Context) (io.ReadCloser, error)
+
+type AttachFileCommand interface {
+	UserID() shared.ID
+	TransactionID() shared.ID
+	Kind() attachment.Kind
+	FileID() string
+	FileUniqueID() string
+	FileName() string
+	MimeType() string
+	Size() int64
+
+	// Open возвращает функцию чтения содержимого файла или nil, если содержимое недоступно.
+	Open() FileOpener
+}
+
+type attachFileCommand struct {
+	userID        shared.ID
+	transactionID shared.ID
+	kind          attachment.Kind
+	fileID        string
+	fileUniqueID  string
+	fileName      string
+	mimeType      string
+	size          int64
+	open          FileOpener
+}
+
+// NewAttachFileCommand создает команду прикрепления файла к транзакции transactionID пользователя userID.
+// open может быть nil: тогда копия файла не сохраняется.
+func NewAttachFileCommand(
+	userID shared.ID,
+	transactionID shared.ID,
+	kind attachment.Kind,
+	fileID string,
+	fileUniqueID string,
+	fileName string,
+	mimeType string,
+	size int64,
+	open FileOpener,
+) (AttachFileCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if transactionID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("transactionID")
+	}
+
+	return &attachFileCommand{
+		userID:        userID,
+		transactionID: transactionID,
+		kind:          kind,
+		fileID:        fileID,
+		fileUniqueID:  fileUniqueID,
+		fileName:      fileName,
+		mimeType:      mimeType,
+		size:          size,
+		open:          open,
+	}, nil
+}
+
+func (c attachFileCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c attachFileCommand) TransactionID() shared.ID {
+	return c.transactionID
+}
+
+func (c attachFileCommand) Kind() attachment.Kind {
+	return c.kind
+}
+
+func (c attachFileCommand) FileID() string {
+	return c.fileID
+}
+
+func (c attachFileCommand) FileUniqueID() string {
+	return c.fileUniqueID
+}
+
+func (c attachFileCommand) FileName() string {
+	return c.fileName
+}
+
+func (c attachFileCommand) MimeType() string {
+	return c.mimeType
+}
+
+func (c attachFileCommand) Size() int64 {
+	return c.size
+}
+
+func (c attachFileCommand) Open() FileOpener {
+	return c.open
+}
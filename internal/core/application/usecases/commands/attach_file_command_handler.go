@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type AttachFileCommandHandler interface {
+	// Handle прикрепляет файл к транзакции пользователя и возвращает вложение.
+	// Возвращает errs.ErrObjectNotFound, если у пользователя нет такой транзакции,
+	// attachment.ErrAlreadyAttached или attachment.ErrTooMany.
+	Handle(ctx context.Context, command AttachFileCommand) (*attachment.Attachment, error)
+}
+
+type attachFileCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+	blobs      ports.BlobStore
+}
+
+// NewAttachFileCommandHandler создает обработчик прикрепления файлов. blobs может быть nil:
+// тогда вложения хранятся только ссылками на файлы в Telegram.
+func NewAttachFileCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory, blobs ports.BlobStore) (AttachFileCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &attachFileCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+		blobs:      blobs,
+	}, nil
+}
+
+func (h attachFileCommandHandler) Handle(ctx context.Context, command AttachFileCommand) (*attachment.Attachment, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := attachment.New(command.TransactionID(), command.UserID(), command.Kind(), command.FileID(), command.FileUniqueID())
+	if err != nil {
+		return nil, err
+	}
+
+	a.Describe(command.FileName(), command.MimeType(), command.Size())
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("attach file command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+
+	t, err := uow.TransactionRepository().Get(ctx, command.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.UserID() != command.UserID() {
+		return nil, errs.NewObjectNotFoundError("transaction", command.TransactionID().String())
+	}
+
+	existing, err := uow.AttachmentRepository().FindByTransactionID(ctx, command.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachment.EnsureCanAttach(existing, command.FileUniqueID()); err != nil {
+		return nil, err
+	}
+
+	// Копия необязательна: файл остается доступен в Telegram, поэтому ошибка сохранения копии
+	// не мешает прикрепить его.
+	if err := h.storeCopy(ctx, a, command.Open()); err != nil {
+		h.logger.Error("attach file command handler: store copy failed", "attachment", a.ID(), "err", err)
+	}
+
+	if err := uow.AttachmentRepository().Add(ctx, a); err != nil {
+		h.deleteCopy(ctx, a)
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		h.deleteCopy(ctx, a)
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (h attachFileCommandHandler) storeCopy(ctx context.Context, a *attachment.Attachment, open FileOpener) error {
+	if h.blobs == nil || open == nil {
+		return nil
+	}
+
+	r, err := open(ctx)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+
+	defer func() {
+		if err := r.Close(); err != nil {
+			h.logger.Error("attach file command handler: close file", "err", err)
+		}
+	}()
+
+	key := a.DefaultStorageKey()
+	if err := h.blobs.Put(ctx, key, r); err != nil {
+		return err
+	}
+
+	a.SetStorageKey(key)
+
+	return nil
+}
+
+func (h attachFileCommandHandler) deleteCopy(ctx context.Context, a *attachment.Attachment) {
+	if h.blobs == nil || a.StorageKey() == "" {
+		return
+	}
+
+	if err := h.blobs.Delete(ctx, a.StorageKey()); err != nil {
+		h.logger.Error("attach file command handler: delete copy failed", "key", a.StorageKey(), "err", err)
+	}
+}
@@ -0,0 +1,192 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+type attachFileMocks struct {
+	uow         *portsmocks.UnitOfWorkMock
+	transaction *portsmocks.TransactionRepositoryMock
+	attachment  *portsmocks.AttachmentRepositoryMock
+	blobs       *portsmocks.BlobStoreMock
+}
+
+func newAttachFileMocks(t *testing.T) attachFileMocks {
+	m := attachFileMocks{
+		uow:         portsmocks.NewUnitOfWorkMock(t),
+		transaction: portsmocks.NewTransactionRepositoryMock(t),
+		attachment:  portsmocks.NewAttachmentRepositoryMock(t),
+		blobs:       portsmocks.NewBlobStoreMock(t),
+	}
+
+	m.uow.On("TransactionRepository").Return(m.transaction).Maybe()
+	m.uow.On("AttachmentRepository").Return(m.attachment).Maybe()
+
+	return m
+}
+
+func newOwnedTransaction(t *testing.T, userID shared.ID) *transaction.Transaction {
+	t.Helper()
+
+	amount, err := transaction.NewAmountFromString("100")
+	require.NoError(t, err)
+
+	return transaction.Restore(shared.NewID(), userID, amount, shared.NewID(), "", time.Now(), "", receipt.FiscalID{}, time.Now())
+}
+
+func openString(s string) commands.FileOpener {
+	return func(context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(s)), nil
+	}
+}
+
+func TestAttachFileCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	m := newAttachFileMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.attachment.EXPECT().FindByTransactionID(ctx, tr.ID()).Return(nil, nil).Once()
+
+	var stored string
+	m.blobs.EXPECT().Put(ctx, mock.AnythingOfType("string"), mock.Anything).
+		Run(func(_ context.Context, _ string, r io.Reader) {
+			content, _ := io.ReadAll(r)
+			stored = string(content)
+		}).
+		Return(nil).
+		Once()
+
+	m.attachment.EXPECT().Add(ctx, mock.AnythingOfType("*attachment.Attachment")).Return(nil).Once()
+
+	handler, err := commands.NewAttachFileCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.blobs)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewAttachFileCommand(
+		userID, tr.ID(), attachment.KindPhoto, "file", "unique", "", "image/jpeg", 4, openString("jpeg"),
+	)
+	require.NoError(t, err)
+
+	a, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	assert.Equal(t, "jpeg", stored)
+	assert.Equal(t, tr.ID(), a.TransactionID())
+	assert.Equal(t, a.DefaultStorageKey(), a.StorageKey())
+}
+
+func TestAttachFileCommandHandler_CopyFailed(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	m := newAttachFileMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.attachment.EXPECT().FindByTransactionID(ctx, tr.ID()).Return(nil, nil).Once()
+	m.blobs.EXPECT().Put(ctx, mock.AnythingOfType("string"), mock.Anything).Return(errors.New("disk full")).Once()
+	m.attachment.EXPECT().Add(ctx, mock.AnythingOfType("*attachment.Attachment")).Return(nil).Once()
+
+	handler, err := commands.NewAttachFileCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.blobs)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewAttachFileCommand(
+		userID, tr.ID(), attachment.KindDocument, "file", "unique", "warranty.pdf", "application/pdf", 4, openString("%PDF"),
+	)
+	require.NoError(t, err)
+
+	a, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	assert.Empty(t, a.StorageKey())
+	assert.Contains(t, buf.String(), "store copy failed")
+}
+
+func TestAttachFileCommandHandler_ForeignTransaction(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	tr := newOwnedTransaction(t, shared.NewID())
+	m := newAttachFileMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+
+	handler, err := commands.NewAttachFileCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.blobs)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewAttachFileCommand(
+		shared.NewID(), tr.ID(), attachment.KindPhoto, "file", "unique", "", "", 0, openString("jpeg"),
+	)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+
+	m.attachment.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+	m.blobs.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachFileCommandHandler_AlreadyAttached(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	m := newAttachFileMocks(t)
+
+	existing, err := attachment.New(tr.ID(), userID, attachment.KindPhoto, "file", "unique")
+	require.NoError(t, err)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.attachment.EXPECT().FindByTransactionID(ctx, tr.ID()).Return([]*attachment.Attachment{existing}, nil).Once()
+
+	handler, err := commands.NewAttachFileCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), nil)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewAttachFileCommand(userID, tr.ID(), attachment.KindPhoto, "file-2", "unique", "", "", 0, nil)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, attachment.ErrAlreadyAttached)
+
+	m.uow.AssertNotCalled(t, "Commit", ctx)
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type CreateCategoryCommand interface {
+	UserID() shared.ID
+	Name() string
+	CategoryType() category.Type
+	ParentID() shared.ID
+}
+
+type createCategoryCommand struct {
+	userID       shared.ID
+	name         string
+	categoryType category.Type
+	parentID     shared.ID
+}
+
+// NewCreateCategoryCommand создает команду добавления категории. Нулевой parentID означает
+// корневую категорию: группу расходов или категорию доходов.
+func NewCreateCategoryCommand(userID shared.ID, name string, categoryType category.Type, parentID shared.ID) (CreateCategoryCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if !categoryType.IsValid() {
+		return nil, errs.NewValueIsInvalidError("categoryType")
+	}
+
+	return &createCategoryCommand{
+		userID:       userID,
+		name:         name,
+		categoryType: categoryType,
+		parentID:     parentID,
+	}, nil
+}
+
+func (c createCategoryCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c createCategoryCommand) Name() string {
+	return c.name
+}
+
+func (c createCategoryCommand) CategoryType() category.Type {
+	return c.categoryType
+}
+
+func (c createCategoryCommand) ParentID() shared.ID {
+	return c.parentID
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type CreateCategoryCommandHandler interface {
+	// Handle добавляет категорию пользователя и возвращает ее. Возвращает errs.ErrObjectNotFound,
+	// если у пользователя нет родительской категории, и category.ErrInvalidParent, если в нее нельзя вкладывать.
+	Handle(ctx context.Context, command CreateCategoryCommand) (*category.Category, error)
+}
+
+type createCategoryCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewCreateCategoryCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (CreateCategoryCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &createCategoryCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h createCategoryCommandHandler) Handle(ctx context.Context, command CreateCategoryCommand) (*category.Category, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("create category command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !command.ParentID().IsZero() {
+		parent, err := uow.CategoryRepository().Get(ctx, command.UserID(), command.ParentID())
+		if err != nil {
+			return nil, err
+		}
+
+		if err := parent.AcceptChild(command.CategoryType()); err != nil {
+			return nil, err
+		}
+	}
+
+	pID := command.ParentID()
+
+	c, err := category.New(command.Name(), command.CategoryType(), command.UserID(), &pID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.CategoryRepository().Create(ctx, c); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
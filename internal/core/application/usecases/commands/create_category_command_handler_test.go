@@ -0,0 +1,138 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+func TestCreateCategoryCommandHandler_Child(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	group := category.Restore(shared.NewID(), "Покупки", userID, nil, category.TypeExpense, time.Now())
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewCategoryRepositoryMock(t)
+	uow.On("CategoryRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uow.EXPECT().Commit(ctx).Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, group.ID()).Return(group, nil).Once()
+	repo.EXPECT().Create(ctx, mock.AnythingOfType("*category.Category")).Return(nil).Once()
+
+	handler, err := commands.NewCreateCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateCategoryCommand(userID, " Книги ", category.TypeExpense, group.ID())
+	require.NoError(t, err)
+
+	c, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "Книги", c.Name())
+	assert.Equal(t, userID, c.OwnerID())
+	assert.Equal(t, group.ID(), c.ParentID())
+	assert.Equal(t, category.TypeExpense, c.Type())
+}
+
+func TestCreateCategoryCommandHandler_Root(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewCategoryRepositoryMock(t)
+	uow.On("CategoryRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uow.EXPECT().Commit(ctx).Return(nil).Once()
+	repo.EXPECT().Create(ctx, mock.AnythingOfType("*category.Category")).Return(nil).Once()
+
+	handler, err := commands.NewCreateCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateCategoryCommand(userID, "Фриланс", category.TypeIncome, shared.ID{})
+	require.NoError(t, err)
+
+	c, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.True(t, c.ParentID().IsZero())
+	assert.Equal(t, category.TypeIncome, c.Type())
+}
+
+func TestCreateCategoryCommandHandler_InvalidParent(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	income := category.Restore(shared.NewID(), "Зарплата", userID, nil, category.TypeIncome, time.Now())
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewCategoryRepositoryMock(t)
+	uow.On("CategoryRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, income.ID()).Return(income, nil).Once()
+
+	handler, err := commands.NewCreateCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateCategoryCommand(userID, "Премия", category.TypeIncome, income.ID())
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, category.ErrInvalidParent)
+}
+
+func TestCreateCategoryCommandHandler_ParentNotFound(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID, parentID := shared.NewID(), shared.NewID()
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewCategoryRepositoryMock(t)
+	uow.On("CategoryRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, parentID).Return(nil, errs.NewObjectNotFoundError("category", parentID)).Once()
+
+	handler, err := commands.NewCreateCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateCategoryCommand(userID, "Книги", category.TypeExpense, parentID)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
+
+func TestNewCreateCategoryCommand_Invalid(t *testing.T) {
+	_, err := commands.NewCreateCategoryCommand(shared.ID{}, "Книги", category.TypeExpense, shared.ID{})
+	require.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = commands.NewCreateCategoryCommand(shared.NewID(), "Книги", category.Type("other"), shared.ID{})
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
@@ -1,18 +1,23 @@
 package commands
 
 import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
 )
 
+// CreateDefaultCategoryCommand указывает пользователя либо внешним идентификатором, либо,
+// если он уже известен, идентификатором UserID. Пустой UserID означает поиск по внешнему идентификатору.
 type CreateDefaultCategoryCommand interface {
 	ExternalID() string
 	Provider() user.Provider
+	UserID() shared.ID
 }
 
 type createDefaultCategoryCommand struct {
 	externalID string
 	provider   user.Provider
+	userID     shared.ID
 }
 
 func (c createDefaultCategoryCommand) ExternalID() string {
@@ -23,6 +28,10 @@ func (c createDefaultCategoryCommand) Provider() user.Provider {
 	return c.provider
 }
 
+func (c createDefaultCategoryCommand) UserID() shared.ID {
+	return c.userID
+}
+
 func NewCreateDefaultCategoryCommand(externalID string, provider user.Provider) (CreateDefaultCategoryCommand, error) {
 	if externalID == "" || externalID == "0" {
 		return nil, errs.NewValueIsRequiredError("externalID")
@@ -34,3 +43,13 @@ func NewCreateDefaultCategoryCommand(externalID string, provider user.Provider)
 
 	return createDefaultCategoryCommand{externalID: externalID, provider: provider}, nil
 }
+
+// NewCreateDefaultCategoryCommandForUser создает команду для уже найденного пользователя userID,
+// например владельца токена API.
+func NewCreateDefaultCategoryCommandForUser(userID shared.ID) (CreateDefaultCategoryCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	return createDefaultCategoryCommand{userID: userID}, nil
+}
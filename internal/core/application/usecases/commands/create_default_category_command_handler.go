@@ -21,64 +21,74 @@ type CreateDefaultCategoryCommandHandler interface {
 var _ CreateDefaultCategoryCommandHandler = createDefaultCategoryCommandHandler{}
 
 type createDefaultCategoryCommandHandler struct {
-	logger ports.Logger
-	uow    ports.UnitOfWork
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
 }
 
-func NewCreateDefaultCategoryCommandHandler(logger ports.Logger, uow ports.UnitOfWork) (CreateDefaultCategoryCommandHandler, error) {
+func NewCreateDefaultCategoryCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (CreateDefaultCategoryCommandHandler, error) {
 	if logger == nil {
 		return nil, errs.NewValueIsRequiredError("logger")
 	}
 
-	if uow == nil {
-		return nil, errs.NewValueIsRequiredError("uow")
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
 	}
 
 	return &createDefaultCategoryCommandHandler{
-		logger: logger,
-		uow:    uow,
+		logger:     logger,
+		uowFactory: uowFactory,
 	}, nil
 }
 
 func (c createDefaultCategoryCommandHandler) Handle(ctx context.Context, command CreateDefaultCategoryCommand) error {
+	uow, err := c.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
 	defer func(uow ports.UnitOfWork) {
 		err := uow.RollbackUnlessCommitted()
 		if err != nil {
 			c.logger.Error("create default category command handler: rollback failed", "err", err)
 		}
-	}(c.uow)
+	}(uow)
 
-	err := c.uow.Begin(ctx)
+	err = uow.Begin(ctx)
 	if err != nil {
 		return err
 	}
 
-	u, err := c.uow.UserRepository().FindByExternalProvider(ctx, command.Provider(), command.ExternalID())
-	if err != nil {
-		return err
+	userID := command.UserID()
+	if userID.IsZero() {
+		u, err := uow.UserRepository().FindByExternalProvider(ctx, command.Provider(), command.ExternalID())
+		if err != nil {
+			return err
+		}
+
+		userID = u.ID()
 	}
 
-	hasCategories, err := c.uow.CategoryRepository().HasCategoriesByUserID(ctx, u.ID())
+	hasCategories, err := uow.CategoryRepository().HasCategoriesByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
 	if hasCategories {
-		return errs.NewEntityAlreadyExistsError("categories", "user_id", u.ID().String())
+		return errs.NewEntityAlreadyExistsError("categories", "user_id", userID.String())
 	}
 
 	for _, tpl := range c.getDefaultsCategory() {
 		parent, err := category.New(
 			tpl.name,
 			tpl.cType,
-			u.ID(),
+			userID,
 			nil,
 		)
 		if err != nil {
 			return err
 		}
 
-		if err := c.uow.CategoryRepository().Create(ctx, parent); err != nil {
+		if err := uow.CategoryRepository().Create(ctx, parent); err != nil {
 			return err
 		}
 
@@ -87,20 +97,20 @@ func (c createDefaultCategoryCommandHandler) Handle(ctx context.Context, command
 			child, err := category.New(
 				childName,
 				tpl.cType,
-				u.ID(),
+				userID,
 				&pID,
 			)
 			if err != nil {
 				return err
 			}
 
-			if err := c.uow.CategoryRepository().Create(ctx, child); err != nil {
+			if err := uow.CategoryRepository().Create(ctx, child); err != nil {
 				return err
 			}
 		}
 	}
 
-	return c.uow.Commit(ctx)
+	return uow.Commit(ctx)
 }
 
 func (c createDefaultCategoryCommandHandler) getDefaultsCategory() []defaultCategoryTemplate {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
 	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
@@ -121,7 +122,7 @@ func TestCreateDefaultCategoryCommandHandler_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -133,6 +134,41 @@ func TestCreateDefaultCategoryCommandHandler_Success(t *testing.T) {
 	uowMock.AssertExpectations(t)
 }
 
+func TestCreateDefaultCategoryCommandHandler_ForUser(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+
+	cmd, err := commands.NewCreateDefaultCategoryCommandForUser(userID)
+	require.NoError(t, err)
+
+	uowMock, userRepoMock, categoryRepoMock := setupCreateDefaultCategoryMocks()
+
+	categoryRepoMock.EXPECT().HasCategoriesByUserID(ctx, userID).Return(false, nil).Once()
+	categoryRepoMock.EXPECT().
+		Create(ctx, mock.MatchedBy(func(c *category.Category) bool { return c.OwnerID() == userID })).
+		Times(36).
+		Return(nil)
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uowMock.EXPECT().Commit(ctx).Return(nil).Once()
+
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, cmd))
+
+	userRepoMock.AssertNotCalled(t, "FindByExternalProvider", mock.Anything, mock.Anything, mock.Anything)
+	categoryRepoMock.AssertExpectations(t)
+	uowMock.AssertCalled(t, "Commit", ctx)
+
+	_, err = commands.NewCreateDefaultCategoryCommandForUser(shared.ID{})
+	require.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
 func TestCreateDefaultCategoryCommandHandler_UserNotFoundError(t *testing.T) {
 	ctx := context.Background()
 	var buf bytes.Buffer
@@ -167,7 +203,7 @@ func TestCreateDefaultCategoryCommandHandler_UserNotFoundError(t *testing.T) {
 		Once()
 	// Commit не вызывается при ошибке поиска пользователя
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -207,7 +243,7 @@ func TestCreateDefaultCategoryCommandHandler_BeginTransactionError(t *testing.T)
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -268,7 +304,7 @@ func TestCreateDefaultCategoryCommandHandler_CommitError(t *testing.T) {
 		Return(errors.New("commit error")).
 		Once()
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -314,7 +350,7 @@ func TestCreateDefaultCategoryCommandHandler_FindUserError(t *testing.T) {
 		Once()
 	// Commit не вызывается при ошибке поиска пользователя
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -375,7 +411,7 @@ func TestCreateDefaultCategoryCommandHandler_CategoryCreateError(t *testing.T) {
 		Once()
 	// Commit не вызывается при ошибке создания категории
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -441,7 +477,7 @@ func TestCreateDefaultCategoryCommandHandler_CategoryTypeValidation(t *testing.T
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -494,7 +530,7 @@ func TestCreateDefaultCategoryCommandHandler_CategoriesAlreadyExist(t *testing.T
 		Once()
 	// Commit не вызывается при наличии существующих категорий
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -547,7 +583,7 @@ func TestCreateDefaultCategoryCommandHandler_CategoriesAlreadyExistReturnsCorrec
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateDefaultCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
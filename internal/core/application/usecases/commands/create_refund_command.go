@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// CreateRefundCommand записывает возврат по расходной транзакции пользователя.
+type CreateRefundCommand interface {
+	UserID() shared.ID
+	TransactionID() shared.ID
+	CategoryID() shared.ID
+	Amount() transaction.Amount
+	Note() string
+}
+
+type createRefundCommand struct {
+	userID        shared.ID
+	transactionID shared.ID
+	categoryID    shared.ID
+	amount        transaction.Amount
+	note          string
+}
+
+// NewCreateRefundCommand создает команду возврата суммы amount по транзакции transactionID.
+// Пустой categoryID означает категорию транзакции; для разделенной транзакции это категория одной из частей.
+func NewCreateRefundCommand(
+	userID shared.ID,
+	transactionID shared.ID,
+	categoryID shared.ID,
+	amount transaction.Amount,
+	note string,
+) (CreateRefundCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if transactionID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("transactionID")
+	}
+
+	if amount.Value().IsZero() {
+		return nil, errs.NewValueIsRequiredError("amount")
+	}
+
+	return &createRefundCommand{
+		userID:        userID,
+		transactionID: transactionID,
+		categoryID:    categoryID,
+		amount:        amount,
+		note:          note,
+	}, nil
+}
+
+func (c createRefundCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c createRefundCommand) TransactionID() shared.ID {
+	return c.transactionID
+}
+
+func (c createRefundCommand) CategoryID() shared.ID {
+	return c.categoryID
+}
+
+func (c createRefundCommand) Amount() transaction.Amount {
+	return c.amount
+}
+
+func (c createRefundCommand) Note() string {
+	return c.note
+}
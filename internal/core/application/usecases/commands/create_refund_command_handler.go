@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type CreateRefundCommandHandler interface {
+	// Handle записывает возврат и возвращает его.
+	// Возвращает errs.ErrObjectNotFound, если у пользователя нет такой транзакции, refund.ErrNotExpense,
+	// если транзакция не расход, и refund.ErrExceedsOriginal, если вместе с прежними возвратами
+	// сумма превышает исходную.
+	Handle(ctx context.Context, command CreateRefundCommand) (*refund.Refund, error)
+}
+
+var _ CreateRefundCommandHandler = createRefundCommandHandler{}
+
+type createRefundCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewCreateRefundCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (CreateRefundCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &createRefundCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+	}, nil
+}
+
+func (h createRefundCommandHandler) Handle(ctx context.Context, command CreateRefundCommand) (*refund.Refund, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("create refund command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+
+	t, err := uow.TransactionRepository().Get(ctx, command.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.UserID() != command.UserID() {
+		return nil, errs.NewObjectNotFoundError("transaction", command.TransactionID().String())
+	}
+
+	if err := h.checkExpense(ctx, uow, t); err != nil {
+		return nil, err
+	}
+
+	// Get заблокировал исходную транзакцию, поэтому параллельный возврат не появится
+	// между чтением прежних возвратов и сохранением нового.
+	previous, err := uow.RefundRepository().FindByTransactionID(ctx, t.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := refund.New(t, command.CategoryID(), command.Amount(), previous)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.SetNote(command.Note()); err != nil {
+		return nil, err
+	}
+
+	if err := uow.RefundRepository().Add(ctx, r); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// checkExpense проверяет, что транзакция относится к расходной категории пользователя.
+func (h createRefundCommandHandler) checkExpense(ctx context.Context, uow ports.UnitOfWork, t *transaction.Transaction) error {
+	categories, err := uow.CategoryRepository().GetAllByUserID(ctx, t.UserID())
+	if err != nil {
+		return err
+	}
+
+	for _, c := range categories {
+		if c.ID() == t.CategoryID() {
+			if c.Type() != category.TypeExpense {
+				return refund.ErrNotExpense
+			}
+
+			return nil
+		}
+	}
+
+	return errs.NewObjectNotFoundError("category", t.CategoryID().String())
+}
@@ -0,0 +1,141 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func newRefundAmount(t *testing.T, value string) transaction.Amount {
+	t.Helper()
+
+	amount, err := transaction.NewAmountFromString(value)
+	require.NoError(t, err)
+
+	return amount
+}
+
+func TestCreateRefundCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	clothes := category.Restore(tr.CategoryID(), "Одежда", userID, nil, category.TypeExpense, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	previous, err := refund.New(tr, shared.ID{}, newRefundAmount(t, "30"), nil)
+	require.NoError(t, err)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{clothes}, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return([]*refund.Refund{previous}, nil).Once()
+	m.refund.EXPECT().Add(ctx, mock.AnythingOfType("*refund.Refund")).Return(nil).Once()
+
+	handler, err := commands.NewCreateRefundCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateRefundCommand(userID, tr.ID(), shared.ID{}, newRefundAmount(t, "70"), "не подошел размер")
+	require.NoError(t, err)
+
+	r, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, tr.ID(), r.TransactionID())
+	assert.Equal(t, clothes.ID(), r.CategoryID())
+	assert.Equal(t, "70.00", r.Amount().String())
+	assert.Equal(t, "не подошел размер", r.Note())
+}
+
+func TestCreateRefundCommandHandler_ExceedsOriginal(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	clothes := category.Restore(tr.CategoryID(), "Одежда", userID, nil, category.TypeExpense, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{clothes}, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return(nil, nil).Once()
+
+	handler, err := commands.NewCreateRefundCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateRefundCommand(userID, tr.ID(), shared.ID{}, newRefundAmount(t, "100.01"), "")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, refund.ErrExceedsOriginal)
+}
+
+func TestCreateRefundCommandHandler_NotExpense(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	salary := category.Restore(tr.CategoryID(), "Зарплата", userID, nil, category.TypeIncome, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{salary}, nil).Once()
+
+	handler, err := commands.NewCreateRefundCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateRefundCommand(userID, tr.ID(), shared.ID{}, newRefundAmount(t, "10"), "")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, refund.ErrNotExpense)
+}
+
+func TestCreateRefundCommandHandler_ForeignTransaction(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	tr := newOwnedTransaction(t, shared.NewID())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+
+	handler, err := commands.NewCreateRefundCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateRefundCommand(shared.NewID(), tr.ID(), shared.ID{}, newRefundAmount(t, "10"), "")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
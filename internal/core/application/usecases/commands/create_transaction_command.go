@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 )
@@ -9,16 +12,61 @@ type CreateTransactionCommand interface {
 	UserID() shared.ID
 	Amount() transaction.Amount
 	CategoryID() shared.ID
+	Note() string
+
+	// OccurredAt возвращает время операции или нулевое время, если операция совершена сейчас.
+	OccurredAt() time.Time
+
+	// FiscalID возвращает реквизиты чека или нулевое значение, если транзакция записывается не по чеку.
+	FiscalID() receipt.FiscalID
+
+	// Tags возвращает названия меток транзакции. Недостающие метки создаются при записи.
+	Tags() []string
 }
 
 type createTransactionCommand struct {
 	userID     shared.ID
 	amount     transaction.Amount
 	categoryID shared.ID
+	note       string
+	occurredAt time.Time
+	fiscalID   receipt.FiscalID
+	tags       []string
 }
 
-func NewCreateTransactionCommand(userID shared.ID, amount transaction.Amount, categoryID shared.ID) (CreateTransactionCommand, error) {
-	return &createTransactionCommand{userID: userID, amount: amount, categoryID: categoryID}, nil
+func NewCreateTransactionCommand(
+	userID shared.ID,
+	amount transaction.Amount,
+	categoryID shared.ID,
+	note string,
+	tags ...string,
+) (CreateTransactionCommand, error) {
+	return &createTransactionCommand{userID: userID, amount: amount, categoryID: categoryID, note: note, tags: tags}, nil
+}
+
+// NewCreateTransactionFromReceiptCommand создает команду записи транзакции по кассовому чеку:
+// сумма и время операции берутся из чека, реквизиты чека сохраняются, чтобы не записать его дважды.
+func NewCreateTransactionFromReceiptCommand(
+	userID shared.ID,
+	r receipt.Receipt,
+	categoryID shared.ID,
+	note string,
+	tags ...string,
+) (CreateTransactionCommand, error) {
+	amount, err := transaction.NewAmount(r.Amount())
+	if err != nil {
+		return nil, err
+	}
+
+	return &createTransactionCommand{
+		userID:     userID,
+		amount:     amount,
+		categoryID: categoryID,
+		note:       note,
+		occurredAt: r.OccurredAt(),
+		fiscalID:   r.FiscalID(),
+		tags:       tags,
+	}, nil
 }
 
 func (c createTransactionCommand) UserID() shared.ID {
@@ -32,3 +80,19 @@ func (c createTransactionCommand) Amount() transaction.Amount {
 func (c createTransactionCommand) CategoryID() shared.ID {
 	return c.categoryID
 }
+
+func (c createTransactionCommand) Note() string {
+	return c.note
+}
+
+func (c createTransactionCommand) OccurredAt() time.Time {
+	return c.occurredAt
+}
+
+func (c createTransactionCommand) FiscalID() receipt.FiscalID {
+	return c.fiscalID
+}
+
+func (c createTransactionCommand) Tags() []string {
+	return c.tags
+}
@@ -3,57 +3,129 @@ package commands
 import (
 	"context"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
 )
 
 type CreateTransactionCommandHandler interface {
-	Handle(ctx context.Context, command CreateTransactionCommand) error
+	// Handle записывает транзакцию и возвращает её. Если чек команды уже записан, возвращает receipt.ErrAlreadyRecorded.
+	Handle(ctx context.Context, command CreateTransactionCommand) (*transaction.Transaction, error)
 }
 
 type createTransactionCommandHandler struct {
-	logger ports.Logger
-	uow    ports.UnitOfWork
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
 }
 
-func NewCreateTransactionCommandHandler(logger ports.Logger, uow ports.UnitOfWork) (CreateTransactionCommandHandler, error) {
+func NewCreateTransactionCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (CreateTransactionCommandHandler, error) {
 	if logger == nil {
 		return nil, errs.NewValueIsRequiredError("logger")
 	}
 
-	if uow == nil {
-		return nil, errs.NewValueIsRequiredError("uow")
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
 	}
 
 	return &createTransactionCommandHandler{
-		logger: logger,
-		uow:    uow,
+		logger:     logger,
+		uowFactory: uowFactory,
 	}, nil
 }
 
-func (t createTransactionCommandHandler) Handle(ctx context.Context, command CreateTransactionCommand) error {
+func (t createTransactionCommandHandler) Handle(ctx context.Context, command CreateTransactionCommand) (*transaction.Transaction, error) {
+	uow, err := t.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
 	defer func(uow ports.UnitOfWork) {
 		err := uow.RollbackUnlessCommitted()
 		if err != nil {
 			t.logger.Error("create transaction command handler: rollback failed", "err", err)
 		}
-	}(t.uow)
+	}(uow)
 
-	err := t.uow.Begin(ctx)
+	err = uow.Begin(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	nt, err := transaction.New(command.UserID(), command.Amount(), command.CategoryID())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = t.uow.TransactionRepository().Add(ctx, nt)
+	err = nt.SetNote(command.Note())
+	if err != nil {
+		return nil, err
+	}
+
+	if !command.OccurredAt().IsZero() {
+		if err := nt.SetOccurredAt(command.OccurredAt()); err != nil {
+			return nil, err
+		}
+	}
+
+	if fiscalID := command.FiscalID(); !fiscalID.IsZero() {
+		recorded, err := uow.TransactionRepository().HasFiscalID(ctx, command.UserID(), fiscalID)
+		if err != nil {
+			return nil, err
+		}
+
+		if recorded {
+			return nil, receipt.ErrAlreadyRecorded
+		}
+
+		nt.SetFiscalID(fiscalID)
+	}
+
+	err = uow.TransactionRepository().Add(ctx, nt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(command.Tags()) > 0 {
+		if err := t.tag(ctx, uow, command, nt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return nt, nil
+}
+
+// tag связывает транзакцию с метками команды, создавая метки, которых у пользователя еще нет.
+func (t createTransactionCommandHandler) tag(ctx context.Context, uow ports.UnitOfWork, command CreateTransactionCommand, nt *transaction.Transaction) error {
+	if len(command.Tags()) > tag.MaxPerTransaction {
+		return tag.ErrTooMany
+	}
+
+	tags := make([]*tag.Tag, 0, len(command.Tags()))
+	for _, name := range command.Tags() {
+		tg, err := tag.New(command.UserID(), name)
+		if err != nil {
+			return err
+		}
+
+		tags = append(tags, tg)
+	}
+
+	stored, err := uow.TagRepository().Ensure(ctx, command.UserID(), tags)
 	if err != nil {
 		return err
 	}
 
-	return t.uow.Commit(ctx)
+	ids := make([]shared.ID, 0, len(stored))
+	for _, tg := range stored {
+		ids = append(ids, tg.ID())
+	}
+
+	return uow.TagRepository().Link(ctx, nt.ID(), ids)
 }
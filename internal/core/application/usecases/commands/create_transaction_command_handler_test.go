@@ -6,13 +6,16 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
 )
@@ -93,7 +96,7 @@ func TestCreateTransactionCommandHandler_Success(t *testing.T) {
 	categoryID := shared.NewID()
 	amount := createValidAmount(t)
 
-	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID)
+	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID, "")
 	require.NoError(t, err)
 
 	uowMock, transactionRepoMock := setupCreateTransactionMocks()
@@ -121,10 +124,10 @@ func TestCreateTransactionCommandHandler_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewCreateTransactionCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
-	err = handler.Handle(ctx, cmd)
+	_, err = handler.Handle(ctx, cmd)
 	assert.NoError(t, err)
 
 	// Проверяем, что моки вызваны в соответствии с ожиданиями
@@ -141,7 +144,7 @@ func TestCreateTransactionCommandHandler_BeginTransactionError(t *testing.T) {
 	categoryID := shared.NewID()
 	amount := createValidAmount(t)
 
-	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID)
+	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID, "")
 	require.NoError(t, err)
 
 	// В этом тесте TransactionRepository() не будет вызван, так как ошибка происходит в Begin
@@ -162,10 +165,10 @@ func TestCreateTransactionCommandHandler_BeginTransactionError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewCreateTransactionCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
-	err = handler.Handle(ctx, cmd)
+	_, err = handler.Handle(ctx, cmd)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "begin transaction error")
 
@@ -181,7 +184,7 @@ func TestCreateTransactionCommandHandler_CommitError(t *testing.T) {
 	categoryID := shared.NewID()
 	amount := createValidAmount(t)
 
-	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID)
+	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID, "")
 	require.NoError(t, err)
 
 	uowMock, transactionRepoMock := setupCreateTransactionMocks()
@@ -209,10 +212,10 @@ func TestCreateTransactionCommandHandler_CommitError(t *testing.T) {
 		Return(errors.New("commit error")).
 		Once()
 
-	handler, err := commands.NewCreateTransactionCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
-	err = handler.Handle(ctx, cmd)
+	_, err = handler.Handle(ctx, cmd)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "commit error")
 
@@ -229,7 +232,7 @@ func TestCreateTransactionCommandHandler_TransactionValidationError(t *testing.T
 	zeroID := shared.ID{}
 	amount := createValidAmount(t)
 
-	cmd, err := commands.NewCreateTransactionCommand(zeroID, amount, zeroID)
+	cmd, err := commands.NewCreateTransactionCommand(zeroID, amount, zeroID, "")
 	require.NoError(t, err)
 
 	// В этом тесте TransactionRepository() не будет вызван, так как ошибка происходит при создании транзакции
@@ -249,10 +252,10 @@ func TestCreateTransactionCommandHandler_TransactionValidationError(t *testing.T
 		Once()
 	// Commit не вызывается при ошибке создания транзакции
 
-	handler, err := commands.NewCreateTransactionCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
-	err = handler.Handle(ctx, cmd)
+	_, err = handler.Handle(ctx, cmd)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid user id") // или "invalid category id"
 
@@ -270,7 +273,7 @@ func TestCreateTransactionCommandHandler_AddTransactionError(t *testing.T) {
 	categoryID := shared.NewID()
 	amount := createValidAmount(t)
 
-	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID)
+	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID, "")
 	require.NoError(t, err)
 
 	uowMock, transactionRepoMock := setupCreateTransactionMocks()
@@ -295,10 +298,10 @@ func TestCreateTransactionCommandHandler_AddTransactionError(t *testing.T) {
 		Once()
 	// Commit не вызывается при ошибке добавления транзакции
 
-	handler, err := commands.NewCreateTransactionCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
-	err = handler.Handle(ctx, cmd)
+	_, err = handler.Handle(ctx, cmd)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "add transaction error")
 
@@ -315,7 +318,7 @@ func TestCreateTransactionCommandHandler_InvalidUserID(t *testing.T) {
 	categoryID := shared.NewID()
 	amount := createValidAmount(t)
 
-	cmd, err := commands.NewCreateTransactionCommand(zeroID, amount, categoryID)
+	cmd, err := commands.NewCreateTransactionCommand(zeroID, amount, categoryID, "")
 	require.NoError(t, err)
 
 	// В этом тесте TransactionRepository() не будет вызван, так как ошибка происходит при создании транзакции
@@ -335,10 +338,10 @@ func TestCreateTransactionCommandHandler_InvalidUserID(t *testing.T) {
 		Once()
 	// Commit не вызывается при ошибке создания транзакции
 
-	handler, err := commands.NewCreateTransactionCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
-	err = handler.Handle(ctx, cmd)
+	_, err = handler.Handle(ctx, cmd)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid user id")
 
@@ -356,7 +359,7 @@ func TestCreateTransactionCommandHandler_Logging(t *testing.T) {
 	categoryID := shared.NewID()
 	amount := createValidAmount(t)
 
-	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID)
+	cmd, err := commands.NewCreateTransactionCommand(userID, amount, categoryID, "")
 	require.NoError(t, err)
 
 	uowMock, transactionRepoMock := setupCreateTransactionMocks()
@@ -384,12 +387,155 @@ func TestCreateTransactionCommandHandler_Logging(t *testing.T) {
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewCreateTransactionCommandHandler(logger, uowMock)
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
-	err = handler.Handle(ctx, cmd)
+	_, err = handler.Handle(ctx, cmd)
 	assert.NoError(t, err)
 
 	// Проверяем, что логгер не вызвал панику
 	assert.True(t, true) // Заглушка - в реальности проверить вывод логов
 }
+
+func TestCreateTransactionCommandHandler_FromReceipt(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	categoryID := shared.NewID()
+
+	r, err := receipt.Parse("t=20260315T1230&s=1234.50&fn=9287440300090728&i=12345&fp=1234567890&n=1", time.UTC)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateTransactionFromReceiptCommand(userID, r, categoryID, "")
+	require.NoError(t, err)
+
+	uowMock, transactionRepoMock := setupCreateTransactionMocks()
+
+	transactionRepoMock.EXPECT().HasFiscalID(ctx, userID, r.FiscalID()).Return(false, nil).Once()
+
+	var added *transaction.Transaction
+	transactionRepoMock.EXPECT().Add(ctx, mock.AnythingOfType("*transaction.Transaction")).
+		Run(func(_ context.Context, tr *transaction.Transaction) { added = tr }).
+		Return(nil).
+		Once()
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uowMock.EXPECT().Commit(ctx).Return(nil).Once()
+
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	created, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	require.NotNil(t, added)
+	assert.Same(t, added, created)
+	assert.Equal(t, "1234.50", added.Amount().String())
+	assert.Equal(t, time.Date(2026, 3, 15, 12, 30, 0, 0, time.UTC), added.OccurredAt())
+	assert.Equal(t, r.FiscalID(), added.FiscalID())
+
+	transactionRepoMock.AssertExpectations(t)
+	uowMock.AssertExpectations(t)
+}
+
+func TestCreateTransactionCommandHandler_ReceiptAlreadyRecorded(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+
+	r, err := receipt.Parse("t=20260315T1230&s=99&fn=1&i=2&fp=3", time.UTC)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewCreateTransactionFromReceiptCommand(userID, r, shared.NewID(), "")
+	require.NoError(t, err)
+
+	uowMock, transactionRepoMock := setupCreateTransactionMocks()
+
+	transactionRepoMock.EXPECT().HasFiscalID(ctx, userID, r.FiscalID()).Return(true, nil).Once()
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, receipt.ErrAlreadyRecorded)
+
+	transactionRepoMock.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+	uowMock.AssertNotCalled(t, "Commit", ctx)
+}
+
+func TestCreateTransactionCommandHandler_Tags(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+
+	cmd, err := commands.NewCreateTransactionCommand(userID, createValidAmount(t), shared.NewID(), "билеты", "отпуск2026", "#Семья")
+	require.NoError(t, err)
+
+	uowMock, transactionRepoMock := setupCreateTransactionMocks()
+	tagRepoMock := portsmocks.NewTagRepositoryMock(t)
+	uowMock.On("TagRepository").Return(tagRepoMock)
+
+	var added *transaction.Transaction
+	transactionRepoMock.EXPECT().Add(ctx, mock.AnythingOfType("*transaction.Transaction")).
+		Run(func(_ context.Context, tr *transaction.Transaction) { added = tr }).
+		Return(nil).
+		Once()
+
+	vacation := tag.Restore(shared.NewID(), userID, "отпуск2026", time.Now())
+	family := tag.Restore(shared.NewID(), userID, "семья", time.Now())
+
+	tagRepoMock.EXPECT().Ensure(ctx, userID, mock.MatchedBy(func(tags []*tag.Tag) bool {
+		return len(tags) == 2 && tags[0].Name() == "отпуск2026" && tags[1].Name() == "семья"
+	})).Return([]*tag.Tag{vacation, family}, nil).Once()
+
+	tagRepoMock.EXPECT().Link(ctx, mock.AnythingOfType("shared.ID"), []shared.ID{vacation.ID(), family.ID()}).
+		Run(func(_ context.Context, transactionID shared.ID, _ []shared.ID) {
+			assert.Equal(t, added.ID(), transactionID)
+		}).
+		Return(nil).
+		Once()
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uowMock.EXPECT().Commit(ctx).Return(nil).Once()
+
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+}
+
+func TestCreateTransactionCommandHandler_InvalidTag(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	cmd, err := commands.NewCreateTransactionCommand(shared.NewID(), createValidAmount(t), shared.NewID(), "", "два слова")
+	require.NoError(t, err)
+
+	uowMock, transactionRepoMock := setupCreateTransactionMocks()
+
+	transactionRepoMock.EXPECT().Add(ctx, mock.AnythingOfType("*transaction.Transaction")).Return(nil).Once()
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	handler, err := commands.NewCreateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, tag.ErrInvalidName)
+
+	uowMock.AssertNotCalled(t, "Commit", ctx)
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type DeleteTransactionCommand interface {
+	UserID() shared.ID
+	TransactionID() shared.ID
+}
+
+type deleteTransactionCommand struct {
+	userID        shared.ID
+	transactionID shared.ID
+}
+
+func NewDeleteTransactionCommand(userID shared.ID, transactionID shared.ID) (DeleteTransactionCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if transactionID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("transactionID")
+	}
+
+	return &deleteTransactionCommand{userID: userID, transactionID: transactionID}, nil
+}
+
+func (c deleteTransactionCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c deleteTransactionCommand) TransactionID() shared.ID {
+	return c.transactionID
+}
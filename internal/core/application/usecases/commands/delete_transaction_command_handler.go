@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type DeleteTransactionCommandHandler interface {
+	// Handle удаляет транзакцию пользователя вместе с вложениями.
+	// Возвращает errs.ErrObjectNotFound, если у пользователя нет такой транзакции.
+	Handle(ctx context.Context, command DeleteTransactionCommand) error
+}
+
+var _ DeleteTransactionCommandHandler = deleteTransactionCommandHandler{}
+
+type deleteTransactionCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+	blobs      ports.BlobStore
+}
+
+// NewDeleteTransactionCommandHandler создает обработчик удаления транзакций. blobs может быть nil,
+// если копии вложений не сохраняются.
+func NewDeleteTransactionCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory, blobs ports.BlobStore) (DeleteTransactionCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &deleteTransactionCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+		blobs:      blobs,
+	}, nil
+}
+
+func (h deleteTransactionCommandHandler) Handle(ctx context.Context, command DeleteTransactionCommand) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("delete transaction command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+
+	t, err := uow.TransactionRepository().Get(ctx, command.TransactionID())
+	if err != nil {
+		return err
+	}
+
+	if t.UserID() != command.UserID() {
+		return errs.NewObjectNotFoundError("transaction", command.TransactionID().String())
+	}
+
+	attachments, err := uow.AttachmentRepository().FindByTransactionID(ctx, t.ID())
+	if err != nil {
+		return err
+	}
+
+	// Вложения удаляются из базы каскадно вместе с транзакцией.
+	if err := uow.TransactionRepository().Delete(ctx, t.ID()); err != nil {
+		return err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return err
+	}
+
+	// Копии удаляются после фиксации: если удаление не удалось, копии остаются нужны.
+	for _, a := range attachments {
+		if h.blobs == nil || a.StorageKey() == "" {
+			continue
+		}
+
+		if err := h.blobs.Delete(ctx, a.StorageKey()); err != nil {
+			h.logger.Error("delete transaction command handler: delete copy failed", "key", a.StorageKey(), "err", err)
+		}
+	}
+
+	return nil
+}
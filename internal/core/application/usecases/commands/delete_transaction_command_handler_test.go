@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestDeleteTransactionCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	m := newAttachFileMocks(t)
+
+	a, err := attachment.New(tr.ID(), userID, attachment.KindPhoto, "file", "unique")
+	require.NoError(t, err)
+	a.SetStorageKey(a.DefaultStorageKey())
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.attachment.EXPECT().FindByTransactionID(ctx, tr.ID()).Return([]*attachment.Attachment{a}, nil).Once()
+	m.transaction.EXPECT().Delete(ctx, tr.ID()).Return(nil).Once()
+	m.blobs.EXPECT().Delete(ctx, a.StorageKey()).Return(errors.New("disk is gone")).Once()
+
+	handler, err := commands.NewDeleteTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.blobs)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewDeleteTransactionCommand(userID, tr.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, cmd))
+	assert.Contains(t, buf.String(), "delete copy failed")
+}
+
+func TestDeleteTransactionCommandHandler_NotOwner(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	tr := newOwnedTransaction(t, shared.NewID())
+	m := newAttachFileMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+
+	handler, err := commands.NewDeleteTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), nil)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewDeleteTransactionCommand(shared.NewID(), tr.ID())
+	require.NoError(t, err)
+
+	require.ErrorIs(t, handler.Handle(ctx, cmd), errs.ErrObjectNotFound)
+
+	m.transaction.AssertNotCalled(t, "Delete", ctx, tr.ID())
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+)
+
+// digestTopCategories количество категорий, перечисляемых в сводке поименно.
+const digestTopCategories = 5
+
+// composeDigest формирует текст сводки, например:
+//
+//	Вчера: расходы 2 450 ₽ (кафе 800, продукты 1 650)
+func composeDigest(mode settings.DigestMode, summary *report.Summary, loc *time.Location) string {
+	title := "Вчера"
+	if mode == settings.DigestModeWeekly {
+		title = fmt.Sprintf(
+			"За неделю %s–%s",
+			summary.From().In(loc).Format("02.01"),
+			summary.To().In(loc).AddDate(0, 0, -1).Format("02.01"),
+		)
+	}
+
+	var lines []string
+
+	if len(summary.Expenses()) > 0 {
+		lines = append(lines, fmt.Sprintf(
+			"%s: расходы %s (%s)",
+			title,
+			report.FormatMoney(summary.TotalExpense()),
+			listCategories(summary.Expenses()),
+		))
+	} else {
+		lines = append(lines, title+": расходов нет")
+	}
+
+	if len(summary.Incomes()) > 0 {
+		lines = append(lines, fmt.Sprintf(
+			"Доходы %s (%s)",
+			report.FormatMoney(summary.TotalIncome()),
+			listCategories(summary.Incomes()),
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func listCategories(totals []report.CategoryTotal) string {
+	parts := make([]string, 0, digestTopCategories+1)
+
+	for i, t := range totals {
+		if i == digestTopCategories {
+			parts = append(parts, fmt.Sprintf("и еще %d", len(totals)-digestTopCategories))
+			break
+		}
+
+		parts = append(parts, fmt.Sprintf("%s %s", t.Name(), report.FormatAmount(t.Amount())))
+	}
+
+	return strings.Join(parts, ", ")
+}
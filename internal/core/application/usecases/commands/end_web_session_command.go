@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type EndWebSessionCommand interface {
+	SessionSecret() string
+}
+
+type endWebSessionCommand struct {
+	sessionSecret string
+}
+
+func NewEndWebSessionCommand(sessionSecret string) (EndWebSessionCommand, error) {
+	if sessionSecret == "" {
+		return nil, errs.NewValueIsRequiredError("sessionSecret")
+	}
+
+	return &endWebSessionCommand{sessionSecret: sessionSecret}, nil
+}
+
+func (c endWebSessionCommand) SessionSecret() string {
+	return c.sessionSecret
+}
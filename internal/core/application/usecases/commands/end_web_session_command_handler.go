@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type EndWebSessionCommandHandler interface {
+	// Handle завершает сеанс веб-панели. Завершение неизвестного сеанса ошибкой не считается.
+	Handle(ctx context.Context, command EndWebSessionCommand) error
+}
+
+type endWebSessionCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewEndWebSessionCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (EndWebSessionCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &endWebSessionCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h endWebSessionCommandHandler) Handle(ctx context.Context, command EndWebSessionCommand) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("end web session command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	s, err := uow.WebSessionRepository().GetByHash(ctx, websession.Hash(command.SessionSecret()))
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := uow.WebSessionRepository().Delete(ctx, s.ID()); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
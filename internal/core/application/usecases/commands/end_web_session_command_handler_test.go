@@ -0,0 +1,59 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestEndWebSessionCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	s := websession.Restore(shared.NewID(), shared.NewID(), websession.Hash("secret"), now, now.Add(websession.SessionTTL), now)
+
+	m := newWebSessionMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+	m.sessions.EXPECT().GetByHash(ctx, websession.Hash("secret")).Return(s, nil).Once()
+	m.sessions.EXPECT().Delete(ctx, s.ID()).Return(nil).Once()
+
+	handler, err := commands.NewEndWebSessionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewEndWebSessionCommand("secret")
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, cmd))
+}
+
+func TestEndWebSessionCommandHandler_UnknownSession(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	m := newWebSessionMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.sessions.EXPECT().GetByHash(ctx, websession.Hash("secret")).Return(nil, errs.NewObjectNotFoundError("web session", "hash")).Once()
+
+	handler, err := commands.NewEndWebSessionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewEndWebSessionCommand("secret")
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, cmd))
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type ImportTransactionsCommand interface {
+	UserID() shared.ID
+	Items() []statement.ImportItem
+}
+
+type importTransactionsCommand struct {
+	userID shared.ID
+	items  []statement.ImportItem
+}
+
+// NewImportTransactionsCommand создает команду импорта подготовленных операций выписки.
+func NewImportTransactionsCommand(userID shared.ID, items []statement.ImportItem) (ImportTransactionsCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if len(items) == 0 {
+		return nil, errs.NewValueIsRequiredError("items")
+	}
+
+	return &importTransactionsCommand{userID: userID, items: items}, nil
+}
+
+func (c importTransactionsCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c importTransactionsCommand) Items() []statement.ImportItem {
+	return c.items
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type ImportTransactionsCommandHandler interface {
+	// Handle сохраняет операции одной транзакцией и возвращает число импортированных.
+	// Операции, импортированные ранее, и операции без категории пропускаются.
+	Handle(ctx context.Context, command ImportTransactionsCommand) (int, error)
+}
+
+var _ ImportTransactionsCommandHandler = importTransactionsCommandHandler{}
+
+type importTransactionsCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewImportTransactionsCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (ImportTransactionsCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &importTransactionsCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+	}, nil
+}
+
+func (h importTransactionsCommandHandler) Handle(ctx context.Context, command ImportTransactionsCommand) (int, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return 0, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("import transactions command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return 0, err
+	}
+
+	fingerprints := make([]string, 0, len(command.Items()))
+	for _, item := range command.Items() {
+		fingerprints = append(fingerprints, item.Fingerprint())
+	}
+
+	// Повторная проверка внутри транзакции отсекает операции, импортированные
+	// после показа предпросмотра, например при двойном нажатии на кнопку.
+	existing, err := uow.TransactionRepository().FindFingerprints(ctx, command.UserID(), fingerprints)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]bool, len(existing)+len(fingerprints))
+	for _, f := range existing {
+		seen[f] = true
+	}
+
+	imported := 0
+
+	for _, item := range command.Items() {
+		if !item.Importable() || seen[item.Fingerprint()] {
+			continue
+		}
+
+		seen[item.Fingerprint()] = true
+
+		nt, err := newImportedTransaction(command.UserID(), item)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %w", item.Record().Line(), err)
+		}
+
+		if err := uow.TransactionRepository().Add(ctx, nt); err != nil {
+			return 0, err
+		}
+
+		imported++
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return imported, nil
+}
+
+func newImportedTransaction(userID shared.ID, item statement.ImportItem) (*transaction.Transaction, error) {
+	r := item.Record()
+
+	amount, err := transaction.NewAmount(r.Amount())
+	if err != nil {
+		return nil, err
+	}
+
+	nt, err := transaction.New(userID, amount, item.Category().ID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := nt.SetNote(truncateNote(r.Description())); err != nil {
+		return nil, err
+	}
+
+	if err := nt.SetOccurredAt(r.OccurredAt()); err != nil {
+		return nil, err
+	}
+
+	nt.SetFingerprint(item.Fingerprint())
+
+	return nt, nil
+}
+
+// truncateNote обрезает описание операции из выписки до допустимой длины комментария.
+func truncateNote(note string) string {
+	runes := []rune(note)
+	if len(runes) <= transaction.MaxNoteLength {
+		return note
+	}
+
+	return string(runes[:transaction.MaxNoteLength])
+}
@@ -0,0 +1,126 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+func newImportPlan(t *testing.T, userID shared.ID, rows [][]string) *statement.ImportPlan {
+	t.Helper()
+
+	header := []string{"Дата операции", "Статус", "Сумма операции", "Категория", "Описание"}
+	table, err := statement.NewTable(header, rows)
+	require.NoError(t, err)
+
+	mapping, ok := statement.DetectMapping(header)
+	require.True(t, ok)
+
+	other, err := category.New("Прочее", category.TypeExpense, userID, nil)
+	require.NoError(t, err)
+
+	records, rowErrors := mapping.Parse(table, time.UTC)
+	require.Empty(t, rowErrors)
+
+	return statement.NewImportPlan(mapping, records, nil, statement.NewCategorizer([]*category.Category{other}, nil))
+}
+
+func TestImportTransactionsCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	plan := newImportPlan(t, userID, [][]string{
+		{"15.03.2026 09:00:00", "OK", "-150,00", "Кафе", "Кофе"},
+		{"15.03.2026 09:00:00", "OK", "-150,00", "Кафе", "Кофе"},
+		{"16.03.2026 13:00:00", "OK", "-420,00", "Кафе", "Обед"},
+		{"17.03.2026 10:00:00", "OK", "5000,00", "Пополнения", "Перевод"},
+	})
+	fingerprints := plan.Fingerprints()
+
+	transactionRepoMock := portsmocks.NewTransactionRepositoryMock(t)
+	uowMock := portsmocks.NewUnitOfWorkMock(t)
+	uowMock.On("TransactionRepository").Return(transactionRepoMock)
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uowMock.EXPECT().Commit(ctx).Return(nil).Once()
+
+	// Обед импортирован между предпросмотром и подтверждением.
+	transactionRepoMock.EXPECT().FindFingerprints(ctx, userID, fingerprints).Return([]string{fingerprints[2]}, nil).Once()
+
+	var added []*transaction.Transaction
+	transactionRepoMock.EXPECT().Add(ctx, mock.AnythingOfType("*transaction.Transaction")).
+		Run(func(_ context.Context, tr *transaction.Transaction) { added = append(added, tr) }).
+		Return(nil).
+		Times(2)
+
+	handler, err := commands.NewImportTransactionsCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewImportTransactionsCommand(userID, plan.Items())
+	require.NoError(t, err)
+
+	imported, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, 2, imported)
+
+	require.Len(t, added, 2)
+	for i, tr := range added {
+		assert.Equal(t, userID, tr.UserID())
+		assert.Equal(t, "150.00", tr.Amount().String())
+		assert.Equal(t, "Кофе", tr.Note())
+		assert.Equal(t, time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC), tr.OccurredAt())
+		assert.Equal(t, fingerprints[i], tr.Fingerprint())
+	}
+}
+
+func TestImportTransactionsCommandHandler_AddFails(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	plan := newImportPlan(t, userID, [][]string{
+		{"15.03.2026 09:00:00", "OK", "-150,00", "Кафе", "Кофе"},
+		{"16.03.2026 13:00:00", "OK", "-420,00", "Кафе", "Обед"},
+	})
+
+	transactionRepoMock := portsmocks.NewTransactionRepositoryMock(t)
+	uowMock := portsmocks.NewUnitOfWorkMock(t)
+	uowMock.On("TransactionRepository").Return(transactionRepoMock)
+
+	addErr := errors.New("db error")
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	transactionRepoMock.EXPECT().FindFingerprints(ctx, userID, plan.Fingerprints()).Return(nil, nil).Once()
+	transactionRepoMock.EXPECT().Add(ctx, mock.AnythingOfType("*transaction.Transaction")).Return(addErr).Once()
+
+	handler, err := commands.NewImportTransactionsCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewImportTransactionsCommand(userID, plan.Items())
+	require.NoError(t, err)
+
+	imported, err := handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, addErr)
+	assert.Zero(t, imported)
+
+	uowMock.AssertNotCalled(t, "Commit", ctx)
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type IssueAPITokenCommand interface {
+	UserID() shared.ID
+	Name() string
+	Scopes() []apitoken.Scope
+
+	// ExpiresAt возвращает момент, с которого токен перестанет приниматься, или нулевое время для бессрочного токена.
+	ExpiresAt() time.Time
+
+	Now() time.Time
+}
+
+type issueAPITokenCommand struct {
+	userID    shared.ID
+	name      string
+	scopes    []apitoken.Scope
+	expiresAt time.Time
+	now       time.Time
+}
+
+func NewIssueAPITokenCommand(
+	userID shared.ID,
+	name string,
+	scopes []apitoken.Scope,
+	expiresAt time.Time,
+	now time.Time,
+) (IssueAPITokenCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &issueAPITokenCommand{
+		userID:    userID,
+		name:      name,
+		scopes:    slices.Clone(scopes),
+		expiresAt: expiresAt,
+		now:       now,
+	}, nil
+}
+
+func (c issueAPITokenCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c issueAPITokenCommand) Name() string {
+	return c.name
+}
+
+func (c issueAPITokenCommand) Scopes() []apitoken.Scope {
+	return c.scopes
+}
+
+func (c issueAPITokenCommand) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+func (c issueAPITokenCommand) Now() time.Time {
+	return c.now
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// IssuedAPIToken выпущенный токен и его секрет. Секрет больше нигде не хранится,
+// поэтому его нужно сразу передать пользователю.
+type IssuedAPIToken struct {
+	Token  *apitoken.Token
+	Secret string
+}
+
+type IssueAPITokenCommandHandler interface {
+	// Handle выпускает токен. Возвращает apitoken.ErrTooMany, если у пользователя
+	// уже apitoken.MaxActivePerUser действующих токенов.
+	Handle(ctx context.Context, command IssueAPITokenCommand) (*IssuedAPIToken, error)
+}
+
+type issueAPITokenCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewIssueAPITokenCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (IssueAPITokenCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &issueAPITokenCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h issueAPITokenCommandHandler) Handle(ctx context.Context, command IssueAPITokenCommand) (*IssuedAPIToken, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("issue api token command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := uow.APITokenRepository().FindByUserID(ctx, command.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	active := 0
+	for _, t := range tokens {
+		if t.IsActive(command.Now()) {
+			active++
+		}
+	}
+
+	if active >= apitoken.MaxActivePerUser {
+		return nil, apitoken.ErrTooMany
+	}
+
+	t, secret, err := apitoken.Issue(command.UserID(), command.Name(), command.Scopes(), command.ExpiresAt(), command.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.APITokenRepository().Add(ctx, t); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &IssuedAPIToken{Token: t, Secret: secret}, nil
+}
@@ -0,0 +1,147 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+type issueAPITokenMocks struct {
+	uow    *portsmocks.UnitOfWorkMock
+	tokens *portsmocks.APITokenRepositoryMock
+}
+
+func newIssueAPITokenMocks(t *testing.T) issueAPITokenMocks {
+	m := issueAPITokenMocks{
+		uow:    portsmocks.NewUnitOfWorkMock(t),
+		tokens: portsmocks.NewAPITokenRepositoryMock(t),
+	}
+
+	m.uow.On("APITokenRepository").Return(m.tokens).Maybe()
+
+	return m
+}
+
+func TestIssueAPITokenCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	now := time.Now()
+
+	m := newIssueAPITokenMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+	m.tokens.EXPECT().FindByUserID(ctx, userID).Return(nil, nil).Once()
+	m.tokens.EXPECT().Add(ctx, mock.AnythingOfType("*apitoken.Token")).Return(nil).Once()
+
+	handler, err := commands.NewIssueAPITokenCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewIssueAPITokenCommand(userID, "cli", []apitoken.Scope{apitoken.ScopeRead}, time.Time{}, now)
+	require.NoError(t, err)
+
+	issued, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, userID, issued.Token.UserID())
+	assert.Equal(t, apitoken.Hash(issued.Secret), issued.Token.Hash())
+}
+
+func TestIssueAPITokenCommandHandler_TooMany(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	now := time.Now()
+
+	existing := make([]*apitoken.Token, 0, apitoken.MaxActivePerUser)
+	for range apitoken.MaxActivePerUser {
+		existing = append(existing, apitoken.Restore(
+			shared.NewID(), userID, "cli", []byte("hash"), "ctb_abcd",
+			[]apitoken.Scope{apitoken.ScopeRead}, time.Time{}, time.Time{}, now,
+		))
+	}
+
+	m := newIssueAPITokenMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.tokens.EXPECT().FindByUserID(ctx, userID).Return(existing, nil).Once()
+
+	handler, err := commands.NewIssueAPITokenCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewIssueAPITokenCommand(userID, "cli", []apitoken.Scope{apitoken.ScopeRead}, time.Time{}, now)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, apitoken.ErrTooMany)
+}
+
+func TestIssueAPITokenCommandHandler_ExpiredTokensDoNotCount(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	now := time.Now()
+
+	existing := make([]*apitoken.Token, 0, apitoken.MaxActivePerUser)
+	for range apitoken.MaxActivePerUser {
+		existing = append(existing, apitoken.Restore(
+			shared.NewID(), userID, "cli", []byte("hash"), "ctb_abcd",
+			[]apitoken.Scope{apitoken.ScopeRead}, now.Add(-time.Hour), time.Time{}, now.AddDate(0, -1, 0),
+		))
+	}
+
+	m := newIssueAPITokenMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+	m.tokens.EXPECT().FindByUserID(ctx, userID).Return(existing, nil).Once()
+	m.tokens.EXPECT().Add(ctx, mock.AnythingOfType("*apitoken.Token")).Return(nil).Once()
+
+	handler, err := commands.NewIssueAPITokenCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewIssueAPITokenCommand(userID, "cli", []apitoken.Scope{apitoken.ScopeWrite}, time.Time{}, now)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+}
+
+func TestIssueAPITokenCommandHandler_InvalidName(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+
+	m := newIssueAPITokenMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.tokens.EXPECT().FindByUserID(ctx, userID).Return(nil, nil).Once()
+
+	handler, err := commands.NewIssueAPITokenCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewIssueAPITokenCommand(userID, " ", []apitoken.Scope{apitoken.ScopeRead}, time.Time{}, time.Now())
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, apitoken.ErrInvalidName)
+}
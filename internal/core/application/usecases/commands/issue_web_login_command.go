@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type IssueWebLoginCommand interface {
+	UserID() shared.ID
+	Now() time.Time
+}
+
+type issueWebLoginCommand struct {
+	userID shared.ID
+	now    time.Time
+}
+
+func NewIssueWebLoginCommand(userID shared.ID, now time.Time) (IssueWebLoginCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &issueWebLoginCommand{userID: userID, now: now}, nil
+}
+
+func (c issueWebLoginCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c issueWebLoginCommand) Now() time.Time {
+	return c.now
+}
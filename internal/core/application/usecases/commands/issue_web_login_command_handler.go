@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// IssuedWebLogin секрет одноразовой ссылки для входа в веб-панель и срок ее действия.
+type IssuedWebLogin struct {
+	Secret    string
+	ExpiresAt time.Time
+}
+
+type IssueWebLoginCommandHandler interface {
+	// Handle создает ссылку для входа. Заодно удаляет истекшие сеансы и ссылки всех пользователей.
+	Handle(ctx context.Context, command IssueWebLoginCommand) (*IssuedWebLogin, error)
+}
+
+type issueWebLoginCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewIssueWebLoginCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (IssueWebLoginCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &issueWebLoginCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h issueWebLoginCommandHandler) Handle(ctx context.Context, command IssueWebLoginCommand) (*IssuedWebLogin, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("issue web login command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.WebSessionRepository().DeleteExpired(ctx, command.Now()); err != nil {
+		return nil, err
+	}
+
+	s, secret, err := websession.New(command.UserID(), command.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.WebSessionRepository().Add(ctx, s); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &IssuedWebLogin{Secret: secret, ExpiresAt: s.ExpiresAt()}, nil
+}
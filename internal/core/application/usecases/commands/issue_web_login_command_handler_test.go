@@ -0,0 +1,65 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+func TestIssueWebLoginCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	now := time.Now()
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewWebSessionRepositoryMock(t)
+	uow.On("WebSessionRepository").Return(repo)
+
+	var added *websession.Session
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uow.EXPECT().Commit(ctx).Return(nil).Once()
+	repo.EXPECT().DeleteExpired(ctx, now).Return(nil).Once()
+	repo.EXPECT().Add(ctx, mock.AnythingOfType("*websession.Session")).RunAndReturn(func(_ context.Context, s *websession.Session) error {
+		added = s
+		return nil
+	}).Once()
+
+	handler, err := commands.NewIssueWebLoginCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewIssueWebLoginCommand(userID, now)
+	require.NoError(t, err)
+
+	issued, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	require.NotNil(t, added)
+	assert.Equal(t, userID, added.UserID())
+	assert.Equal(t, websession.Hash(issued.Secret), added.Hash())
+	assert.Equal(t, now.Add(websession.LinkTTL), issued.ExpiresAt)
+	assert.False(t, added.IsActivated())
+}
+
+func TestNewIssueWebLoginCommand_Invalid(t *testing.T) {
+	_, err := commands.NewIssueWebLoginCommand(shared.ID{}, time.Now())
+	require.Error(t, err)
+
+	_, err = commands.NewIssueWebLoginCommand(shared.NewID(), time.Time{})
+	require.Error(t, err)
+}
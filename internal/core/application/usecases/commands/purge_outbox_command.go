@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type PurgeOutboxCommand interface {
+	Now() time.Time
+	// DeliveredRetention срок хранения доставленных сообщений.
+	DeliveredRetention() time.Duration
+	// DeadRetention срок хранения сообщений, которые так и не удалось доставить. Они нужны
+	// для разбора ошибок, поэтому обычно хранятся дольше доставленных.
+	DeadRetention() time.Duration
+}
+
+type purgeOutboxCommand struct {
+	now                time.Time
+	deliveredRetention time.Duration
+	deadRetention      time.Duration
+}
+
+func (c purgeOutboxCommand) Now() time.Time {
+	return c.now
+}
+
+func (c purgeOutboxCommand) DeliveredRetention() time.Duration {
+	return c.deliveredRetention
+}
+
+func (c purgeOutboxCommand) DeadRetention() time.Duration {
+	return c.deadRetention
+}
+
+func NewPurgeOutboxCommand(now time.Time, deliveredRetention, deadRetention time.Duration) (PurgeOutboxCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	if deliveredRetention <= 0 {
+		return nil, errs.NewValueIsInvalidError("deliveredRetention")
+	}
+
+	if deadRetention <= 0 {
+		return nil, errs.NewValueIsInvalidError("deadRetention")
+	}
+
+	return purgeOutboxCommand{now: now, deliveredRetention: deliveredRetention, deadRetention: deadRetention}, nil
+}
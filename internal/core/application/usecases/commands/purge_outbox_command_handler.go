@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type PurgeOutboxCommandHandler interface {
+	// Handle удаляет из outbox доставленные и мертвые сообщения, срок хранения которых истек.
+	// Ожидающие доставки сообщения не удаляются.
+	Handle(ctx context.Context, command PurgeOutboxCommand) error
+}
+
+var _ PurgeOutboxCommandHandler = purgeOutboxCommandHandler{}
+
+type purgeOutboxCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewPurgeOutboxCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (PurgeOutboxCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &purgeOutboxCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h purgeOutboxCommandHandler) Handle(ctx context.Context, command PurgeOutboxCommand) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("purge outbox command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+
+	now := command.Now()
+
+	delivered, err := uow.OutboxRepository().DeleteFinished(ctx, outbox.StatusDelivered, now.Add(-command.DeliveredRetention()))
+	if err != nil {
+		return err
+	}
+
+	dead, err := uow.OutboxRepository().DeleteFinished(ctx, outbox.StatusDead, now.Add(-command.DeadRetention()))
+	if err != nil {
+		return err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return err
+	}
+
+	if delivered > 0 || dead > 0 {
+		h.logger.InfoContext(ctx, "outbox purged", "delivered", delivered, "dead", dead)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+func TestNewPurgeOutboxCommand_Invalid(t *testing.T) {
+	now := time.Now()
+
+	_, err := commands.NewPurgeOutboxCommand(time.Time{}, time.Hour, time.Hour)
+	require.Error(t, err)
+
+	_, err = commands.NewPurgeOutboxCommand(now, 0, time.Hour)
+	require.Error(t, err)
+
+	_, err = commands.NewPurgeOutboxCommand(now, time.Hour, -time.Hour)
+	require.Error(t, err)
+}
+
+func TestPurgeOutboxCommandHandler_DeletesExpiredFinishedMessages(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	m := newRelayOutboxMocks(t)
+	m.expectTx(ctx, 1)
+	m.outbox.EXPECT().DeleteFinished(ctx, outbox.StatusDelivered, now.Add(-7*24*time.Hour)).Return(12, nil).Once()
+	m.outbox.EXPECT().DeleteFinished(ctx, outbox.StatusDead, now.Add(-30*24*time.Hour)).Return(1, nil).Once()
+
+	handler, err := commands.NewPurgeOutboxCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewPurgeOutboxCommand(now, 7*24*time.Hour, 30*24*time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, cmd))
+	require.Contains(t, buf.String(), "delivered=12 dead=1")
+}
+
+func TestPurgeOutboxCommandHandler_DeleteFails(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	errDB := errors.New("db is down")
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewOutboxRepositoryMock(t)
+	uow.On("OutboxRepository").Return(repo)
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	repo.EXPECT().DeleteFinished(ctx, outbox.StatusDelivered, mock.Anything).Return(0, errDB).Once()
+
+	handler, err := commands.NewPurgeOutboxCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewPurgeOutboxCommand(time.Now(), time.Hour, time.Hour)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, handler.Handle(ctx, cmd), errDB)
+	uow.AssertNotCalled(t, "Commit", ctx)
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RelayOutboxCommand interface {
+	Now() time.Time
+	// LockTimeout время, на которое захватываются сообщения. Если результат доставки не сохранен
+	// за это время, сообщения будут доставлены повторно.
+	LockTimeout() time.Duration
+	// BatchSize наибольшее количество сообщений, доставляемых за один вызов.
+	BatchSize() int
+}
+
+type relayOutboxCommand struct {
+	now         time.Time
+	lockTimeout time.Duration
+	batchSize   int
+}
+
+func (c relayOutboxCommand) Now() time.Time {
+	return c.now
+}
+
+func (c relayOutboxCommand) LockTimeout() time.Duration {
+	return c.lockTimeout
+}
+
+func (c relayOutboxCommand) BatchSize() int {
+	return c.batchSize
+}
+
+func NewRelayOutboxCommand(now time.Time, lockTimeout time.Duration, batchSize int) (RelayOutboxCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	if lockTimeout <= 0 {
+		return nil, errs.NewValueIsInvalidError("lockTimeout")
+	}
+
+	if batchSize <= 0 {
+		return nil, errs.NewValueIsInvalidError("batchSize")
+	}
+
+	return relayOutboxCommand{now: now, lockTimeout: lockTimeout, batchSize: batchSize}, nil
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RelayOutboxCommandHandler interface {
+	// Handle доставляет подписчикам очередную порцию событий из outbox и возвращает
+	// количество захваченных сообщений.
+	Handle(ctx context.Context, command RelayOutboxCommand) (int, error)
+}
+
+var _ RelayOutboxCommandHandler = relayOutboxCommandHandler{}
+
+type relayOutboxCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+	codec      ports.EventCodec
+	mediatr    ddd.Mediatr
+}
+
+func NewRelayOutboxCommandHandler(
+	logger ports.Logger,
+	uowFactory ports.UnitOfWorkFactory,
+	codec ports.EventCodec,
+	mediatr ddd.Mediatr,
+) (RelayOutboxCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if codec == nil {
+		return nil, errs.NewValueIsRequiredError("codec")
+	}
+
+	if mediatr == nil {
+		return nil, errs.NewValueIsRequiredError("mediatr")
+	}
+
+	return &relayOutboxCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+		codec:      codec,
+		mediatr:    mediatr,
+	}, nil
+}
+
+// Handle доставляет события не менее одного раза: сообщения захватываются в отдельной транзакции,
+// а результат доставки каждого сохраняется после вызова подписчиков. Ошибка доставки одного
+// сообщения не мешает доставке остальных.
+func (h relayOutboxCommandHandler) Handle(ctx context.Context, command RelayOutboxCommand) (int, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return 0, err
+	}
+
+	// Токен захвата отличает эту порцию от повторного захвата тех же сообщений другим экземпляром.
+	claim := shared.NewID()
+
+	messages, err := h.claim(ctx, uow, claim, command)
+	if err != nil {
+		return 0, err
+	}
+
+	var saveErrs []error
+
+	for _, m := range messages {
+		h.deliver(ctx, m)
+
+		err := h.save(ctx, uow, claim, m)
+
+		switch {
+		case errors.Is(err, outbox.ErrLeaseLost):
+			// Результатом сообщения распоряжается экземпляр, захвативший его повторно.
+			h.logger.Info("relay outbox lease lost, result discarded", "message_id", m.ID().String(), "event", m.Name())
+		case err != nil:
+			h.logger.Error("relay outbox save", "message_id", m.ID().String(), "err", err)
+			saveErrs = append(saveErrs, err)
+		}
+	}
+
+	return len(messages), errors.Join(saveErrs...)
+}
+
+func (h relayOutboxCommandHandler) claim(
+	ctx context.Context,
+	uow ports.UnitOfWork,
+	claim shared.ID,
+	command RelayOutboxCommand,
+) ([]*outbox.Message, error) {
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("relay outbox command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+
+	now := command.Now()
+
+	messages, err := uow.OutboxRepository().ClaimDue(ctx, claim, now, now.Add(command.LockTimeout()), command.BatchSize())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// deliver передает событие подписчикам и отмечает результат в сообщении.
+func (h relayOutboxCommandHandler) deliver(ctx context.Context, m *outbox.Message) {
+	event, err := h.codec.Decode(m.Name(), m.Payload())
+	if err == nil {
+		err = h.mediatr.Publish(ctx, event)
+	}
+
+	if err == nil {
+		m.Delivered(time.Now())
+		return
+	}
+
+	m.Fail(err, time.Now())
+
+	if m.IsDead() {
+		h.logger.Error(
+			"outbox message dead-lettered",
+			"message_id", m.ID().String(),
+			"event", m.Name(),
+			"attempts", m.Attempts(),
+			"err", err,
+		)
+
+		return
+	}
+
+	h.logger.Info(
+		"outbox message delivery failed, will retry",
+		"message_id", m.ID().String(),
+		"event", m.Name(),
+		"attempts", m.Attempts(),
+		"next_attempt_at", m.NextAttemptAt(),
+		"err", err,
+	)
+}
+
+func (h relayOutboxCommandHandler) save(ctx context.Context, uow ports.UnitOfWork, claim shared.ID, m *outbox.Message) error {
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("relay outbox command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+
+	if err := uow.OutboxRepository().Save(ctx, claim, m); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
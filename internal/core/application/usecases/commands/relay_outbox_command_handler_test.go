@@ -0,0 +1,258 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+type relayOutboxMocks struct {
+	uow    *portsmocks.UnitOfWorkMock
+	outbox *portsmocks.OutboxRepositoryMock
+	codec  *portsmocks.EventCodecMock
+}
+
+func newRelayOutboxMocks(t *testing.T) relayOutboxMocks {
+	m := relayOutboxMocks{
+		uow:    portsmocks.NewUnitOfWorkMock(t),
+		outbox: portsmocks.NewOutboxRepositoryMock(t),
+		codec:  portsmocks.NewEventCodecMock(t),
+	}
+
+	m.uow.On("OutboxRepository").Return(m.outbox).Maybe()
+
+	return m
+}
+
+// expectTx ожидает times транзакций: захват сообщений и сохранение результата каждого.
+func (m relayOutboxMocks) expectTx(ctx context.Context, times int) {
+	m.uow.EXPECT().Begin(ctx).Return(nil).Times(times)
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Times(times)
+	m.uow.EXPECT().Commit(ctx).Return(nil).Times(times)
+}
+
+// failingHandler подписчик, не справляющийся с обработкой событий.
+type failingHandler struct {
+	err error
+}
+
+func (h failingHandler) Handle(context.Context, ddd.DomainEvent) error {
+	return h.err
+}
+
+func pendingMessage(attempts int) *outbox.Message {
+	now := time.Now()
+
+	return outbox.Restore(shared.NewID(), "UserRegistered", []byte(`{}`), outbox.StatusPending, attempts, 3, now, "", now, now)
+}
+
+func newRelayOutboxCommand(t *testing.T, now time.Time) commands.RelayOutboxCommand {
+	t.Helper()
+
+	cmd, err := commands.NewRelayOutboxCommand(now, time.Minute, 10)
+	require.NoError(t, err)
+
+	return cmd
+}
+
+func TestRelayOutboxCommandHandler_Delivers(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	message := pendingMessage(1)
+	event := user.UserRegistered{ID: uuid.New(), UserID: shared.NewID(), Name: "Alice"}
+
+	var received []user.UserRegistered
+	mediatr := ddd.NewMediatr()
+	ddd.Subscribe(mediatr, func(_ context.Context, e user.UserRegistered) error {
+		received = append(received, e)
+		return nil
+	})
+
+	m := newRelayOutboxMocks(t)
+	m.expectTx(ctx, 2)
+	m.outbox.EXPECT().ClaimDue(ctx, mock.AnythingOfType("shared.ID"), now, now.Add(time.Minute), 10).Return([]*outbox.Message{message}, nil).Once()
+	m.codec.EXPECT().Decode("UserRegistered", message.Payload()).Return(event, nil).Once()
+	m.outbox.EXPECT().
+		Save(ctx, mock.AnythingOfType("shared.ID"), mock.MatchedBy(func(saved *outbox.Message) bool {
+			return saved.ID() == message.ID() && saved.Status() == outbox.StatusDelivered
+		})).
+		Return(nil).
+		Once()
+
+	handler, err := commands.NewRelayOutboxCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.codec, mediatr)
+	require.NoError(t, err)
+
+	claimed, err := handler.Handle(ctx, newRelayOutboxCommand(t, now))
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	assert.Equal(t, []user.UserRegistered{event}, received)
+}
+
+func TestRelayOutboxCommandHandler_RetriesFailedDelivery(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	failing, delivered := pendingMessage(1), pendingMessage(1)
+	event := user.UserRegistered{ID: uuid.New(), UserID: shared.NewID(), Name: "Alice"}
+
+	subscriber := failingHandler{err: errors.New("subscriber failed")}
+	mediatr := ddd.NewMediatr()
+	mediatr.Subscribe(subscriber, user.UserRegistered{})
+
+	m := newRelayOutboxMocks(t)
+	m.expectTx(ctx, 3)
+	m.outbox.EXPECT().ClaimDue(ctx, mock.AnythingOfType("shared.ID"), now, now.Add(time.Minute), 10).Return([]*outbox.Message{failing, delivered}, nil).Once()
+	m.codec.EXPECT().Decode("UserRegistered", mock.Anything).Return(event, nil).Once()
+	m.codec.EXPECT().Decode("UserRegistered", mock.Anything).Return(nil, errors.New("unknown event")).Once()
+	m.outbox.EXPECT().Save(ctx, mock.AnythingOfType("shared.ID"), mock.AnythingOfType("*outbox.Message")).Return(nil).Twice()
+
+	handler, err := commands.NewRelayOutboxCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.codec, mediatr)
+	require.NoError(t, err)
+
+	claimed, err := handler.Handle(ctx, newRelayOutboxCommand(t, now))
+	require.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+
+	for _, msg := range []*outbox.Message{failing, delivered} {
+		assert.Equal(t, outbox.StatusPending, msg.Status())
+		assert.NotEmpty(t, msg.LastError())
+		assert.True(t, msg.NextAttemptAt().After(now))
+	}
+}
+
+func TestRelayOutboxCommandHandler_DeadLettersExhaustedMessage(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	message := pendingMessage(3)
+
+	subscriber := failingHandler{err: errors.New("subscriber failed")}
+	mediatr := ddd.NewMediatr()
+	mediatr.Subscribe(subscriber, user.UserRegistered{})
+
+	m := newRelayOutboxMocks(t)
+	m.expectTx(ctx, 2)
+	m.outbox.EXPECT().ClaimDue(ctx, mock.AnythingOfType("shared.ID"), now, now.Add(time.Minute), 10).Return([]*outbox.Message{message}, nil).Once()
+	m.codec.EXPECT().Decode("UserRegistered", message.Payload()).Return(user.UserRegistered{ID: uuid.New()}, nil).Once()
+	m.outbox.EXPECT().
+		Save(ctx, mock.AnythingOfType("shared.ID"), mock.MatchedBy(func(saved *outbox.Message) bool {
+			return saved.Status() == outbox.StatusDead
+		})).
+		Return(nil).
+		Once()
+
+	handler, err := commands.NewRelayOutboxCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.codec, mediatr)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, newRelayOutboxCommand(t, now))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "outbox message dead-lettered")
+}
+
+func TestRelayOutboxCommandHandler_SaveError(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	message := pendingMessage(1)
+	saveErr := errors.New("connection lost")
+
+	m := newRelayOutboxMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Twice()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Twice()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+	m.outbox.EXPECT().ClaimDue(ctx, mock.AnythingOfType("shared.ID"), now, now.Add(time.Minute), 10).Return([]*outbox.Message{message}, nil).Once()
+	m.codec.EXPECT().Decode("UserRegistered", message.Payload()).Return(user.UserRegistered{ID: uuid.New()}, nil).Once()
+	m.outbox.EXPECT().Save(ctx, mock.AnythingOfType("shared.ID"), message).Return(saveErr).Once()
+
+	handler, err := commands.NewRelayOutboxCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.codec, ddd.NewMediatr())
+	require.NoError(t, err)
+
+	claimed, err := handler.Handle(ctx, newRelayOutboxCommand(t, now))
+	require.ErrorIs(t, err, saveErr)
+	assert.Equal(t, 1, claimed)
+}
+
+func TestRelayOutboxCommandHandler_LeaseLost(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	stale, saved := pendingMessage(1), pendingMessage(1)
+
+	m := newRelayOutboxMocks(t)
+	m.expectTx(ctx, 2)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	var claim shared.ID
+	m.outbox.EXPECT().
+		ClaimDue(ctx, mock.AnythingOfType("shared.ID"), now, now.Add(time.Minute), 10).
+		Run(func(_ context.Context, c shared.ID, _ time.Time, _ time.Time, _ int) {
+			claim = c
+		}).
+		Return([]*outbox.Message{stale, saved}, nil).
+		Once()
+	m.codec.EXPECT().Decode("UserRegistered", mock.Anything).Return(user.UserRegistered{ID: uuid.New()}, nil).Twice()
+
+	// Захват первого сообщения истек, и его уже захватил другой экземпляр: результат отбрасывается,
+	// а доставка остальных сообщений порции продолжается.
+	m.outbox.EXPECT().
+		Save(ctx, mock.AnythingOfType("shared.ID"), stale).
+		RunAndReturn(func(_ context.Context, c shared.ID, _ *outbox.Message) error {
+			assert.Equal(t, claim, c)
+			return outbox.ErrLeaseLost
+		}).
+		Once()
+	m.outbox.EXPECT().
+		Save(ctx, mock.AnythingOfType("shared.ID"), saved).
+		RunAndReturn(func(_ context.Context, c shared.ID, _ *outbox.Message) error {
+			assert.Equal(t, claim, c)
+			return nil
+		}).
+		Once()
+
+	handler, err := commands.NewRelayOutboxCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.codec, ddd.NewMediatr())
+	require.NoError(t, err)
+
+	claimed, err := handler.Handle(ctx, newRelayOutboxCommand(t, now))
+	require.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+	assert.False(t, claim.IsZero())
+	assert.Contains(t, buf.String(), "relay outbox lease lost")
+}
+
+func TestNewRelayOutboxCommand_Invalid(t *testing.T) {
+	_, err := commands.NewRelayOutboxCommand(time.Time{}, time.Minute, 10)
+	require.Error(t, err)
+
+	_, err = commands.NewRelayOutboxCommand(time.Now(), 0, 10)
+	require.Error(t, err)
+
+	_, err = commands.NewRelayOutboxCommand(time.Now(), time.Minute, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+)
+
+// composeReminder формирует текст напоминания, например:
+//
+//	📝 Вы не записывали траты 2 дня. Отправьте сумму сообщением или выберите ниже.
+func composeReminder(now time.Time, lastActivity time.Time) string {
+	const hint = "Отправьте сумму сообщением или выберите ниже."
+
+	if lastActivity.IsZero() {
+		return "📝 Вы еще не записали ни одной траты. " + hint
+	}
+
+	return fmt.Sprintf("📝 Вы не записывали траты %s. %s", formatSilence(now.Sub(lastActivity)), hint)
+}
+
+func formatSilence(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours < 24 {
+		return fmt.Sprintf("%d %s", hours, plural(hours, "час", "часа", "часов"))
+	}
+
+	days := hours / 24
+
+	return fmt.Sprintf("%d %s", days, plural(days, "день", "дня", "дней"))
+}
+
+// plural выбирает форму слова для числа n по правилам русского языка.
+func plural(n int, one string, few string, many string) string {
+	n %= 100
+	if n >= 11 && n <= 14 {
+		return many
+	}
+
+	switch n % 10 {
+	case 1:
+		return one
+	case 2, 3, 4:
+		return few
+	}
+
+	return many
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RenameCategoryCommand interface {
+	UserID() shared.ID
+	CategoryID() shared.ID
+	Name() string
+}
+
+type renameCategoryCommand struct {
+	userID     shared.ID
+	categoryID shared.ID
+	name       string
+}
+
+func NewRenameCategoryCommand(userID shared.ID, categoryID shared.ID, name string) (RenameCategoryCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if categoryID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("categoryID")
+	}
+
+	return &renameCategoryCommand{userID: userID, categoryID: categoryID, name: name}, nil
+}
+
+func (c renameCategoryCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c renameCategoryCommand) CategoryID() shared.ID {
+	return c.categoryID
+}
+
+func (c renameCategoryCommand) Name() string {
+	return c.name
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RenameCategoryCommandHandler interface {
+	// Handle переименовывает категорию пользователя и возвращает ее. Возвращает errs.ErrObjectNotFound,
+	// если у пользователя нет такой категории.
+	Handle(ctx context.Context, command RenameCategoryCommand) (*category.Category, error)
+}
+
+type renameCategoryCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewRenameCategoryCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (RenameCategoryCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &renameCategoryCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h renameCategoryCommandHandler) Handle(ctx context.Context, command RenameCategoryCommand) (*category.Category, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("rename category command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := uow.CategoryRepository().Get(ctx, command.UserID(), command.CategoryID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Rename(command.Name()); err != nil {
+		return nil, err
+	}
+
+	if err := uow.CategoryRepository().Save(ctx, c); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
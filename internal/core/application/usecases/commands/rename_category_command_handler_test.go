@@ -0,0 +1,98 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+func TestRenameCategoryCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	c := category.Restore(shared.NewID(), "Кафе", userID, nil, category.TypeExpense, time.Now())
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewCategoryRepositoryMock(t)
+	uow.On("CategoryRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uow.EXPECT().Commit(ctx).Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, c.ID()).Return(c, nil).Once()
+	repo.EXPECT().Save(ctx, c).Return(nil).Once()
+
+	handler, err := commands.NewRenameCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRenameCategoryCommand(userID, c.ID(), "Рестораны")
+	require.NoError(t, err)
+
+	renamed, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "Рестораны", renamed.Name())
+}
+
+func TestRenameCategoryCommandHandler_EmptyName(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	c := category.Restore(shared.NewID(), "Кафе", userID, nil, category.TypeExpense, time.Now())
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewCategoryRepositoryMock(t)
+	uow.On("CategoryRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, c.ID()).Return(c, nil).Once()
+
+	handler, err := commands.NewRenameCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRenameCategoryCommand(userID, c.ID(), "  ")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, category.ErrEmptyName)
+}
+
+func TestRenameCategoryCommandHandler_NotFound(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID, categoryID := shared.NewID(), shared.NewID()
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewCategoryRepositoryMock(t)
+	uow.On("CategoryRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, categoryID).Return(nil, errs.NewObjectNotFoundError("category", categoryID)).Once()
+
+	handler, err := commands.NewRenameCategoryCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRenameCategoryCommand(userID, categoryID, "Рестораны")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
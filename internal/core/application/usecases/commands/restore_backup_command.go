@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RestoreBackupCommand interface {
+	UserID() shared.ID
+	Snapshot() *backup.Snapshot
+}
+
+type restoreBackupCommand struct {
+	userID   shared.ID
+	snapshot *backup.Snapshot
+}
+
+// NewRestoreBackupCommand создает команду восстановления резервной копии в аккаунт пользователя userID.
+func NewRestoreBackupCommand(userID shared.ID, snapshot *backup.Snapshot) (RestoreBackupCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if snapshot == nil {
+		return nil, errs.NewValueIsRequiredError("snapshot")
+	}
+
+	return &restoreBackupCommand{userID: userID, snapshot: snapshot}, nil
+}
+
+func (c restoreBackupCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c restoreBackupCommand) Snapshot() *backup.Snapshot {
+	return c.snapshot
+}
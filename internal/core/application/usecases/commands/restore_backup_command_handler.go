@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RestoreBackupCommandHandler interface {
+	// Handle восстанавливает категории, транзакции, метки, возвраты и настройки из копии одной транзакцией.
+	// Восстановление возможно только в пустой аккаунт, иначе возвращается backup.ErrAccountNotEmpty.
+	Handle(ctx context.Context, command RestoreBackupCommand) error
+}
+
+var _ RestoreBackupCommandHandler = restoreBackupCommandHandler{}
+
+type restoreBackupCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewRestoreBackupCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (RestoreBackupCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &restoreBackupCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+	}, nil
+}
+
+func (h restoreBackupCommandHandler) Handle(ctx context.Context, command RestoreBackupCommand) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	plan, err := command.Snapshot().Rebase(command.UserID())
+	if err != nil {
+		return err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("restore backup command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+
+	// Проверка повторяется внутри транзакции: с момента чтения копии пользователь
+	// мог успеть создать категории или записать транзакцию.
+	hasCategories, err := uow.CategoryRepository().HasCategoriesByUserID(ctx, command.UserID())
+	if err != nil {
+		return err
+	}
+
+	lastCreatedAt, err := uow.TransactionRepository().GetLastCreatedAt(ctx, command.UserID())
+	if err != nil {
+		return err
+	}
+
+	if hasCategories || !lastCreatedAt.IsZero() {
+		return backup.ErrAccountNotEmpty
+	}
+
+	for _, c := range plan.Categories() {
+		if err := uow.CategoryRepository().Create(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range plan.Transactions() {
+		if err := uow.TransactionRepository().Add(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range plan.Refunds() {
+		if err := uow.RefundRepository().Add(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	if err := h.restoreTags(ctx, uow, command.UserID(), plan); err != nil {
+		return err
+	}
+
+	if err := uow.SettingsRepository().Save(ctx, plan.Settings()); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
+
+// restoreTags сохраняет метки плана и связывает их с восстановленными транзакциями.
+// Пустой аккаунт может сохранить метки удаленных транзакций, поэтому идентификаторы
+// берутся из сохраненных меток по названию.
+func (h restoreBackupCommandHandler) restoreTags(ctx context.Context, uow ports.UnitOfWork, userID shared.ID, plan *backup.RestorePlan) error {
+	if len(plan.Tags()) == 0 {
+		return nil
+	}
+
+	stored, err := uow.TagRepository().Ensure(ctx, userID, plan.Tags())
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]shared.ID, len(stored))
+	for _, tg := range stored {
+		ids[tg.Name()] = tg.ID()
+	}
+
+	for _, t := range plan.Transactions() {
+		names := plan.TagNames(t.ID())
+		if len(names) == 0 {
+			continue
+		}
+
+		tagIDs := make([]shared.ID, 0, len(names))
+		for _, name := range names {
+			tagIDs = append(tagIDs, ids[name])
+		}
+
+		if err := uow.TagRepository().Link(ctx, t.ID(), tagIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+type restoreBackupMocks struct {
+	uow         *portsmocks.UnitOfWorkMock
+	category    *portsmocks.CategoryRepositoryMock
+	transaction *portsmocks.TransactionRepositoryMock
+	tag         *portsmocks.TagRepositoryMock
+	refund      *portsmocks.RefundRepositoryMock
+	settings    *portsmocks.SettingsRepositoryMock
+}
+
+func newRestoreBackupMocks(t *testing.T) restoreBackupMocks {
+	m := restoreBackupMocks{
+		uow:         portsmocks.NewUnitOfWorkMock(t),
+		category:    portsmocks.NewCategoryRepositoryMock(t),
+		transaction: portsmocks.NewTransactionRepositoryMock(t),
+		tag:         portsmocks.NewTagRepositoryMock(t),
+		refund:      portsmocks.NewRefundRepositoryMock(t),
+		settings:    portsmocks.NewSettingsRepositoryMock(t),
+	}
+
+	m.uow.On("CategoryRepository").Return(m.category).Maybe()
+	m.uow.On("TransactionRepository").Return(m.transaction).Maybe()
+	m.uow.On("TagRepository").Return(m.tag).Maybe()
+	m.uow.On("RefundRepository").Return(m.refund).Maybe()
+	m.uow.On("SettingsRepository").Return(m.settings).Maybe()
+
+	return m
+}
+
+func newBackupSnapshot(t *testing.T) *backup.Snapshot {
+	t.Helper()
+
+	u := user.Restore(shared.NewID(), "Иван", time.Now())
+
+	food := category.Restore(shared.NewID(), "Еда", u.ID(), nil, category.TypeExpense, time.Now())
+	foodID := food.ID()
+	cafe := category.Restore(shared.NewID(), "Кафе", u.ID(), &foodID, category.TypeExpense, time.Now())
+
+	amount, err := transaction.NewAmountFromString("150")
+	require.NoError(t, err)
+
+	lunch := transaction.Restore(shared.NewID(), u.ID(), amount, cafe.ID(), "обед", time.Now(), "", receipt.FiscalID{}, time.Now())
+
+	vacation := tag.Restore(shared.NewID(), u.ID(), "отпуск", time.Now())
+	link, err := tag.NewLink(lunch.ID(), vacation.ID())
+	require.NoError(t, err)
+
+	refundAmount, err := transaction.NewAmountFromString("40")
+	require.NoError(t, err)
+
+	returned := refund.Restore(shared.NewID(), u.ID(), lunch.ID(), cafe.ID(), refundAmount, "", time.Now(), time.Now())
+
+	s, err := settings.New(u.ID())
+	require.NoError(t, err)
+
+	snapshot, err := backup.NewSnapshot(
+		u,
+		nil,
+		[]*category.Category{cafe, food},
+		[]*transaction.Transaction{lunch},
+		[]*tag.Tag{vacation},
+		[]tag.Link{link},
+		[]*refund.Refund{returned},
+		s,
+	)
+	require.NoError(t, err)
+
+	return snapshot
+}
+
+func TestRestoreBackupCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	m := newRestoreBackupMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.category.EXPECT().HasCategoriesByUserID(ctx, userID).Return(false, nil).Once()
+	m.transaction.EXPECT().GetLastCreatedAt(ctx, userID).Return(time.Time{}, nil).Once()
+
+	var created []*category.Category
+	m.category.EXPECT().Create(ctx, mock.AnythingOfType("*category.Category")).
+		Run(func(_ context.Context, c *category.Category) { created = append(created, c) }).
+		Return(nil).
+		Times(2)
+
+	var added *transaction.Transaction
+	m.transaction.EXPECT().Add(ctx, mock.AnythingOfType("*transaction.Transaction")).
+		Run(func(_ context.Context, tr *transaction.Transaction) { added = tr }).
+		Return(nil).
+		Once()
+
+	var restored *refund.Refund
+	m.refund.EXPECT().Add(ctx, mock.AnythingOfType("*refund.Refund")).
+		Run(func(_ context.Context, r *refund.Refund) { restored = r }).
+		Return(nil).
+		Once()
+
+	// У пользователя осталась метка удаленной транзакции: связь получает её идентификатор.
+	existing := tag.Restore(shared.NewID(), userID, "отпуск", time.Now())
+	m.tag.EXPECT().Ensure(ctx, userID, mock.MatchedBy(func(tags []*tag.Tag) bool {
+		return len(tags) == 1 && tags[0].Name() == "отпуск" && tags[0].UserID() == userID
+	})).Return([]*tag.Tag{existing}, nil).Once()
+
+	var linked shared.ID
+	m.tag.EXPECT().Link(ctx, mock.AnythingOfType("shared.ID"), []shared.ID{existing.ID()}).
+		Run(func(_ context.Context, transactionID shared.ID, _ []shared.ID) { linked = transactionID }).
+		Return(nil).
+		Once()
+
+	m.settings.EXPECT().Save(ctx, mock.MatchedBy(func(s *settings.Settings) bool {
+		return s.UserID() == userID
+	})).Return(nil).Once()
+
+	handler, err := commands.NewRestoreBackupCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRestoreBackupCommand(userID, newBackupSnapshot(t))
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(ctx, cmd))
+
+	require.Len(t, created, 2)
+	assert.Equal(t, "Еда", created[0].Name())
+	assert.Equal(t, created[0].ID(), created[1].ParentID())
+
+	require.NotNil(t, added)
+	assert.Equal(t, userID, added.UserID())
+	assert.Equal(t, created[1].ID(), added.CategoryID())
+	assert.Equal(t, added.ID(), linked)
+
+	require.NotNil(t, restored)
+	assert.Equal(t, userID, restored.UserID())
+	assert.Equal(t, added.ID(), restored.TransactionID())
+	assert.Equal(t, created[1].ID(), restored.CategoryID())
+}
+
+func TestRestoreBackupCommandHandler_AccountNotEmpty(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	m := newRestoreBackupMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.category.EXPECT().HasCategoriesByUserID(ctx, userID).Return(false, nil).Once()
+	m.transaction.EXPECT().GetLastCreatedAt(ctx, userID).Return(time.Now(), nil).Once()
+
+	handler, err := commands.NewRestoreBackupCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRestoreBackupCommand(userID, newBackupSnapshot(t))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, handler.Handle(ctx, cmd), backup.ErrAccountNotEmpty)
+
+	m.uow.AssertNotCalled(t, "Commit", ctx)
+	m.category.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRestoreBackupCommandHandler_UnsupportedVersion(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	m := newRestoreBackupMocks(t)
+
+	handler, err := commands.NewRestoreBackupCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	snapshot := backup.Restore(backup.SchemaVersion+1, time.Now(), nil, nil, nil, nil, nil, nil, nil, nil)
+	cmd, err := commands.NewRestoreBackupCommand(shared.NewID(), snapshot)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, handler.Handle(ctx, cmd), backup.ErrUnsupportedVersion)
+
+	m.uow.AssertNotCalled(t, "Begin", ctx)
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RevokeAPITokenCommand interface {
+	UserID() shared.ID
+	TokenID() shared.ID
+	Now() time.Time
+}
+
+type revokeAPITokenCommand struct {
+	userID  shared.ID
+	tokenID shared.ID
+	now     time.Time
+}
+
+func NewRevokeAPITokenCommand(userID shared.ID, tokenID shared.ID, now time.Time) (RevokeAPITokenCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if tokenID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("tokenID")
+	}
+
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &revokeAPITokenCommand{userID: userID, tokenID: tokenID, now: now}, nil
+}
+
+func (c revokeAPITokenCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c revokeAPITokenCommand) TokenID() shared.ID {
+	return c.tokenID
+}
+
+func (c revokeAPITokenCommand) Now() time.Time {
+	return c.now
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type RevokeAPITokenCommandHandler interface {
+	// Handle отзывает токен пользователя и возвращает его. Возвращает errs.ErrObjectNotFound,
+	// если у пользователя нет такого токена, и apitoken.ErrAlreadyRevoked для отозванного токена.
+	Handle(ctx context.Context, command RevokeAPITokenCommand) (*apitoken.Token, error)
+}
+
+type revokeAPITokenCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewRevokeAPITokenCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (RevokeAPITokenCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &revokeAPITokenCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h revokeAPITokenCommandHandler) Handle(ctx context.Context, command RevokeAPITokenCommand) (*apitoken.Token, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("revoke api token command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := uow.APITokenRepository().Get(ctx, command.UserID(), command.TokenID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.Revoke(command.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uow.APITokenRepository().Save(ctx, t); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
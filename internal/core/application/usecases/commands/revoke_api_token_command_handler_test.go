@@ -0,0 +1,113 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+func TestRevokeAPITokenCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	now := time.Now()
+	token, _, err := apitoken.Issue(userID, "cli", []apitoken.Scope{apitoken.ScopeRead}, time.Time{}, now)
+	require.NoError(t, err)
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewAPITokenRepositoryMock(t)
+	uow.On("APITokenRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uow.EXPECT().Commit(ctx).Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, token.ID()).Return(token, nil).Once()
+	repo.EXPECT().Save(ctx, token).Return(nil).Once()
+
+	handler, err := commands.NewRevokeAPITokenCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRevokeAPITokenCommand(userID, token.ID(), now)
+	require.NoError(t, err)
+
+	revoked, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.True(t, revoked.IsRevoked())
+	assert.False(t, revoked.IsActive(now))
+}
+
+func TestRevokeAPITokenCommandHandler_NotFound(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID, tokenID := shared.NewID(), shared.NewID()
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewAPITokenRepositoryMock(t)
+	uow.On("APITokenRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, tokenID).Return(nil, errs.NewObjectNotFoundError("api token", tokenID)).Once()
+
+	handler, err := commands.NewRevokeAPITokenCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRevokeAPITokenCommand(userID, tokenID, time.Now())
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
+
+func TestRevokeAPITokenCommandHandler_AlreadyRevoked(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	now := time.Now()
+	token := apitoken.Restore(
+		shared.NewID(), userID, "cli", []byte("hash"), "ctb_abcd",
+		[]apitoken.Scope{apitoken.ScopeRead}, time.Time{}, now.Add(-time.Hour), now.AddDate(0, -1, 0),
+	)
+
+	uow := portsmocks.NewUnitOfWorkMock(t)
+	repo := portsmocks.NewAPITokenRepositoryMock(t)
+	uow.On("APITokenRepository").Return(repo)
+
+	uow.EXPECT().Begin(ctx).Return(nil).Once()
+	uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	repo.EXPECT().Get(ctx, userID, token.ID()).Return(token, nil).Once()
+
+	handler, err := commands.NewRevokeAPITokenCommandHandler(logger, newUnitOfWorkFactory(t, uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewRevokeAPITokenCommand(userID, token.ID(), now)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, apitoken.ErrAlreadyRevoked)
+}
+
+func TestNewRevokeAPITokenCommand_Invalid(t *testing.T) {
+	_, err := commands.NewRevokeAPITokenCommand(shared.ID{}, shared.NewID(), time.Now())
+	require.Error(t, err)
+
+	_, err = commands.NewRevokeAPITokenCommand(shared.NewID(), shared.ID{}, time.Now())
+	require.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type SendDigestsCommand interface {
+	Now() time.Time
+}
+
+type sendDigestsCommand struct {
+	now time.Time
+}
+
+func (c sendDigestsCommand) Now() time.Time {
+	return c.now
+}
+
+func NewSendDigestsCommand(now time.Time) (SendDigestsCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return sendDigestsCommand{now: now}, nil
+}
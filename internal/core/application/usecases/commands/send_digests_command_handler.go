@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type SendDigestsCommandHandler interface {
+	Handle(ctx context.Context, command SendDigestsCommand) error
+}
+
+var _ SendDigestsCommandHandler = sendDigestsCommandHandler{}
+
+type sendDigestsCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+	notifier   ports.Notifier
+}
+
+func NewSendDigestsCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory, notifier ports.Notifier) (SendDigestsCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if notifier == nil {
+		return nil, errs.NewValueIsRequiredError("notifier")
+	}
+
+	return &sendDigestsCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+		notifier:   notifier,
+	}, nil
+}
+
+// Handle отправляет сводки всем пользователям, для которых наступило время отправки.
+// Ошибка отправки одному пользователю не мешает отправке остальным.
+func (h sendDigestsCommandHandler) Handle(ctx context.Context, command SendDigestsCommand) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	subscribers, err := uow.SettingsRepository().FindDigestSubscribers(ctx)
+	if err != nil {
+		return err
+	}
+
+	var sendErrs []error
+
+	for _, s := range subscribers {
+		if err := h.sendDigest(ctx, uow, s, command); err != nil {
+			h.logger.Error("send digest", "user_id", s.UserID().String(), "err", err)
+			sendErrs = append(sendErrs, err)
+		}
+	}
+
+	return errors.Join(sendErrs...)
+}
+
+func (h sendDigestsCommandHandler) sendDigest(ctx context.Context, uow ports.UnitOfWork, s *settings.Settings, command SendDigestsCommand) error {
+	from, to, due := s.DigestPeriod(command.Now())
+	if !due {
+		return nil
+	}
+
+	totals, err := uow.TransactionRepository().GetTotalsByCategory(ctx, s.UserID(), from, to)
+	if err != nil {
+		return err
+	}
+
+	summary := report.NewSummary(from, to, totals)
+
+	// Сводку отмечаем отправленной до отправки: при сбое пользователь пропустит одну сводку,
+	// но не получит её дважды.
+	if err := h.markSent(ctx, uow, s, command); err != nil {
+		return err
+	}
+
+	if summary.IsEmpty() {
+		return nil
+	}
+
+	recipient, err := uow.UserRepository().GetExternalIdentity(ctx, s.UserID(), user.ProviderTelegram)
+	if err != nil {
+		return err
+	}
+
+	notification := ports.Notification{Text: composeDigest(s.DigestMode(), summary, s.Location())}
+	if err := h.notifier.Notify(ctx, recipient, notification); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+
+	return nil
+}
+
+func (h sendDigestsCommandHandler) markSent(ctx context.Context, uow ports.UnitOfWork, s *settings.Settings, command SendDigestsCommand) error {
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("send digests command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+
+	// Настройки перечитываются в транзакции: пока готовилась сводка, пользователь мог их изменить,
+	// и сохранение прочитанной раньше копии отменило бы его изменения.
+	fresh, err := uow.SettingsRepository().Get(ctx, s.UserID())
+	if err != nil {
+		return err
+	}
+
+	fresh.MarkDigestSent(command.Now())
+
+	if err := uow.SettingsRepository().Save(ctx, fresh); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
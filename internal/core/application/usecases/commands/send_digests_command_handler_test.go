@@ -0,0 +1,233 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+type notificationMocks struct {
+	uow         *portsmocks.UnitOfWorkMock
+	settings    *portsmocks.SettingsRepositoryMock
+	transaction *portsmocks.TransactionRepositoryMock
+	user        *portsmocks.UserRepositoryMock
+	notifier    *portsmocks.NotifierMock
+}
+
+func setupNotificationMocks(t *testing.T) notificationMocks {
+	m := notificationMocks{
+		uow:         portsmocks.NewUnitOfWorkMock(t),
+		settings:    portsmocks.NewSettingsRepositoryMock(t),
+		transaction: portsmocks.NewTransactionRepositoryMock(t),
+		user:        portsmocks.NewUserRepositoryMock(t),
+		notifier:    portsmocks.NewNotifierMock(t),
+	}
+
+	m.uow.On("SettingsRepository").Return(m.settings).Maybe()
+	m.uow.On("TransactionRepository").Return(m.transaction).Maybe()
+	m.uow.On("UserRepository").Return(m.user).Maybe()
+
+	return m
+}
+
+func (m notificationMocks) expectSettingsSaved(ctx context.Context) {
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+	m.settings.EXPECT().Save(ctx, mock.AnythingOfType("*settings.Settings")).Return(nil).Once()
+}
+
+// expectSettingsReloaded ожидает, что отметка об отправке сохраняется в настройки, перечитанные в транзакции.
+func (m notificationMocks) expectSettingsReloaded(ctx context.Context, s *settings.Settings) {
+	m.settings.EXPECT().Get(ctx, s.UserID()).Return(s, nil).Once()
+	m.expectSettingsSaved(ctx)
+}
+
+func dailySettings(t *testing.T, userID shared.ID) *settings.Settings {
+	t.Helper()
+
+	return settings.Restore(userID, "Europe/Moscow", settings.DigestModeDaily, 9, time.Time{}, 0, 22, 9, time.Time{}, time.Now())
+}
+
+func TestSendDigestsCommandHandler_SendsDigest(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	msk, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, msk)
+	from := time.Date(2026, 10, 18, 0, 0, 0, 0, msk)
+	to := time.Date(2026, 10, 19, 0, 0, 0, 0, msk)
+
+	userID := shared.NewID()
+	s := dailySettings(t, userID)
+	recipient := user.RestoreExternalIdentity(shared.NewID(), userID, user.ProviderTelegram, "42", time.Now())
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindDigestSubscribers(ctx).Return([]*settings.Settings{s}, nil).Once()
+	m.transaction.EXPECT().
+		GetTotalsByCategory(ctx, userID, mock.MatchedBy(from.Equal), mock.MatchedBy(to.Equal)).
+		Return([]report.CategoryTotal{
+			report.NewCategoryTotal(shared.NewID(), "Кафе", "", category.TypeExpense, decimal.NewFromInt(800)),
+			report.NewCategoryTotal(shared.NewID(), "Продукты", "", category.TypeExpense, decimal.NewFromInt(1650)),
+		}, nil).
+		Once()
+	m.expectSettingsReloaded(ctx, s)
+	m.user.EXPECT().GetExternalIdentity(ctx, userID, user.ProviderTelegram).Return(recipient, nil).Once()
+	m.notifier.EXPECT().
+		Notify(ctx, recipient, mock.MatchedBy(func(n ports.Notification) bool {
+			return assert.Contains(t, n.Text, "Вчера: расходы 2 450 ₽")
+		})).
+		Return(nil).
+		Once()
+
+	handler, err := commands.NewSendDigestsCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSendDigestsCommand(now)
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	assert.False(t, s.DigestSentAt().IsZero())
+}
+
+func TestSendDigestsCommandHandler_EmptySummaryIsNotSent(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	s := dailySettings(t, userID)
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindDigestSubscribers(ctx).Return([]*settings.Settings{s}, nil).Once()
+	m.transaction.EXPECT().
+		GetTotalsByCategory(ctx, userID, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+	m.expectSettingsReloaded(ctx, s)
+
+	handler, err := commands.NewSendDigestsCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSendDigestsCommand(time.Date(2026, 10, 19, 10, 0, 0, 0, s.Location()))
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	m.notifier.AssertNotCalled(t, "Notify")
+}
+
+func TestSendDigestsCommandHandler_NotDue(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	s := dailySettings(t, shared.NewID())
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindDigestSubscribers(ctx).Return([]*settings.Settings{s}, nil).Once()
+
+	handler, err := commands.NewSendDigestsCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	// Время сводки 09:00, а сейчас 08:00 по времени пользователя.
+	cmd, err := commands.NewSendDigestsCommand(time.Date(2026, 10, 19, 8, 0, 0, 0, s.Location()))
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	m.transaction.AssertNotCalled(t, "GetTotalsByCategory")
+	m.notifier.AssertNotCalled(t, "Notify")
+}
+
+func TestSendDigestsCommandHandler_ErrorDoesNotStopOthers(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	failed := dailySettings(t, shared.NewID())
+	ok := dailySettings(t, shared.NewID())
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindDigestSubscribers(ctx).Return([]*settings.Settings{failed, ok}, nil).Once()
+	m.transaction.EXPECT().
+		GetTotalsByCategory(ctx, failed.UserID(), mock.Anything, mock.Anything).
+		Return(nil, errors.New("db error")).
+		Once()
+	m.transaction.EXPECT().
+		GetTotalsByCategory(ctx, ok.UserID(), mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+	m.expectSettingsReloaded(ctx, ok)
+
+	handler, err := commands.NewSendDigestsCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSendDigestsCommand(time.Date(2026, 10, 19, 10, 0, 0, 0, ok.Location()))
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "db error")
+	assert.False(t, ok.DigestSentAt().IsZero())
+}
+
+func TestSendDigestsCommandHandler_KeepsConcurrentSettingsChanges(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	stale := dailySettings(t, userID)
+	// Пока готовилась сводка, пользователь сменил часовой пояс.
+	fresh := settings.Restore(userID, "Asia/Yekaterinburg", settings.DigestModeDaily, 9, time.Time{}, 0, 22, 9, time.Time{}, time.Now())
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindDigestSubscribers(ctx).Return([]*settings.Settings{stale}, nil).Once()
+	m.transaction.EXPECT().
+		GetTotalsByCategory(ctx, userID, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+	m.settings.EXPECT().Get(ctx, userID).Return(fresh, nil).Once()
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+	m.settings.EXPECT().Save(ctx, fresh).Return(nil).Once()
+
+	handler, err := commands.NewSendDigestsCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSendDigestsCommand(time.Date(2026, 10, 19, 10, 0, 0, 0, stale.Location()))
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Asia/Yekaterinburg", fresh.Timezone())
+	assert.False(t, fresh.DigestSentAt().IsZero())
+	assert.True(t, stale.DigestSentAt().IsZero())
+}
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type SendRemindersCommand interface {
+	Now() time.Time
+}
+
+type sendRemindersCommand struct {
+	now time.Time
+}
+
+func (c sendRemindersCommand) Now() time.Time {
+	return c.now
+}
+
+func NewSendRemindersCommand(now time.Time) (SendRemindersCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return sendRemindersCommand{now: now}, nil
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// reminderQuickAmounts суммы расхода, предлагаемые в напоминании для быстрого ввода.
+var reminderQuickAmounts = []int64{100, 300, 500, 1000}
+
+type SendRemindersCommandHandler interface {
+	Handle(ctx context.Context, command SendRemindersCommand) error
+}
+
+var _ SendRemindersCommandHandler = sendRemindersCommandHandler{}
+
+type sendRemindersCommandHandler struct {
+	logger       ports.Logger
+	uowFactory   ports.UnitOfWorkFactory
+	notifier     ports.Notifier
+	quickAmounts []transaction.Amount
+}
+
+func NewSendRemindersCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory, notifier ports.Notifier) (SendRemindersCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if notifier == nil {
+		return nil, errs.NewValueIsRequiredError("notifier")
+	}
+
+	quickAmounts := make([]transaction.Amount, 0, len(reminderQuickAmounts))
+	for _, v := range reminderQuickAmounts {
+		amount, err := transaction.NewAmount(decimal.NewFromInt(v))
+		if err != nil {
+			return nil, err
+		}
+
+		quickAmounts = append(quickAmounts, amount)
+	}
+
+	return &sendRemindersCommandHandler{
+		logger:       logger,
+		uowFactory:   uowFactory,
+		notifier:     notifier,
+		quickAmounts: quickAmounts,
+	}, nil
+}
+
+// Handle напоминает записать траты пользователям, которые давно ничего не записывали.
+// Ошибка отправки одному пользователю не мешает отправке остальным.
+func (h sendRemindersCommandHandler) Handle(ctx context.Context, command SendRemindersCommand) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	subscribers, err := uow.SettingsRepository().FindReminderSubscribers(ctx)
+	if err != nil {
+		return err
+	}
+
+	var sendErrs []error
+
+	for _, s := range subscribers {
+		if err := h.sendReminder(ctx, uow, s, command); err != nil {
+			h.logger.Error("send reminder", "user_id", s.UserID().String(), "err", err)
+			sendErrs = append(sendErrs, err)
+		}
+	}
+
+	return errors.Join(sendErrs...)
+}
+
+func (h sendRemindersCommandHandler) sendReminder(ctx context.Context, uow ports.UnitOfWork, s *settings.Settings, command SendRemindersCommand) error {
+	// Тихие часы проверяем до обращения к транзакциям, чтобы не нагружать базу ночью.
+	if s.InQuietHours(command.Now()) {
+		return nil
+	}
+
+	lastActivity, err := uow.TransactionRepository().GetLastCreatedAt(ctx, s.UserID())
+	if err != nil {
+		return err
+	}
+
+	if !s.ReminderDue(command.Now(), lastActivity) {
+		return nil
+	}
+
+	// Напоминание отмечаем отправленным до отправки: при сбое пользователь пропустит одно напоминание,
+	// но не получит его дважды.
+	if err := h.markReminded(ctx, uow, s, command); err != nil {
+		return err
+	}
+
+	recipient, err := uow.UserRepository().GetExternalIdentity(ctx, s.UserID(), user.ProviderTelegram)
+	if err != nil {
+		return err
+	}
+
+	notification := ports.Notification{
+		Text:         composeReminder(command.Now(), lastActivity),
+		QuickAmounts: h.quickAmounts,
+	}
+	if err := h.notifier.Notify(ctx, recipient, notification); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+
+	return nil
+}
+
+func (h sendRemindersCommandHandler) markReminded(ctx context.Context, uow ports.UnitOfWork, s *settings.Settings, command SendRemindersCommand) error {
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("send reminders command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+
+	// Настройки перечитываются в транзакции, чтобы не отменить изменения, сделанные пользователем
+	// после выборки подписчиков.
+	fresh, err := uow.SettingsRepository().Get(ctx, s.UserID())
+	if err != nil {
+		return err
+	}
+
+	fresh.MarkReminded(command.Now())
+
+	if err := uow.SettingsRepository().Save(ctx, fresh); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
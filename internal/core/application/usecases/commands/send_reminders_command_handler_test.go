@@ -0,0 +1,119 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+)
+
+func reminderSettings(userID shared.ID) *settings.Settings {
+	return settings.Restore(
+		userID,
+		"Europe/Moscow",
+		settings.DigestModeOff,
+		settings.DefaultDigestHour,
+		time.Time{},
+		24,
+		settings.DefaultQuietFrom,
+		settings.DefaultQuietTo,
+		time.Time{},
+		time.Now(),
+	)
+}
+
+func TestSendRemindersCommandHandler_SendsReminder(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	s := reminderSettings(userID)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, s.Location())
+	recipient := user.RestoreExternalIdentity(shared.NewID(), userID, user.ProviderTelegram, "42", time.Now())
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindReminderSubscribers(ctx).Return([]*settings.Settings{s}, nil).Once()
+	m.transaction.EXPECT().GetLastCreatedAt(ctx, userID).Return(now.Add(-50*time.Hour), nil).Once()
+	m.expectSettingsReloaded(ctx, s)
+	m.user.EXPECT().GetExternalIdentity(ctx, userID, user.ProviderTelegram).Return(recipient, nil).Once()
+	m.notifier.EXPECT().
+		Notify(ctx, recipient, mock.MatchedBy(func(n ports.Notification) bool {
+			return assert.Contains(t, n.Text, "не записывали траты 2 дня") &&
+				assert.NotEmpty(t, n.QuickAmounts)
+		})).
+		Return(nil).
+		Once()
+
+	handler, err := commands.NewSendRemindersCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSendRemindersCommand(now)
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	assert.Equal(t, now, s.RemindedAt())
+}
+
+func TestSendRemindersCommandHandler_RecentActivity(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	s := reminderSettings(userID)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, s.Location())
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindReminderSubscribers(ctx).Return([]*settings.Settings{s}, nil).Once()
+	m.transaction.EXPECT().GetLastCreatedAt(ctx, userID).Return(now.Add(-time.Hour), nil).Once()
+
+	handler, err := commands.NewSendRemindersCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSendRemindersCommand(now)
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	m.settings.AssertNotCalled(t, "Save")
+	m.notifier.AssertNotCalled(t, "Notify")
+}
+
+func TestSendRemindersCommandHandler_QuietHours(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	s := reminderSettings(shared.NewID())
+
+	m := setupNotificationMocks(t)
+	m.settings.EXPECT().FindReminderSubscribers(ctx).Return([]*settings.Settings{s}, nil).Once()
+
+	handler, err := commands.NewSendRemindersCommandHandler(logger, newUnitOfWorkFactory(t, m.uow), m.notifier)
+	require.NoError(t, err)
+
+	// 03:00 по Москве попадает в тихие часы 22:00–09:00.
+	cmd, err := commands.NewSendRemindersCommand(time.Date(2026, 10, 19, 3, 0, 0, 0, s.Location()))
+	require.NoError(t, err)
+
+	err = handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	m.transaction.AssertNotCalled(t, "GetLastCreatedAt")
+	m.notifier.AssertNotCalled(t, "Notify")
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// SplitTransactionCommand делит транзакцию пользователя на части по категориям
+// или отменяет разделение, если частей нет.
+type SplitTransactionCommand interface {
+	UserID() shared.ID
+	TransactionID() shared.ID
+	Parts() []transaction.Split
+}
+
+type splitTransactionCommand struct {
+	userID        shared.ID
+	transactionID shared.ID
+	parts         []transaction.Split
+}
+
+// NewSplitTransactionCommand создает команду разделения. Проверка частей выполняется транзакцией,
+// принадлежность категорий пользователю проверяет обработчик.
+func NewSplitTransactionCommand(userID shared.ID, transactionID shared.ID, parts []transaction.Split) (SplitTransactionCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if transactionID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("transactionID")
+	}
+
+	return &splitTransactionCommand{userID: userID, transactionID: transactionID, parts: parts}, nil
+}
+
+// NewUnsplitTransactionCommand отменяет разделение транзакции.
+func NewUnsplitTransactionCommand(userID shared.ID, transactionID shared.ID) (SplitTransactionCommand, error) {
+	return NewSplitTransactionCommand(userID, transactionID, nil)
+}
+
+func (c splitTransactionCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c splitTransactionCommand) TransactionID() shared.ID {
+	return c.transactionID
+}
+
+func (c splitTransactionCommand) Parts() []transaction.Split {
+	return c.parts
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type SplitTransactionCommandHandler interface {
+	// Handle делит транзакцию на части и возвращает её.
+	// Возвращает errs.ErrObjectNotFound, если у пользователя нет такой транзакции или категории части,
+	// и ErrCategoryTypeMismatch, если категория части другого типа, чем транзакция.
+	Handle(ctx context.Context, command SplitTransactionCommand) (*transaction.Transaction, error)
+}
+
+var _ SplitTransactionCommandHandler = splitTransactionCommandHandler{}
+
+type splitTransactionCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewSplitTransactionCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (SplitTransactionCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &splitTransactionCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+	}, nil
+}
+
+func (h splitTransactionCommandHandler) Handle(ctx context.Context, command SplitTransactionCommand) (*transaction.Transaction, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("split transaction command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+
+	t, err := uow.TransactionRepository().Get(ctx, command.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.UserID() != command.UserID() {
+		return nil, errs.NewObjectNotFoundError("transaction", command.TransactionID().String())
+	}
+
+	if len(command.Parts()) == 0 {
+		t.Unsplit()
+	} else {
+		if err := h.checkCategories(ctx, uow, command, t); err != nil {
+			return nil, err
+		}
+
+		if err := t.SplitInto(command.Parts()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkRefundsCovered(ctx, uow, t); err != nil {
+		return nil, err
+	}
+
+	if err := uow.TransactionRepository().Update(ctx, t); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// checkCategories проверяет, что категории частей принадлежат пользователю и совпадают по типу с транзакцией.
+func (h splitTransactionCommandHandler) checkCategories(ctx context.Context, uow ports.UnitOfWork, command SplitTransactionCommand, t *transaction.Transaction) error {
+	categories, err := uow.CategoryRepository().GetAllByUserID(ctx, command.UserID())
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[shared.ID]*category.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID()] = c
+	}
+
+	current := byID[t.CategoryID()]
+
+	for _, part := range command.Parts() {
+		c, ok := byID[part.CategoryID()]
+		if !ok {
+			return errs.NewObjectNotFoundError("category", part.CategoryID().String())
+		}
+
+		if current != nil && current.Type() != c.Type() {
+			return ErrCategoryTypeMismatch
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func newSplitPart(t *testing.T, categoryID shared.ID, value string) transaction.Split {
+	t.Helper()
+
+	amount, err := transaction.NewAmountFromString(value)
+	require.NoError(t, err)
+
+	part, err := transaction.NewSplit(categoryID, amount)
+	require.NoError(t, err)
+
+	return part
+}
+
+func TestSplitTransactionCommandHandler_Split(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	food := category.Restore(tr.CategoryID(), "Еда", userID, nil, category.TypeExpense, time.Now())
+	household := category.Restore(shared.NewID(), "Хозтовары", userID, nil, category.TypeExpense, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{food, household}, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return(nil, nil).Once()
+	m.transaction.EXPECT().Update(ctx, tr).Return(nil).Once()
+
+	handler, err := commands.NewSplitTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	parts := []transaction.Split{newSplitPart(t, household.ID(), "30"), newSplitPart(t, food.ID(), "70")}
+
+	cmd, err := commands.NewSplitTransactionCommand(userID, tr.ID(), parts)
+	require.NoError(t, err)
+
+	split, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, parts, split.Splits())
+	assert.Equal(t, household.ID(), split.CategoryID())
+}
+
+func TestSplitTransactionCommandHandler_Unsplit(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	require.NoError(t, tr.SplitInto([]transaction.Split{
+		newSplitPart(t, tr.CategoryID(), "60"),
+		newSplitPart(t, shared.NewID(), "40"),
+	}))
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return(nil, nil).Once()
+	m.transaction.EXPECT().Update(ctx, tr).Return(nil).Once()
+
+	handler, err := commands.NewSplitTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewUnsplitTransactionCommand(userID, tr.ID())
+	require.NoError(t, err)
+
+	unsplit, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.False(t, unsplit.IsSplit())
+}
+
+func TestSplitTransactionCommandHandler_RefundedCategoryDropped(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	food := category.Restore(tr.CategoryID(), "Еда", userID, nil, category.TypeExpense, time.Now())
+	household := category.Restore(shared.NewID(), "Хозтовары", userID, nil, category.TypeExpense, time.Now())
+	taxi := category.Restore(shared.NewID(), "Такси", userID, nil, category.TypeExpense, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	refunded, err := refund.New(tr, food.ID(), newRefundAmount(t, "20"), nil)
+	require.NoError(t, err)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{food, household, taxi}, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return([]*refund.Refund{refunded}, nil).Once()
+
+	handler, err := commands.NewSplitTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	parts := []transaction.Split{newSplitPart(t, household.ID(), "30"), newSplitPart(t, taxi.ID(), "70")}
+
+	cmd, err := commands.NewSplitTransactionCommand(userID, tr.ID(), parts)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, refund.ErrBelowRefunded)
+
+	m.transaction.AssertNotCalled(t, "Update", ctx, tr)
+}
+
+func TestSplitTransactionCommandHandler_SumMismatch(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	food := category.Restore(tr.CategoryID(), "Еда", userID, nil, category.TypeExpense, time.Now())
+	gift := category.Restore(shared.NewID(), "Подарки", userID, nil, category.TypeExpense, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{food, gift}, nil).Once()
+
+	handler, err := commands.NewSplitTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSplitTransactionCommand(userID, tr.ID(), []transaction.Split{
+		newSplitPart(t, food.ID(), "50"),
+		newSplitPart(t, gift.ID(), "20"),
+	})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, transaction.ErrSplitSumMismatch)
+
+	assert.False(t, tr.IsSplit())
+	m.transaction.AssertNotCalled(t, "Update", ctx, tr)
+}
+
+func TestSplitTransactionCommandHandler_ForeignCategory(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	food := category.Restore(tr.CategoryID(), "Еда", userID, nil, category.TypeExpense, time.Now())
+	salary := category.Restore(shared.NewID(), "Зарплата", userID, nil, category.TypeIncome, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Twice()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Twice()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Twice()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{food, salary}, nil).Twice()
+
+	handler, err := commands.NewSplitTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSplitTransactionCommand(userID, tr.ID(), []transaction.Split{
+		newSplitPart(t, food.ID(), "50"),
+		newSplitPart(t, shared.NewID(), "50"),
+	})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+
+	cmd, err = commands.NewSplitTransactionCommand(userID, tr.ID(), []transaction.Split{
+		newSplitPart(t, food.ID(), "50"),
+		newSplitPart(t, salary.ID(), "50"),
+	})
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, commands.ErrCategoryTypeMismatch)
+
+	m.transaction.AssertNotCalled(t, "Update", ctx, tr)
+}
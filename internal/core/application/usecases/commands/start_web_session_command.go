@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type StartWebSessionCommand interface {
+	// LinkSecret возвращает секрет одноразовой ссылки для входа.
+	LinkSecret() string
+	Now() time.Time
+}
+
+type startWebSessionCommand struct {
+	linkSecret string
+	now        time.Time
+}
+
+func NewStartWebSessionCommand(linkSecret string, now time.Time) (StartWebSessionCommand, error) {
+	if linkSecret == "" {
+		return nil, errs.NewValueIsRequiredError("linkSecret")
+	}
+
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &startWebSessionCommand{linkSecret: linkSecret, now: now}, nil
+}
+
+func (c startWebSessionCommand) LinkSecret() string {
+	return c.linkSecret
+}
+
+func (c startWebSessionCommand) Now() time.Time {
+	return c.now
+}
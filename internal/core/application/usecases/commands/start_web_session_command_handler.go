@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// StartedWebSession секрет сеанса веб-панели и срок его действия.
+type StartedWebSession struct {
+	Secret    string
+	ExpiresAt time.Time
+}
+
+type StartWebSessionCommandHandler interface {
+	// Handle выполняет вход по одноразовой ссылке. Возвращает websession.ErrLinkUsed или
+	// websession.ErrLinkExpired для использованной или истекшей ссылки и errs.ErrObjectNotFound
+	// для неизвестной.
+	Handle(ctx context.Context, command StartWebSessionCommand) (*StartedWebSession, error)
+}
+
+type startWebSessionCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewStartWebSessionCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (StartWebSessionCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &startWebSessionCommandHandler{logger: logger, uowFactory: uowFactory}, nil
+}
+
+func (h startWebSessionCommandHandler) Handle(ctx context.Context, command StartWebSessionCommand) (*StartedWebSession, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("start web session command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uow.WebSessionRepository().GetByHash(ctx, websession.Hash(command.LinkSecret()))
+	if err != nil {
+		return nil, err
+	}
+
+	// По хешу находится и секрет действующего сеанса: для него Activate вернет ErrLinkUsed.
+	secret, err := s.Activate(command.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.WebSessionRepository().Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &StartedWebSession{Secret: secret, ExpiresAt: s.ExpiresAt()}, nil
+}
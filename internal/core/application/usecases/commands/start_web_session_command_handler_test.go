@@ -0,0 +1,134 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+type webSessionMocks struct {
+	uow      *portsmocks.UnitOfWorkMock
+	sessions *portsmocks.WebSessionRepositoryMock
+}
+
+func newWebSessionMocks(t *testing.T) webSessionMocks {
+	m := webSessionMocks{
+		uow:      portsmocks.NewUnitOfWorkMock(t),
+		sessions: portsmocks.NewWebSessionRepositoryMock(t),
+	}
+
+	m.uow.On("WebSessionRepository").Return(m.sessions).Maybe()
+
+	return m
+}
+
+func TestStartWebSessionCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	s, linkSecret, err := websession.New(shared.NewID(), now)
+	require.NoError(t, err)
+
+	m := newWebSessionMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+	m.sessions.EXPECT().GetByHash(ctx, websession.Hash(linkSecret)).Return(s, nil).Once()
+	m.sessions.EXPECT().Save(ctx, s).Return(nil).Once()
+
+	handler, err := commands.NewStartWebSessionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	loginAt := now.Add(time.Minute)
+
+	cmd, err := commands.NewStartWebSessionCommand(linkSecret, loginAt)
+	require.NoError(t, err)
+
+	started, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, linkSecret, started.Secret)
+	assert.Equal(t, websession.Hash(started.Secret), s.Hash())
+	assert.Equal(t, loginAt.Add(websession.SessionTTL), started.ExpiresAt)
+	assert.True(t, s.IsActive(loginAt))
+}
+
+func TestStartWebSessionCommandHandler_LinkUsed(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	s := websession.Restore(shared.NewID(), shared.NewID(), []byte("hash"), now, now.Add(websession.SessionTTL), now)
+
+	m := newWebSessionMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.sessions.EXPECT().GetByHash(ctx, websession.Hash("secret")).Return(s, nil).Once()
+
+	handler, err := commands.NewStartWebSessionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewStartWebSessionCommand("secret", now)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, websession.ErrLinkUsed)
+}
+
+func TestStartWebSessionCommandHandler_LinkExpired(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	now := time.Now()
+	s, linkSecret, err := websession.New(shared.NewID(), now)
+	require.NoError(t, err)
+
+	m := newWebSessionMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.sessions.EXPECT().GetByHash(ctx, websession.Hash(linkSecret)).Return(s, nil).Once()
+
+	handler, err := commands.NewStartWebSessionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewStartWebSessionCommand(linkSecret, now.Add(websession.LinkTTL))
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, websession.ErrLinkExpired)
+}
+
+func TestStartWebSessionCommandHandler_UnknownLink(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	m := newWebSessionMocks(t)
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.sessions.EXPECT().GetByHash(ctx, websession.Hash("secret")).Return(nil, errs.NewObjectNotFoundError("web session", "hash")).Once()
+
+	handler, err := commands.NewStartWebSessionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewStartWebSessionCommand("secret", time.Now())
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
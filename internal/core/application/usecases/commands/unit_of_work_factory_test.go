@@ -0,0 +1,119 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+// newUnitOfWorkFactory возвращает фабрику, которая отдает обработчику заданный UnitOfWork.
+func newUnitOfWorkFactory(t *testing.T, uow ports.UnitOfWork) *portsmocks.UnitOfWorkFactoryMock {
+	t.Helper()
+
+	factory := portsmocks.NewUnitOfWorkFactoryMock(t)
+	factory.EXPECT().New().Return(uow, nil).Maybe()
+
+	return factory
+}
+
+func TestUnitOfWorkFactory_ParallelCallsAreIsolated(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	// Оба вызова ждут друг друга внутри транзакции, поэтому общий UnitOfWork получил бы второй Begin.
+	var inTx sync.WaitGroup
+	inTx.Add(2)
+
+	var (
+		mu    sync.Mutex
+		saved = map[*portsmocks.UnitOfWorkMock][]shared.ID{}
+	)
+
+	factory := portsmocks.NewUnitOfWorkFactoryMock(t)
+
+	for range 2 {
+		uow := portsmocks.NewUnitOfWorkMock(t)
+		repo := portsmocks.NewSettingsRepositoryMock(t)
+		uow.On("SettingsRepository").Return(repo)
+
+		uow.EXPECT().Begin(ctx).Return(nil).Once()
+		uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+		uow.EXPECT().Commit(ctx).Return(nil).Once()
+		repo.EXPECT().Get(ctx, mock.Anything).RunAndReturn(func(_ context.Context, userID shared.ID) (*settings.Settings, error) {
+			inTx.Done()
+			inTx.Wait()
+
+			return settings.New(userID)
+		}).Once()
+		repo.EXPECT().Save(ctx, mock.AnythingOfType("*settings.Settings")).RunAndReturn(func(_ context.Context, s *settings.Settings) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			saved[uow] = append(saved[uow], s.UserID())
+
+			return nil
+		}).Once()
+
+		factory.EXPECT().New().Return(uow, nil).Once()
+	}
+
+	handler, err := commands.NewUpdateSettingsCommandHandler(logger, factory)
+	require.NoError(t, err)
+
+	users := []shared.ID{shared.NewID(), shared.NewID()}
+
+	var wg sync.WaitGroup
+	for _, userID := range users {
+		cmd, err := commands.NewSetDigestModeCommand(userID, settings.DigestModeWeekly)
+		require.NoError(t, err)
+
+		wg.Go(func() {
+			_, err := handler.Handle(ctx, cmd)
+			assert.NoError(t, err)
+		})
+	}
+
+	wg.Wait()
+
+	var got []shared.ID
+	for _, ids := range saved {
+		require.Len(t, ids, 1)
+		got = append(got, ids...)
+	}
+
+	assert.ElementsMatch(t, users, got)
+}
+
+func TestUnitOfWorkFactory_Error(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	factoryErr := errors.New("pool is closed")
+
+	factory := portsmocks.NewUnitOfWorkFactoryMock(t)
+	factory.EXPECT().New().Return(nil, factoryErr).Once()
+
+	handler, err := commands.NewUpdateSettingsCommandHandler(logger, factory)
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetDigestModeCommand(shared.NewID(), settings.DigestModeWeekly)
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, factoryErr)
+}
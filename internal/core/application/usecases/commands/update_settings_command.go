@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// UpdateSettingsCommand изменяет одну из настроек пользователя.
+// Конкретное изменение задается конструктором команды.
+type UpdateSettingsCommand interface {
+	UserID() shared.ID
+	Apply(s *settings.Settings) error
+}
+
+type updateSettingsCommand struct {
+	userID shared.ID
+	apply  func(s *settings.Settings) error
+}
+
+func (c updateSettingsCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c updateSettingsCommand) Apply(s *settings.Settings) error {
+	return c.apply(s)
+}
+
+func newUpdateSettingsCommand(userID shared.ID, apply func(s *settings.Settings) error) (UpdateSettingsCommand, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	return updateSettingsCommand{userID: userID, apply: apply}, nil
+}
+
+func NewSetTimezoneCommand(userID shared.ID, timezone string) (UpdateSettingsCommand, error) {
+	return newUpdateSettingsCommand(userID, func(s *settings.Settings) error {
+		return s.SetTimezone(timezone)
+	})
+}
+
+func NewSetDigestModeCommand(userID shared.ID, mode settings.DigestMode) (UpdateSettingsCommand, error) {
+	if !mode.IsValid() {
+		return nil, errs.NewValueIsInvalidError("mode")
+	}
+
+	return newUpdateSettingsCommand(userID, func(s *settings.Settings) error {
+		return s.SetDigestMode(mode)
+	})
+}
+
+func NewSetDigestHourCommand(userID shared.ID, hour int) (UpdateSettingsCommand, error) {
+	return newUpdateSettingsCommand(userID, func(s *settings.Settings) error {
+		return s.SetDigestHour(hour)
+	})
+}
+
+// NewSetReminderCommand включает напоминание после hours часов без записей, 0 выключает напоминания.
+func NewSetReminderCommand(userID shared.ID, hours int) (UpdateSettingsCommand, error) {
+	return newUpdateSettingsCommand(userID, func(s *settings.Settings) error {
+		return s.SetReminderAfterHours(hours)
+	})
+}
+
+func NewSetQuietHoursCommand(userID shared.ID, from int, to int) (UpdateSettingsCommand, error) {
+	return newUpdateSettingsCommand(userID, func(s *settings.Settings) error {
+		return s.SetQuietHours(from, to)
+	})
+}
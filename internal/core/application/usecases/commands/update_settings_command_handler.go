@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type UpdateSettingsCommandHandler interface {
+	Handle(ctx context.Context, command UpdateSettingsCommand) (*settings.Settings, error)
+}
+
+var _ UpdateSettingsCommandHandler = updateSettingsCommandHandler{}
+
+type updateSettingsCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewUpdateSettingsCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (UpdateSettingsCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &updateSettingsCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+	}, nil
+}
+
+// Handle применяет изменение и возвращает обновленные настройки.
+func (h updateSettingsCommandHandler) Handle(ctx context.Context, command UpdateSettingsCommand) (*settings.Settings, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("update settings command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	err = uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, command.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := command.Apply(s); err != nil {
+		return nil, err
+	}
+
+	if err := uow.SettingsRepository().Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+func TestUpdateSettingsCommandHandler_Success(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	current, err := settings.New(userID)
+	require.NoError(t, err)
+
+	settingsRepoMock := portsmocks.NewSettingsRepositoryMock(t)
+	uowMock := portsmocks.NewUnitOfWorkMock(t)
+	uowMock.On("SettingsRepository").Return(settingsRepoMock)
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	uowMock.EXPECT().Commit(ctx).Return(nil).Once()
+	settingsRepoMock.EXPECT().Get(ctx, userID).Return(current, nil).Once()
+	settingsRepoMock.EXPECT().Save(ctx, mock.AnythingOfType("*settings.Settings")).Return(nil).Once()
+
+	handler, err := commands.NewUpdateSettingsCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetDigestModeCommand(userID, settings.DigestModeWeekly)
+	require.NoError(t, err)
+
+	got, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, settings.DigestModeWeekly, got.DigestMode())
+}
+
+func TestUpdateSettingsCommandHandler_InvalidValue(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	current, err := settings.New(userID)
+	require.NoError(t, err)
+
+	settingsRepoMock := portsmocks.NewSettingsRepositoryMock(t)
+	uowMock := portsmocks.NewUnitOfWorkMock(t)
+	uowMock.On("SettingsRepository").Return(settingsRepoMock)
+
+	uowMock.EXPECT().Begin(ctx).Return(nil).Once()
+	uowMock.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	settingsRepoMock.EXPECT().Get(ctx, userID).Return(current, nil).Once()
+
+	handler, err := commands.NewUpdateSettingsCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetTimezoneCommand(userID, "Mars/Olympus")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	settingsRepoMock.AssertNotCalled(t, "Save")
+}
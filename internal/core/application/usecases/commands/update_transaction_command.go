@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// UpdateTransactionCommand исправляет одно из полей транзакции пользователя.
+// Конкретное изменение задается конструктором команды.
+type UpdateTransactionCommand interface {
+	UserID() shared.ID
+	TransactionID() shared.ID
+
+	// CategoryID возвращает новую категорию транзакции или нулевой идентификатор,
+	// если команда не меняет категорию. Принадлежность категории пользователю проверяет обработчик.
+	CategoryID() shared.ID
+
+	Apply(t *transaction.Transaction) error
+}
+
+type updateTransactionCommand struct {
+	userID        shared.ID
+	transactionID shared.ID
+	categoryID    shared.ID
+	apply         func(t *transaction.Transaction) error
+}
+
+func (c updateTransactionCommand) UserID() shared.ID {
+	return c.userID
+}
+
+func (c updateTransactionCommand) TransactionID() shared.ID {
+	return c.transactionID
+}
+
+func (c updateTransactionCommand) CategoryID() shared.ID {
+	return c.categoryID
+}
+
+func (c updateTransactionCommand) Apply(t *transaction.Transaction) error {
+	return c.apply(t)
+}
+
+func newUpdateTransactionCommand(
+	userID shared.ID,
+	transactionID shared.ID,
+	apply func(t *transaction.Transaction) error,
+) (updateTransactionCommand, error) {
+	if userID.IsZero() {
+		return updateTransactionCommand{}, errs.NewValueIsRequiredError("userID")
+	}
+
+	if transactionID.IsZero() {
+		return updateTransactionCommand{}, errs.NewValueIsRequiredError("transactionID")
+	}
+
+	return updateTransactionCommand{userID: userID, transactionID: transactionID, apply: apply}, nil
+}
+
+func NewSetTransactionAmountCommand(userID shared.ID, transactionID shared.ID, amount transaction.Amount) (UpdateTransactionCommand, error) {
+	return newUpdateTransactionCommand(userID, transactionID, func(t *transaction.Transaction) error {
+		return t.SetAmount(amount)
+	})
+}
+
+// NewSetTransactionNoteCommand задает комментарий к транзакции, пустая строка удаляет комментарий.
+func NewSetTransactionNoteCommand(userID shared.ID, transactionID shared.ID, note string) (UpdateTransactionCommand, error) {
+	return newUpdateTransactionCommand(userID, transactionID, func(t *transaction.Transaction) error {
+		return t.SetNote(note)
+	})
+}
+
+func NewSetTransactionCategoryCommand(userID shared.ID, transactionID shared.ID, categoryID shared.ID) (UpdateTransactionCommand, error) {
+	if categoryID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("categoryID")
+	}
+
+	c, err := newUpdateTransactionCommand(userID, transactionID, func(t *transaction.Transaction) error {
+		return t.SetCategoryID(categoryID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.categoryID = categoryID
+
+	return c, nil
+}
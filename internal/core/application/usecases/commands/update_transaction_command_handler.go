@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// ErrCategoryTypeMismatch возвращается при переносе транзакции в категорию другого типа:
+// расход нельзя перенести в категорию доходов и наоборот.
+var ErrCategoryTypeMismatch = errors.New("category type mismatch")
+
+type UpdateTransactionCommandHandler interface {
+	// Handle применяет изменение и возвращает обновленную транзакцию.
+	// Возвращает errs.ErrObjectNotFound, если у пользователя нет такой транзакции или категории.
+	Handle(ctx context.Context, command UpdateTransactionCommand) (*transaction.Transaction, error)
+}
+
+var _ UpdateTransactionCommandHandler = updateTransactionCommandHandler{}
+
+type updateTransactionCommandHandler struct {
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewUpdateTransactionCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (UpdateTransactionCommandHandler, error) {
+	if logger == nil {
+		return nil, errs.NewValueIsRequiredError("logger")
+	}
+
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &updateTransactionCommandHandler{
+		logger:     logger,
+		uowFactory: uowFactory,
+	}, nil
+}
+
+func (h updateTransactionCommandHandler) Handle(ctx context.Context, command UpdateTransactionCommand) (*transaction.Transaction, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(uow ports.UnitOfWork) {
+		err := uow.RollbackUnlessCommitted()
+		if err != nil {
+			h.logger.Error("update transaction command handler: rollback failed", "err", err)
+		}
+	}(uow)
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+
+	t, err := uow.TransactionRepository().Get(ctx, command.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.UserID() != command.UserID() {
+		return nil, errs.NewObjectNotFoundError("transaction", command.TransactionID().String())
+	}
+
+	if !command.CategoryID().IsZero() {
+		if err := h.checkCategory(ctx, uow, command, t); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := command.Apply(t); err != nil {
+		return nil, err
+	}
+
+	if err := checkRefundsCovered(ctx, uow, t); err != nil {
+		return nil, err
+	}
+
+	if err := uow.TransactionRepository().Update(ctx, t); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// checkCategory проверяет, что новая категория принадлежит пользователю и совпадает по типу с прежней.
+func (h updateTransactionCommandHandler) checkCategory(ctx context.Context, uow ports.UnitOfWork, command UpdateTransactionCommand, t *transaction.Transaction) error {
+	categories, err := uow.CategoryRepository().GetAllByUserID(ctx, command.UserID())
+	if err != nil {
+		return err
+	}
+
+	var current, next *category.Category
+	for _, c := range categories {
+		if c.ID() == t.CategoryID() {
+			current = c
+		}
+
+		if c.ID() == command.CategoryID() {
+			next = c
+		}
+	}
+
+	if next == nil {
+		return errs.NewObjectNotFoundError("category", command.CategoryID().String())
+	}
+
+	if current != nil && current.Type() != next.Type() {
+		return ErrCategoryTypeMismatch
+	}
+
+	return nil
+}
+
+// checkRefundsCovered проверяет, что измененная транзакция покрывает возвраты по ней.
+// Транзакция уже заблокирована чтением в UnitOfWork, поэтому новый возврат не появится до сохранения.
+func checkRefundsCovered(ctx context.Context, uow ports.UnitOfWork, t *transaction.Transaction) error {
+	refunds, err := uow.RefundRepository().FindByTransactionID(ctx, t.ID())
+	if err != nil {
+		return err
+	}
+
+	return refund.CheckCovered(t, refunds)
+}
@@ -0,0 +1,189 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/application/usecases/commands"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+	"github.com/Nemizar/coin_tamer_bot/mocks/core/portsmocks"
+)
+
+type updateTransactionMocks struct {
+	uow         *portsmocks.UnitOfWorkMock
+	transaction *portsmocks.TransactionRepositoryMock
+	category    *portsmocks.CategoryRepositoryMock
+	refund      *portsmocks.RefundRepositoryMock
+}
+
+func newUpdateTransactionMocks(t *testing.T) updateTransactionMocks {
+	m := updateTransactionMocks{
+		uow:         portsmocks.NewUnitOfWorkMock(t),
+		transaction: portsmocks.NewTransactionRepositoryMock(t),
+		category:    portsmocks.NewCategoryRepositoryMock(t),
+		refund:      portsmocks.NewRefundRepositoryMock(t),
+	}
+
+	m.uow.On("TransactionRepository").Return(m.transaction).Maybe()
+	m.uow.On("CategoryRepository").Return(m.category).Maybe()
+	m.uow.On("RefundRepository").Return(m.refund).Maybe()
+
+	return m
+}
+
+func TestUpdateTransactionCommandHandler_SetAmount(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return(nil, nil).Once()
+	m.transaction.EXPECT().Update(ctx, tr).Return(nil).Once()
+
+	handler, err := commands.NewUpdateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	amount, err := transaction.NewAmountFromString("250.75")
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetTransactionAmountCommand(userID, tr.ID(), amount)
+	require.NoError(t, err)
+
+	updated, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, amount, updated.Amount())
+}
+
+func TestUpdateTransactionCommandHandler_BelowRefunded(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	m := newUpdateTransactionMocks(t)
+
+	refunded, err := refund.New(tr, shared.ID{}, newRefundAmount(t, "80"), nil)
+	require.NoError(t, err)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return([]*refund.Refund{refunded}, nil).Once()
+
+	handler, err := commands.NewUpdateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetTransactionAmountCommand(userID, tr.ID(), newRefundAmount(t, "50"))
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, refund.ErrBelowRefunded)
+
+	m.transaction.AssertNotCalled(t, "Update", ctx, tr)
+}
+
+func TestUpdateTransactionCommandHandler_NotOwner(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	tr := newOwnedTransaction(t, shared.NewID())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+
+	handler, err := commands.NewUpdateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetTransactionNoteCommand(shared.NewID(), tr.ID(), "такси")
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, errs.ErrObjectNotFound)
+
+	m.transaction.AssertNotCalled(t, "Update", ctx, tr)
+}
+
+func TestUpdateTransactionCommandHandler_SetCategory(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	current := category.Restore(tr.CategoryID(), "Еда", userID, nil, category.TypeExpense, time.Now())
+	taxi := category.Restore(shared.NewID(), "Такси", userID, nil, category.TypeExpense, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+	m.uow.EXPECT().Commit(ctx).Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{current, taxi}, nil).Once()
+	m.refund.EXPECT().FindByTransactionID(ctx, tr.ID()).Return(nil, nil).Once()
+	m.transaction.EXPECT().Update(ctx, tr).Return(nil).Once()
+
+	handler, err := commands.NewUpdateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetTransactionCategoryCommand(userID, tr.ID(), taxi.ID())
+	require.NoError(t, err)
+
+	updated, err := handler.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, taxi.ID(), updated.CategoryID())
+}
+
+func TestUpdateTransactionCommandHandler_CategoryTypeMismatch(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	userID := shared.NewID()
+	tr := newOwnedTransaction(t, userID)
+	current := category.Restore(tr.CategoryID(), "Еда", userID, nil, category.TypeExpense, time.Now())
+	salary := category.Restore(shared.NewID(), "Зарплата", userID, nil, category.TypeIncome, time.Now())
+	m := newUpdateTransactionMocks(t)
+
+	m.uow.EXPECT().Begin(ctx).Return(nil).Once()
+	m.uow.EXPECT().RollbackUnlessCommitted().Return(nil).Once()
+
+	m.transaction.EXPECT().Get(ctx, tr.ID()).Return(tr, nil).Once()
+	m.category.EXPECT().GetAllByUserID(ctx, userID).Return([]*category.Category{current, salary}, nil).Once()
+
+	handler, err := commands.NewUpdateTransactionCommandHandler(logger, newUnitOfWorkFactory(t, m.uow))
+	require.NoError(t, err)
+
+	cmd, err := commands.NewSetTransactionCategoryCommand(userID, tr.ID(), salary.ID())
+	require.NoError(t, err)
+
+	_, err = handler.Handle(ctx, cmd)
+	require.ErrorIs(t, err, commands.ErrCategoryTypeMismatch)
+
+	assert.Equal(t, current.ID(), tr.CategoryID())
+	m.transaction.AssertNotCalled(t, "Update", ctx, tr)
+}
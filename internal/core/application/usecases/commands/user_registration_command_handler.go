@@ -16,39 +16,44 @@ type UserRegistrationCommandHandler interface {
 var _ UserRegistrationCommandHandler = userRegistrationCommandHandler{}
 
 type userRegistrationCommandHandler struct {
-	logger ports.Logger
-	uow    ports.UnitOfWork
+	logger     ports.Logger
+	uowFactory ports.UnitOfWorkFactory
 }
 
-func NewUserRegistrationCommandHandler(logger ports.Logger, uow ports.UnitOfWork) (UserRegistrationCommandHandler, error) {
+func NewUserRegistrationCommandHandler(logger ports.Logger, uowFactory ports.UnitOfWorkFactory) (UserRegistrationCommandHandler, error) {
 	if logger == nil {
 		return nil, errs.NewValueIsRequiredError("logger")
 	}
 
-	if uow == nil {
-		return nil, errs.NewValueIsRequiredError("uow")
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
 	}
 
 	return &userRegistrationCommandHandler{
-		logger: logger,
-		uow:    uow,
+		logger:     logger,
+		uowFactory: uowFactory,
 	}, nil
 }
 
 func (u userRegistrationCommandHandler) Handle(ctx context.Context, command UserRegistrationCommand) error {
+	uow, err := u.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
 	defer func(uow ports.UnitOfWork) {
 		err := uow.RollbackUnlessCommitted()
 		if err != nil {
 			u.logger.Error("user registration command handler: rollback failed", "err", err)
 		}
-	}(u.uow)
+	}(uow)
 
-	err := u.uow.Begin(ctx)
+	err = uow.Begin(ctx)
 	if err != nil {
 		return err
 	}
 
-	existsUser, err := u.uow.UserRepository().FindByExternalProvider(ctx, command.Provider(), command.ChatID())
+	existsUser, err := uow.UserRepository().FindByExternalProvider(ctx, command.Provider(), command.ChatID())
 	if err != nil {
 		if !errors.Is(err, errs.ErrObjectNotFound) {
 			return err
@@ -64,10 +69,10 @@ func (u userRegistrationCommandHandler) Handle(ctx context.Context, command User
 		return err
 	}
 
-	err = u.uow.UserRepository().Create(ctx, nu)
+	err = uow.UserRepository().Create(ctx, nu)
 	if err != nil {
 		return err
 	}
 
-	return u.uow.Commit(ctx)
+	return uow.Commit(ctx)
 }
@@ -123,7 +123,7 @@ func TestUserRegistrationCommandHandler_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -177,7 +177,7 @@ func TestUserRegistrationCommandHandler_UserAlreadyExists(t *testing.T) {
 		Once()
 	// Commit не вызывается, когда пользователь уже существует
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -227,7 +227,7 @@ func TestUserRegistrationCommandHandler_UserAlreadyExistsReturnsCorrectErrorType
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -272,7 +272,7 @@ func TestUserRegistrationCommandHandler_BeginTransactionError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -319,7 +319,7 @@ func TestUserRegistrationCommandHandler_CommitError(t *testing.T) {
 		Return(errors.New("commit error")).
 		Once()
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -360,7 +360,7 @@ func TestUserRegistrationCommandHandler_FindByExternalProviderError(t *testing.T
 		Once()
 	// Commit не вызывается при ошибке в репозитории
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -406,7 +406,7 @@ func TestUserRegistrationCommandHandler_CreateError(t *testing.T) {
 		Once()
 	// Commit не вызывается при ошибке создания
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
@@ -454,7 +454,7 @@ func TestUserRegistrationCommandHandler_Logging(t *testing.T) {
 		Return(nil).
 		Once()
 
-	handler, err := commands.NewUserRegistrationCommandHandler(logger, uowMock)
+	handler, err := commands.NewUserRegistrationCommandHandler(logger, newUnitOfWorkFactory(t, uowMock))
 	require.NoError(t, err)
 
 	err = handler.Handle(ctx, cmd)
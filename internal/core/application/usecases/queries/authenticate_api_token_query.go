@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type AuthenticateAPITokenQuery interface {
+	Secret() string
+	Now() time.Time
+}
+
+type authenticateAPITokenQuery struct {
+	secret string
+	now    time.Time
+}
+
+func NewAuthenticateAPITokenQuery(secret string, now time.Time) (AuthenticateAPITokenQuery, error) {
+	if secret == "" {
+		return nil, errs.NewValueIsRequiredError("secret")
+	}
+
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &authenticateAPITokenQuery{secret: secret, now: now}, nil
+}
+
+func (q authenticateAPITokenQuery) Secret() string {
+	return q.secret
+}
+
+func (q authenticateAPITokenQuery) Now() time.Time {
+	return q.now
+}
@@ -0,0 +1,62 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// APITokenOwner пользователь, которому принадлежит предъявленный токен, и сам токен с его правами.
+type APITokenOwner struct {
+	User  *user.User
+	Token *apitoken.Token
+}
+
+type AuthenticateAPITokenQueryHandler interface {
+	// Handle находит владельца токена. Возвращает apitoken.ErrInvalidSecret, если токен
+	// неизвестен, отозван или истек.
+	Handle(ctx context.Context, query AuthenticateAPITokenQuery) (*APITokenOwner, error)
+}
+
+type authenticateAPITokenQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewAuthenticateAPITokenQueryHandler(uowFactory ports.UnitOfWorkFactory) (AuthenticateAPITokenQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &authenticateAPITokenQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h authenticateAPITokenQueryHandler) Handle(ctx context.Context, query AuthenticateAPITokenQuery) (*APITokenOwner, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := uow.APITokenRepository().GetByHash(ctx, apitoken.Hash(query.Secret()))
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return nil, apitoken.ErrInvalidSecret
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.IsActive(query.Now()) {
+		return nil, apitoken.ErrInvalidSecret
+	}
+
+	u, err := uow.UserRepository().Get(ctx, t.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &APITokenOwner{User: u, Token: t}, nil
+}
@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type AuthenticateWebSessionQuery interface {
+	SessionSecret() string
+	Now() time.Time
+}
+
+type authenticateWebSessionQuery struct {
+	sessionSecret string
+	now           time.Time
+}
+
+func NewAuthenticateWebSessionQuery(sessionSecret string, now time.Time) (AuthenticateWebSessionQuery, error) {
+	if sessionSecret == "" {
+		return nil, errs.NewValueIsRequiredError("sessionSecret")
+	}
+
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &authenticateWebSessionQuery{sessionSecret: sessionSecret, now: now}, nil
+}
+
+func (q authenticateWebSessionQuery) SessionSecret() string {
+	return q.sessionSecret
+}
+
+func (q authenticateWebSessionQuery) Now() time.Time {
+	return q.now
+}
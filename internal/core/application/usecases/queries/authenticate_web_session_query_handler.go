@@ -0,0 +1,51 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type AuthenticateWebSessionQueryHandler interface {
+	// Handle возвращает владельца сеанса. Возвращает websession.ErrInvalidSession для недействительного сеанса.
+	Handle(ctx context.Context, query AuthenticateWebSessionQuery) (*user.User, error)
+}
+
+type authenticateWebSessionQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewAuthenticateWebSessionQueryHandler(uowFactory ports.UnitOfWorkFactory) (AuthenticateWebSessionQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &authenticateWebSessionQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h authenticateWebSessionQueryHandler) Handle(ctx context.Context, query AuthenticateWebSessionQuery) (*user.User, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uow.WebSessionRepository().GetByHash(ctx, websession.Hash(query.SessionSecret()))
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return nil, websession.ErrInvalidSession
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Секрет неиспользованной ссылки тоже находится по хешу, но сеансом не является.
+	if !s.IsActive(query.Now()) {
+		return nil, websession.ErrInvalidSession
+	}
+
+	return uow.UserRepository().Get(ctx, s.UserID())
+}
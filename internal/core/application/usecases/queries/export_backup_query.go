@@ -0,0 +1,21 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type ExportBackupQuery interface {
+	UserID() shared.ID
+}
+
+type exportBackupQuery struct {
+	userID shared.ID
+}
+
+func NewExportBackupQuery(userID shared.ID) ExportBackupQuery {
+	return &exportBackupQuery{userID: userID}
+}
+
+func (q exportBackupQuery) UserID() shared.ID {
+	return q.userID
+}
@@ -0,0 +1,91 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type ExportBackupQueryHandler interface {
+	// Handle записывает в w полную резервную копию данных пользователя.
+	Handle(ctx context.Context, query ExportBackupQuery, w io.Writer) error
+}
+
+type exportBackupQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+	codec      ports.BackupCodec
+}
+
+func NewExportBackupQueryHandler(uowFactory ports.UnitOfWorkFactory, codec ports.BackupCodec) (ExportBackupQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if codec == nil {
+		return nil, errs.NewValueIsRequiredError("codec")
+	}
+
+	return &exportBackupQueryHandler{uowFactory: uowFactory, codec: codec}, nil
+}
+
+func (h exportBackupQueryHandler) Handle(ctx context.Context, query ExportBackupQuery, w io.Writer) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	u, err := uow.UserRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	identities, err := uow.UserRepository().FindExternalIdentities(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	categories, err := uow.CategoryRepository().GetAllByUserID(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	transactions, err := uow.TransactionRepository().FindByUserID(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	tags, err := uow.TagRepository().FindByUserID(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	links, err := uow.TagRepository().FindLinksByUserID(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	refunds, err := uow.RefundRepository().FindByUserID(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := backup.NewSnapshot(u, identities, categories, transactions, tags, links, refunds, s)
+	if err != nil {
+		return err
+	}
+
+	if err := h.codec.Encode(w, snapshot); err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+
+	return nil
+}
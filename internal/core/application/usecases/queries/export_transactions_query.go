@@ -0,0 +1,71 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type ExportTransactionsQuery interface {
+	UserID() shared.ID
+	From() time.Time
+	To() time.Time
+	Format() report.ExportFormat
+
+	// TagID возвращает метку, транзакции с которой выгружаются, или нулевой идентификатор для всех транзакций.
+	TagID() shared.ID
+}
+
+type exportTransactionsQuery struct {
+	userID shared.ID
+	from   time.Time
+	to     time.Time
+	format report.ExportFormat
+	tagID  shared.ID
+}
+
+// NewExportTransactionsQuery создает запрос выгрузки транзакций за период [from, to).
+// Нулевые границы не ограничивают период, нулевой tagID не ограничивает выборку по меткам.
+func NewExportTransactionsQuery(
+	userID shared.ID,
+	from time.Time,
+	to time.Time,
+	format report.ExportFormat,
+	tagID shared.ID,
+) (ExportTransactionsQuery, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, errs.NewValueIsInvalidError("period")
+	}
+
+	if !format.IsValid() {
+		return nil, errs.NewValueIsInvalidError("format")
+	}
+
+	return &exportTransactionsQuery{userID: userID, from: from, to: to, format: format, tagID: tagID}, nil
+}
+
+func (q exportTransactionsQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q exportTransactionsQuery) From() time.Time {
+	return q.from
+}
+
+func (q exportTransactionsQuery) To() time.Time {
+	return q.to
+}
+
+func (q exportTransactionsQuery) Format() report.ExportFormat {
+	return q.format
+}
+
+func (q exportTransactionsQuery) TagID() shared.ID {
+	return q.tagID
+}
@@ -0,0 +1,75 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type ExportTransactionsQueryHandler interface {
+	// Handle записывает выгрузку транзакций в w по мере чтения из хранилища.
+	Handle(ctx context.Context, query ExportTransactionsQuery, w io.Writer) error
+}
+
+type exportTransactionsQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+	exporters  map[report.ExportFormat]ports.Exporter
+}
+
+func NewExportTransactionsQueryHandler(uowFactory ports.UnitOfWorkFactory, exporters ...ports.Exporter) (ExportTransactionsQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if len(exporters) == 0 {
+		return nil, errs.NewValueIsRequiredError("exporters")
+	}
+
+	byFormat := make(map[report.ExportFormat]ports.Exporter, len(exporters))
+	for _, e := range exporters {
+		if e == nil {
+			return nil, errs.NewValueIsRequiredError("exporter")
+		}
+
+		byFormat[e.Format()] = e
+	}
+
+	return &exportTransactionsQueryHandler{uowFactory: uowFactory, exporters: byFormat}, nil
+}
+
+func (h exportTransactionsQueryHandler) Handle(ctx context.Context, query ExportTransactionsQuery, w io.Writer) error {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return err
+	}
+
+	exporter, ok := h.exporters[query.Format()]
+	if !ok {
+		return errs.NewValueIsInvalidError("format " + query.Format().String())
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return err
+	}
+
+	ew, err := exporter.NewWriter(w, s.Location())
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	err = uow.TransactionRepository().StreamLines(ctx, query.UserID(), query.From(), query.To(), query.TagID(), ew.Write)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	if err := ew.Close(); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	return nil
+}
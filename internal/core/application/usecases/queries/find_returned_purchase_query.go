@@ -0,0 +1,29 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type FindReturnedPurchaseQuery interface {
+	UserID() shared.ID
+	Receipt() receipt.Receipt
+}
+
+type findReturnedPurchaseQuery struct {
+	userID  shared.ID
+	receipt receipt.Receipt
+}
+
+// NewFindReturnedPurchaseQuery создает запрос поиска покупки пользователя userID, которую возвращает чек возврата r.
+func NewFindReturnedPurchaseQuery(userID shared.ID, r receipt.Receipt) FindReturnedPurchaseQuery {
+	return &findReturnedPurchaseQuery{userID: userID, receipt: r}
+}
+
+func (q findReturnedPurchaseQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q findReturnedPurchaseQuery) Receipt() receipt.Receipt {
+	return q.receipt
+}
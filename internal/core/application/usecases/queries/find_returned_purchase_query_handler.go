@@ -0,0 +1,103 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// sameDrivePurchasesLimit сколько последних покупок с того же фискального накопителя просматривается.
+	sameDrivePurchasesLimit = 20
+
+	// returnCandidatesLimit сколько покупок предлагается на выбор, если исходную не удалось найти.
+	returnCandidatesLimit = 5
+)
+
+// ReturnedPurchase результат поиска покупки по чеку возврата.
+type ReturnedPurchase struct {
+	// Purchase покупка, которую однозначно возвращает чек. Имеет смысл, только если Found.
+	Purchase report.TransactionLine
+	Found    bool
+
+	// Candidates покупки, из которых пользователь выбирает возвращенную, если Found ложно:
+	// сначала подходящие покупки с того же фискального накопителя, а если их нет — последние
+	// расходы до возврата на сумму не меньше возвращенной. Может быть пусто.
+	Candidates []report.TransactionLine
+}
+
+type FindReturnedPurchaseQueryHandler interface {
+	// Handle ищет покупку, которую возвращает чек возврата: расход, записанный по чеку
+	// того же фискального накопителя на ту же сумму.
+	Handle(ctx context.Context, query FindReturnedPurchaseQuery) (*ReturnedPurchase, error)
+}
+
+type findReturnedPurchaseQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewFindReturnedPurchaseQueryHandler(uowFactory ports.UnitOfWorkFactory) (FindReturnedPurchaseQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &findReturnedPurchaseQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h findReturnedPurchaseQueryHandler) Handle(ctx context.Context, query FindReturnedPurchaseQuery) (*ReturnedPurchase, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	r := query.Receipt()
+
+	sameDrive, err := uow.TransactionRepository().FindLinesByFiscalDrive(ctx, query.UserID(), r.FiscalID().Drive(), sameDrivePurchasesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if purchase, ok := r.ReturnedPurchase(sameDrive); ok {
+		return &ReturnedPurchase{Purchase: purchase, Found: true}, nil
+	}
+
+	var candidates []report.TransactionLine
+	for _, p := range sameDrive {
+		if r.CanReturn(p) && len(candidates) < returnCandidatesLimit {
+			candidates = append(candidates, p)
+		}
+	}
+
+	if len(candidates) > 0 {
+		return &ReturnedPurchase{Candidates: candidates}, nil
+	}
+
+	filter, err := report.HistoryFilter{}.WithType(category.TypeExpense)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err = filter.WithAmountRange(r.Amount(), decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+
+	// Граница периода не включается, поэтому берем минуту после возврата: время в чеке указано с точностью до минуты.
+	filter, err = filter.WithPeriod(time.Time{}, r.OccurredAt().Add(time.Minute))
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err = uow.TransactionRepository().FindHistory(ctx, query.UserID(), filter, report.HistoryCursor{}, returnCandidatesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReturnedPurchase{Candidates: candidates}, nil
+}
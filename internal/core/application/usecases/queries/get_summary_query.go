@@ -0,0 +1,45 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type GetSummaryQuery interface {
+	UserID() shared.ID
+	From() time.Time
+	To() time.Time
+}
+
+type getSummaryQuery struct {
+	userID shared.ID
+	from   time.Time
+	to     time.Time
+}
+
+// NewGetSummaryQuery создает запрос сводки доходов и расходов пользователя за период [from, to).
+func NewGetSummaryQuery(userID shared.ID, from time.Time, to time.Time) (GetSummaryQuery, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return nil, errs.NewValueIsInvalidError("period")
+	}
+
+	return &getSummaryQuery{userID: userID, from: from, to: to}, nil
+}
+
+func (q getSummaryQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q getSummaryQuery) From() time.Time {
+	return q.from
+}
+
+func (q getSummaryQuery) To() time.Time {
+	return q.to
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type GetSummaryQueryHandler interface {
+	// Handle возвращает суммы транзакций пользователя за период по категориям.
+	Handle(ctx context.Context, query GetSummaryQuery) (*report.Summary, error)
+}
+
+type getSummaryQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetSummaryQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetSummaryQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getSummaryQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getSummaryQueryHandler) Handle(ctx context.Context, query GetSummaryQuery) (*report.Summary, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uow.TransactionRepository().GetTotalsByCategory(ctx, query.UserID(), query.From(), query.To())
+	if err != nil {
+		return nil, err
+	}
+
+	return report.NewSummary(query.From(), query.To(), totals), nil
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type GetTagSummaryQuery interface {
+	UserID() shared.ID
+	TagName() string
+}
+
+type getTagSummaryQuery struct {
+	userID  shared.ID
+	tagName string
+}
+
+// NewGetTagSummaryQuery создает запрос итогов по метке name, записанной со знаком # или без него.
+// Возвращает tag.ErrInvalidName для недопустимого названия.
+func NewGetTagSummaryQuery(userID shared.ID, name string) (GetTagSummaryQuery, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	name, err := tag.NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &getTagSummaryQuery{userID: userID, tagName: name}, nil
+}
+
+func (q getTagSummaryQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q getTagSummaryQuery) TagName() string {
+	return q.tagName
+}
@@ -0,0 +1,53 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// TagSummary суммы транзакций с меткой по категориям за все время.
+type TagSummary struct {
+	Tag     *tag.Tag
+	Summary *report.Summary
+}
+
+type GetTagSummaryQueryHandler interface {
+	// Handle возвращает итоги по метке. Возвращает errs.ErrObjectNotFound, если у пользователя нет такой метки.
+	Handle(ctx context.Context, query GetTagSummaryQuery) (*TagSummary, error)
+}
+
+type getTagSummaryQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetTagSummaryQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetTagSummaryQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getTagSummaryQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getTagSummaryQueryHandler) Handle(ctx context.Context, query GetTagSummaryQuery) (*TagSummary, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := uow.TagRepository().GetByName(ctx, query.UserID(), query.TagName())
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uow.TransactionRepository().GetTotalsByTag(ctx, query.UserID(), t.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TagSummary{Tag: t, Summary: report.NewSummary(time.Time{}, time.Time{}, totals)}, nil
+}
@@ -0,0 +1,28 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type GetTransactionDetailsQuery interface {
+	UserID() shared.ID
+	TransactionID() shared.ID
+}
+
+type getTransactionDetailsQuery struct {
+	userID        shared.ID
+	transactionID shared.ID
+}
+
+// NewGetTransactionDetailsQuery создает запрос подробностей транзакции transactionID пользователя userID.
+func NewGetTransactionDetailsQuery(userID shared.ID, transactionID shared.ID) GetTransactionDetailsQuery {
+	return &getTransactionDetailsQuery{userID: userID, transactionID: transactionID}
+}
+
+func (q getTransactionDetailsQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q getTransactionDetailsQuery) TransactionID() shared.ID {
+	return q.transactionID
+}
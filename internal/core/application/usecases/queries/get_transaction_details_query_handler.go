@@ -0,0 +1,146 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// TransactionDetails транзакция с данными категории и вложениями для просмотра.
+type TransactionDetails struct {
+	Line        report.TransactionLine
+	Attachments []*attachment.Attachment
+
+	// Parts части разделенной транзакции в порядке ввода. Пусто, если транзакция не разделена.
+	Parts []report.CategoryTotal
+
+	// Refunds возвраты по транзакции в порядке времени возврата.
+	Refunds []*refund.Refund
+
+	// Refundable сумма, которую еще можно вернуть, по категориям расходной транзакции:
+	// по категории транзакции или по категориям частей разделенной. Пусто для доходов.
+	Refundable map[shared.ID]decimal.Decimal
+
+	// Location часовой пояс пользователя, в котором показывается время операции.
+	Location *time.Location
+}
+
+// CanRefund сообщает, что по транзакции еще можно оформить возврат.
+func (d *TransactionDetails) CanRefund() bool {
+	for _, available := range d.Refundable {
+		if available.IsPositive() {
+			return true
+		}
+	}
+
+	return false
+}
+
+type GetTransactionDetailsQueryHandler interface {
+	// Handle возвращает подробности транзакции пользователя.
+	// Возвращает errs.ErrObjectNotFound, если у пользователя нет такой транзакции.
+	Handle(ctx context.Context, query GetTransactionDetailsQuery) (*TransactionDetails, error)
+}
+
+type getTransactionDetailsQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetTransactionDetailsQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetTransactionDetailsQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getTransactionDetailsQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getTransactionDetailsQueryHandler) Handle(ctx context.Context, query GetTransactionDetailsQuery) (*TransactionDetails, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := uow.TransactionRepository().GetLine(ctx, query.UserID(), query.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := uow.AttachmentRepository().FindByTransactionID(ctx, query.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := uow.TransactionRepository().FindParts(ctx, query.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := uow.RefundRepository().FindByTransactionID(ctx, query.TransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	var refundable map[shared.ID]decimal.Decimal
+	if line.CategoryType() == category.TypeExpense {
+		refundable, err = h.refundable(ctx, uow, query.TransactionID(), refunds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionDetails{
+		Line:        line,
+		Attachments: attachments,
+		Parts:       parts,
+		Refunds:     refunds,
+		Refundable:  refundable,
+		Location:    s.Location(),
+	}, nil
+}
+
+// refundable считает остаток к возврату по каждой категории транзакции.
+func (h getTransactionDetailsQueryHandler) refundable(
+	ctx context.Context,
+	uow ports.UnitOfWork,
+	transactionID shared.ID,
+	refunds []*refund.Refund,
+) (map[shared.ID]decimal.Decimal, error) {
+	t, err := uow.TransactionRepository().Get(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs := []shared.ID{t.CategoryID()}
+	if t.IsSplit() {
+		categoryIDs = categoryIDs[:0]
+		for _, p := range t.Splits() {
+			categoryIDs = append(categoryIDs, p.CategoryID())
+		}
+	}
+
+	result := make(map[shared.ID]decimal.Decimal, len(categoryIDs))
+	for _, id := range categoryIDs {
+		available, err := refund.Available(t, id, refunds)
+		if err != nil {
+			return nil, err
+		}
+
+		result[id] = available
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// MaxHistoryPageSize наибольшее число транзакций на странице истории.
+const MaxHistoryPageSize = 50
+
+type GetTransactionHistoryQuery interface {
+	UserID() shared.ID
+	Filter() report.HistoryFilter
+	After() report.HistoryCursor
+	Limit() int
+}
+
+type getTransactionHistoryQuery struct {
+	userID shared.ID
+	filter report.HistoryFilter
+	after  report.HistoryCursor
+	limit  int
+}
+
+// NewGetTransactionHistoryQuery создает запрос страницы истории из limit транзакций, начинающейся
+// сразу после позиции after. Нулевая позиция означает первую страницу.
+func NewGetTransactionHistoryQuery(
+	userID shared.ID,
+	filter report.HistoryFilter,
+	after report.HistoryCursor,
+	limit int,
+) (GetTransactionHistoryQuery, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if limit <= 0 || limit > MaxHistoryPageSize {
+		return nil, errs.NewValueIsInvalidError("limit")
+	}
+
+	return &getTransactionHistoryQuery{userID: userID, filter: filter, after: after, limit: limit}, nil
+}
+
+func (q getTransactionHistoryQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q getTransactionHistoryQuery) Filter() report.HistoryFilter {
+	return q.filter
+}
+
+func (q getTransactionHistoryQuery) After() report.HistoryCursor {
+	return q.after
+}
+
+func (q getTransactionHistoryQuery) Limit() int {
+	return q.limit
+}
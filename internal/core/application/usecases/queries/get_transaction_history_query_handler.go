@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// HistoryPage страница истории транзакций.
+type HistoryPage struct {
+	Lines []report.TransactionLine
+
+	// HasMore сообщает, что за последней строкой страницы есть еще транзакции.
+	HasMore bool
+
+	// Location часовой пояс пользователя, в котором показывается время операций.
+	Location *time.Location
+}
+
+// Next возвращает позицию, с которой начинается следующая страница.
+func (p *HistoryPage) Next() report.HistoryCursor {
+	if len(p.Lines) == 0 {
+		return report.HistoryCursor{}
+	}
+
+	return report.NewHistoryCursor(p.Lines[len(p.Lines)-1])
+}
+
+type GetTransactionHistoryQueryHandler interface {
+	Handle(ctx context.Context, query GetTransactionHistoryQuery) (*HistoryPage, error)
+}
+
+type getTransactionHistoryQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetTransactionHistoryQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetTransactionHistoryQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getTransactionHistoryQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getTransactionHistoryQueryHandler) Handle(ctx context.Context, query GetTransactionHistoryQuery) (*HistoryPage, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	// Одна лишняя строка показывает, есть ли следующая страница, без отдельного подсчета.
+	lines, err := uow.TransactionRepository().FindHistory(ctx, query.UserID(), query.Filter(), query.After(), query.Limit()+1)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	page := &HistoryPage{Lines: lines, Location: s.Location()}
+	if len(lines) > query.Limit() {
+		page.Lines = lines[:query.Limit()]
+		page.HasMore = true
+	}
+
+	return page, nil
+}
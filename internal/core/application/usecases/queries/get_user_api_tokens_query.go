@@ -0,0 +1,21 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type GetUserAPITokensQuery interface {
+	UserID() shared.ID
+}
+
+type getUserAPITokensQuery struct {
+	userID shared.ID
+}
+
+func NewGetUserAPITokensQuery(userID shared.ID) GetUserAPITokensQuery {
+	return &getUserAPITokensQuery{userID: userID}
+}
+
+func (q getUserAPITokensQuery) UserID() shared.ID {
+	return q.userID
+}
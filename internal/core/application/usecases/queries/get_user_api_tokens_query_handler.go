@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type GetUserAPITokensQueryHandler interface {
+	// Handle возвращает неотозванные токены пользователя, включая истекшие, от старых к новым.
+	Handle(ctx context.Context, query GetUserAPITokensQuery) ([]*apitoken.Token, error)
+}
+
+type getUserAPITokensQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetUserAPITokensQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetUserAPITokensQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getUserAPITokensQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getUserAPITokensQueryHandler) Handle(ctx context.Context, query GetUserAPITokensQuery) ([]*apitoken.Token, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return uow.APITokenRepository().FindByUserID(ctx, query.UserID())
+}
@@ -13,30 +13,32 @@ type GetUserCategoriesByTypeQueryHandler interface {
 }
 
 type getUserCategoriesByTypeQueryHandler struct {
-	uow ports.UnitOfWork
+	uowFactory ports.UnitOfWorkFactory
 }
 
-func NewGetUserCategoriesByTypeQueryHandler(uow ports.UnitOfWork) (GetUserCategoriesByTypeQueryHandler, error) {
-	if uow == nil {
-		return nil, errs.NewValueIsRequiredError("uow")
+func NewGetUserCategoriesByTypeQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetUserCategoriesByTypeQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
 	}
 
-	return &getUserCategoriesByTypeQueryHandler{uow: uow}, nil
+	return &getUserCategoriesByTypeQueryHandler{uowFactory: uowFactory}, nil
 }
 
 func (h getUserCategoriesByTypeQueryHandler) Handle(ctx context.Context, query GetUserCategoriesByTypeQuery) ([]*category.Category, error) {
-	var (
-		result []*category.Category
-		err    error
-	)
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*category.Category
 
 	if query.CategoryType() == category.TypeIncome {
-		result, err = h.uow.CategoryRepository().GetIncomeByUserID(ctx, query.UserID())
+		result, err = uow.CategoryRepository().GetIncomeByUserID(ctx, query.UserID())
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		result, err = h.uow.CategoryRepository().GetExpenseByUserID(ctx, query.UserID())
+		result, err = uow.CategoryRepository().GetExpenseByUserID(ctx, query.UserID())
 		if err != nil {
 			return nil, err
 		}
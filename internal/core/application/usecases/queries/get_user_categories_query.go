@@ -0,0 +1,21 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type GetUserCategoriesQuery interface {
+	UserID() shared.ID
+}
+
+type getUserCategoriesQuery struct {
+	userID shared.ID
+}
+
+func NewGetUserCategoriesQuery(userID shared.ID) GetUserCategoriesQuery {
+	return &getUserCategoriesQuery{userID: userID}
+}
+
+func (q getUserCategoriesQuery) UserID() shared.ID {
+	return q.userID
+}
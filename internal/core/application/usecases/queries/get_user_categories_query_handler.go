@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type GetUserCategoriesQueryHandler interface {
+	// Handle возвращает все категории пользователя обоих типов, включая родительские категории расходов.
+	Handle(ctx context.Context, query GetUserCategoriesQuery) ([]*category.Category, error)
+}
+
+type getUserCategoriesQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetUserCategoriesQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetUserCategoriesQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getUserCategoriesQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getUserCategoriesQueryHandler) Handle(ctx context.Context, query GetUserCategoriesQuery) ([]*category.Category, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return uow.CategoryRepository().GetAllByUserID(ctx, query.UserID())
+}
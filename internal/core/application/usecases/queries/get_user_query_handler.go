@@ -13,17 +13,22 @@ type GetUserQueryHandler interface {
 }
 
 type getUserQueryHandler struct {
-	uow ports.UnitOfWork
+	uowFactory ports.UnitOfWorkFactory
 }
 
-func NewGetUserQueryHandler(uow ports.UnitOfWork) (GetUserQueryHandler, error) {
-	if uow == nil {
-		return nil, errs.NewValueIsRequiredError("uow")
+func NewGetUserQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetUserQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
 	}
 
-	return &getUserQueryHandler{uow: uow}, nil
+	return &getUserQueryHandler{uowFactory: uowFactory}, nil
 }
 
 func (h getUserQueryHandler) Handle(ctx context.Context, query GetUserQuery) (*user.User, error) {
-	return h.uow.UserRepository().FindByExternalProvider(ctx, query.Provider(), query.ExternalID())
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return uow.UserRepository().FindByExternalProvider(ctx, query.Provider(), query.ExternalID())
 }
@@ -0,0 +1,21 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type GetUserSettingsQuery interface {
+	UserID() shared.ID
+}
+
+type getUserSettingsQuery struct {
+	userID shared.ID
+}
+
+func NewGetUserSettingsQuery(userID shared.ID) GetUserSettingsQuery {
+	return &getUserSettingsQuery{userID: userID}
+}
+
+func (q getUserSettingsQuery) UserID() shared.ID {
+	return q.userID
+}
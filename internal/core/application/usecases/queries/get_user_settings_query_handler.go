@@ -0,0 +1,34 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type GetUserSettingsQueryHandler interface {
+	Handle(ctx context.Context, query GetUserSettingsQuery) (*settings.Settings, error)
+}
+
+type getUserSettingsQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetUserSettingsQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetUserSettingsQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getUserSettingsQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getUserSettingsQueryHandler) Handle(ctx context.Context, query GetUserSettingsQuery) (*settings.Settings, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return uow.SettingsRepository().Get(ctx, query.UserID())
+}
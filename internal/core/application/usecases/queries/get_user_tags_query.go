@@ -0,0 +1,21 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type GetUserTagsQuery interface {
+	UserID() shared.ID
+}
+
+type getUserTagsQuery struct {
+	userID shared.ID
+}
+
+func NewGetUserTagsQuery(userID shared.ID) GetUserTagsQuery {
+	return &getUserTagsQuery{userID: userID}
+}
+
+func (q getUserTagsQuery) UserID() shared.ID {
+	return q.userID
+}
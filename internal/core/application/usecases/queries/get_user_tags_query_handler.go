@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type GetUserTagsQueryHandler interface {
+	// Handle возвращает метки пользователя в алфавитном порядке.
+	Handle(ctx context.Context, query GetUserTagsQuery) ([]*tag.Tag, error)
+}
+
+type getUserTagsQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewGetUserTagsQueryHandler(uowFactory ports.UnitOfWorkFactory) (GetUserTagsQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &getUserTagsQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h getUserTagsQueryHandler) Handle(ctx context.Context, query GetUserTagsQuery) ([]*tag.Tag, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return uow.TagRepository().FindByUserID(ctx, query.UserID())
+}
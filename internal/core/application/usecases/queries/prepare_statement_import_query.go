@@ -0,0 +1,50 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type PrepareStatementImportQuery interface {
+	UserID() shared.ID
+	Format() statement.FileFormat
+	// Mapping возвращает разметку столбцов, заданную пользователем, или nil для определения по заголовку.
+	Mapping() *statement.Mapping
+}
+
+type prepareStatementImportQuery struct {
+	userID  shared.ID
+	format  statement.FileFormat
+	mapping *statement.Mapping
+}
+
+// NewPrepareStatementImportQuery создает запрос разбора выписки в формате format перед импортом.
+// Если mapping не задан, разметка берется из формата файла или определяется по заголовку.
+func NewPrepareStatementImportQuery(
+	userID shared.ID,
+	format statement.FileFormat,
+	mapping *statement.Mapping,
+) (PrepareStatementImportQuery, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if !format.IsValid() {
+		return nil, errs.NewValueIsInvalidError("format")
+	}
+
+	return &prepareStatementImportQuery{userID: userID, format: format, mapping: mapping}, nil
+}
+
+func (q prepareStatementImportQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q prepareStatementImportQuery) Format() statement.FileFormat {
+	return q.format
+}
+
+func (q prepareStatementImportQuery) Mapping() *statement.Mapping {
+	return q.mapping
+}
@@ -0,0 +1,116 @@
+package queries
+
+import (
+	"context"
+	"io"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type PrepareStatementImportQueryHandler interface {
+	// Handle читает выписку из r и возвращает план импорта с подобранными категориями
+	// и отмеченными повторами. Если формат выписки не распознан, возвращает *statement.UnknownFormatError.
+	Handle(ctx context.Context, query PrepareStatementImportQuery, r io.Reader) (*statement.ImportPlan, error)
+}
+
+type prepareStatementImportQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+	readers    map[statement.FileFormat]ports.StatementReader
+}
+
+func NewPrepareStatementImportQueryHandler(
+	uowFactory ports.UnitOfWorkFactory,
+	readers ...ports.StatementReader,
+) (PrepareStatementImportQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if len(readers) == 0 {
+		return nil, errs.NewValueIsRequiredError("readers")
+	}
+
+	byFormat := make(map[statement.FileFormat]ports.StatementReader, len(readers))
+	for _, r := range readers {
+		if r == nil {
+			return nil, errs.NewValueIsRequiredError("reader")
+		}
+
+		byFormat[r.Format()] = r
+	}
+
+	return &prepareStatementImportQueryHandler{uowFactory: uowFactory, readers: byFormat}, nil
+}
+
+func (h prepareStatementImportQueryHandler) Handle(
+	ctx context.Context,
+	query PrepareStatementImportQuery,
+	r io.Reader,
+) (*statement.ImportPlan, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, ok := h.readers[query.Format()]
+	if !ok {
+		return nil, errs.NewValueIsInvalidError("format " + query.Format().String())
+	}
+
+	table, err := reader.Read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := resolveMapping(query, table)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	expense, err := uow.CategoryRepository().GetExpenseByUserID(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	income, err := uow.CategoryRepository().GetIncomeByUserID(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	records, rowErrors := mapping.Parse(table, s.Location())
+	plan := statement.NewImportPlan(mapping, records, rowErrors, statement.NewCategorizer(expense, income))
+
+	existing, err := uow.TransactionRepository().FindFingerprints(ctx, query.UserID(), plan.Fingerprints())
+	if err != nil {
+		return nil, err
+	}
+
+	plan.MarkDuplicates(existing)
+
+	return plan, nil
+}
+
+// resolveMapping выбирает разметку столбцов: заданную пользователем, известную из формата файла
+// или определенную по заголовку выписки.
+func resolveMapping(query PrepareStatementImportQuery, table statement.Table) (statement.Mapping, error) {
+	if query.Mapping() != nil {
+		return *query.Mapping(), nil
+	}
+
+	if mapping, ok := table.Mapping(); ok {
+		return mapping, nil
+	}
+
+	if mapping, ok := statement.DetectMapping(table.Header()); ok {
+		return mapping, nil
+	}
+
+	return statement.Mapping{}, statement.NewUnknownFormatError(table.Header())
+}
@@ -0,0 +1,22 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type ReadBackupQuery interface {
+	UserID() shared.ID
+}
+
+type readBackupQuery struct {
+	userID shared.ID
+}
+
+// NewReadBackupQuery создает запрос чтения резервной копии для восстановления в аккаунт пользователя userID.
+func NewReadBackupQuery(userID shared.ID) ReadBackupQuery {
+	return &readBackupQuery{userID: userID}
+}
+
+func (q readBackupQuery) UserID() shared.ID {
+	return q.userID
+}
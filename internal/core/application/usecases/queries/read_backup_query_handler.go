@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"context"
+	"io"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type ReadBackupQueryHandler interface {
+	// Handle читает резервную копию из r и проверяет, что её можно восстановить:
+	// версия формата поддерживается, данные целостны, а аккаунт пользователя пуст.
+	// Возвращает backup.ErrUnsupportedVersion, backup.ErrInvalidBackup или backup.ErrAccountNotEmpty.
+	Handle(ctx context.Context, query ReadBackupQuery, r io.Reader) (*backup.Snapshot, error)
+}
+
+type readBackupQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+	codec      ports.BackupCodec
+}
+
+func NewReadBackupQueryHandler(uowFactory ports.UnitOfWorkFactory, codec ports.BackupCodec) (ReadBackupQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if codec == nil {
+		return nil, errs.NewValueIsRequiredError("codec")
+	}
+
+	return &readBackupQueryHandler{uowFactory: uowFactory, codec: codec}, nil
+}
+
+func (h readBackupQueryHandler) Handle(ctx context.Context, query ReadBackupQuery, r io.Reader) (*backup.Snapshot, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := h.codec.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := snapshot.Validate(); err != nil {
+		return nil, err
+	}
+
+	hasCategories, err := uow.CategoryRepository().HasCategoriesByUserID(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	lastCreatedAt, err := uow.TransactionRepository().GetLastCreatedAt(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	if hasCategories || !lastCreatedAt.IsZero() {
+		return nil, backup.ErrAccountNotEmpty
+	}
+
+	return snapshot, nil
+}
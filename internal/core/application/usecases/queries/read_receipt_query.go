@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+type ReadReceiptQuery interface {
+	UserID() shared.ID
+	// Image возвращает фотографию чека. Пуст, если QR-код передан текстом.
+	Image() []byte
+	Text() string
+}
+
+type readReceiptQuery struct {
+	userID shared.ID
+	image  []byte
+	text   string
+}
+
+// NewReadReceiptQueryFromImage создает запрос чтения чека по фотографии QR-кода.
+func NewReadReceiptQueryFromImage(userID shared.ID, image []byte) ReadReceiptQuery {
+	return &readReceiptQuery{userID: userID, image: image}
+}
+
+// NewReadReceiptQueryFromText создает запрос чтения чека по строке QR-кода, отправленной текстом.
+func NewReadReceiptQueryFromText(userID shared.ID, text string) ReadReceiptQuery {
+	return &readReceiptQuery{userID: userID, text: text}
+}
+
+func (q readReceiptQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q readReceiptQuery) Image() []byte {
+	return q.image
+}
+
+func (q readReceiptQuery) Text() string {
+	return q.text
+}
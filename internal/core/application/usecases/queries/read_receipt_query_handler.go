@@ -0,0 +1,73 @@
+package queries
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type ReadReceiptQueryHandler interface {
+	// Handle распознает QR-код чека и разбирает его в часовом поясе пользователя.
+	// Возвращает receipt.ErrCodeNotFound, receipt.ErrNotReceipt или receipt.ErrAlreadyRecorded,
+	// если чек уже записан.
+	Handle(ctx context.Context, query ReadReceiptQuery) (receipt.Receipt, error)
+}
+
+type readReceiptQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+	decoder    ports.QRDecoder
+}
+
+func NewReadReceiptQueryHandler(uowFactory ports.UnitOfWorkFactory, decoder ports.QRDecoder) (ReadReceiptQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	if decoder == nil {
+		return nil, errs.NewValueIsRequiredError("decoder")
+	}
+
+	return &readReceiptQueryHandler{uowFactory: uowFactory, decoder: decoder}, nil
+}
+
+func (h readReceiptQueryHandler) Handle(ctx context.Context, query ReadReceiptQuery) (receipt.Receipt, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+
+	text := query.Text()
+
+	if len(query.Image()) > 0 {
+		decoded, err := h.decoder.Decode(bytes.NewReader(query.Image()))
+		if err != nil {
+			return receipt.Receipt{}, err
+		}
+
+		text = decoded
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+
+	r, err := receipt.Parse(text, s.Location())
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+
+	recorded, err := uow.TransactionRepository().HasFiscalID(ctx, query.UserID(), r.FiscalID())
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+
+	if recorded {
+		return receipt.Receipt{}, receipt.ErrAlreadyRecorded
+	}
+
+	return r, nil
+}
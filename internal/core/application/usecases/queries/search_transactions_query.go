@@ -0,0 +1,50 @@
+package queries
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type SearchTransactionsQuery interface {
+	UserID() shared.ID
+	Text() string
+	Limit() int
+}
+
+type searchTransactionsQuery struct {
+	userID shared.ID
+	text   string
+	limit  int
+}
+
+// NewSearchTransactionsQuery создает запрос поиска транзакций по тексту text.
+// Возвращает report.ErrEmptySearchText или report.ErrTooLongSearchText для неподходящего текста.
+func NewSearchTransactionsQuery(userID shared.ID, text string, limit int) (SearchTransactionsQuery, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	text, err := report.NormalizeSearchText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		return nil, errs.NewValueIsInvalidError("limit")
+	}
+
+	return &searchTransactionsQuery{userID: userID, text: text, limit: limit}, nil
+}
+
+func (q searchTransactionsQuery) UserID() shared.ID {
+	return q.userID
+}
+
+func (q searchTransactionsQuery) Text() string {
+	return q.text
+}
+
+func (q searchTransactionsQuery) Limit() int {
+	return q.limit
+}
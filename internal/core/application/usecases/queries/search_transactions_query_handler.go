@@ -0,0 +1,53 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/ports"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// TransactionSearch результат поиска транзакций.
+type TransactionSearch struct {
+	Result *report.SearchResult
+
+	// Location часовой пояс пользователя, в котором показывается время операций.
+	Location *time.Location
+}
+
+type SearchTransactionsQueryHandler interface {
+	Handle(ctx context.Context, query SearchTransactionsQuery) (*TransactionSearch, error)
+}
+
+type searchTransactionsQueryHandler struct {
+	uowFactory ports.UnitOfWorkFactory
+}
+
+func NewSearchTransactionsQueryHandler(uowFactory ports.UnitOfWorkFactory) (SearchTransactionsQueryHandler, error) {
+	if uowFactory == nil {
+		return nil, errs.NewValueIsRequiredError("uowFactory")
+	}
+
+	return &searchTransactionsQueryHandler{uowFactory: uowFactory}, nil
+}
+
+func (h searchTransactionsQueryHandler) Handle(ctx context.Context, query SearchTransactionsQuery) (*TransactionSearch, error) {
+	uow, err := h.uowFactory.New()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := uow.TransactionRepository().Search(ctx, query.UserID(), query.Text(), query.Limit())
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uow.SettingsRepository().Get(ctx, query.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionSearch{Result: result, Location: s.Location()}, nil
+}
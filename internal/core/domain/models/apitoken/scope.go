@@ -0,0 +1,5 @@
+package apitoken
+
+// Scope право, которое дает токен.
+// ENUM(read, write)
+type Scope string
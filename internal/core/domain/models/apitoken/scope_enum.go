@@ -0,0 +1,45 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: v0.9.2
+
+// Built By: go install
+
+package apitoken
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ScopeRead is a Scope of type read.
+	ScopeRead Scope = "read"
+	// ScopeWrite is a Scope of type write.
+	ScopeWrite Scope = "write"
+)
+
+var ErrInvalidScope = errors.New("not a valid Scope")
+
+// String implements the Stringer interface.
+func (x Scope) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Scope) IsValid() bool {
+	_, err := ParseScope(string(x))
+	return err == nil
+}
+
+var _ScopeValue = map[string]Scope{
+	"read":  ScopeRead,
+	"write": ScopeWrite,
+}
+
+// ParseScope attempts to convert a string to a Scope.
+func ParseScope(name string) (Scope, error) {
+	if x, ok := _ScopeValue[name]; ok {
+		return x, nil
+	}
+	return Scope(""), fmt.Errorf("%s is %w", name, ErrInvalidScope)
+}
@@ -0,0 +1,211 @@
+// Package apitoken описывает персональные токены доступа к HTTP API.
+// Токен показывается пользователю один раз при выпуске, а хранится только его хеш.
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// MaxNameLength наибольшая длина названия токена в символах.
+	MaxNameLength = 64
+
+	// MaxActivePerUser наибольшее число действующих токенов одного пользователя.
+	MaxActivePerUser = 10
+
+	// secretPrefix начало каждого токена: по нему токен легко найти в коде и конфигурации.
+	secretPrefix = "ctb_"
+
+	secretBytes = 32
+
+	// hintLength число первых символов токена, которые хранятся открыто, чтобы пользователь
+	// мог узнать токен в списке.
+	hintLength = len(secretPrefix) + 4
+)
+
+var (
+	ErrInvalidName    = errors.New("invalid token name")
+	ErrNoScopes       = errors.New("token must have at least one scope")
+	ErrExpiresInPast  = errors.New("token expiry must be in the future")
+	ErrAlreadyRevoked = errors.New("token is already revoked")
+	ErrTooMany        = errors.New("too many active tokens")
+
+	// ErrInvalidSecret токен неизвестен, отозван или истек. Причина намеренно не уточняется.
+	ErrInvalidSecret = errors.New("invalid token")
+)
+
+// Token персональный токен доступа пользователя к HTTP API.
+type Token struct {
+	baseAggregate *ddd.BaseAggregate[shared.ID]
+	userID        shared.ID
+	name          string
+	hash          []byte
+	hint          string
+	scopes        []Scope
+	expiresAt     time.Time
+	revokedAt     time.Time
+	createdAt     time.Time
+}
+
+// Issue выпускает токен пользователя userID и возвращает его вместе с секретом, который нужно
+// передать пользователю. Нулевой expiresAt означает бессрочный токен.
+func Issue(userID shared.ID, name string, scopes []Scope, expiresAt time.Time, now time.Time) (*Token, string, error) {
+	if userID.IsZero() {
+		return nil, "", errs.NewValueIsRequiredError("userID")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return nil, "", ErrInvalidName
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", ErrNoScopes
+	}
+
+	for _, s := range scopes {
+		if !s.IsValid() {
+			return nil, "", errs.NewValueIsInvalidError("scope")
+		}
+	}
+
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return nil, "", ErrExpiresInPast
+	}
+
+	raw := make([]byte, secretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+
+	secret := secretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	scopes = slices.Clone(scopes)
+	slices.Sort(scopes)
+
+	return &Token{
+		baseAggregate: ddd.NewBaseAggregate(shared.NewID()),
+		userID:        userID,
+		name:          name,
+		hash:          Hash(secret),
+		hint:          secret[:hintLength],
+		scopes:        slices.Compact(scopes),
+		expiresAt:     expiresAt,
+		createdAt:     now,
+	}, secret, nil
+}
+
+func Restore(
+	id shared.ID,
+	userID shared.ID,
+	name string,
+	hash []byte,
+	hint string,
+	scopes []Scope,
+	expiresAt time.Time,
+	revokedAt time.Time,
+	createdAt time.Time,
+) *Token {
+	return &Token{
+		baseAggregate: ddd.NewBaseAggregate(id),
+		userID:        userID,
+		name:          name,
+		hash:          hash,
+		hint:          hint,
+		scopes:        scopes,
+		expiresAt:     expiresAt,
+		revokedAt:     revokedAt,
+		createdAt:     createdAt,
+	}
+}
+
+// Hash возвращает хеш секрета, по которому токен хранится и ищется. Секрет содержит 256 случайных
+// бит, поэтому медленный хеш для паролей ему не нужен.
+func Hash(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+
+	return sum[:]
+}
+
+// Revoke отзывает токен. Отозванный токен больше не принимается.
+func (t *Token) Revoke(now time.Time) error {
+	if t.IsRevoked() {
+		return ErrAlreadyRevoked
+	}
+
+	t.revokedAt = now
+
+	return nil
+}
+
+// IsActive сообщает, что токен не отозван и не истек к моменту now.
+func (t *Token) IsActive(now time.Time) bool {
+	return !t.IsRevoked() && !t.IsExpired(now)
+}
+
+func (t *Token) IsRevoked() bool {
+	return !t.revokedAt.IsZero()
+}
+
+func (t *Token) IsExpired(now time.Time) bool {
+	return !t.expiresAt.IsZero() && !now.Before(t.expiresAt)
+}
+
+// Allows сообщает, что токен дает право scope. Право записи включает право чтения.
+func (t *Token) Allows(scope Scope) bool {
+	if slices.Contains(t.scopes, scope) {
+		return true
+	}
+
+	return scope == ScopeRead && slices.Contains(t.scopes, ScopeWrite)
+}
+
+func (t *Token) ID() shared.ID {
+	return t.baseAggregate.ID()
+}
+
+func (t *Token) UserID() shared.ID {
+	return t.userID
+}
+
+func (t *Token) Name() string {
+	return t.name
+}
+
+func (t *Token) Hash() []byte {
+	return t.hash
+}
+
+// Hint возвращает первые символы токена, по которым его можно узнать в списке.
+func (t *Token) Hint() string {
+	return t.hint
+}
+
+func (t *Token) Scopes() []Scope {
+	return t.scopes
+}
+
+// ExpiresAt возвращает момент, с которого токен не принимается, или нулевое время для бессрочного токена.
+func (t *Token) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// RevokedAt возвращает момент отзыва или нулевое время, если токен не отозван.
+func (t *Token) RevokedAt() time.Time {
+	return t.revokedAt
+}
+
+func (t *Token) CreatedAt() time.Time {
+	return t.createdAt
+}
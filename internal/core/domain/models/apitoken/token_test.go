@@ -0,0 +1,113 @@
+package apitoken_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+func TestIssue(t *testing.T) {
+	userID := shared.NewID()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(30 * 24 * time.Hour)
+
+	tk, secret, err := apitoken.Issue(userID, "  скрипт  ", []apitoken.Scope{apitoken.ScopeWrite, apitoken.ScopeRead, apitoken.ScopeWrite}, expiresAt, now)
+	require.NoError(t, err)
+
+	assert.False(t, tk.ID().IsZero())
+	assert.Equal(t, userID, tk.UserID())
+	assert.Equal(t, "скрипт", tk.Name())
+	assert.Equal(t, []apitoken.Scope{apitoken.ScopeRead, apitoken.ScopeWrite}, tk.Scopes())
+	assert.Equal(t, expiresAt, tk.ExpiresAt())
+	assert.Equal(t, now, tk.CreatedAt())
+
+	assert.True(t, strings.HasPrefix(secret, "ctb_"))
+	assert.True(t, strings.HasPrefix(secret, tk.Hint()))
+	assert.Less(t, len(tk.Hint()), len(secret))
+	assert.Equal(t, apitoken.Hash(secret), tk.Hash())
+	assert.NotContains(t, string(tk.Hash()), secret)
+}
+
+func TestIssue_UniqueSecrets(t *testing.T) {
+	now := time.Now()
+	scopes := []apitoken.Scope{apitoken.ScopeRead}
+
+	_, first, err := apitoken.Issue(shared.NewID(), "a", scopes, time.Time{}, now)
+	require.NoError(t, err)
+
+	_, second, err := apitoken.Issue(shared.NewID(), "a", scopes, time.Time{}, now)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestIssue_Invalid(t *testing.T) {
+	now := time.Now()
+	read := []apitoken.Scope{apitoken.ScopeRead}
+
+	_, _, err := apitoken.Issue(shared.ID{}, "a", read, time.Time{}, now)
+	require.Error(t, err)
+
+	_, _, err = apitoken.Issue(shared.NewID(), " ", read, time.Time{}, now)
+	require.ErrorIs(t, err, apitoken.ErrInvalidName)
+
+	_, _, err = apitoken.Issue(shared.NewID(), strings.Repeat("я", apitoken.MaxNameLength+1), read, time.Time{}, now)
+	require.ErrorIs(t, err, apitoken.ErrInvalidName)
+
+	_, _, err = apitoken.Issue(shared.NewID(), "a", nil, time.Time{}, now)
+	require.ErrorIs(t, err, apitoken.ErrNoScopes)
+
+	_, _, err = apitoken.Issue(shared.NewID(), "a", []apitoken.Scope{"admin"}, time.Time{}, now)
+	require.Error(t, err)
+
+	_, _, err = apitoken.Issue(shared.NewID(), "a", read, now, now)
+	require.ErrorIs(t, err, apitoken.ErrExpiresInPast)
+}
+
+func TestToken_IsActive(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	forever, _, err := apitoken.Issue(shared.NewID(), "a", []apitoken.Scope{apitoken.ScopeRead}, time.Time{}, now)
+	require.NoError(t, err)
+	assert.True(t, forever.IsActive(now.AddDate(10, 0, 0)))
+
+	expiring, _, err := apitoken.Issue(shared.NewID(), "a", []apitoken.Scope{apitoken.ScopeRead}, now.Add(time.Hour), now)
+	require.NoError(t, err)
+	assert.True(t, expiring.IsActive(now.Add(time.Hour-time.Second)))
+	assert.False(t, expiring.IsActive(now.Add(time.Hour)))
+	assert.True(t, expiring.IsExpired(now.Add(time.Hour)))
+}
+
+func TestToken_Revoke(t *testing.T) {
+	now := time.Now()
+
+	tk, _, err := apitoken.Issue(shared.NewID(), "a", []apitoken.Scope{apitoken.ScopeRead}, time.Time{}, now)
+	require.NoError(t, err)
+
+	require.NoError(t, tk.Revoke(now))
+	assert.True(t, tk.IsRevoked())
+	assert.Equal(t, now, tk.RevokedAt())
+	assert.False(t, tk.IsActive(now))
+
+	require.ErrorIs(t, tk.Revoke(now), apitoken.ErrAlreadyRevoked)
+}
+
+func TestToken_Allows(t *testing.T) {
+	now := time.Now()
+
+	read, _, err := apitoken.Issue(shared.NewID(), "a", []apitoken.Scope{apitoken.ScopeRead}, time.Time{}, now)
+	require.NoError(t, err)
+	assert.True(t, read.Allows(apitoken.ScopeRead))
+	assert.False(t, read.Allows(apitoken.ScopeWrite))
+
+	write, _, err := apitoken.Issue(shared.NewID(), "a", []apitoken.Scope{apitoken.ScopeWrite}, time.Time{}, now)
+	require.NoError(t, err)
+	assert.True(t, write.Allows(apitoken.ScopeRead))
+	assert.True(t, write.Allows(apitoken.ScopeWrite))
+}
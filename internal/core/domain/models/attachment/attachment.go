@@ -0,0 +1,192 @@
+// Package attachment описывает файлы, прикрепленные к транзакциям: фотографии чеков,
+// гарантийные талоны и другие документы.
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// MaxPerTransaction наибольшее число вложений одной транзакции.
+	MaxPerTransaction = 10
+
+	// maxFileNameLength наибольшая длина сохраняемого имени файла, более длинные имена обрезаются.
+	maxFileNameLength = 255
+)
+
+var (
+	ErrTooMany         = errors.New("too many attachments")
+	ErrAlreadyAttached = errors.New("file is already attached")
+)
+
+// Attachment файл, прикрепленный к транзакции. Сам файл хранится в Telegram и отправляется
+// повторно по fileID; копия файла в хранилище необязательна.
+type Attachment struct {
+	baseAggregate *ddd.BaseAggregate[shared.ID]
+	transactionID shared.ID
+	userID        shared.ID
+	kind          Kind
+	fileID        string
+	fileUniqueID  string
+	fileName      string
+	mimeType      string
+	size          int64
+	storageKey    string
+	createdAt     time.Time
+}
+
+// New создает вложение транзакции transactionID. fileID служит для повторной отправки файла,
+// fileUniqueID не меняется со временем и позволяет не прикрепить один файл дважды.
+func New(transactionID shared.ID, userID shared.ID, kind Kind, fileID string, fileUniqueID string) (*Attachment, error) {
+	if transactionID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("transactionID")
+	}
+
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if !kind.IsValid() {
+		return nil, errs.NewValueIsInvalidError("kind")
+	}
+
+	if fileID == "" {
+		return nil, errs.NewValueIsRequiredError("fileID")
+	}
+
+	if fileUniqueID == "" {
+		return nil, errs.NewValueIsRequiredError("fileUniqueID")
+	}
+
+	return &Attachment{
+		baseAggregate: ddd.NewBaseAggregate(shared.NewID()),
+		transactionID: transactionID,
+		userID:        userID,
+		kind:          kind,
+		fileID:        fileID,
+		fileUniqueID:  fileUniqueID,
+		createdAt:     time.Now(),
+	}, nil
+}
+
+func Restore(
+	id shared.ID,
+	transactionID shared.ID,
+	userID shared.ID,
+	kind Kind,
+	fileID string,
+	fileUniqueID string,
+	fileName string,
+	mimeType string,
+	size int64,
+	storageKey string,
+	createdAt time.Time,
+) *Attachment {
+	return &Attachment{
+		baseAggregate: ddd.NewBaseAggregate(id),
+		transactionID: transactionID,
+		userID:        userID,
+		kind:          kind,
+		fileID:        fileID,
+		fileUniqueID:  fileUniqueID,
+		fileName:      fileName,
+		mimeType:      mimeType,
+		size:          size,
+		storageKey:    storageKey,
+		createdAt:     createdAt,
+	}
+}
+
+// Describe задает сведения о файле, известные на момент отправки: имя, MIME-тип и размер в байтах.
+// Слишком длинное имя обрезается.
+func (a *Attachment) Describe(fileName string, mimeType string, size int64) {
+	fileName = strings.TrimSpace(fileName)
+	for utf8.RuneCountInString(fileName) > maxFileNameLength {
+		_, n := utf8.DecodeLastRuneInString(fileName)
+		fileName = fileName[:len(fileName)-n]
+	}
+
+	a.fileName = fileName
+	a.mimeType = strings.TrimSpace(mimeType)
+	a.size = max(size, 0)
+}
+
+// SetStorageKey задает ключ копии файла в хранилище.
+func (a *Attachment) SetStorageKey(key string) {
+	a.storageKey = key
+}
+
+// DefaultStorageKey возвращает ключ, под которым копия файла сохраняется в хранилище.
+func (a *Attachment) DefaultStorageKey() string {
+	return fmt.Sprintf("%s/%s", a.userID, a.ID())
+}
+
+// EnsureCanAttach проверяет, что к транзакции с вложениями existing можно прикрепить
+// еще один файл с постоянным идентификатором fileUniqueID.
+func EnsureCanAttach(existing []*Attachment, fileUniqueID string) error {
+	for _, a := range existing {
+		if a.fileUniqueID == fileUniqueID {
+			return ErrAlreadyAttached
+		}
+	}
+
+	if len(existing) >= MaxPerTransaction {
+		return fmt.Errorf("%w: max %d", ErrTooMany, MaxPerTransaction)
+	}
+
+	return nil
+}
+
+func (a *Attachment) ID() shared.ID {
+	return a.baseAggregate.ID()
+}
+
+func (a *Attachment) TransactionID() shared.ID {
+	return a.transactionID
+}
+
+func (a *Attachment) UserID() shared.ID {
+	return a.userID
+}
+
+func (a *Attachment) Kind() Kind {
+	return a.kind
+}
+
+// FileID возвращает идентификатор файла в Telegram, по которому файл отправляется повторно.
+func (a *Attachment) FileID() string {
+	return a.fileID
+}
+
+func (a *Attachment) FileUniqueID() string {
+	return a.fileUniqueID
+}
+
+func (a *Attachment) FileName() string {
+	return a.fileName
+}
+
+func (a *Attachment) MimeType() string {
+	return a.mimeType
+}
+
+func (a *Attachment) Size() int64 {
+	return a.size
+}
+
+// StorageKey возвращает ключ копии файла в хранилище или пустую строку, если копии нет.
+func (a *Attachment) StorageKey() string {
+	return a.storageKey
+}
+
+func (a *Attachment) CreatedAt() time.Time {
+	return a.createdAt
+}
@@ -0,0 +1,107 @@
+package attachment_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	transactionID := shared.NewID()
+	userID := shared.NewID()
+
+	tests := []struct {
+		name          string
+		transactionID shared.ID
+		userID        shared.ID
+		kind          attachment.Kind
+		fileID        string
+		fileUniqueID  string
+		wantErr       error
+	}{
+		{
+			name:          "Фотография",
+			transactionID: transactionID,
+			userID:        userID,
+			kind:          attachment.KindPhoto,
+			fileID:        "file",
+			fileUniqueID:  "unique",
+		},
+		{
+			name:         "Без транзакции. Ошибка",
+			userID:       userID,
+			kind:         attachment.KindPhoto,
+			fileID:       "file",
+			fileUniqueID: "unique",
+			wantErr:      errs.ErrValueIsRequired,
+		},
+		{
+			name:          "Неизвестный вид. Ошибка",
+			transactionID: transactionID,
+			userID:        userID,
+			kind:          attachment.Kind("video"),
+			fileID:        "file",
+			fileUniqueID:  "unique",
+			wantErr:       errs.ErrValueIsInvalid,
+		},
+		{
+			name:          "Без идентификатора файла. Ошибка",
+			transactionID: transactionID,
+			userID:        userID,
+			kind:          attachment.KindDocument,
+			fileUniqueID:  "unique",
+			wantErr:       errs.ErrValueIsRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := attachment.New(tt.transactionID, tt.userID, tt.kind, tt.fileID, tt.fileUniqueID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.False(t, a.ID().IsZero())
+			assert.Equal(t, tt.transactionID, a.TransactionID())
+			assert.Equal(t, tt.userID, a.UserID())
+			assert.Equal(t, tt.kind, a.Kind())
+			assert.Empty(t, a.StorageKey())
+		})
+	}
+}
+
+func TestAttachment_Describe(t *testing.T) {
+	a, err := attachment.New(shared.NewID(), shared.NewID(), attachment.KindDocument, "file", "unique")
+	require.NoError(t, err)
+
+	a.Describe(" "+strings.Repeat("я", 300)+".pdf ", "application/pdf", -1)
+
+	assert.Equal(t, strings.Repeat("я", 255), a.FileName())
+	assert.Equal(t, "application/pdf", a.MimeType())
+	assert.Zero(t, a.Size())
+}
+
+func TestEnsureCanAttach(t *testing.T) {
+	transactionID := shared.NewID()
+	userID := shared.NewID()
+
+	existing := make([]*attachment.Attachment, 0, attachment.MaxPerTransaction)
+	for i := range attachment.MaxPerTransaction {
+		a, err := attachment.New(transactionID, userID, attachment.KindPhoto, "file", "unique-"+string(rune('a'+i)))
+		require.NoError(t, err)
+
+		existing = append(existing, a)
+	}
+
+	require.NoError(t, attachment.EnsureCanAttach(existing[:1], "other"))
+	require.ErrorIs(t, attachment.EnsureCanAttach(existing[:1], "unique-a"), attachment.ErrAlreadyAttached)
+	require.ErrorIs(t, attachment.EnsureCanAttach(existing, "other"), attachment.ErrTooMany)
+}
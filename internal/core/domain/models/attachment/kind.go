@@ -0,0 +1,5 @@
+package attachment
+
+// Kind вид вложения: фотография, сжатая Telegram, или файл, отправленный документом
+// ENUM(photo, document)
+type Kind string
@@ -0,0 +1,45 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: v0.9.2
+
+// Built By: go install
+
+package attachment
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// KindPhoto is a Kind of type photo.
+	KindPhoto Kind = "photo"
+	// KindDocument is a Kind of type document.
+	KindDocument Kind = "document"
+)
+
+var ErrInvalidKind = errors.New("not a valid Kind")
+
+// String implements the Stringer interface.
+func (x Kind) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Kind) IsValid() bool {
+	_, err := ParseKind(string(x))
+	return err == nil
+}
+
+var _KindValue = map[string]Kind{
+	"photo":    KindPhoto,
+	"document": KindDocument,
+}
+
+// ParseKind attempts to convert a string to a Kind.
+func ParseKind(name string) (Kind, error) {
+	if x, ok := _KindValue[name]; ok {
+		return x, nil
+	}
+	return Kind(""), fmt.Errorf("%s is %w", name, ErrInvalidKind)
+}
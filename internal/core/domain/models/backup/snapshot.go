@@ -0,0 +1,519 @@
+// Package backup описывает полную резервную копию данных пользователя и её восстановление.
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// SchemaVersion версия формата резервной копии. Увеличивается при несовместимых изменениях формата.
+	SchemaVersion = 3
+
+	// MinSchemaVersion наименьшая версия, копии которой еще восстанавливаются. Копии прежних версий
+	// отличаются только отсутствием новых данных: в версии 1 нет меток, в версиях 1 и 2 нет возвратов.
+	MinSchemaVersion = 1
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+	ErrInvalidBackup      = errors.New("invalid backup")
+	ErrAccountNotEmpty    = errors.New("account is not empty")
+)
+
+// Snapshot снимок данных пользователя: профиль, внешние идентификаторы, категории,
+// транзакции, метки с их связями, возвраты и настройки.
+type Snapshot struct {
+	version      int
+	createdAt    time.Time
+	user         *user.User
+	identities   []*user.ExternalIdentity
+	categories   []*category.Category
+	transactions []*transaction.Transaction
+	tags         []*tag.Tag
+	links        []tag.Link
+	refunds      []*refund.Refund
+	settings     *settings.Settings
+}
+
+// NewSnapshot создает снимок текущей версии формата.
+func NewSnapshot(
+	u *user.User,
+	identities []*user.ExternalIdentity,
+	categories []*category.Category,
+	transactions []*transaction.Transaction,
+	tags []*tag.Tag,
+	links []tag.Link,
+	refunds []*refund.Refund,
+	s *settings.Settings,
+) (*Snapshot, error) {
+	if u == nil {
+		return nil, errs.NewValueIsRequiredError("user")
+	}
+
+	if s == nil {
+		return nil, errs.NewValueIsRequiredError("settings")
+	}
+
+	return &Snapshot{
+		version:      SchemaVersion,
+		createdAt:    time.Now(),
+		user:         u,
+		identities:   identities,
+		categories:   categories,
+		transactions: transactions,
+		tags:         tags,
+		links:        links,
+		refunds:      refunds,
+		settings:     s,
+	}, nil
+}
+
+// Restore восстанавливает снимок, прочитанный из файла. Снимок нужно проверить методом Validate.
+func Restore(
+	version int,
+	createdAt time.Time,
+	u *user.User,
+	identities []*user.ExternalIdentity,
+	categories []*category.Category,
+	transactions []*transaction.Transaction,
+	tags []*tag.Tag,
+	links []tag.Link,
+	refunds []*refund.Refund,
+	s *settings.Settings,
+) *Snapshot {
+	return &Snapshot{
+		version:      version,
+		createdAt:    createdAt,
+		user:         u,
+		identities:   identities,
+		categories:   categories,
+		transactions: transactions,
+		tags:         tags,
+		links:        links,
+		refunds:      refunds,
+		settings:     s,
+	}
+}
+
+// Validate проверяет версию формата и целостность снимка: уникальность идентификаторов,
+// иерархию категорий, ссылки транзакций на категории, связи меток с транзакциями и возвраты.
+func (s *Snapshot) Validate() error {
+	if s.version < MinSchemaVersion || s.version > SchemaVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, s.version)
+	}
+
+	if s.user == nil {
+		return fmt.Errorf("%w: user is missing", ErrInvalidBackup)
+	}
+
+	if s.settings == nil {
+		return fmt.Errorf("%w: settings are missing", ErrInvalidBackup)
+	}
+
+	byID := make(map[shared.ID]*category.Category, len(s.categories))
+	for _, c := range s.categories {
+		if c.ID().IsZero() {
+			return fmt.Errorf("%w: category without id", ErrInvalidBackup)
+		}
+
+		if _, ok := byID[c.ID()]; ok {
+			return fmt.Errorf("%w: duplicate category %s", ErrInvalidBackup, c.ID())
+		}
+
+		if strings.TrimSpace(c.Name()) == "" {
+			return fmt.Errorf("%w: category %s has no name", ErrInvalidBackup, c.ID())
+		}
+
+		if !c.Type().IsValid() {
+			return fmt.Errorf("%w: category %s has invalid type", ErrInvalidBackup, c.ID())
+		}
+
+		byID[c.ID()] = c
+	}
+
+	for _, c := range s.categories {
+		if c.ParentID().IsZero() {
+			continue
+		}
+
+		parent, ok := byID[c.ParentID()]
+		if !ok {
+			return fmt.Errorf("%w: category %s refers to unknown parent", ErrInvalidBackup, c.ID())
+		}
+
+		if !parent.ParentID().IsZero() {
+			return fmt.Errorf("%w: category %s is nested too deep", ErrInvalidBackup, c.ID())
+		}
+
+		if parent.Type() != c.Type() {
+			return fmt.Errorf("%w: category %s differs in type from its parent", ErrInvalidBackup, c.ID())
+		}
+	}
+
+	seen := make(map[shared.ID]*transaction.Transaction, len(s.transactions))
+	for _, t := range s.transactions {
+		if t.ID().IsZero() {
+			return fmt.Errorf("%w: transaction without id", ErrInvalidBackup)
+		}
+
+		if _, ok := seen[t.ID()]; ok {
+			return fmt.Errorf("%w: duplicate transaction %s", ErrInvalidBackup, t.ID())
+		}
+
+		seen[t.ID()] = t
+
+		if _, ok := byID[t.CategoryID()]; !ok {
+			return fmt.Errorf("%w: transaction %s refers to unknown category", ErrInvalidBackup, t.ID())
+		}
+
+		for _, part := range t.Splits() {
+			if _, ok := byID[part.CategoryID()]; !ok {
+				return fmt.Errorf("%w: transaction %s part refers to unknown category", ErrInvalidBackup, t.ID())
+			}
+		}
+
+		if utf8.RuneCountInString(t.Note()) > transaction.MaxNoteLength {
+			return fmt.Errorf("%w: transaction %s note is too long", ErrInvalidBackup, t.ID())
+		}
+
+		if t.OccurredAt().IsZero() {
+			return fmt.Errorf("%w: transaction %s has no occurrence time", ErrInvalidBackup, t.ID())
+		}
+	}
+
+	if err := s.validateTags(seen); err != nil {
+		return err
+	}
+
+	return s.validateRefunds(seen)
+}
+
+// validateTags проверяет метки и их связи с транзакциями transactions.
+func (s *Snapshot) validateTags(transactions map[shared.ID]*transaction.Transaction) error {
+	tags := make(map[shared.ID]bool, len(s.tags))
+	names := make(map[string]bool, len(s.tags))
+	for _, tg := range s.tags {
+		if tg.ID().IsZero() {
+			return fmt.Errorf("%w: tag without id", ErrInvalidBackup)
+		}
+
+		if tags[tg.ID()] {
+			return fmt.Errorf("%w: duplicate tag %s", ErrInvalidBackup, tg.ID())
+		}
+
+		if name, err := tag.NormalizeName(tg.Name()); err != nil || name != tg.Name() {
+			return fmt.Errorf("%w: tag %s has invalid name", ErrInvalidBackup, tg.ID())
+		}
+
+		if names[tg.Name()] {
+			return fmt.Errorf("%w: duplicate tag name %s", ErrInvalidBackup, tg.Name())
+		}
+
+		tags[tg.ID()] = true
+		names[tg.Name()] = true
+	}
+
+	type key struct{ transactionID, tagID shared.ID }
+
+	linked := make(map[key]bool, len(s.links))
+	perTransaction := make(map[shared.ID]int)
+	for _, l := range s.links {
+		if _, ok := transactions[l.TransactionID()]; !ok {
+			return fmt.Errorf("%w: tag link refers to unknown transaction %s", ErrInvalidBackup, l.TransactionID())
+		}
+
+		if !tags[l.TagID()] {
+			return fmt.Errorf("%w: transaction %s refers to unknown tag", ErrInvalidBackup, l.TransactionID())
+		}
+
+		k := key{transactionID: l.TransactionID(), tagID: l.TagID()}
+		if linked[k] {
+			return fmt.Errorf("%w: transaction %s has duplicate tag", ErrInvalidBackup, l.TransactionID())
+		}
+
+		linked[k] = true
+
+		perTransaction[l.TransactionID()]++
+		if perTransaction[l.TransactionID()] > tag.MaxPerTransaction {
+			return fmt.Errorf("%w: transaction %s has too many tags", ErrInvalidBackup, l.TransactionID())
+		}
+	}
+
+	return nil
+}
+
+// validateRefunds проверяет, что возвраты относятся к транзакциям transactions и не превышают их сумм.
+func (s *Snapshot) validateRefunds(transactions map[shared.ID]*transaction.Transaction) error {
+	seen := make(map[shared.ID]bool, len(s.refunds))
+	byTransaction := make(map[shared.ID][]*refund.Refund)
+	for _, r := range s.refunds {
+		if r.ID().IsZero() {
+			return fmt.Errorf("%w: refund without id", ErrInvalidBackup)
+		}
+
+		if seen[r.ID()] {
+			return fmt.Errorf("%w: duplicate refund %s", ErrInvalidBackup, r.ID())
+		}
+
+		seen[r.ID()] = true
+
+		if _, ok := transactions[r.TransactionID()]; !ok {
+			return fmt.Errorf("%w: refund %s refers to unknown transaction", ErrInvalidBackup, r.ID())
+		}
+
+		if utf8.RuneCountInString(r.Note()) > transaction.MaxNoteLength {
+			return fmt.Errorf("%w: refund %s note is too long", ErrInvalidBackup, r.ID())
+		}
+
+		if r.OccurredAt().IsZero() {
+			return fmt.Errorf("%w: refund %s has no occurrence time", ErrInvalidBackup, r.ID())
+		}
+
+		byTransaction[r.TransactionID()] = append(byTransaction[r.TransactionID()], r)
+	}
+
+	for transactionID, refunds := range byTransaction {
+		if err := refund.CheckCovered(transactions[transactionID], refunds); err != nil {
+			return fmt.Errorf("%w: transaction %s: %w", ErrInvalidBackup, transactionID, err)
+		}
+	}
+
+	return nil
+}
+
+// Rebase переносит данные снимка на пользователя userID. Категории, транзакции, метки и возвраты получают
+// новые идентификаторы, ссылки между ними пересчитываются, поэтому копию можно восстановить
+// в другой аккаунт, пока исходные данные еще не удалены. Настройки проверяются заново.
+func (s *Snapshot) Rebase(userID shared.ID) (*RestorePlan, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[shared.ID]shared.ID, len(s.categories))
+	categories := make([]*category.Category, 0, len(s.categories))
+
+	// Родительские категории создаются раньше дочерних, чтобы не нарушать внешний ключ.
+	for _, roots := range []bool{true, false} {
+		for _, c := range s.categories {
+			if c.ParentID().IsZero() != roots {
+				continue
+			}
+
+			id := shared.NewID()
+			ids[c.ID()] = id
+
+			parentID := ids[c.ParentID()]
+			categories = append(categories, category.Restore(id, c.Name(), userID, &parentID, c.Type(), c.CreatedAt()))
+		}
+	}
+
+	transactionIDs := make(map[shared.ID]shared.ID, len(s.transactions))
+	transactions := make([]*transaction.Transaction, 0, len(s.transactions))
+	for _, t := range s.transactions {
+		nt := transaction.Restore(
+			shared.NewID(),
+			userID,
+			t.Amount(),
+			ids[t.CategoryID()],
+			t.Note(),
+			t.OccurredAt(),
+			t.Fingerprint(),
+			t.FiscalID(),
+			t.CreatedAt(),
+		)
+
+		if err := rebaseSplits(nt, t.Splits(), ids); err != nil {
+			return nil, fmt.Errorf("%w: transaction %s: %w", ErrInvalidBackup, t.ID(), err)
+		}
+
+		transactionIDs[t.ID()] = nt.ID()
+		transactions = append(transactions, nt)
+	}
+
+	tagNames := make(map[shared.ID]string, len(s.tags))
+	tags := make([]*tag.Tag, 0, len(s.tags))
+	for _, tg := range s.tags {
+		tagNames[tg.ID()] = tg.Name()
+		tags = append(tags, tag.Restore(shared.NewID(), userID, tg.Name(), tg.CreatedAt()))
+	}
+
+	refunds := make([]*refund.Refund, 0, len(s.refunds))
+	for _, r := range s.refunds {
+		refunds = append(refunds, refund.Restore(
+			shared.NewID(),
+			userID,
+			transactionIDs[r.TransactionID()],
+			ids[r.CategoryID()],
+			r.Amount(),
+			r.Note(),
+			r.OccurredAt(),
+			r.CreatedAt(),
+		))
+	}
+
+	transactionTags := make(map[shared.ID][]string)
+	for _, l := range s.links {
+		id := transactionIDs[l.TransactionID()]
+		transactionTags[id] = append(transactionTags[id], tagNames[l.TagID()])
+	}
+
+	st, err := s.rebaseSettings(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+
+	return &RestorePlan{
+		categories:      categories,
+		transactions:    transactions,
+		tags:            tags,
+		transactionTags: transactionTags,
+		refunds:         refunds,
+		settings:        st,
+	}, nil
+}
+
+// rebaseSplits делит транзакцию nt на части исходной транзакции с пересчитанными категориями.
+// Разделение проверяется заново, поэтому несходящиеся части из поврежденной копии не восстанавливаются.
+func rebaseSplits(nt *transaction.Transaction, parts []transaction.Split, ids map[shared.ID]shared.ID) error {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	rebased := make([]transaction.Split, 0, len(parts))
+	for _, part := range parts {
+		p, err := transaction.NewSplit(ids[part.CategoryID()], part.Amount())
+		if err != nil {
+			return err
+		}
+
+		rebased = append(rebased, p)
+	}
+
+	return nt.SplitInto(rebased)
+}
+
+func (s *Snapshot) rebaseSettings(userID shared.ID) (*settings.Settings, error) {
+	st, err := settings.New(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := st.SetTimezone(s.settings.Timezone()); err != nil {
+		return nil, err
+	}
+
+	if err := st.SetDigestMode(s.settings.DigestMode()); err != nil {
+		return nil, err
+	}
+
+	if err := st.SetDigestHour(s.settings.DigestHour()); err != nil {
+		return nil, err
+	}
+
+	if err := st.SetReminderAfterHours(s.settings.ReminderAfterHours()); err != nil {
+		return nil, err
+	}
+
+	if err := st.SetQuietHours(s.settings.QuietFrom(), s.settings.QuietTo()); err != nil {
+		return nil, err
+	}
+
+	return st, nil
+}
+
+func (s *Snapshot) Version() int {
+	return s.version
+}
+
+func (s *Snapshot) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Snapshot) User() *user.User {
+	return s.user
+}
+
+// ExternalIdentities возвращает внешние идентификаторы пользователя. Они сохраняются для справки
+// и не восстанавливаются: аккаунт, в который восстанавливается копия, уже привязан к своему чату.
+func (s *Snapshot) ExternalIdentities() []*user.ExternalIdentity {
+	return s.identities
+}
+
+func (s *Snapshot) Categories() []*category.Category {
+	return s.categories
+}
+
+func (s *Snapshot) Transactions() []*transaction.Transaction {
+	return s.transactions
+}
+
+func (s *Snapshot) Tags() []*tag.Tag {
+	return s.tags
+}
+
+// TagLinks возвращает связи меток с транзакциями снимка.
+func (s *Snapshot) TagLinks() []tag.Link {
+	return s.links
+}
+
+func (s *Snapshot) Refunds() []*refund.Refund {
+	return s.refunds
+}
+
+func (s *Snapshot) Settings() *settings.Settings {
+	return s.settings
+}
+
+// RestorePlan данные снимка, перенесенные на пользователя, в которого восстанавливается копия.
+type RestorePlan struct {
+	categories      []*category.Category
+	transactions    []*transaction.Transaction
+	tags            []*tag.Tag
+	transactionTags map[shared.ID][]string
+	refunds         []*refund.Refund
+	settings        *settings.Settings
+}
+
+// Categories возвращает категории в порядке создания: родительские раньше дочерних.
+func (p *RestorePlan) Categories() []*category.Category {
+	return p.categories
+}
+
+func (p *RestorePlan) Transactions() []*transaction.Transaction {
+	return p.transactions
+}
+
+func (p *RestorePlan) Tags() []*tag.Tag {
+	return p.tags
+}
+
+// TagNames возвращает названия меток транзакции плана с идентификатором transactionID.
+// Метки связываются по названию: у пользователя уже может быть метка с тем же названием.
+func (p *RestorePlan) TagNames(transactionID shared.ID) []string {
+	return p.transactionTags[transactionID]
+}
+
+func (p *RestorePlan) Refunds() []*refund.Refund {
+	return p.refunds
+}
+
+func (p *RestorePlan) Settings() *settings.Settings {
+	return p.settings
+}
@@ -0,0 +1,331 @@
+package backup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+type fixture struct {
+	user     *user.User
+	food     *category.Category
+	cafe     *category.Category
+	salary   *category.Category
+	lunch    *transaction.Transaction
+	vacation *tag.Tag
+	refund   *refund.Refund
+	settings *settings.Settings
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+
+	u := user.Restore(shared.NewID(), "Иван", time.Now().Add(-24*time.Hour))
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	food := category.Restore(shared.NewID(), "Еда", u.ID(), nil, category.TypeExpense, createdAt)
+	foodID := food.ID()
+	cafe := category.Restore(shared.NewID(), "Кафе", u.ID(), &foodID, category.TypeExpense, createdAt)
+	salary := category.Restore(shared.NewID(), "Зарплата", u.ID(), nil, category.TypeIncome, createdAt)
+
+	amount, err := transaction.NewAmountFromString("350.50")
+	require.NoError(t, err)
+
+	occurredAt := time.Date(2026, 2, 3, 12, 30, 0, 0, time.UTC)
+	lunch := transaction.Restore(shared.NewID(), u.ID(), amount, cafe.ID(), "обед", occurredAt, "fp", receipt.FiscalID{}, occurredAt)
+
+	vacation := tag.Restore(shared.NewID(), u.ID(), "отпуск2026", createdAt)
+
+	refundAmount, err := transaction.NewAmountFromString("50")
+	require.NoError(t, err)
+
+	returned := refund.Restore(shared.NewID(), u.ID(), lunch.ID(), cafe.ID(), refundAmount, "остыл", occurredAt, occurredAt)
+
+	s, err := settings.New(u.ID())
+	require.NoError(t, err)
+	require.NoError(t, s.SetTimezone("Asia/Novosibirsk"))
+	require.NoError(t, s.SetDigestMode(settings.DigestModeWeekly))
+
+	return fixture{user: u, food: food, cafe: cafe, salary: salary, lunch: lunch, vacation: vacation, refund: returned, settings: s}
+}
+
+func (f fixture) link(t *testing.T, tr *transaction.Transaction, tg *tag.Tag) tag.Link {
+	t.Helper()
+
+	link, err := tag.NewLink(tr.ID(), tg.ID())
+	require.NoError(t, err)
+
+	return link
+}
+
+func (f fixture) snapshot(t *testing.T) *backup.Snapshot {
+	t.Helper()
+
+	// Дочерняя категория идет раньше родительской, чтобы проверить порядок создания.
+	s, err := backup.NewSnapshot(
+		f.user,
+		nil,
+		[]*category.Category{f.cafe, f.food, f.salary},
+		[]*transaction.Transaction{f.lunch},
+		[]*tag.Tag{f.vacation},
+		[]tag.Link{f.link(t, f.lunch, f.vacation)},
+		[]*refund.Refund{f.refund},
+		f.settings,
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestSnapshot_Validate(t *testing.T) {
+	f := newFixture(t)
+
+	require.NoError(t, f.snapshot(t).Validate())
+}
+
+func TestSnapshot_Validate_UnsupportedVersion(t *testing.T) {
+	f := newFixture(t)
+
+	for _, version := range []int{backup.MinSchemaVersion - 1, backup.SchemaVersion + 1} {
+		s := backup.Restore(version, time.Now(), f.user, nil, nil, nil, nil, nil, nil, f.settings)
+
+		require.ErrorIs(t, s.Validate(), backup.ErrUnsupportedVersion, version)
+	}
+}
+
+func TestSnapshot_Validate_PreviousVersion(t *testing.T) {
+	f := newFixture(t)
+
+	categories := []*category.Category{f.food, f.cafe}
+	s := backup.Restore(backup.MinSchemaVersion, time.Now(), f.user, nil, categories, []*transaction.Transaction{f.lunch}, nil, nil, nil, f.settings)
+
+	require.NoError(t, s.Validate())
+}
+
+func TestSnapshot_Validate_Invalid(t *testing.T) {
+	f := newFixture(t)
+
+	cafeID := f.cafe.ID()
+	nested := category.Restore(shared.NewID(), "Кофе", f.user.ID(), &cafeID, category.TypeExpense, time.Now())
+	salaryID := f.salary.ID()
+	mixed := category.Restore(shared.NewID(), "Такси", f.user.ID(), &salaryID, category.TypeExpense, time.Now())
+	unknownParent := shared.NewID()
+	orphan := category.Restore(shared.NewID(), "Такси", f.user.ID(), &unknownParent, category.TypeExpense, time.Now())
+	orphanTransaction := transaction.Restore(
+		shared.NewID(), f.user.ID(), f.lunch.Amount(), shared.NewID(), "", time.Now(), "", receipt.FiscalID{}, time.Now(),
+	)
+
+	uppercase := tag.Restore(shared.NewID(), f.user.ID(), "Отпуск", time.Now())
+	namesake := tag.Restore(shared.NewID(), f.user.ID(), f.vacation.Name(), time.Now())
+	excessive := refund.Restore(shared.NewID(), f.user.ID(), f.lunch.ID(), f.cafe.ID(), f.lunch.Amount(), "", time.Now(), time.Now())
+	foreignCategory := refund.Restore(shared.NewID(), f.user.ID(), f.lunch.ID(), f.food.ID(), f.refund.Amount(), "", time.Now(), time.Now())
+
+	tests := []struct {
+		name         string
+		categories   []*category.Category
+		transactions []*transaction.Transaction
+		tags         []*tag.Tag
+		links        []tag.Link
+		refunds      []*refund.Refund
+	}{
+		{name: "duplicate category", categories: []*category.Category{f.food, f.food}},
+		{name: "unknown parent", categories: []*category.Category{orphan}},
+		{name: "nested too deep", categories: []*category.Category{f.food, f.cafe, nested}},
+		{name: "parent of another type", categories: []*category.Category{f.salary, mixed}},
+		{
+			name:         "duplicate transaction",
+			categories:   []*category.Category{f.food, f.cafe},
+			transactions: []*transaction.Transaction{f.lunch, f.lunch},
+		},
+		{
+			name:         "unknown category",
+			categories:   []*category.Category{f.food},
+			transactions: []*transaction.Transaction{orphanTransaction},
+		},
+		{name: "invalid tag name", tags: []*tag.Tag{uppercase}},
+		{name: "duplicate tag name", tags: []*tag.Tag{f.vacation, namesake}},
+		{
+			name:  "link to unknown transaction",
+			tags:  []*tag.Tag{f.vacation},
+			links: []tag.Link{f.link(t, f.lunch, f.vacation)},
+		},
+		{
+			name:         "link to unknown tag",
+			categories:   []*category.Category{f.food, f.cafe},
+			transactions: []*transaction.Transaction{f.lunch},
+			links:        []tag.Link{f.link(t, f.lunch, f.vacation)},
+		},
+		{
+			name:         "duplicate link",
+			categories:   []*category.Category{f.food, f.cafe},
+			transactions: []*transaction.Transaction{f.lunch},
+			tags:         []*tag.Tag{f.vacation},
+			links:        []tag.Link{f.link(t, f.lunch, f.vacation), f.link(t, f.lunch, f.vacation)},
+		},
+		{name: "refund of unknown transaction", refunds: []*refund.Refund{f.refund}},
+		{
+			name:         "duplicate refund",
+			categories:   []*category.Category{f.food, f.cafe},
+			transactions: []*transaction.Transaction{f.lunch},
+			refunds:      []*refund.Refund{f.refund, f.refund},
+		},
+		{
+			name:         "refunds exceed transaction",
+			categories:   []*category.Category{f.food, f.cafe},
+			transactions: []*transaction.Transaction{f.lunch},
+			refunds:      []*refund.Refund{f.refund, excessive},
+		},
+		{
+			name:         "refund category not in transaction",
+			categories:   []*category.Category{f.food, f.cafe},
+			transactions: []*transaction.Transaction{f.lunch},
+			refunds:      []*refund.Refund{foreignCategory},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := backup.Restore(backup.SchemaVersion, time.Now(), f.user, nil, tt.categories, tt.transactions, tt.tags, tt.links, tt.refunds, f.settings)
+
+			require.ErrorIs(t, s.Validate(), backup.ErrInvalidBackup)
+		})
+	}
+}
+
+func TestSnapshot_Rebase(t *testing.T) {
+	f := newFixture(t)
+	userID := shared.NewID()
+
+	plan, err := f.snapshot(t).Rebase(userID)
+	require.NoError(t, err)
+
+	categories := plan.Categories()
+	require.Len(t, categories, 3)
+
+	byName := make(map[string]*category.Category, len(categories))
+	for i, c := range categories {
+		assert.Equal(t, userID, c.OwnerID())
+		assert.NotEqual(t, f.food.ID(), c.ID())
+		assert.NotEqual(t, f.cafe.ID(), c.ID())
+
+		if !c.ParentID().IsZero() {
+			assert.Equal(t, 2, i, "дочерняя категория создается после родительских")
+		}
+
+		byName[c.Name()] = c
+	}
+
+	assert.Equal(t, byName["Еда"].ID(), byName["Кафе"].ParentID())
+	assert.True(t, byName["Зарплата"].ParentID().IsZero())
+	assert.Equal(t, f.cafe.CreatedAt(), byName["Кафе"].CreatedAt())
+
+	require.Len(t, plan.Transactions(), 1)
+	tr := plan.Transactions()[0]
+	assert.NotEqual(t, f.lunch.ID(), tr.ID())
+	assert.Equal(t, userID, tr.UserID())
+	assert.Equal(t, byName["Кафе"].ID(), tr.CategoryID())
+	assert.Equal(t, f.lunch.Amount(), tr.Amount())
+	assert.Equal(t, "обед", tr.Note())
+	assert.Equal(t, f.lunch.OccurredAt(), tr.OccurredAt())
+	assert.Equal(t, "fp", tr.Fingerprint())
+
+	require.Len(t, plan.Tags(), 1)
+	assert.NotEqual(t, f.vacation.ID(), plan.Tags()[0].ID())
+	assert.Equal(t, userID, plan.Tags()[0].UserID())
+	assert.Equal(t, f.vacation.Name(), plan.Tags()[0].Name())
+	assert.Equal(t, []string{f.vacation.Name()}, plan.TagNames(tr.ID()))
+
+	require.Len(t, plan.Refunds(), 1)
+	rf := plan.Refunds()[0]
+	assert.NotEqual(t, f.refund.ID(), rf.ID())
+	assert.Equal(t, userID, rf.UserID())
+	assert.Equal(t, tr.ID(), rf.TransactionID())
+	assert.Equal(t, byName["Кафе"].ID(), rf.CategoryID())
+	assert.Equal(t, f.refund.Amount(), rf.Amount())
+	assert.Equal(t, "остыл", rf.Note())
+
+	assert.Equal(t, userID, plan.Settings().UserID())
+	assert.Equal(t, "Asia/Novosibirsk", plan.Settings().Timezone())
+	assert.Equal(t, settings.DigestModeWeekly, plan.Settings().DigestMode())
+}
+
+func TestSnapshot_Rebase_InvalidSettings(t *testing.T) {
+	f := newFixture(t)
+	st := settings.Restore(f.user.ID(), "Mars/Olympus", settings.DigestModeOff, 9, time.Time{}, 0, 22, 9, time.Time{}, time.Now())
+
+	s := backup.Restore(backup.SchemaVersion, time.Now(), f.user, nil, nil, nil, nil, nil, nil, st)
+
+	_, err := s.Rebase(shared.NewID())
+	require.ErrorIs(t, err, backup.ErrInvalidBackup)
+	assert.ErrorContains(t, err, settings.ErrInvalidTimezone.Error())
+}
+
+func TestSnapshot_Rebase_Splits(t *testing.T) {
+	f := newFixture(t)
+
+	cafePart, err := transaction.NewAmountFromString("300.50")
+	require.NoError(t, err)
+
+	foodPart, err := transaction.NewAmountFromString("50")
+	require.NoError(t, err)
+
+	parts := []transaction.Split{
+		mustSplit(t, f.cafe.ID(), cafePart),
+		mustSplit(t, f.food.ID(), foodPart),
+	}
+	require.NoError(t, f.lunch.SplitInto(parts))
+
+	s := f.snapshot(t)
+	require.NoError(t, s.Validate())
+
+	plan, err := s.Rebase(shared.NewID())
+	require.NoError(t, err)
+
+	byName := make(map[string]shared.ID, len(plan.Categories()))
+	for _, c := range plan.Categories() {
+		byName[c.Name()] = c.ID()
+	}
+
+	require.Len(t, plan.Transactions(), 1)
+	splits := plan.Transactions()[0].Splits()
+	require.Len(t, splits, 2)
+	assert.Equal(t, byName["Кафе"], splits[0].CategoryID())
+	assert.Equal(t, cafePart, splits[0].Amount())
+	assert.Equal(t, byName["Еда"], splits[1].CategoryID())
+	assert.Equal(t, foodPart, splits[1].Amount())
+}
+
+func TestSnapshot_Validate_UnknownSplitCategory(t *testing.T) {
+	f := newFixture(t)
+
+	half, err := transaction.NewAmountFromString("175.25")
+	require.NoError(t, err)
+
+	require.NoError(t, f.lunch.SplitInto([]transaction.Split{
+		mustSplit(t, f.cafe.ID(), half),
+		mustSplit(t, shared.NewID(), half),
+	}))
+
+	require.ErrorIs(t, f.snapshot(t).Validate(), backup.ErrInvalidBackup)
+}
+
+func mustSplit(t *testing.T, categoryID shared.ID, amount transaction.Amount) transaction.Split {
+	t.Helper()
+
+	part, err := transaction.NewSplit(categoryID, amount)
+	require.NoError(t, err)
+
+	return part
+}
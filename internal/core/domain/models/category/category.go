@@ -13,6 +13,10 @@ import (
 var (
 	ErrEmptyName   = errors.New("name cannot be empty")
 	ErrTooLongName = errors.New("name too long (max 100 characters)")
+
+	// ErrInvalidParent родительской может быть только корневая категория расходов: расходы
+	// собраны в группы, а доходы не вкладываются.
+	ErrInvalidParent = errors.New("invalid parent category")
 )
 
 type Category struct {
@@ -25,14 +29,9 @@ type Category struct {
 }
 
 func New(name string, categoryType Type, uID shared.ID, pID *shared.ID) (*Category, error) {
-	name = strings.TrimSpace(name)
-
-	if name == "" {
-		return nil, ErrEmptyName
-	}
-
-	if len(name) > 100 {
-		return nil, errs.NewValueIsInvalidErrorWithCause("name", ErrTooLongName)
+	name, err := normalizeName(name)
+	if err != nil {
+		return nil, err
 	}
 
 	if uID.IsZero() {
@@ -55,9 +54,33 @@ func New(name string, categoryType Type, uID shared.ID, pID *shared.ID) (*Catego
 		c.parentID = *pID
 	}
 
+	c.RaiseDomainEvent(CategoryCreated{
+		ID:         shared.NewID().Value(),
+		CategoryID: c.ID(),
+		OwnerID:    c.ownerID,
+		ParentID:   c.parentID,
+		Name:       c.name,
+		Type:       c.categoryType,
+		OccurredAt: c.createdAt,
+	})
+
 	return &c, nil
 }
 
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", ErrEmptyName
+	}
+
+	if len(name) > 100 {
+		return "", errs.NewValueIsInvalidErrorWithCause("name", ErrTooLongName)
+	}
+
+	return name, nil
+}
+
 func Restore(id shared.ID, name string, ownerID shared.ID, parentID *shared.ID, categoryType Type, createdAt time.Time) *Category {
 	c := Category{
 		baseAggregate: ddd.NewBaseAggregate(id),
@@ -74,6 +97,39 @@ func Restore(id shared.ID, name string, ownerID shared.ID, parentID *shared.ID,
 	return &c
 }
 
+// Rename меняет название категории. Транзакции категории остаются в ней.
+func (c *Category) Rename(name string) error {
+	name, err := normalizeName(name)
+	if err != nil {
+		return err
+	}
+
+	if name == c.name {
+		return nil
+	}
+
+	c.name = name
+
+	c.RaiseDomainEvent(CategoryRenamed{
+		ID:         shared.NewID().Value(),
+		CategoryID: c.ID(),
+		OwnerID:    c.ownerID,
+		Name:       c.name,
+		OccurredAt: time.Now(),
+	})
+
+	return nil
+}
+
+// AcceptChild проверяет, что в категорию можно вложить новую категорию типа childType.
+func (c Category) AcceptChild(childType Type) error {
+	if c.categoryType != TypeExpense || childType != TypeExpense || !c.parentID.IsZero() {
+		return ErrInvalidParent
+	}
+
+	return nil
+}
+
 func (c Category) ID() shared.ID {
 	return c.baseAggregate.ID()
 }
@@ -105,3 +161,15 @@ func (c Category) Equals(other *Category) bool {
 
 	return c.baseAggregate.Equal(other.baseAggregate)
 }
+
+func (c *Category) ClearDomainEvents() {
+	c.baseAggregate.ClearDomainEvents()
+}
+
+func (c *Category) GetDomainEvents() []ddd.DomainEvent {
+	return c.baseAggregate.GetDomainEvents()
+}
+
+func (c *Category) RaiseDomainEvent(event ddd.DomainEvent) {
+	c.baseAggregate.RaiseDomainEvent(event)
+}
@@ -227,3 +227,70 @@ func TestCategory_Getters(t *testing.T) {
 	assert.Equal(t, catType, cat.Type())
 	assert.Equal(t, createdAt, cat.CreatedAt())
 }
+
+func TestNewCategory_RaisesCategoryCreated(t *testing.T) {
+	userID := shared.NewID()
+	parentID := shared.NewID()
+
+	cat, err := category.New("Кафе", category.TypeExpense, userID, &parentID)
+	require.NoError(t, err)
+
+	require.Len(t, cat.GetDomainEvents(), 1)
+
+	event, ok := cat.GetDomainEvents()[0].(category.CategoryCreated)
+	require.True(t, ok)
+	assert.Equal(t, cat.ID(), event.CategoryID)
+	assert.Equal(t, userID, event.OwnerID)
+	assert.Equal(t, parentID, event.ParentID)
+	assert.Equal(t, "Кафе", event.Name)
+	assert.Equal(t, category.TypeExpense, event.Type)
+
+	restored := category.Restore(cat.ID(), cat.Name(), userID, nil, cat.Type(), cat.CreatedAt())
+	assert.Empty(t, restored.GetDomainEvents())
+}
+
+func TestCategory_Rename(t *testing.T) {
+	userID := shared.NewID()
+	cat := category.Restore(shared.NewID(), "Кафе", userID, nil, category.TypeExpense, time.Now())
+
+	require.NoError(t, cat.Rename("  Кафе и рестораны "))
+	assert.Equal(t, "Кафе и рестораны", cat.Name())
+
+	require.Len(t, cat.GetDomainEvents(), 1)
+
+	event, ok := cat.GetDomainEvents()[0].(category.CategoryRenamed)
+	require.True(t, ok)
+	assert.Equal(t, cat.ID(), event.CategoryID)
+	assert.Equal(t, userID, event.OwnerID)
+	assert.Equal(t, "Кафе и рестораны", event.Name)
+}
+
+func TestCategory_Rename_SameNameRaisesNothing(t *testing.T) {
+	cat := category.Restore(shared.NewID(), "Кафе", shared.NewID(), nil, category.TypeExpense, time.Now())
+
+	require.NoError(t, cat.Rename("Кафе"))
+	assert.Empty(t, cat.GetDomainEvents())
+}
+
+func TestCategory_Rename_Invalid(t *testing.T) {
+	cat := category.Restore(shared.NewID(), "Кафе", shared.NewID(), nil, category.TypeExpense, time.Now())
+
+	require.ErrorIs(t, cat.Rename(" "), category.ErrEmptyName)
+	require.ErrorIs(t, cat.Rename(strings.Repeat("а", 101)), errs.ErrValueIsInvalid)
+	assert.Equal(t, "Кафе", cat.Name())
+	assert.Empty(t, cat.GetDomainEvents())
+}
+
+func TestCategory_AcceptChild(t *testing.T) {
+	userID := shared.NewID()
+	parentID := shared.NewID()
+
+	group := category.Restore(shared.NewID(), "Покупки", userID, nil, category.TypeExpense, time.Now())
+	child := category.Restore(shared.NewID(), "Еда", userID, &parentID, category.TypeExpense, time.Now())
+	income := category.Restore(shared.NewID(), "Зарплата", userID, nil, category.TypeIncome, time.Now())
+
+	require.NoError(t, group.AcceptChild(category.TypeExpense))
+	require.ErrorIs(t, group.AcceptChild(category.TypeIncome), category.ErrInvalidParent)
+	require.ErrorIs(t, child.AcceptChild(category.TypeExpense), category.ErrInvalidParent)
+	require.ErrorIs(t, income.AcceptChild(category.TypeIncome), category.ErrInvalidParent)
+}
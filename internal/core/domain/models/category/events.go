@@ -0,0 +1,45 @@
+package category
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// CategoryCreated событие создания категории пользователя.
+type CategoryCreated struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID shared.ID `json:"category_id"`
+	OwnerID    shared.ID `json:"owner_id"`
+	ParentID   shared.ID `json:"parent_id"`
+	Name       string    `json:"name"`
+	Type       Type      `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e CategoryCreated) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e CategoryCreated) GetName() string {
+	return "CategoryCreated"
+}
+
+// CategoryRenamed событие переименования категории пользователя.
+type CategoryRenamed struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID shared.ID `json:"category_id"`
+	OwnerID    shared.ID `json:"owner_id"`
+	Name       string    `json:"name"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e CategoryRenamed) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e CategoryRenamed) GetName() string {
+	return "CategoryRenamed"
+}
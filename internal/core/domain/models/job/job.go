@@ -0,0 +1,249 @@
+// Package job описывает фоновые задачи, которые выполняет планировщик.
+package job
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// DefaultMaxAttempts количество попыток выполнения задачи по умолчанию.
+	DefaultMaxAttempts = 5
+
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = time.Hour
+
+	maxErrorLength = 1000
+)
+
+var (
+	ErrEmptyKind       = errors.New("kind cannot be empty")
+	ErrInvalidInterval = errors.New("interval must be positive")
+
+	// ErrLeaseLost сообщает, что блокировка задачи истекла и задачу уже захватил другой экземпляр.
+	ErrLeaseLost = errors.New("job lease lost")
+)
+
+// Job представляет персистентную фоновую задачу.
+// Разовая задача выполняется один раз, периодическая (interval > 0)
+// после выполнения планируется заново через interval.
+type Job struct {
+	baseAggregate *ddd.BaseAggregate[shared.ID]
+	kind          string
+	key           string
+	payload       []byte
+	status        Status
+	attempts      int
+	maxAttempts   int
+	interval      time.Duration
+	runAt         time.Time
+	lastError     string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// New создает разовую задачу, которая будет выполнена не раньше runAt.
+func New(kind string, payload []byte, runAt time.Time, maxAttempts int) (*Job, error) {
+	kind = strings.TrimSpace(kind)
+	if kind == "" {
+		return nil, errs.NewValueIsInvalidErrorWithCause("kind", ErrEmptyKind)
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	now := time.Now()
+
+	return &Job{
+		baseAggregate: ddd.NewBaseAggregate(shared.NewID()),
+		kind:          kind,
+		payload:       payload,
+		status:        StatusPending,
+		maxAttempts:   maxAttempts,
+		runAt:         runAt,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// NewRecurring создает периодическую задачу.
+// Ключ задачи совпадает с её типом, поэтому в хранилище существует не более одной
+// периодической задачи каждого типа, сколько бы экземпляров бота ни было запущено.
+func NewRecurring(kind string, interval time.Duration, firstRunAt time.Time) (*Job, error) {
+	if interval <= 0 {
+		return nil, errs.NewValueIsInvalidErrorWithCause("interval", ErrInvalidInterval)
+	}
+
+	j, err := New(kind, nil, firstRunAt, DefaultMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	j.key = j.kind
+	j.interval = interval
+
+	return j, nil
+}
+
+func Restore(
+	id shared.ID,
+	kind string,
+	key string,
+	payload []byte,
+	status Status,
+	attempts int,
+	maxAttempts int,
+	interval time.Duration,
+	runAt time.Time,
+	lastError string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Job {
+	return &Job{
+		baseAggregate: ddd.NewBaseAggregate(id),
+		kind:          kind,
+		key:           key,
+		payload:       payload,
+		status:        status,
+		attempts:      attempts,
+		maxAttempts:   maxAttempts,
+		interval:      interval,
+		runAt:         runAt,
+		lastError:     lastError,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+// Complete отмечает успешное выполнение задачи.
+// Периодическая задача возвращается в очередь со следующим временем запуска.
+func (j *Job) Complete(now time.Time) {
+	j.updatedAt = now
+	j.lastError = ""
+
+	if j.IsRecurring() {
+		j.reschedule(now)
+		return
+	}
+
+	j.status = StatusDone
+}
+
+// Fail фиксирует ошибку выполнения задачи.
+// Пока попытки не исчерпаны, задача повторяется с экспоненциальной задержкой.
+// Исчерпавшая попытки разовая задача переходит в статус failed,
+// а периодическая ждет следующего планового запуска.
+func (j *Job) Fail(cause error, now time.Time) {
+	j.updatedAt = now
+	j.lastError = truncateError(cause)
+
+	if j.attempts < j.maxAttempts {
+		j.status = StatusPending
+		j.runAt = now.Add(RetryDelay(j.attempts))
+
+		return
+	}
+
+	if j.IsRecurring() {
+		j.reschedule(now)
+		return
+	}
+
+	j.status = StatusFailed
+}
+
+// RetryDelay возвращает задержку перед повтором после attempt неудачных попыток.
+// Задержка удваивается с каждой попыткой и ограничена сверху одним часом.
+func RetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := retryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+
+	return delay
+}
+
+func (j *Job) reschedule(now time.Time) {
+	j.status = StatusPending
+	j.attempts = 0
+	j.runAt = now.Add(j.interval)
+}
+
+func truncateError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+
+	return msg
+}
+
+func (j *Job) ID() shared.ID {
+	return j.baseAggregate.ID()
+}
+
+func (j *Job) Kind() string {
+	return j.kind
+}
+
+// Key возвращает ключ уникальности задачи. Пустой для разовых задач.
+func (j *Job) Key() string {
+	return j.key
+}
+
+func (j *Job) Payload() []byte {
+	return j.payload
+}
+
+func (j *Job) Status() Status {
+	return j.status
+}
+
+func (j *Job) Attempts() int {
+	return j.attempts
+}
+
+func (j *Job) MaxAttempts() int {
+	return j.maxAttempts
+}
+
+func (j *Job) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *Job) IsRecurring() bool {
+	return j.interval > 0
+}
+
+func (j *Job) RunAt() time.Time {
+	return j.runAt
+}
+
+func (j *Job) LastError() string {
+	return j.lastError
+}
+
+func (j *Job) CreatedAt() time.Time {
+	return j.createdAt
+}
+
+func (j *Job) UpdatedAt() time.Time {
+	return j.updatedAt
+}
@@ -0,0 +1,158 @@
+package job_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestNewJob(t *testing.T) {
+	runAt := time.Now().Add(time.Minute)
+
+	j, err := job.New(" digest ", []byte(`{"a":1}`), runAt, 0)
+
+	require.NoError(t, err)
+	assert.False(t, j.ID().IsZero())
+	assert.Equal(t, "digest", j.Kind())
+	assert.Empty(t, j.Key())
+	assert.Equal(t, job.StatusPending, j.Status())
+	assert.Equal(t, job.DefaultMaxAttempts, j.MaxAttempts())
+	assert.Equal(t, runAt, j.RunAt())
+	assert.False(t, j.IsRecurring())
+}
+
+func TestNewJob_EmptyKind(t *testing.T) {
+	j, err := job.New("  ", nil, time.Now(), 1)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+	assert.Nil(t, j)
+}
+
+func TestNewRecurring(t *testing.T) {
+	j, err := job.NewRecurring("digest", time.Minute, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, "digest", j.Key())
+	assert.Equal(t, time.Minute, j.Interval())
+	assert.True(t, j.IsRecurring())
+}
+
+func TestNewRecurring_InvalidInterval(t *testing.T) {
+	j, err := job.NewRecurring("digest", 0, time.Now())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+	assert.Nil(t, j)
+}
+
+func TestJob_Complete(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Разовая задача завершается", func(t *testing.T) {
+		j := restore(1, 5, 0)
+
+		j.Complete(now)
+
+		assert.Equal(t, job.StatusDone, j.Status())
+		assert.Equal(t, now, j.UpdatedAt())
+	})
+
+	t.Run("Периодическая задача планируется заново", func(t *testing.T) {
+		j := restore(3, 5, time.Hour)
+
+		j.Complete(now)
+
+		assert.Equal(t, job.StatusPending, j.Status())
+		assert.Equal(t, now.Add(time.Hour), j.RunAt())
+		assert.Equal(t, 0, j.Attempts())
+	})
+}
+
+func TestJob_Fail(t *testing.T) {
+	now := time.Now()
+	cause := errors.New("boom")
+
+	t.Run("Повтор с задержкой, пока есть попытки", func(t *testing.T) {
+		j := restore(2, 5, 0)
+
+		j.Fail(cause, now)
+
+		assert.Equal(t, job.StatusPending, j.Status())
+		assert.Equal(t, now.Add(job.RetryDelay(2)), j.RunAt())
+		assert.Equal(t, "boom", j.LastError())
+	})
+
+	t.Run("Разовая задача без попыток помечается как failed", func(t *testing.T) {
+		j := restore(5, 5, 0)
+
+		j.Fail(cause, now)
+
+		assert.Equal(t, job.StatusFailed, j.Status())
+	})
+
+	t.Run("Периодическая задача без попыток ждет следующего запуска", func(t *testing.T) {
+		j := restore(5, 5, time.Hour)
+
+		j.Fail(cause, now)
+
+		assert.Equal(t, job.StatusPending, j.Status())
+		assert.Equal(t, now.Add(time.Hour), j.RunAt())
+		assert.Equal(t, 0, j.Attempts())
+		assert.Equal(t, "boom", j.LastError())
+	})
+
+	t.Run("Длинная ошибка обрезается", func(t *testing.T) {
+		j := restore(1, 5, 0)
+
+		j.Fail(errors.New(strings.Repeat("x", 5000)), now)
+
+		assert.Len(t, j.LastError(), 1000)
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 30 * time.Second},
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 7, want: 32 * time.Minute},
+		{attempt: 8, want: time.Hour},
+		{attempt: 20, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, job.RetryDelay(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func restore(attempts, maxAttempts int, interval time.Duration) *job.Job {
+	now := time.Now()
+
+	return job.Restore(
+		shared.NewID(),
+		"kind",
+		"",
+		nil,
+		job.StatusRunning,
+		attempts,
+		maxAttempts,
+		interval,
+		now,
+		"",
+		now,
+		now,
+	)
+}
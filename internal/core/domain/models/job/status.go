@@ -0,0 +1,5 @@
+package job
+
+// Status представляет состояние фоновой задачи
+// ENUM(pending, running, done, failed)
+type Status string
@@ -0,0 +1,197 @@
+// Package outbox описывает сообщения transactional outbox: доменные события, сохраненные
+// в одной транзакции с изменениями агрегатов и доставляемые подписчикам после фиксации.
+package outbox
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// DefaultMaxAttempts количество попыток доставки сообщения, после которого оно считается недоставляемым.
+	DefaultMaxAttempts = 10
+
+	retryBaseDelay = 5 * time.Second
+	retryMaxDelay  = time.Hour
+
+	maxErrorLength = 1000
+)
+
+var (
+	ErrEmptyName = errors.New("event name cannot be empty")
+
+	// ErrLeaseLost сообщает, что захват сообщения истек и сообщение уже захватил другой экземпляр.
+	ErrLeaseLost = errors.New("outbox message lease lost")
+)
+
+// Message доменное событие, ожидающее доставки подписчикам.
+// Доставка выполняется не менее одного раза: при сбое после доставки событие может быть доставлено повторно,
+// поэтому подписчики должны быть идемпотентными. Сообщение, исчерпавшее попытки, переходит в статус dead
+// и больше не доставляется.
+type Message struct {
+	id            shared.ID
+	name          string
+	payload       []byte
+	status        Status
+	attempts      int
+	maxAttempts   int
+	nextAttemptAt time.Time
+	lastError     string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// New создает сообщение для события event, сериализованного в payload.
+// Идентификатор сообщения совпадает с идентификатором события.
+func New(event ddd.DomainEvent, payload []byte, now time.Time) (*Message, error) {
+	if event == nil {
+		return nil, errs.NewValueIsRequiredError("event")
+	}
+
+	name := strings.TrimSpace(event.GetName())
+	if name == "" {
+		return nil, errs.NewValueIsInvalidErrorWithCause("event", ErrEmptyName)
+	}
+
+	return &Message{
+		id:            shared.RestoreID(event.GetID()),
+		name:          name,
+		payload:       payload,
+		status:        StatusPending,
+		maxAttempts:   DefaultMaxAttempts,
+		nextAttemptAt: now,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+func Restore(
+	id shared.ID,
+	name string,
+	payload []byte,
+	status Status,
+	attempts int,
+	maxAttempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Message {
+	return &Message{
+		id:            id,
+		name:          name,
+		payload:       payload,
+		status:        status,
+		attempts:      attempts,
+		maxAttempts:   maxAttempts,
+		nextAttemptAt: nextAttemptAt,
+		lastError:     lastError,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+// Delivered отмечает успешную доставку сообщения всем подписчикам.
+func (m *Message) Delivered(now time.Time) {
+	m.status = StatusDelivered
+	m.lastError = ""
+	m.updatedAt = now
+}
+
+// Fail фиксирует ошибку доставки. Пока попытки не исчерпаны, доставка повторяется
+// с экспоненциальной задержкой, затем сообщение переходит в статус dead.
+func (m *Message) Fail(cause error, now time.Time) {
+	m.updatedAt = now
+	m.lastError = truncateError(cause)
+
+	if m.attempts < m.maxAttempts {
+		m.status = StatusPending
+		m.nextAttemptAt = now.Add(RetryDelay(m.attempts))
+
+		return
+	}
+
+	m.status = StatusDead
+}
+
+// RetryDelay возвращает задержку перед повтором после attempt неудачных попыток.
+// Задержка удваивается с каждой попыткой и ограничена сверху одним часом.
+func RetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := retryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+
+	return delay
+}
+
+func truncateError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+
+	return msg
+}
+
+func (m *Message) ID() shared.ID {
+	return m.id
+}
+
+// Name возвращает имя события, по которому выбираются подписчики.
+func (m *Message) Name() string {
+	return m.name
+}
+
+func (m *Message) Payload() []byte {
+	return m.payload
+}
+
+func (m *Message) Status() Status {
+	return m.status
+}
+
+func (m *Message) IsDead() bool {
+	return m.status == StatusDead
+}
+
+// Attempts возвращает количество начатых попыток доставки.
+func (m *Message) Attempts() int {
+	return m.attempts
+}
+
+func (m *Message) MaxAttempts() int {
+	return m.maxAttempts
+}
+
+func (m *Message) NextAttemptAt() time.Time {
+	return m.nextAttemptAt
+}
+
+func (m *Message) LastError() string {
+	return m.lastError
+}
+
+func (m *Message) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m *Message) UpdatedAt() time.Time {
+	return m.updatedAt
+}
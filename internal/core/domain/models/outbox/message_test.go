@@ -0,0 +1,99 @@
+package outbox_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+type testEvent struct {
+	id   uuid.UUID
+	name string
+}
+
+func (e testEvent) GetID() uuid.UUID {
+	return e.id
+}
+
+func (e testEvent) GetName() string {
+	return e.name
+}
+
+func TestNewMessage(t *testing.T) {
+	now := time.Now()
+	event := testEvent{id: uuid.New(), name: "UserRegistered"}
+
+	m, err := outbox.New(event, []byte(`{"a":1}`), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, event.id, m.ID().Value())
+	assert.Equal(t, "UserRegistered", m.Name())
+	assert.JSONEq(t, `{"a":1}`, string(m.Payload()))
+	assert.Equal(t, outbox.StatusPending, m.Status())
+	assert.Equal(t, outbox.DefaultMaxAttempts, m.MaxAttempts())
+	assert.Equal(t, now, m.NextAttemptAt())
+}
+
+func TestNewMessage_Invalid(t *testing.T) {
+	_, err := outbox.New(nil, nil, time.Now())
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = outbox.New(testEvent{id: uuid.New(), name: " "}, nil, time.Now())
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func restoreMessage(attempts int) *outbox.Message {
+	now := time.Now()
+
+	return outbox.Restore(shared.NewID(), "UserRegistered", nil, outbox.StatusPending, attempts, 3, now, "boom", now, now)
+}
+
+func TestMessage_Delivered(t *testing.T) {
+	m := restoreMessage(1)
+	now := time.Now()
+
+	m.Delivered(now)
+
+	assert.Equal(t, outbox.StatusDelivered, m.Status())
+	assert.Empty(t, m.LastError())
+	assert.Equal(t, now, m.UpdatedAt())
+}
+
+func TestMessage_Fail(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Доставка повторяется, пока попытки не исчерпаны", func(t *testing.T) {
+		m := restoreMessage(2)
+
+		m.Fail(errors.New("handler failed"), now)
+
+		assert.Equal(t, outbox.StatusPending, m.Status())
+		assert.False(t, m.IsDead())
+		assert.Equal(t, "handler failed", m.LastError())
+		assert.Equal(t, now.Add(outbox.RetryDelay(2)), m.NextAttemptAt())
+	})
+
+	t.Run("Исчерпавшее попытки сообщение становится недоставляемым", func(t *testing.T) {
+		m := restoreMessage(3)
+
+		m.Fail(errors.New("handler failed"), now)
+
+		assert.Equal(t, outbox.StatusDead, m.Status())
+		assert.True(t, m.IsDead())
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, outbox.RetryDelay(0))
+	assert.Equal(t, 5*time.Second, outbox.RetryDelay(1))
+	assert.Equal(t, 10*time.Second, outbox.RetryDelay(2))
+	assert.Equal(t, time.Hour, outbox.RetryDelay(20))
+}
@@ -0,0 +1,5 @@
+package outbox
+
+// Status представляет состояние сообщения outbox
+// ENUM(pending, delivered, dead)
+type Status string
@@ -0,0 +1,53 @@
+package receipt
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// FiscalID фискальные реквизиты чека: номер фискального накопителя (ФН), номер фискального
+// документа (ФД) и фискальный признак документа (ФП). Вместе они однозначно определяют чек.
+type FiscalID struct {
+	drive    string
+	document string
+	sign     string
+}
+
+func NewFiscalID(drive string, document string, sign string) (FiscalID, error) {
+	if drive == "" {
+		return FiscalID{}, errs.NewValueIsRequiredError("fiscal drive")
+	}
+
+	if document == "" {
+		return FiscalID{}, errs.NewValueIsRequiredError("fiscal document")
+	}
+
+	if sign == "" {
+		return FiscalID{}, errs.NewValueIsRequiredError("fiscal sign")
+	}
+
+	return FiscalID{drive: drive, document: document, sign: sign}, nil
+}
+
+// Drive возвращает номер фискального накопителя (fn).
+func (f FiscalID) Drive() string {
+	return f.drive
+}
+
+// Document возвращает номер фискального документа (i).
+func (f FiscalID) Document() string {
+	return f.document
+}
+
+// Sign возвращает фискальный признак документа (fp).
+func (f FiscalID) Sign() string {
+	return f.sign
+}
+
+// IsZero сообщает, что реквизиты не заданы: транзакция записана не по чеку.
+func (f FiscalID) IsZero() bool {
+	return f == FiscalID{}
+}
+
+func (f FiscalID) String() string {
+	return f.drive + "/" + f.document + "/" + f.sign
+}
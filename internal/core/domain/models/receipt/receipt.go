@@ -0,0 +1,194 @@
+// Package receipt разбирает QR-код кассового чека по формату ФНС России.
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var (
+	ErrNotReceipt       = errors.New("text is not a receipt qr code")
+	ErrCodeNotFound     = errors.New("qr code not found")
+	ErrAlreadyRecorded  = errors.New("receipt is already recorded")
+	ErrInvalidOperation = errors.New("invalid receipt operation")
+)
+
+// Operation признак расчета чека (параметр n).
+type Operation int
+
+const (
+	// OperationIncome приход — обычная покупка.
+	OperationIncome Operation = 1
+	// OperationIncomeReturn возврат прихода — возврат покупки.
+	OperationIncomeReturn Operation = 2
+	// OperationExpense расход — продавец платит покупателю, например при скупке.
+	OperationExpense Operation = 3
+	// OperationExpenseReturn возврат расхода.
+	OperationExpenseReturn Operation = 4
+)
+
+// timeLayouts форматы параметра t: касса печатает время с секундами или без.
+var timeLayouts = []string{
+	"20060102T150405",
+	"20060102T1504",
+}
+
+// Receipt данные кассового чека из QR-кода.
+type Receipt struct {
+	occurredAt time.Time
+	amount     decimal.Decimal
+	operation  Operation
+	fiscalID   FiscalID
+}
+
+// LooksLikeReceipt сообщает, похож ли текст на содержимое QR-кода чека,
+// чтобы отличить его от обычного сообщения с суммой.
+func LooksLikeReceipt(text string) bool {
+	text = strings.TrimSpace(text)
+
+	return !strings.ContainsAny(text, " \n") &&
+		strings.Contains(text, "fn=") &&
+		strings.Contains(text, "fp=") &&
+		strings.Contains(text, "s=")
+}
+
+// Parse разбирает строку вида t=20260315T1230&s=1234.50&fn=...&i=...&fp=...&n=1.
+// Время чека указано по местному времени кассы, оно считается в часовом поясе loc.
+func Parse(text string, loc *time.Location) (Receipt, error) {
+	if loc == nil {
+		return Receipt{}, errs.NewValueIsRequiredError("loc")
+	}
+
+	text = strings.TrimSpace(text)
+
+	// Некоторые кассы печатают QR-код ссылкой на проверку чека, параметры идут после знака вопроса.
+	if _, query, ok := strings.Cut(text, "?"); ok {
+		text = query
+	}
+
+	values, err := url.ParseQuery(text)
+	if err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrNotReceipt, err)
+	}
+
+	fiscalID, err := NewFiscalID(values.Get("fn"), values.Get("i"), values.Get("fp"))
+	if err != nil {
+		return Receipt{}, fmt.Errorf("%w: %w", ErrNotReceipt, err)
+	}
+
+	occurredAt, err := parseTime(values.Get("t"), loc)
+	if err != nil {
+		return Receipt{}, err
+	}
+
+	amount, err := decimal.NewFromString(values.Get("s"))
+	if err != nil {
+		return Receipt{}, errs.NewValueIsInvalidErrorWithCause("s", err)
+	}
+
+	if !amount.IsPositive() {
+		return Receipt{}, errs.NewValueIsInvalidError("s")
+	}
+
+	operation := OperationIncome
+	if n := values.Get("n"); n != "" {
+		v, err := strconv.Atoi(n)
+		if err != nil || Operation(v) < OperationIncome || Operation(v) > OperationExpenseReturn {
+			return Receipt{}, fmt.Errorf("%w: %s", ErrInvalidOperation, n)
+		}
+
+		operation = Operation(v)
+	}
+
+	return Receipt{
+		occurredAt: occurredAt,
+		amount:     amount,
+		operation:  operation,
+		fiscalID:   fiscalID,
+	}, nil
+}
+
+func parseTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errs.NewValueIsInvalidError("t")
+}
+
+// OccurredAt возвращает время расчета, напечатанное на чеке.
+func (r Receipt) OccurredAt() time.Time {
+	return r.occurredAt
+}
+
+func (r Receipt) Amount() decimal.Decimal {
+	return r.amount
+}
+
+func (r Receipt) Operation() Operation {
+	return r.operation
+}
+
+func (r Receipt) FiscalID() FiscalID {
+	return r.fiscalID
+}
+
+// Type возвращает тип транзакции для покупателя: покупка и возврат расхода — траты,
+// возврат покупки и расход продавца — поступления. Возврат покупки записывается не доходом,
+// а возвратом по исходной покупке, см. IsPurchaseReturn.
+func (r Receipt) Type() category.Type {
+	switch r.operation {
+	case OperationIncomeReturn, OperationExpense:
+		return category.TypeIncome
+	}
+
+	return category.TypeExpense
+}
+
+// IsPurchaseReturn сообщает, что чек оформляет возврат покупки.
+func (r Receipt) IsPurchaseReturn() bool {
+	return r.operation == OperationIncomeReturn
+}
+
+// CanReturn сообщает, может ли чек возврата r относиться к покупке purchase:
+// это расход не позже возврата на сумму не меньше возвращенной.
+func (r Receipt) CanReturn(purchase report.TransactionLine) bool {
+	return purchase.CategoryType() == category.TypeExpense &&
+		!purchase.OccurredAt().After(r.occurredAt) &&
+		purchase.Amount().GreaterThanOrEqual(r.amount)
+}
+
+// ReturnedPurchase находит покупку, которую возвращает чек возврата r, среди покупок purchases,
+// пробитых тем же фискальным накопителем: расход на ту же сумму. Совпадение засчитывается, только
+// если оно единственное, иначе выбор остается за пользователем.
+func (r Receipt) ReturnedPurchase(purchases []report.TransactionLine) (report.TransactionLine, bool) {
+	var (
+		found report.TransactionLine
+		count int
+	)
+
+	for _, p := range purchases {
+		if r.CanReturn(p) && p.Amount().Equal(r.amount) {
+			found = p
+			count++
+		}
+	}
+
+	if count != 1 {
+		return report.TransactionLine{}, false
+	}
+
+	return found, true
+}
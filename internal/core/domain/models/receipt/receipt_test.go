@@ -0,0 +1,176 @@
+package receipt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestParse(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	r, err := receipt.Parse("t=20260315T1230&s=1234.50&fn=9287440300090728&i=12345&fp=1234567890&n=1", loc)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 3, 15, 12, 30, 0, 0, loc), r.OccurredAt())
+	assert.Equal(t, "1234.5", r.Amount().String())
+	assert.Equal(t, receipt.OperationIncome, r.Operation())
+	assert.Equal(t, category.TypeExpense, r.Type())
+	assert.Equal(t, "9287440300090728", r.FiscalID().Drive())
+	assert.Equal(t, "12345", r.FiscalID().Document())
+	assert.Equal(t, "1234567890", r.FiscalID().Sign())
+}
+
+func TestParse_Variants(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		occurredAt time.Time
+		typ        category.Type
+	}{
+		{
+			name:       "seconds and no operation",
+			text:       "t=20260315T123045&s=99.00&fn=1&i=2&fp=3",
+			occurredAt: time.Date(2026, 3, 15, 12, 30, 45, 0, time.UTC),
+			typ:        category.TypeExpense,
+		},
+		{
+			name:       "refund",
+			text:       "t=20260315T1230&s=99.00&fn=1&i=2&fp=3&n=2",
+			occurredAt: time.Date(2026, 3, 15, 12, 30, 0, 0, time.UTC),
+			typ:        category.TypeIncome,
+		},
+		{
+			name:       "check url",
+			text:       " https://check.ofd.ru/rec?t=20260315T1230&s=99.00&fn=1&i=2&fp=3&n=1\n",
+			occurredAt: time.Date(2026, 3, 15, 12, 30, 0, 0, time.UTC),
+			typ:        category.TypeExpense,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := receipt.Parse(tt.text, time.UTC)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.occurredAt, r.OccurredAt())
+			assert.Equal(t, tt.typ, r.Type())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		err  error
+	}{
+		{name: "plain text", text: "350 кофе", err: receipt.ErrNotReceipt},
+		{name: "no fiscal sign", text: "t=20260315T1230&s=99.00&fn=1&i=2", err: receipt.ErrNotReceipt},
+		{name: "bad time", text: "t=15.03.2026&s=99.00&fn=1&i=2&fp=3", err: errs.ErrValueIsInvalid},
+		{name: "bad sum", text: "t=20260315T1230&s=abc&fn=1&i=2&fp=3", err: errs.ErrValueIsInvalid},
+		{name: "zero sum", text: "t=20260315T1230&s=0&fn=1&i=2&fp=3", err: errs.ErrValueIsInvalid},
+		{name: "bad operation", text: "t=20260315T1230&s=1&fn=1&i=2&fp=3&n=7", err: receipt.ErrInvalidOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := receipt.Parse(tt.text, time.UTC)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestLooksLikeReceipt(t *testing.T) {
+	assert.True(t, receipt.LooksLikeReceipt("t=20260315T1230&s=99.00&fn=1&i=2&fp=3&n=1"))
+	assert.False(t, receipt.LooksLikeReceipt("350 кофе"))
+	assert.False(t, receipt.LooksLikeReceipt("350 fn= fp= s="))
+}
+
+func purchase(occurredAt time.Time, amount string, categoryType category.Type) report.TransactionLine {
+	return report.NewTransactionLine(shared.NewID(), occurredAt, decimal.RequireFromString(amount), categoryType, "Одежда", "", "")
+}
+
+func TestReceipt_IsPurchaseReturn(t *testing.T) {
+	for n, want := range map[string]bool{"1": false, "2": true, "3": false, "4": false} {
+		r, err := receipt.Parse("t=20260320T1000&s=1500.00&fn=1&i=2&fp=3&n="+n, time.UTC)
+		require.NoError(t, err)
+
+		assert.Equal(t, want, r.IsPurchaseReturn(), "n=%s", n)
+	}
+}
+
+func TestReceipt_ReturnedPurchase(t *testing.T) {
+	r, err := receipt.Parse("t=20260320T1000&s=1500.00&fn=1&i=20&fp=3&n=2", time.UTC)
+	require.NoError(t, err)
+
+	bought := time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC)
+	match := purchase(bought, "1500", category.TypeExpense)
+
+	tests := []struct {
+		name      string
+		purchases []report.TransactionLine
+		wantFound bool
+	}{
+		{
+			name:      "single purchase with the same amount",
+			purchases: []report.TransactionLine{purchase(bought, "300", category.TypeExpense), match},
+			wantFound: true,
+		},
+		{
+			name:      "no purchases",
+			purchases: nil,
+		},
+		{
+			name:      "only larger purchases",
+			purchases: []report.TransactionLine{purchase(bought, "4200", category.TypeExpense)},
+		},
+		{
+			name:      "purchase after the return",
+			purchases: []report.TransactionLine{purchase(time.Date(2026, 3, 21, 9, 0, 0, 0, time.UTC), "1500", category.TypeExpense)},
+		},
+		{
+			name:      "income with the same amount",
+			purchases: []report.TransactionLine{purchase(bought, "1500", category.TypeIncome)},
+		},
+		{
+			name:      "two purchases with the same amount",
+			purchases: []report.TransactionLine{match, purchase(bought.Add(time.Hour), "1500", category.TypeExpense)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.ReturnedPurchase(tt.purchases)
+
+			assert.Equal(t, tt.wantFound, ok)
+			if tt.wantFound {
+				assert.Equal(t, match.ID(), got.ID())
+			}
+		})
+	}
+}
+
+func TestReceipt_CanReturn(t *testing.T) {
+	r, err := receipt.Parse("t=20260320T1000&s=1500.00&fn=1&i=20&fp=3&n=2", time.UTC)
+	require.NoError(t, err)
+
+	bought := time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC)
+
+	// Частичный возврат относится к покупке на большую сумму.
+	assert.True(t, r.CanReturn(purchase(bought, "4200", category.TypeExpense)))
+	assert.True(t, r.CanReturn(purchase(r.OccurredAt(), "1500", category.TypeExpense)))
+	assert.False(t, r.CanReturn(purchase(bought, "1499.99", category.TypeExpense)))
+	assert.False(t, r.CanReturn(purchase(r.OccurredAt().Add(time.Minute), "1500", category.TypeExpense)))
+	assert.False(t, r.CanReturn(purchase(bought, "1500", category.TypeIncome)))
+}
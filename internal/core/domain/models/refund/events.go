@@ -0,0 +1,29 @@
+package refund
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// RefundCreated событие записи возврата по расходу.
+type RefundCreated struct {
+	ID            uuid.UUID       `json:"id"`
+	RefundID      shared.ID       `json:"refund_id"`
+	TransactionID shared.ID       `json:"transaction_id"`
+	UserID        shared.ID       `json:"user_id"`
+	CategoryID    shared.ID       `json:"category_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+func (e RefundCreated) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e RefundCreated) GetName() string {
+	return "RefundCreated"
+}
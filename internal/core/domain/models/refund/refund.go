@@ -0,0 +1,259 @@
+// Package refund описывает возвраты по расходам: деньги за сданный товар или отмененную услугу.
+// Возврат не считается доходом, а уменьшает расход по категории исходной транзакции.
+package refund
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var (
+	ErrNotExpense      = errors.New("only expenses can be refunded")
+	ErrExceedsOriginal = errors.New("refund exceeds original amount")
+	ErrUnknownCategory = errors.New("category is not part of the original transaction")
+
+	// ErrBelowRefunded сообщает, что изменение транзакции оставило бы возвраты по ней без покрытия.
+	ErrBelowRefunded = errors.New("transaction is less than its refunds")
+)
+
+// Refund возврат по расходной транзакции. Возврат относится к одной категории исходной транзакции:
+// к её категории или, если транзакция разделена, к категории одной из частей.
+type Refund struct {
+	baseAggregate *ddd.BaseAggregate[shared.ID]
+	userID        shared.ID
+	transactionID shared.ID
+	categoryID    shared.ID
+	amount        transaction.Amount
+	note          string
+	occurredAt    time.Time
+	createdAt     time.Time
+}
+
+// New создает возврат суммы amount по транзакции original. Пустой categoryID означает категорию
+// транзакции. Вместе с прежними возвратами previous сумма не должна превышать исходную сумму категории.
+func New(
+	original *transaction.Transaction,
+	categoryID shared.ID,
+	amount transaction.Amount,
+	previous []*Refund,
+) (*Refund, error) {
+	if original == nil {
+		return nil, errs.NewValueIsRequiredError("original")
+	}
+
+	if amount.Value().IsZero() {
+		return nil, transaction.ErrInvalidAmount
+	}
+
+	if categoryID.IsZero() {
+		categoryID = original.CategoryID()
+	}
+
+	available, err := Available(original, categoryID, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Value().GreaterThan(available) {
+		return nil, fmt.Errorf("%w: %s of %s available", ErrExceedsOriginal, amount, available.StringFixed(2))
+	}
+
+	now := time.Now()
+
+	r := &Refund{
+		baseAggregate: ddd.NewBaseAggregate(shared.NewID()),
+		userID:        original.UserID(),
+		transactionID: original.ID(),
+		categoryID:    categoryID,
+		amount:        amount,
+		occurredAt:    now,
+		createdAt:     now,
+	}
+
+	r.RaiseDomainEvent(RefundCreated{
+		ID:            shared.NewID().Value(),
+		RefundID:      r.ID(),
+		TransactionID: r.transactionID,
+		UserID:        r.userID,
+		CategoryID:    categoryID,
+		Amount:        amount.Value(),
+		OccurredAt:    now,
+	})
+
+	return r, nil
+}
+
+func Restore(
+	id shared.ID,
+	userID shared.ID,
+	transactionID shared.ID,
+	categoryID shared.ID,
+	amount transaction.Amount,
+	note string,
+	occurredAt time.Time,
+	createdAt time.Time,
+) *Refund {
+	return &Refund{
+		baseAggregate: ddd.NewBaseAggregate(id),
+		userID:        userID,
+		transactionID: transactionID,
+		categoryID:    categoryID,
+		amount:        amount,
+		note:          note,
+		occurredAt:    occurredAt,
+		createdAt:     createdAt,
+	}
+}
+
+// Available возвращает сумму, которую ещё можно вернуть по категории categoryID транзакции original
+// с учетом прежних возвратов previous. Возвраты по разделенной транзакции ограничены суммой части,
+// а все возвраты вместе — суммой транзакции.
+func Available(original *transaction.Transaction, categoryID shared.ID, previous []*Refund) (decimal.Decimal, error) {
+	if original == nil {
+		return decimal.Zero, errs.NewValueIsRequiredError("original")
+	}
+
+	limit, ok := categoryAmount(original, categoryID)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: %s", ErrUnknownCategory, categoryID)
+	}
+
+	total := original.Amount().Value()
+
+	for _, r := range previous {
+		if r.transactionID != original.ID() {
+			continue
+		}
+
+		total = total.Sub(r.amount.Value())
+
+		if r.categoryID == categoryID {
+			limit = limit.Sub(r.amount.Value())
+		}
+	}
+
+	return decimal.Max(decimal.Min(limit, total), decimal.Zero), nil
+}
+
+// CheckCovered проверяет, что транзакция original после изменения суммы, категории или частей
+// по-прежнему покрывает свои возвраты refunds: категория каждого возврата осталась в транзакции,
+// возвраты по категории не больше её суммы, а все возвраты вместе — не больше суммы транзакции.
+// Иначе чистый расход по транзакции стал бы отрицательным.
+func CheckCovered(original *transaction.Transaction, refunds []*Refund) error {
+	if original == nil {
+		return errs.NewValueIsRequiredError("original")
+	}
+
+	total := decimal.Zero
+	byCategory := make(map[shared.ID]decimal.Decimal)
+
+	for _, r := range refunds {
+		if r.transactionID != original.ID() {
+			continue
+		}
+
+		total = total.Add(r.amount.Value())
+		byCategory[r.categoryID] = byCategory[r.categoryID].Add(r.amount.Value())
+	}
+
+	if total.GreaterThan(original.Amount().Value()) {
+		return fmt.Errorf("%w: %s refunded", ErrBelowRefunded, total.StringFixed(2))
+	}
+
+	for categoryID, refunded := range byCategory {
+		amount, ok := categoryAmount(original, categoryID)
+		if !ok {
+			return fmt.Errorf("%w: category %s has refunds", ErrBelowRefunded, categoryID)
+		}
+
+		if refunded.GreaterThan(amount) {
+			return fmt.Errorf("%w: %s refunded in category %s", ErrBelowRefunded, refunded.StringFixed(2), categoryID)
+		}
+	}
+
+	return nil
+}
+
+// categoryAmount возвращает сумму, которая приходится в транзакции на категорию categoryID.
+func categoryAmount(original *transaction.Transaction, categoryID shared.ID) (decimal.Decimal, bool) {
+	if !original.IsSplit() {
+		return original.Amount().Value(), categoryID == original.CategoryID()
+	}
+
+	for _, p := range original.Splits() {
+		if p.CategoryID() == categoryID {
+			return p.Amount().Value(), true
+		}
+	}
+
+	return decimal.Zero, false
+}
+
+// SetNote задает комментарий к возврату, например причину. Пустая строка удаляет комментарий.
+func (r *Refund) SetNote(note string) error {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > transaction.MaxNoteLength {
+		return fmt.Errorf("%w: max %d characters", transaction.ErrTooLongNote, transaction.MaxNoteLength)
+	}
+
+	r.note = note
+
+	return nil
+}
+
+func (r *Refund) ID() shared.ID {
+	return r.baseAggregate.ID()
+}
+
+func (r *Refund) UserID() shared.ID {
+	return r.userID
+}
+
+// TransactionID возвращает исходную расходную транзакцию.
+func (r *Refund) TransactionID() shared.ID {
+	return r.transactionID
+}
+
+// CategoryID возвращает категорию, расход по которой уменьшает возврат.
+func (r *Refund) CategoryID() shared.ID {
+	return r.categoryID
+}
+
+func (r *Refund) Amount() transaction.Amount {
+	return r.amount
+}
+
+func (r *Refund) Note() string {
+	return r.note
+}
+
+// OccurredAt возвращает время возврата, по которому он попадает в отчеты.
+func (r *Refund) OccurredAt() time.Time {
+	return r.occurredAt
+}
+
+func (r *Refund) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *Refund) ClearDomainEvents() {
+	r.baseAggregate.ClearDomainEvents()
+}
+
+func (r *Refund) GetDomainEvents() []ddd.DomainEvent {
+	return r.baseAggregate.GetDomainEvents()
+}
+
+func (r *Refund) RaiseDomainEvent(event ddd.DomainEvent) {
+	r.baseAggregate.RaiseDomainEvent(event)
+}
@@ -0,0 +1,197 @@
+package refund_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+)
+
+func newAmount(t *testing.T, value int64) transaction.Amount {
+	t.Helper()
+
+	amount, err := transaction.NewAmount(decimal.NewFromInt(value))
+	require.NoError(t, err)
+
+	return amount
+}
+
+func newOriginal(t *testing.T, value int64) *transaction.Transaction {
+	t.Helper()
+
+	tx, err := transaction.New(shared.NewID(), newAmount(t, value), shared.NewID())
+	require.NoError(t, err)
+
+	return tx
+}
+
+func TestNew(t *testing.T) {
+	original := newOriginal(t, 3000)
+
+	r, err := refund.New(original, shared.ID{}, newAmount(t, 1000), nil)
+	require.NoError(t, err)
+	require.NoError(t, r.SetNote(" не подошел размер "))
+
+	assert.False(t, r.ID().IsZero())
+	assert.Equal(t, original.UserID(), r.UserID())
+	assert.Equal(t, original.ID(), r.TransactionID())
+	assert.Equal(t, original.CategoryID(), r.CategoryID())
+	assert.Equal(t, "1000.00", r.Amount().String())
+	assert.Equal(t, "не подошел размер", r.Note())
+	assert.False(t, r.OccurredAt().IsZero())
+}
+
+func TestNew_ExceedsOriginal(t *testing.T) {
+	original := newOriginal(t, 3000)
+
+	first, err := refund.New(original, shared.ID{}, newAmount(t, 2000), nil)
+	require.NoError(t, err)
+
+	_, err = refund.New(original, shared.ID{}, newAmount(t, 1001), []*refund.Refund{first})
+	require.ErrorIs(t, err, refund.ErrExceedsOriginal)
+
+	_, err = refund.New(original, shared.ID{}, newAmount(t, 1000), []*refund.Refund{first})
+	require.NoError(t, err)
+}
+
+func TestNew_SplitTransaction(t *testing.T) {
+	food, household := shared.NewID(), shared.NewID()
+
+	original := newOriginal(t, 3000)
+
+	foodPart, err := transaction.NewSplit(food, newAmount(t, 2200))
+	require.NoError(t, err)
+
+	householdPart, err := transaction.NewSplit(household, newAmount(t, 800))
+	require.NoError(t, err)
+
+	require.NoError(t, original.SplitInto([]transaction.Split{foodPart, householdPart}))
+
+	_, err = refund.New(original, household, newAmount(t, 900), nil)
+	require.ErrorIs(t, err, refund.ErrExceedsOriginal)
+
+	r, err := refund.New(original, household, newAmount(t, 800), nil)
+	require.NoError(t, err)
+	assert.Equal(t, household, r.CategoryID())
+
+	available, err := refund.Available(original, food, []*refund.Refund{r})
+	require.NoError(t, err)
+	assert.Equal(t, "2200.00", available.StringFixed(2))
+
+	_, err = refund.New(original, shared.NewID(), newAmount(t, 100), nil)
+	require.ErrorIs(t, err, refund.ErrUnknownCategory)
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := refund.New(nil, shared.ID{}, newAmount(t, 100), nil)
+	require.Error(t, err)
+
+	_, err = refund.New(newOriginal(t, 100), shared.ID{}, transaction.Amount{}, nil)
+	require.ErrorIs(t, err, transaction.ErrInvalidAmount)
+}
+
+func TestAvailable_LimitedByTotal(t *testing.T) {
+	food, household := shared.NewID(), shared.NewID()
+
+	original := newOriginal(t, 3000)
+
+	foodPart, err := transaction.NewSplit(food, newAmount(t, 2200))
+	require.NoError(t, err)
+
+	householdPart, err := transaction.NewSplit(household, newAmount(t, 800))
+	require.NoError(t, err)
+
+	require.NoError(t, original.SplitInto([]transaction.Split{foodPart, householdPart}))
+
+	r, err := refund.New(original, household, newAmount(t, 800), nil)
+	require.NoError(t, err)
+
+	original.Unsplit()
+
+	available, err := refund.Available(original, food, []*refund.Refund{r})
+	require.NoError(t, err)
+	assert.Equal(t, "2200.00", available.StringFixed(2))
+}
+
+func TestNew_RaisesRefundCreated(t *testing.T) {
+	original := newOriginal(t, 3000)
+
+	r, err := refund.New(original, shared.ID{}, newAmount(t, 1000), nil)
+	require.NoError(t, err)
+
+	require.Len(t, r.GetDomainEvents(), 1)
+
+	event, ok := r.GetDomainEvents()[0].(refund.RefundCreated)
+	require.True(t, ok)
+	assert.Equal(t, r.ID(), event.RefundID)
+	assert.Equal(t, original.ID(), event.TransactionID)
+	assert.Equal(t, original.UserID(), event.UserID)
+	assert.Equal(t, original.CategoryID(), event.CategoryID)
+	assert.Equal(t, "1000", event.Amount.String())
+}
+
+func TestCheckCovered(t *testing.T) {
+	food, household := shared.NewID(), shared.NewID()
+
+	original := newOriginal(t, 3000)
+
+	r, err := refund.New(original, shared.ID{}, newAmount(t, 1000), nil)
+	require.NoError(t, err)
+
+	refunds := []*refund.Refund{r}
+
+	require.NoError(t, refund.CheckCovered(original, refunds))
+	require.NoError(t, refund.CheckCovered(original, nil))
+
+	// Сумма уменьшена, но всё ещё покрывает возврат.
+	require.NoError(t, original.SetAmount(newAmount(t, 1000)))
+	require.NoError(t, refund.CheckCovered(original, refunds))
+
+	require.NoError(t, original.SetAmount(newAmount(t, 999)))
+	require.ErrorIs(t, refund.CheckCovered(original, refunds), refund.ErrBelowRefunded)
+
+	// Разделение, в котором нет категории возврата.
+	require.NoError(t, original.SetAmount(newAmount(t, 3000)))
+
+	foodPart, err := transaction.NewSplit(food, newAmount(t, 2200))
+	require.NoError(t, err)
+
+	householdPart, err := transaction.NewSplit(household, newAmount(t, 800))
+	require.NoError(t, err)
+
+	require.NoError(t, original.SplitInto([]transaction.Split{foodPart, householdPart}))
+	require.ErrorIs(t, refund.CheckCovered(original, refunds), refund.ErrBelowRefunded)
+}
+
+func TestCheckCovered_SplitPart(t *testing.T) {
+	food, household := shared.NewID(), shared.NewID()
+
+	original := newOriginal(t, 3000)
+
+	foodPart, err := transaction.NewSplit(food, newAmount(t, 2200))
+	require.NoError(t, err)
+
+	householdPart, err := transaction.NewSplit(household, newAmount(t, 800))
+	require.NoError(t, err)
+
+	require.NoError(t, original.SplitInto([]transaction.Split{foodPart, householdPart}))
+
+	r, err := refund.New(original, household, newAmount(t, 600), nil)
+	require.NoError(t, err)
+
+	refunds := []*refund.Refund{r}
+
+	smallerPart, err := transaction.NewSplit(household, newAmount(t, 500))
+	require.NoError(t, err)
+
+	biggerFood, err := transaction.NewSplit(food, newAmount(t, 2500))
+	require.NoError(t, err)
+
+	require.NoError(t, original.SplitInto([]transaction.Split{biggerFood, smallerPart}))
+	require.ErrorIs(t, refund.CheckCovered(original, refunds), refund.ErrBelowRefunded)
+}
@@ -0,0 +1,55 @@
+// Package report содержит модели агрегированных отчетов по транзакциям.
+package report
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// CategoryTotal сумма транзакций по одной категории за период.
+type CategoryTotal struct {
+	categoryID   shared.ID
+	name         string
+	parentName   string
+	categoryType category.Type
+	amount       decimal.Decimal
+}
+
+func NewCategoryTotal(
+	categoryID shared.ID,
+	name string,
+	parentName string,
+	categoryType category.Type,
+	amount decimal.Decimal,
+) CategoryTotal {
+	return CategoryTotal{
+		categoryID:   categoryID,
+		name:         name,
+		parentName:   parentName,
+		categoryType: categoryType,
+		amount:       amount,
+	}
+}
+
+func (c CategoryTotal) CategoryID() shared.ID {
+	return c.categoryID
+}
+
+func (c CategoryTotal) Name() string {
+	return c.name
+}
+
+// ParentName возвращает имя родительской категории или пустую строку для корневой категории.
+func (c CategoryTotal) ParentName() string {
+	return c.parentName
+}
+
+func (c CategoryTotal) Type() category.Type {
+	return c.categoryType
+}
+
+func (c CategoryTotal) Amount() decimal.Decimal {
+	return c.amount
+}
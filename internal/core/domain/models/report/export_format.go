@@ -0,0 +1,5 @@
+package report
+
+// ExportFormat формат файла выгрузки транзакций
+// ENUM(csv, xlsx, ofx, qif)
+type ExportFormat string
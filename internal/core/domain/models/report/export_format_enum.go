@@ -0,0 +1,51 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: v0.9.2
+
+// Built By: go install
+
+package report
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ExportFormatCsv is a ExportFormat of type csv.
+	ExportFormatCsv ExportFormat = "csv"
+	// ExportFormatXlsx is a ExportFormat of type xlsx.
+	ExportFormatXlsx ExportFormat = "xlsx"
+	// ExportFormatOfx is a ExportFormat of type ofx.
+	ExportFormatOfx ExportFormat = "ofx"
+	// ExportFormatQif is a ExportFormat of type qif.
+	ExportFormatQif ExportFormat = "qif"
+)
+
+var ErrInvalidExportFormat = errors.New("not a valid ExportFormat")
+
+// String implements the Stringer interface.
+func (x ExportFormat) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ExportFormat) IsValid() bool {
+	_, err := ParseExportFormat(string(x))
+	return err == nil
+}
+
+var _ExportFormatValue = map[string]ExportFormat{
+	"csv":  ExportFormatCsv,
+	"xlsx": ExportFormatXlsx,
+	"ofx":  ExportFormatOfx,
+	"qif":  ExportFormatQif,
+}
+
+// ParseExportFormat attempts to convert a string to a ExportFormat.
+func ParseExportFormat(name string) (ExportFormat, error) {
+	if x, ok := _ExportFormatValue[name]; ok {
+		return x, nil
+	}
+	return ExportFormat(""), fmt.Errorf("%s is %w", name, ErrInvalidExportFormat)
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// CurrencySign знак валюты, в которой ведется учет.
+	CurrencySign = "₽"
+
+	// CurrencyCode код валюты учета по ISO 4217.
+	CurrencyCode = "RUB"
+)
+
+// FormatAmount форматирует сумму для вывода пользователю: разряды разделяются пробелом,
+// копейки выводятся через запятую и опускаются для целых сумм, например "2 450" или "1 650,50".
+func FormatAmount(amount decimal.Decimal) string {
+	sign := ""
+	if amount.IsNegative() {
+		sign = "-"
+		amount = amount.Neg()
+	}
+
+	fixed := amount.StringFixed(2)
+	intPart, fracPart, _ := strings.Cut(fixed, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteRune(r)
+	}
+
+	if fracPart != "00" {
+		b.WriteByte(',')
+		b.WriteString(fracPart)
+	}
+
+	return b.String()
+}
+
+// FormatMoney форматирует сумму вместе со знаком валюты, например "2 450 ₽".
+func FormatMoney(amount decimal.Decimal) string {
+	return FormatAmount(amount) + " " + CurrencySign
+}
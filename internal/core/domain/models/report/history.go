@@ -0,0 +1,148 @@
+package report
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+var (
+	ErrInvalidAmountRange = errors.New("invalid amount range")
+	ErrInvalidPeriod      = errors.New("invalid period")
+)
+
+// HistoryFilter условия отбора транзакций в истории. Нулевое значение не ограничивает выборку.
+// Методы With возвращают измененную копию фильтра.
+type HistoryFilter struct {
+	categoryID   shared.ID
+	categoryType category.Type
+	tagID        shared.ID
+	minAmount    decimal.Decimal
+	maxAmount    decimal.Decimal
+	from         time.Time
+	to           time.Time
+}
+
+// WithCategory оставляет транзакции категории categoryID и её подкатегорий. Нулевой идентификатор снимает условие.
+func (f HistoryFilter) WithCategory(categoryID shared.ID) HistoryFilter {
+	f.categoryID = categoryID
+
+	return f
+}
+
+// WithTag оставляет транзакции с меткой tagID. Нулевой идентификатор снимает условие.
+func (f HistoryFilter) WithTag(tagID shared.ID) HistoryFilter {
+	f.tagID = tagID
+
+	return f
+}
+
+// WithType оставляет доходы или расходы. Пустой тип снимает условие.
+func (f HistoryFilter) WithType(categoryType category.Type) (HistoryFilter, error) {
+	if categoryType != "" && !categoryType.IsValid() {
+		return f, errs.NewValueIsInvalidError("categoryType")
+	}
+
+	f.categoryType = categoryType
+
+	return f, nil
+}
+
+// WithAmountRange оставляет транзакции с суммой в пределах [minAmount, maxAmount].
+// Нулевая граница не ограничивает выборку с этой стороны.
+func (f HistoryFilter) WithAmountRange(minAmount decimal.Decimal, maxAmount decimal.Decimal) (HistoryFilter, error) {
+	if minAmount.IsNegative() || maxAmount.IsNegative() {
+		return f, ErrInvalidAmountRange
+	}
+
+	if !maxAmount.IsZero() && minAmount.GreaterThan(maxAmount) {
+		return f, ErrInvalidAmountRange
+	}
+
+	f.minAmount = minAmount
+	f.maxAmount = maxAmount
+
+	return f, nil
+}
+
+// WithPeriod оставляет транзакции, совершенные в период [from, to). Нулевая граница не ограничивает период.
+func (f HistoryFilter) WithPeriod(from time.Time, to time.Time) (HistoryFilter, error) {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return f, ErrInvalidPeriod
+	}
+
+	f.from = from
+	f.to = to
+
+	return f, nil
+}
+
+func (f HistoryFilter) CategoryID() shared.ID {
+	return f.categoryID
+}
+
+func (f HistoryFilter) CategoryType() category.Type {
+	return f.categoryType
+}
+
+func (f HistoryFilter) TagID() shared.ID {
+	return f.tagID
+}
+
+func (f HistoryFilter) MinAmount() decimal.Decimal {
+	return f.minAmount
+}
+
+func (f HistoryFilter) MaxAmount() decimal.Decimal {
+	return f.maxAmount
+}
+
+func (f HistoryFilter) From() time.Time {
+	return f.from
+}
+
+func (f HistoryFilter) To() time.Time {
+	return f.to
+}
+
+// IsEmpty сообщает, что фильтр не ограничивает выборку.
+func (f HistoryFilter) IsEmpty() bool {
+	return f.categoryID.IsZero() && f.categoryType == "" && f.tagID.IsZero() &&
+		f.minAmount.IsZero() && f.maxAmount.IsZero() &&
+		f.from.IsZero() && f.to.IsZero()
+}
+
+// HistoryCursor позиция в истории, упорядоченной от новых транзакций к старым.
+// Следующая страница начинается с транзакций, совершенных раньше позиции.
+type HistoryCursor struct {
+	occurredAt time.Time
+	id         shared.ID
+}
+
+// NewHistoryCursor возвращает позицию сразу после строки line.
+func NewHistoryCursor(line TransactionLine) HistoryCursor {
+	return HistoryCursor{occurredAt: line.OccurredAt(), id: line.ID()}
+}
+
+// RestoreHistoryCursor восстанавливает позицию, ранее полученную из NewHistoryCursor.
+func RestoreHistoryCursor(occurredAt time.Time, id shared.ID) HistoryCursor {
+	return HistoryCursor{occurredAt: occurredAt, id: id}
+}
+
+func (c HistoryCursor) OccurredAt() time.Time {
+	return c.occurredAt
+}
+
+func (c HistoryCursor) ID() shared.ID {
+	return c.id
+}
+
+// IsZero сообщает, что позиция указывает на начало истории.
+func (c HistoryCursor) IsZero() bool {
+	return c.id.IsZero()
+}
@@ -0,0 +1,90 @@
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+func TestHistoryFilter(t *testing.T) {
+	var f report.HistoryFilter
+	assert.True(t, f.IsEmpty())
+
+	f, err := f.WithType(category.TypeExpense)
+	require.NoError(t, err)
+
+	f, err = f.WithAmountRange(decimal.NewFromInt(100), decimal.Zero)
+	require.NoError(t, err)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f, err = f.WithPeriod(from, time.Time{})
+	require.NoError(t, err)
+
+	assert.False(t, f.IsEmpty())
+	assert.Equal(t, category.TypeExpense, f.CategoryType())
+	assert.True(t, f.MinAmount().Equal(decimal.NewFromInt(100)))
+	assert.True(t, f.MaxAmount().IsZero())
+	assert.Equal(t, from, f.From())
+
+	f, err = f.WithType("")
+	require.NoError(t, err)
+	assert.Empty(t, f.CategoryType())
+}
+
+func TestHistoryFilter_Tag(t *testing.T) {
+	var f report.HistoryFilter
+
+	tagID := shared.NewID()
+
+	f = f.WithTag(tagID)
+	assert.False(t, f.IsEmpty())
+	assert.Equal(t, tagID, f.TagID())
+
+	f = f.WithTag(shared.ID{})
+	assert.True(t, f.IsEmpty())
+}
+
+func TestHistoryFilter_Invalid(t *testing.T) {
+	var f report.HistoryFilter
+
+	_, err := f.WithType(category.Type("transfer"))
+	require.Error(t, err)
+
+	_, err = f.WithAmountRange(decimal.NewFromInt(500), decimal.NewFromInt(100))
+	require.ErrorIs(t, err, report.ErrInvalidAmountRange)
+
+	_, err = f.WithAmountRange(decimal.NewFromInt(-1), decimal.Zero)
+	require.ErrorIs(t, err, report.ErrInvalidAmountRange)
+
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err = f.WithPeriod(day, day)
+	require.ErrorIs(t, err, report.ErrInvalidPeriod)
+}
+
+func TestNewHistoryCursor(t *testing.T) {
+	assert.True(t, report.HistoryCursor{}.IsZero())
+
+	id := shared.NewID()
+	occurredAt := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+	line := report.NewTransactionLine(id, occurredAt, decimal.NewFromInt(1), category.TypeExpense, "Еда", "", "")
+
+	c := report.NewHistoryCursor(line)
+	assert.False(t, c.IsZero())
+	assert.Equal(t, id, c.ID())
+	assert.Equal(t, occurredAt, c.OccurredAt())
+}
+
+func TestRestoreHistoryCursor(t *testing.T) {
+	id := shared.NewID()
+	occurredAt := time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)
+	line := report.NewTransactionLine(id, occurredAt, decimal.NewFromInt(1), category.TypeExpense, "Еда", "", "")
+
+	assert.Equal(t, report.NewHistoryCursor(line), report.RestoreHistoryCursor(occurredAt, id))
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxSearchTextLength наибольшая длина поискового запроса в символах.
+const MaxSearchTextLength = 100
+
+var (
+	ErrEmptySearchText   = errors.New("search text is empty")
+	ErrTooLongSearchText = errors.New("search text is too long")
+)
+
+// NormalizeSearchText убирает лишние пробелы из поискового запроса и проверяет его длину.
+func NormalizeSearchText(text string) (string, error) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return "", ErrEmptySearchText
+	}
+
+	if utf8.RuneCountInString(text) > MaxSearchTextLength {
+		return "", ErrTooLongSearchText
+	}
+
+	return text, nil
+}
+
+// SearchResult найденные транзакции от новых к старым и итоги по всем совпадениям.
+// Строк может быть меньше, чем совпадений: итоги считаются без ограничения выборки.
+type SearchResult struct {
+	lines        []TransactionLine
+	count        int
+	totalExpense decimal.Decimal
+	totalIncome  decimal.Decimal
+}
+
+func NewSearchResult(lines []TransactionLine, count int, totalExpense decimal.Decimal, totalIncome decimal.Decimal) *SearchResult {
+	return &SearchResult{lines: lines, count: count, totalExpense: totalExpense, totalIncome: totalIncome}
+}
+
+func (r *SearchResult) Lines() []TransactionLine {
+	return r.lines
+}
+
+// Count возвращает число всех совпадений.
+func (r *SearchResult) Count() int {
+	return r.count
+}
+
+func (r *SearchResult) TotalExpense() decimal.Decimal {
+	return r.totalExpense
+}
+
+func (r *SearchResult) TotalIncome() decimal.Decimal {
+	return r.totalIncome
+}
+
+// HasMore сообщает, что найдено больше транзакций, чем строк в результате.
+func (r *SearchResult) HasMore() bool {
+	return r.count > len(r.lines)
+}
@@ -0,0 +1,41 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+func TestNormalizeSearchText(t *testing.T) {
+	text, err := report.NormalizeSearchText("  страховка \t  ОСАГО\n")
+	require.NoError(t, err)
+	assert.Equal(t, "страховка ОСАГО", text)
+}
+
+func TestNormalizeSearchText_Invalid(t *testing.T) {
+	_, err := report.NormalizeSearchText("   ")
+	require.ErrorIs(t, err, report.ErrEmptySearchText)
+
+	_, err = report.NormalizeSearchText(strings.Repeat("я", report.MaxSearchTextLength+1))
+	require.ErrorIs(t, err, report.ErrTooLongSearchText)
+}
+
+func TestSearchResult_HasMore(t *testing.T) {
+	line := report.NewTransactionLine(
+		shared.NewID(), time.Now(), decimal.RequireFromString("1200"), category.TypeExpense, "Страховка", "", "ОСАГО",
+	)
+
+	r := report.NewSearchResult([]report.TransactionLine{line}, 3, decimal.RequireFromString("3600"), decimal.Zero)
+	assert.True(t, r.HasMore())
+
+	r = report.NewSearchResult([]report.TransactionLine{line}, 1, decimal.RequireFromString("1200"), decimal.Zero)
+	assert.False(t, r.HasMore())
+}
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+)
+
+// Summary сводка доходов и расходов за период [from, to).
+// Категории каждого типа упорядочены по убыванию суммы.
+type Summary struct {
+	from         time.Time
+	to           time.Time
+	expenses     []CategoryTotal
+	incomes      []CategoryTotal
+	totalExpense decimal.Decimal
+	totalIncome  decimal.Decimal
+}
+
+func NewSummary(from, to time.Time, totals []CategoryTotal) *Summary {
+	s := &Summary{from: from, to: to}
+
+	for _, t := range totals {
+		if t.Type() == category.TypeIncome {
+			s.incomes = append(s.incomes, t)
+			s.totalIncome = s.totalIncome.Add(t.Amount())
+		} else {
+			s.expenses = append(s.expenses, t)
+			s.totalExpense = s.totalExpense.Add(t.Amount())
+		}
+	}
+
+	byAmountDesc := func(a, b CategoryTotal) int {
+		if c := b.Amount().Cmp(a.Amount()); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Name(), b.Name())
+	}
+
+	slices.SortFunc(s.expenses, byAmountDesc)
+	slices.SortFunc(s.incomes, byAmountDesc)
+
+	return s
+}
+
+func (s *Summary) From() time.Time {
+	return s.from
+}
+
+func (s *Summary) To() time.Time {
+	return s.to
+}
+
+func (s *Summary) Expenses() []CategoryTotal {
+	return s.expenses
+}
+
+func (s *Summary) Incomes() []CategoryTotal {
+	return s.incomes
+}
+
+func (s *Summary) TotalExpense() decimal.Decimal {
+	return s.totalExpense
+}
+
+func (s *Summary) TotalIncome() decimal.Decimal {
+	return s.totalIncome
+}
+
+// IsEmpty сообщает, что за период не было ни одной транзакции.
+func (s *Summary) IsEmpty() bool {
+	return len(s.expenses) == 0 && len(s.incomes) == 0
+}
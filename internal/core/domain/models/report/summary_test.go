@@ -0,0 +1,74 @@
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+func TestNewSummary(t *testing.T) {
+	from := time.Now().Add(-24 * time.Hour)
+	to := time.Now()
+
+	s := report.NewSummary(from, to, []report.CategoryTotal{
+		total("Кафе", category.TypeExpense, "800"),
+		total("Зарплата", category.TypeIncome, "50000"),
+		total("Продукты", category.TypeExpense, "1650"),
+		total("Книги", category.TypeExpense, "800"),
+	})
+
+	require.False(t, s.IsEmpty())
+	assert.Equal(t, from, s.From())
+	assert.Equal(t, to, s.To())
+	assert.True(t, decimal.RequireFromString("3250").Equal(s.TotalExpense()))
+	assert.True(t, decimal.RequireFromString("50000").Equal(s.TotalIncome()))
+
+	var names []string
+	for _, e := range s.Expenses() {
+		names = append(names, e.Name())
+	}
+
+	assert.Equal(t, []string{"Продукты", "Кафе", "Книги"}, names)
+	require.Len(t, s.Incomes(), 1)
+	assert.Equal(t, "Зарплата", s.Incomes()[0].Name())
+}
+
+func TestNewSummary_Empty(t *testing.T) {
+	s := report.NewSummary(time.Now(), time.Now(), nil)
+
+	assert.True(t, s.IsEmpty())
+	assert.True(t, s.TotalExpense().IsZero())
+	assert.True(t, s.TotalIncome().IsZero())
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount string
+		want   string
+	}{
+		{amount: "0", want: "0"},
+		{amount: "800", want: "800"},
+		{amount: "2450", want: "2 450"},
+		{amount: "1650.5", want: "1 650,50"},
+		{amount: "1234567.89", want: "1 234 567,89"},
+		{amount: "100000", want: "100 000"},
+		{amount: "-2450", want: "-2 450"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, report.FormatAmount(decimal.RequireFromString(tt.amount)), tt.amount)
+	}
+
+	assert.Equal(t, "2 450 ₽", report.FormatMoney(decimal.NewFromInt(2450)))
+}
+
+func total(name string, t category.Type, amount string) report.CategoryTotal {
+	return report.NewCategoryTotal(shared.NewID(), name, "", t, decimal.RequireFromString(amount))
+}
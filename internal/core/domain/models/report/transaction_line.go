@@ -0,0 +1,71 @@
+package report
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// TransactionLine транзакция вместе с данными категории в виде, удобном для выгрузок и просмотра.
+type TransactionLine struct {
+	id           shared.ID
+	occurredAt   time.Time
+	amount       decimal.Decimal
+	categoryType category.Type
+	categoryName string
+	parentName   string
+	note         string
+}
+
+func NewTransactionLine(
+	id shared.ID,
+	occurredAt time.Time,
+	amount decimal.Decimal,
+	categoryType category.Type,
+	categoryName string,
+	parentName string,
+	note string,
+) TransactionLine {
+	return TransactionLine{
+		id:           id,
+		occurredAt:   occurredAt,
+		amount:       amount,
+		categoryType: categoryType,
+		categoryName: categoryName,
+		parentName:   parentName,
+		note:         note,
+	}
+}
+
+func (l TransactionLine) ID() shared.ID {
+	return l.id
+}
+
+// OccurredAt возвращает время совершения операции.
+func (l TransactionLine) OccurredAt() time.Time {
+	return l.occurredAt
+}
+
+func (l TransactionLine) Amount() decimal.Decimal {
+	return l.amount
+}
+
+func (l TransactionLine) CategoryType() category.Type {
+	return l.categoryType
+}
+
+func (l TransactionLine) CategoryName() string {
+	return l.categoryName
+}
+
+// ParentName возвращает имя родительской категории или пустую строку для корневой категории.
+func (l TransactionLine) ParentName() string {
+	return l.parentName
+}
+
+func (l TransactionLine) Note() string {
+	return l.note
+}
@@ -0,0 +1,5 @@
+package settings
+
+// DigestMode определяет периодичность отправки сводки по расходам
+// ENUM(off, daily, weekly)
+type DigestMode string
@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: v0.9.2
+
+// Built By: go install
+
+package settings
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// DigestModeOff is a DigestMode of type off.
+	DigestModeOff DigestMode = "off"
+	// DigestModeDaily is a DigestMode of type daily.
+	DigestModeDaily DigestMode = "daily"
+	// DigestModeWeekly is a DigestMode of type weekly.
+	DigestModeWeekly DigestMode = "weekly"
+)
+
+var ErrInvalidDigestMode = errors.New("not a valid DigestMode")
+
+// String implements the Stringer interface.
+func (x DigestMode) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x DigestMode) IsValid() bool {
+	_, err := ParseDigestMode(string(x))
+	return err == nil
+}
+
+var _DigestModeValue = map[string]DigestMode{
+	"off":    DigestModeOff,
+	"daily":  DigestModeDaily,
+	"weekly": DigestModeWeekly,
+}
+
+// ParseDigestMode attempts to convert a string to a DigestMode.
+func ParseDigestMode(name string) (DigestMode, error) {
+	if x, ok := _DigestModeValue[name]; ok {
+		return x, nil
+	}
+	return DigestMode(""), fmt.Errorf("%s is %w", name, ErrInvalidDigestMode)
+}
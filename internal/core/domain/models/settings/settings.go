@@ -0,0 +1,321 @@
+// Package settings описывает пользовательские настройки бота.
+package settings
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	DefaultTimezone   = "Europe/Moscow"
+	DefaultDigestHour = 9
+
+	DefaultQuietFrom = 22
+	DefaultQuietTo   = 9
+
+	// MaxReminderAfterHours наибольший допустимый срок бездействия перед напоминанием — неделя.
+	MaxReminderAfterHours = 7 * 24
+)
+
+var (
+	ErrInvalidTimezone      = errors.New("unknown time zone")
+	ErrInvalidHour          = errors.New("hour must be between 0 and 23")
+	ErrInvalidReminderDelay = errors.New("reminder delay must be between 0 and 168 hours")
+)
+
+// Settings хранит настройки пользователя: часовой пояс, параметры сводки и напоминаний.
+// Идентификатор настроек совпадает с идентификатором пользователя.
+type Settings struct {
+	baseAggregate      *ddd.BaseAggregate[shared.ID]
+	timezone           string
+	location           *time.Location
+	digestMode         DigestMode
+	digestHour         int
+	digestSentAt       time.Time
+	reminderAfterHours int
+	quietFrom          int
+	quietTo            int
+	remindedAt         time.Time
+	updatedAt          time.Time
+}
+
+// New создает настройки пользователя со значениями по умолчанию.
+func New(userID shared.ID) (*Settings, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	loc, err := loadLocation(DefaultTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Settings{
+		baseAggregate: ddd.NewBaseAggregate(userID),
+		timezone:      DefaultTimezone,
+		location:      loc,
+		digestMode:    DigestModeOff,
+		digestHour:    DefaultDigestHour,
+		quietFrom:     DefaultQuietFrom,
+		quietTo:       DefaultQuietTo,
+		updatedAt:     time.Now(),
+	}, nil
+}
+
+func Restore(
+	userID shared.ID,
+	timezone string,
+	digestMode DigestMode,
+	digestHour int,
+	digestSentAt time.Time,
+	reminderAfterHours int,
+	quietFrom int,
+	quietTo int,
+	remindedAt time.Time,
+	updatedAt time.Time,
+) *Settings {
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		// Сохраненный пояс мог исчезнуть из базы часовых поясов, считаем время по UTC.
+		loc = time.UTC
+	}
+
+	return &Settings{
+		baseAggregate:      ddd.NewBaseAggregate(userID),
+		timezone:           timezone,
+		location:           loc,
+		digestMode:         digestMode,
+		digestHour:         digestHour,
+		digestSentAt:       digestSentAt,
+		reminderAfterHours: reminderAfterHours,
+		quietFrom:          quietFrom,
+		quietTo:            quietTo,
+		remindedAt:         remindedAt,
+		updatedAt:          updatedAt,
+	}
+}
+
+// SetTimezone устанавливает часовой пояс пользователя по имени из базы IANA, например Europe/Moscow.
+func (s *Settings) SetTimezone(timezone string) error {
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return err
+	}
+
+	s.timezone = timezone
+	s.location = loc
+	s.updatedAt = time.Now()
+
+	return nil
+}
+
+func (s *Settings) SetDigestMode(mode DigestMode) error {
+	if !mode.IsValid() {
+		return errs.NewValueIsInvalidError("digestMode")
+	}
+
+	s.digestMode = mode
+	s.updatedAt = time.Now()
+
+	return nil
+}
+
+// SetDigestHour устанавливает час отправки сводки по местному времени пользователя.
+func (s *Settings) SetDigestHour(hour int) error {
+	if hour < 0 || hour > 23 {
+		return errs.NewValueIsInvalidErrorWithCause("digestHour", ErrInvalidHour)
+	}
+
+	s.digestHour = hour
+	s.updatedAt = time.Now()
+
+	return nil
+}
+
+// DigestPeriod определяет, пора ли отправлять сводку в момент now, и за какой период.
+// Ежедневная сводка охватывает вчерашний день, еженедельная отправляется по понедельникам
+// и охватывает прошлую неделю. Границы периода считаются по местному времени пользователя,
+// сводка отправляется не чаще раза в день.
+func (s *Settings) DigestPeriod(now time.Time) (from time.Time, to time.Time, due bool) {
+	if s.digestMode == DigestModeOff {
+		return time.Time{}, time.Time{}, false
+	}
+
+	local := now.In(s.location)
+	if local.Hour() < s.digestHour {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if s.digestMode == DigestModeWeekly && local.Weekday() != time.Monday {
+		return time.Time{}, time.Time{}, false
+	}
+
+	today := StartOfDay(local)
+	if !s.digestSentAt.IsZero() && !s.digestSentAt.Before(today) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	days := 1
+	if s.digestMode == DigestModeWeekly {
+		days = 7
+	}
+
+	return today.AddDate(0, 0, -days), today, true
+}
+
+// MarkDigestSent фиксирует отправку сводки, чтобы не отправить её повторно в тот же день.
+func (s *Settings) MarkDigestSent(now time.Time) {
+	s.digestSentAt = now
+	s.updatedAt = now
+}
+
+// SetReminderAfterHours включает напоминание после hours часов без записей, 0 выключает напоминания.
+func (s *Settings) SetReminderAfterHours(hours int) error {
+	if hours < 0 || hours > MaxReminderAfterHours {
+		return errs.NewValueIsInvalidErrorWithCause("reminderAfterHours", ErrInvalidReminderDelay)
+	}
+
+	s.reminderAfterHours = hours
+	s.updatedAt = time.Now()
+
+	return nil
+}
+
+// SetQuietHours задает тихие часы [from, to) по местному времени, в которые напоминания не отправляются.
+// Интервал может переходить через полночь, from == to отключает тихие часы.
+func (s *Settings) SetQuietHours(from int, to int) error {
+	if from < 0 || from > 23 {
+		return errs.NewValueIsInvalidErrorWithCause("quietFrom", ErrInvalidHour)
+	}
+
+	if to < 0 || to > 23 {
+		return errs.NewValueIsInvalidErrorWithCause("quietTo", ErrInvalidHour)
+	}
+
+	s.quietFrom = from
+	s.quietTo = to
+	s.updatedAt = time.Now()
+
+	return nil
+}
+
+// RemindersEnabled сообщает, включены ли напоминания о бездействии.
+func (s *Settings) RemindersEnabled() bool {
+	return s.reminderAfterHours > 0
+}
+
+// HasQuietHours сообщает, заданы ли тихие часы.
+func (s *Settings) HasQuietHours() bool {
+	return s.quietFrom != s.quietTo
+}
+
+// InQuietHours проверяет, попадает ли момент now в тихие часы пользователя.
+func (s *Settings) InQuietHours(now time.Time) bool {
+	if !s.HasQuietHours() {
+		return false
+	}
+
+	hour := now.In(s.location).Hour()
+	if s.quietFrom < s.quietTo {
+		return hour >= s.quietFrom && hour < s.quietTo
+	}
+
+	return hour >= s.quietFrom || hour < s.quietTo
+}
+
+// ReminderDue определяет, пора ли напомнить о записи трат в момент now.
+// lastActivity — время последней записанной транзакции, нулевое, если транзакций нет.
+// Напоминание повторяется не чаще, чем раз в заданный срок бездействия, и не отправляется в тихие часы.
+func (s *Settings) ReminderDue(now time.Time, lastActivity time.Time) bool {
+	if !s.RemindersEnabled() || s.InQuietHours(now) {
+		return false
+	}
+
+	since := lastActivity
+	if s.remindedAt.After(since) {
+		since = s.remindedAt
+	}
+
+	return now.Sub(since) >= s.ReminderAfter()
+}
+
+// MarkReminded фиксирует отправку напоминания.
+func (s *Settings) MarkReminded(now time.Time) {
+	s.remindedAt = now
+	s.updatedAt = now
+}
+
+// StartOfDay возвращает начало суток для момента t в его часовом поясе.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" || timezone == "Local" {
+		return nil, errs.NewValueIsInvalidErrorWithCause("timezone", ErrInvalidTimezone)
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errs.NewValueIsInvalidErrorWithCause("timezone", ErrInvalidTimezone)
+	}
+
+	return loc, nil
+}
+
+// UserID возвращает идентификатор пользователя, которому принадлежат настройки.
+func (s *Settings) UserID() shared.ID {
+	return s.baseAggregate.ID()
+}
+
+func (s *Settings) Timezone() string {
+	return s.timezone
+}
+
+// Location возвращает часовой пояс пользователя.
+func (s *Settings) Location() *time.Location {
+	return s.location
+}
+
+func (s *Settings) DigestMode() DigestMode {
+	return s.digestMode
+}
+
+func (s *Settings) DigestHour() int {
+	return s.digestHour
+}
+
+func (s *Settings) DigestSentAt() time.Time {
+	return s.digestSentAt
+}
+
+func (s *Settings) ReminderAfterHours() int {
+	return s.reminderAfterHours
+}
+
+// ReminderAfter возвращает срок бездействия, после которого отправляется напоминание.
+func (s *Settings) ReminderAfter() time.Duration {
+	return time.Duration(s.reminderAfterHours) * time.Hour
+}
+
+func (s *Settings) QuietFrom() int {
+	return s.quietFrom
+}
+
+func (s *Settings) QuietTo() int {
+	return s.quietTo
+}
+
+func (s *Settings) RemindedAt() time.Time {
+	return s.remindedAt
+}
+
+func (s *Settings) UpdatedAt() time.Time {
+	return s.updatedAt
+}
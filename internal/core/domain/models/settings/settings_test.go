@@ -0,0 +1,330 @@
+package settings_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestNewSettings(t *testing.T) {
+	userID := shared.NewID()
+
+	s, err := settings.New(userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, userID, s.UserID())
+	assert.Equal(t, settings.DefaultTimezone, s.Timezone())
+	assert.Equal(t, settings.DigestModeOff, s.DigestMode())
+	assert.Equal(t, settings.DefaultDigestHour, s.DigestHour())
+}
+
+func TestNewSettings_ZeroUserID(t *testing.T) {
+	s, err := settings.New(shared.ID{})
+
+	require.ErrorIs(t, err, errs.ErrValueIsRequired)
+	assert.Nil(t, s)
+}
+
+func TestSettings_SetTimezone(t *testing.T) {
+	s, err := settings.New(shared.NewID())
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetTimezone("Asia/Novosibirsk"))
+	assert.Equal(t, "Asia/Novosibirsk", s.Timezone())
+	assert.Equal(t, "Asia/Novosibirsk", s.Location().String())
+
+	for _, tz := range []string{"", "Local", "Mars/Olympus"} {
+		err = s.SetTimezone(tz)
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, tz)
+		assert.ErrorContains(t, err, settings.ErrInvalidTimezone.Error(), tz)
+	}
+
+	assert.Equal(t, "Asia/Novosibirsk", s.Timezone())
+}
+
+func TestSettings_SetDigestHour(t *testing.T) {
+	s, err := settings.New(shared.NewID())
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetDigestHour(0))
+	require.NoError(t, s.SetDigestHour(23))
+	require.ErrorIs(t, s.SetDigestHour(24), errs.ErrValueIsInvalid)
+	require.ErrorIs(t, s.SetDigestHour(-1), errs.ErrValueIsInvalid)
+	assert.Equal(t, 23, s.DigestHour())
+}
+
+func TestSettings_SetDigestMode(t *testing.T) {
+	s, err := settings.New(shared.NewID())
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetDigestMode(settings.DigestModeWeekly))
+	assert.Equal(t, settings.DigestModeWeekly, s.DigestMode())
+	require.ErrorIs(t, s.SetDigestMode("hourly"), errs.ErrValueIsInvalid)
+}
+
+func TestSettings_DigestPeriod(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	// Понедельник, 19 октября 2026 года.
+	monday := func(hour int) time.Time {
+		return time.Date(2026, time.October, 19, hour, 30, 0, 0, moscow)
+	}
+	today := time.Date(2026, time.October, 19, 0, 0, 0, 0, moscow)
+
+	tests := []struct {
+		name     string
+		mode     settings.DigestMode
+		sentAt   time.Time
+		now      time.Time
+		wantDue  bool
+		wantFrom time.Time
+	}{
+		{
+			name: "Сводка выключена",
+			mode: settings.DigestModeOff,
+			now:  monday(10),
+		},
+		{
+			name: "Еще не наступил час отправки",
+			mode: settings.DigestModeDaily,
+			now:  monday(8),
+		},
+		{
+			name:     "Ежедневная сводка за вчера",
+			mode:     settings.DigestModeDaily,
+			now:      monday(9),
+			wantDue:  true,
+			wantFrom: today.AddDate(0, 0, -1),
+		},
+		{
+			name:   "Сегодня сводка уже отправлена",
+			mode:   settings.DigestModeDaily,
+			sentAt: monday(9),
+			now:    monday(10),
+		},
+		{
+			name:     "Вчерашняя отправка не мешает сегодняшней",
+			mode:     settings.DigestModeDaily,
+			sentAt:   monday(9).AddDate(0, 0, -1),
+			now:      monday(10),
+			wantDue:  true,
+			wantFrom: today.AddDate(0, 0, -1),
+		},
+		{
+			name:     "Еженедельная сводка в понедельник",
+			mode:     settings.DigestModeWeekly,
+			now:      monday(12),
+			wantDue:  true,
+			wantFrom: today.AddDate(0, 0, -7),
+		},
+		{
+			name: "Еженедельная сводка не отправляется во вторник",
+			mode: settings.DigestModeWeekly,
+			now:  monday(12).AddDate(0, 0, 1),
+		},
+		{
+			name:     "Час отправки считается по местному времени",
+			mode:     settings.DigestModeDaily,
+			now:      monday(9).UTC(),
+			wantDue:  true,
+			wantFrom: today.AddDate(0, 0, -1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := settings.Restore(shared.NewID(), "Europe/Moscow", tt.mode, 9, tt.sentAt, 0, 22, 9, time.Time{}, time.Now())
+
+			from, to, due := s.DigestPeriod(tt.now)
+
+			assert.Equal(t, tt.wantDue, due)
+
+			if tt.wantDue {
+				assert.True(t, tt.wantFrom.Equal(from), "from = %s", from)
+				assert.True(t, today.Equal(to), "to = %s", to)
+			}
+		})
+	}
+}
+
+func TestSettings_MarkDigestSent(t *testing.T) {
+	s := settings.Restore(shared.NewID(), "Europe/Moscow", settings.DigestModeDaily, 0, time.Time{}, 0, 22, 9, time.Time{}, time.Now())
+	now := time.Now()
+
+	_, _, due := s.DigestPeriod(now)
+	require.True(t, due)
+
+	s.MarkDigestSent(now)
+
+	_, _, due = s.DigestPeriod(now)
+	assert.False(t, due)
+	assert.Equal(t, now, s.DigestSentAt())
+}
+
+func TestSettings_SetReminderAfterHours(t *testing.T) {
+	s, err := settings.New(shared.NewID())
+	require.NoError(t, err)
+	assert.False(t, s.RemindersEnabled())
+
+	require.NoError(t, s.SetReminderAfterHours(24))
+	assert.True(t, s.RemindersEnabled())
+	assert.Equal(t, 24*time.Hour, s.ReminderAfter())
+
+	err = s.SetReminderAfterHours(settings.MaxReminderAfterHours + 1)
+	require.ErrorIs(t, err, errs.ErrValueIsInvalid)
+	require.ErrorIs(t, s.SetReminderAfterHours(-1), errs.ErrValueIsInvalid)
+	assert.Equal(t, 24, s.ReminderAfterHours())
+
+	require.NoError(t, s.SetReminderAfterHours(0))
+	assert.False(t, s.RemindersEnabled())
+}
+
+func TestSettings_InQuietHours(t *testing.T) {
+	msk, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		quietFrom int
+		quietTo   int
+		hour      int
+		want      bool
+	}{
+		{name: "Через полночь, ночью", quietFrom: 22, quietTo: 9, hour: 23, want: true},
+		{name: "Через полночь, утром", quietFrom: 22, quietTo: 9, hour: 8, want: true},
+		{name: "Через полночь, конец интервала", quietFrom: 22, quietTo: 9, hour: 9, want: false},
+		{name: "Через полночь, днем", quietFrom: 22, quietTo: 9, hour: 15, want: false},
+		{name: "В пределах суток", quietFrom: 0, quietTo: 7, hour: 3, want: true},
+		{name: "В пределах суток, вне интервала", quietFrom: 0, quietTo: 7, hour: 7, want: false},
+		{name: "Тихие часы выключены", quietFrom: 0, quietTo: 0, hour: 3, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := settings.New(shared.NewID())
+			require.NoError(t, err)
+			require.NoError(t, s.SetQuietHours(tt.quietFrom, tt.quietTo))
+
+			// Время задано в UTC, чтобы проверить перевод в часовой пояс пользователя.
+			now := time.Date(2026, 10, 19, tt.hour, 30, 0, 0, msk).UTC()
+
+			assert.Equal(t, tt.want, s.InQuietHours(now))
+		})
+	}
+}
+
+func TestSettings_SetQuietHours_Invalid(t *testing.T) {
+	s, err := settings.New(shared.NewID())
+	require.NoError(t, err)
+
+	require.ErrorIs(t, s.SetQuietHours(24, 9), errs.ErrValueIsInvalid)
+	require.ErrorIs(t, s.SetQuietHours(22, -1), errs.ErrValueIsInvalid)
+	assert.Equal(t, settings.DefaultQuietFrom, s.QuietFrom())
+	assert.Equal(t, settings.DefaultQuietTo, s.QuietTo())
+}
+
+func TestSettings_ReminderDue(t *testing.T) {
+	msk, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, msk)
+
+	tests := []struct {
+		name         string
+		afterHours   int
+		remindedAt   time.Time
+		lastActivity time.Time
+		now          time.Time
+		want         bool
+	}{
+		{
+			name:         "Напоминания выключены",
+			afterHours:   0,
+			lastActivity: now.Add(-72 * time.Hour),
+			now:          now,
+			want:         false,
+		},
+		{
+			name:         "Пользователь недавно записывал траты",
+			afterHours:   24,
+			lastActivity: now.Add(-23 * time.Hour),
+			now:          now,
+			want:         false,
+		},
+		{
+			name:         "Пользователь молчит дольше срока",
+			afterHours:   24,
+			lastActivity: now.Add(-25 * time.Hour),
+			now:          now,
+			want:         true,
+		},
+		{
+			name:       "Транзакций нет и напоминаний не было",
+			afterHours: 24,
+			now:        now,
+			want:       true,
+		},
+		{
+			name:         "Напоминание уже отправлено",
+			afterHours:   24,
+			remindedAt:   now.Add(-2 * time.Hour),
+			lastActivity: now.Add(-48 * time.Hour),
+			now:          now,
+			want:         false,
+		},
+		{
+			name:         "Повторное напоминание после срока",
+			afterHours:   24,
+			remindedAt:   now.Add(-24 * time.Hour),
+			lastActivity: now.Add(-48 * time.Hour),
+			now:          now,
+			want:         true,
+		},
+		{
+			name:         "Тихие часы",
+			afterHours:   24,
+			lastActivity: now.Add(-48 * time.Hour),
+			now:          time.Date(2026, 10, 19, 23, 0, 0, 0, msk),
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := settings.Restore(
+				shared.NewID(),
+				"Europe/Moscow",
+				settings.DigestModeOff,
+				settings.DefaultDigestHour,
+				time.Time{},
+				tt.afterHours,
+				settings.DefaultQuietFrom,
+				settings.DefaultQuietTo,
+				tt.remindedAt,
+				time.Now(),
+			)
+
+			assert.Equal(t, tt.want, s.ReminderDue(tt.now, tt.lastActivity))
+		})
+	}
+}
+
+func TestSettings_MarkReminded(t *testing.T) {
+	s, err := settings.New(shared.NewID())
+	require.NoError(t, err)
+	require.NoError(t, s.SetQuietHours(0, 0))
+	require.NoError(t, s.SetReminderAfterHours(24))
+
+	now := time.Now()
+	require.True(t, s.ReminderDue(now, time.Time{}))
+
+	s.MarkReminded(now)
+
+	assert.False(t, s.ReminderDue(now, time.Time{}))
+	assert.Equal(t, now, s.RemindedAt())
+}
@@ -37,3 +37,12 @@ func (id ID) IsZero() bool {
 func (id ID) Value() uuid.UUID {
 	return id.value
 }
+
+// MarshalText позволяет сохранять идентификатор в текстовых форматах, например в JSON.
+func (id ID) MarshalText() ([]byte, error) {
+	return id.value.MarshalText()
+}
+
+func (id *ID) UnmarshalText(data []byte) error {
+	return id.value.UnmarshalText(data)
+}
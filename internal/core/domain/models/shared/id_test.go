@@ -1,6 +1,7 @@
 package shared_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/uuid"
@@ -47,3 +48,17 @@ func TestValue(t *testing.T) {
 	require.IsType(t, uuid.UUID{}, val)
 	require.Equal(t, id.String(), val.String())
 }
+
+func TestID_JSON(t *testing.T) {
+	id := shared.NewID()
+
+	data, err := json.Marshal(id)
+	require.NoError(t, err)
+	require.JSONEq(t, `"`+id.String()+`"`, string(data))
+
+	var got shared.ID
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, id, got)
+
+	require.Error(t, json.Unmarshal([]byte(`"not-a-uuid"`), &got))
+}
@@ -0,0 +1,203 @@
+package statement
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+)
+
+// fallbackCategoryName категория для операций, которые не удалось отнести к другим.
+const fallbackCategoryName = "прочее"
+
+// categoryRule относит операцию, в категории банка или описании которой встречается одно из keywords,
+// к первой категории пользователя, название которой содержит одно из targets.
+type categoryRule struct {
+	keywords []string
+	targets  []string
+}
+
+var expenseRules = []categoryRule{
+	{
+		keywords: []string{"супермаркет", "продукт", "пятерочка", "перекресток", "магнит", "вкусвилл", "ашан", "лента", "дикси", "азбука вкуса"},
+		targets:  []string{"еда", "продукт"},
+	},
+	{
+		keywords: []string{"кафе", "ресторан", "фастфуд", "кофе", "coffee", "бургер", "пицц", "вкусно и точка", "шоколадница"},
+		targets:  []string{"кафе", "ресторан"},
+	},
+	{
+		keywords: []string{"азс", "топливо", "бензин", "лукойл", "роснефть", "газпромнефть", "автоуслуги", "парковка", "автомойка"},
+		targets:  []string{"машин", "авто"},
+	},
+	{
+		keywords: []string{"такси", "транспорт", "метро", "ржд", "авиабилет", "аэрофлот", "каршеринг"},
+		targets:  []string{"поездки", "транспорт"},
+	},
+	{
+		keywords: []string{"аптек", "медицин", "клиник", "стоматолог", "анализ"},
+		targets:  []string{"медицин", "здоровье"},
+	},
+	{
+		keywords: []string{"спорт", "фитнес"},
+		targets:  []string{"спорт"},
+	},
+	{
+		keywords: []string{"красота", "салон", "барбер", "косметик"},
+		targets:  []string{"красот"},
+	},
+	{
+		keywords: []string{"одежда", "обувь"},
+		targets:  []string{"одежд"},
+	},
+	{
+		keywords: []string{"жкх", "коммунал", "электроэнерг", "водоканал"},
+		targets:  []string{"жкх", "коммунал"},
+	},
+	{
+		keywords: []string{"мобильная связь", "мтс", "билайн", "мегафон", "теле2", "tele2"},
+		targets:  []string{"телефон", "связь"},
+	},
+	{
+		keywords: []string{"интернет", "ростелеком"},
+		targets:  []string{"интернет"},
+	},
+	{
+		keywords: []string{"кино", "театр", "концерт"},
+		targets:  []string{"кино", "театр"},
+	},
+	{
+		keywords: []string{"книг", "литрес"},
+		targets:  []string{"книг"},
+	},
+	{
+		keywords: []string{"налог", "фнс"},
+		targets:  []string{"налог"},
+	},
+	{
+		keywords: []string{"электроника", "бытовая техника", "мвидео", "эльдорадо", "dns"},
+		targets:  []string{"техник"},
+	},
+}
+
+var incomeRules = []categoryRule{
+	{
+		keywords: []string{"зарплат", "заработн", "аванс"},
+		targets:  []string{"зарплат"},
+	},
+	{
+		keywords: []string{"процент", "вклад", "капитализац"},
+		targets:  []string{"процент"},
+	},
+	{
+		keywords: []string{"кэшбэк", "кешбэк", "кэшбек", "кешбек", "cashback", "бонус"},
+		targets:  []string{"кешбек", "кэшбэк", "кешбэк", "кэшбек"},
+	},
+}
+
+// Categorizer подбирает операциям выписки категории пользователя.
+type Categorizer struct {
+	expense categorySet
+	income  categorySet
+}
+
+// NewCategorizer создает подбор категорий по категориям расходов expense и доходов income.
+func NewCategorizer(expense, income []*category.Category) *Categorizer {
+	return &Categorizer{
+		expense: newCategorySet(expense, expenseRules),
+		income:  newCategorySet(income, incomeRules),
+	}
+}
+
+// Categorize возвращает категорию для операции или nil, если у пользователя нет категорий нужного типа.
+// Сначала ищется категория пользователя с названием категории банка, затем применяются правила
+// по ключевым словам, иначе выбирается категория «Прочее» или первая из категорий.
+func (c *Categorizer) Categorize(r Record) *category.Category {
+	set := c.expense
+	if r.Type() == category.TypeIncome {
+		set = c.income
+	}
+
+	return set.match(r)
+}
+
+type categorySet struct {
+	categories []*category.Category
+	names      []string
+	rules      []categoryRule
+}
+
+func newCategorySet(categories []*category.Category, rules []categoryRule) categorySet {
+	names := make([]string, len(categories))
+	for i, c := range categories {
+		names[i] = normalizeName(c.Name())
+	}
+
+	return categorySet{categories: categories, names: names, rules: rules}
+}
+
+func (s categorySet) match(r Record) *category.Category {
+	if len(s.categories) == 0 {
+		return nil
+	}
+
+	if bankCategory := normalizeName(r.BankCategory()); bankCategory != "" {
+		for i, name := range s.names {
+			if name == bankCategory {
+				return s.categories[i]
+			}
+		}
+	}
+
+	text := normalizeName(r.BankCategory() + " " + r.Description())
+	for _, rule := range s.rules {
+		if !containsAny(text, rule.keywords) {
+			continue
+		}
+
+		if c := s.findByName(rule.targets); c != nil {
+			return c
+		}
+	}
+
+	if c := s.findByName([]string{fallbackCategoryName}); c != nil {
+		return c
+	}
+
+	return s.categories[0]
+}
+
+func (s categorySet) findByName(targets []string) *category.Category {
+	for i, name := range s.names {
+		if containsAny(name, targets) {
+			return s.categories[i]
+		}
+	}
+
+	return nil
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeName приводит название к нижнему регистру и убирает эмодзи и знаки препинания,
+// чтобы «🍎 Еда, продукты» совпадало с «еда продукты».
+func normalizeName(s string) string {
+	s = strings.ReplaceAll(strings.ToLower(s), "ё", "е")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return ' '
+	}, s)
+
+	return strings.Join(strings.Fields(s), " ")
+}
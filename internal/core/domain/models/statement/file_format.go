@@ -0,0 +1,5 @@
+package statement
+
+// FileFormat формат файла с операциями, который можно импортировать
+// ENUM(csv, ofx, qif)
+type FileFormat string
@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: v0.9.2
+
+// Built By: go install
+
+package statement
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// FileFormatCsv is a FileFormat of type csv.
+	FileFormatCsv FileFormat = "csv"
+	// FileFormatOfx is a FileFormat of type ofx.
+	FileFormatOfx FileFormat = "ofx"
+	// FileFormatQif is a FileFormat of type qif.
+	FileFormatQif FileFormat = "qif"
+)
+
+var ErrInvalidFileFormat = errors.New("not a valid FileFormat")
+
+// String implements the Stringer interface.
+func (x FileFormat) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x FileFormat) IsValid() bool {
+	_, err := ParseFileFormat(string(x))
+	return err == nil
+}
+
+var _FileFormatValue = map[string]FileFormat{
+	"csv": FileFormatCsv,
+	"ofx": FileFormatOfx,
+	"qif": FileFormatQif,
+}
+
+// ParseFileFormat attempts to convert a string to a FileFormat.
+func ParseFileFormat(name string) (FileFormat, error) {
+	if x, ok := _FileFormatValue[name]; ok {
+		return x, nil
+	}
+	return FileFormat(""), fmt.Errorf("%s is %w", name, ErrInvalidFileFormat)
+}
@@ -0,0 +1,29 @@
+package statement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fingerprint вычисляет отпечаток операции по дате, сумме и описанию. occurrence — порядковый номер
+// среди одинаковых операций в файле: две одинаковые покупки за день остаются разными операциями,
+// а повторная загрузка того же файла дает те же отпечатки.
+func Fingerprint(r Record, occurrence int) string {
+	key := fingerprintKey(r) + "|" + strconv.Itoa(occurrence)
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func fingerprintKey(r Record) string {
+	description := strings.Join(strings.Fields(strings.ToLower(r.description)), " ")
+
+	return strings.Join([]string{
+		r.occurredAt.UTC().Format(time.RFC3339),
+		r.amount.StringFixed(2),
+		description,
+	}, "|")
+}
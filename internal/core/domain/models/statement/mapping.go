@@ -0,0 +1,160 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// NoColumn означает, что столбца в выписке нет.
+const NoColumn = -1
+
+// Mapping сопоставляет столбцы выписки полям операции.
+type Mapping struct {
+	preset      string
+	date        int
+	amount      int
+	description int
+	category    int
+	status      int
+	direction   int
+}
+
+// NewMapping создает разметку, заданную пользователем вручную. Описание может отсутствовать.
+func NewMapping(date, amount, description int) (Mapping, error) {
+	if date < 0 {
+		return Mapping{}, errs.NewValueIsInvalidError("date column")
+	}
+
+	if amount < 0 || amount == date {
+		return Mapping{}, errs.NewValueIsInvalidError("amount column")
+	}
+
+	if description < NoColumn || description == date || description == amount {
+		return Mapping{}, errs.NewValueIsInvalidError("description column")
+	}
+
+	return Mapping{
+		date:        date,
+		amount:      amount,
+		description: description,
+		category:    NoColumn,
+		status:      NoColumn,
+		direction:   NoColumn,
+	}, nil
+}
+
+// Preset возвращает название формата банка или пустую строку для ручной разметки.
+func (m Mapping) Preset() string {
+	return m.preset
+}
+
+func (m Mapping) Date() int {
+	return m.date
+}
+
+func (m Mapping) Amount() int {
+	return m.amount
+}
+
+func (m Mapping) Description() int {
+	return m.description
+}
+
+// preset известный формат выписки. Для каждого поля перечислены возможные названия столбца.
+// Формат банка (strict) подходит, только если в заголовке нашлись все его столбцы.
+type preset struct {
+	name        string
+	strict      bool
+	date        []string
+	amount      []string
+	description []string
+	category    []string
+	status      []string
+	direction   []string
+}
+
+// presets проверяются по порядку: более специфичные форматы банков идут раньше общего.
+var presets = []preset{
+	{
+		name:        "Т-Банк",
+		strict:      true,
+		date:        []string{"дата операции"},
+		amount:      []string{"сумма операции"},
+		description: []string{"описание"},
+		category:    []string{"категория"},
+		status:      []string{"статус"},
+	},
+	{
+		name:        "Альфа-Банк",
+		strict:      true,
+		date:        []string{"дата операции"},
+		amount:      []string{"сумма", "сумма в валюте счета"},
+		description: []string{"описание"},
+		category:    []string{"категория"},
+		status:      []string{"статус"},
+		direction:   []string{"тип операции"},
+	},
+	{
+		name:        "Общий формат",
+		date:        []string{"дата", "дата операции", "дата и время", "date"},
+		amount:      []string{"сумма", "сумма операции", "amount"},
+		description: []string{"описание", "назначение платежа", "назначение", "комментарий", "description"},
+		category:    []string{"категория", "category"},
+		status:      []string{"статус", "status"},
+		direction:   []string{"тип операции", "тип", "type"},
+	},
+}
+
+// DetectMapping подбирает разметку по заголовку выписки. Для любого формата обязательны дата и сумма.
+func DetectMapping(header []string) (Mapping, bool) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = normalizeHeader(name)
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+
+	for _, p := range presets {
+		missing := false
+		find := func(names []string) int {
+			for _, name := range names {
+				if i, ok := index[name]; ok {
+					return i
+				}
+			}
+
+			if len(names) > 0 {
+				missing = true
+			}
+
+			return NoColumn
+		}
+
+		m := Mapping{
+			preset:      p.name,
+			date:        find(p.date),
+			amount:      find(p.amount),
+			description: find(p.description),
+			category:    find(p.category),
+			status:      find(p.status),
+			direction:   find(p.direction),
+		}
+
+		if m.date == NoColumn || m.amount == NoColumn || p.strict && missing {
+			continue
+		}
+
+		return m, true
+	}
+
+	return Mapping{}, false
+}
+
+func normalizeHeader(s string) string {
+	s = strings.Trim(strings.TrimSpace(s), `"'`)
+	s = strings.ReplaceAll(strings.ToLower(s), "ё", "е")
+
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,108 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+func TestDetectMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      []string
+		preset      string
+		date        int
+		amount      int
+		description int
+	}{
+		{
+			name: "T-Bank",
+			header: []string{
+				"Дата операции", "Дата платежа", "Номер карты", "Статус", "Сумма операции",
+				"Валюта операции", "Сумма платежа", "Валюта платежа", "Кэшбэк", "Категория", "MCC", "Описание",
+			},
+			preset:      "Т-Банк",
+			date:        0,
+			amount:      4,
+			description: 11,
+		},
+		{
+			name:        "Alfa-Bank",
+			header:      []string{"Дата операции", "Категория", "Описание", "Сумма", "Тип операции", "Статус"},
+			preset:      "Альфа-Банк",
+			date:        0,
+			amount:      3,
+			description: 2,
+		},
+		{
+			name:        "Generic",
+			header:      []string{` "ДАТА" `, "Назначение  платежа", "Сумма"},
+			preset:      "Общий формат",
+			date:        0,
+			amount:      2,
+			description: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := statement.DetectMapping(tt.header)
+
+			require.True(t, ok)
+			assert.Equal(t, tt.preset, m.Preset())
+			assert.Equal(t, tt.date, m.Date())
+			assert.Equal(t, tt.amount, m.Amount())
+			assert.Equal(t, tt.description, m.Description())
+		})
+	}
+}
+
+func TestDetectMapping_Unknown(t *testing.T) {
+	_, ok := statement.DetectMapping([]string{"Когда", "Сколько", "Что"})
+
+	assert.False(t, ok)
+}
+
+func TestNewMapping(t *testing.T) {
+	m, err := statement.NewMapping(2, 0, statement.NoColumn)
+	require.NoError(t, err)
+	assert.Empty(t, m.Preset())
+	assert.Equal(t, statement.NoColumn, m.Description())
+
+	for _, columns := range [][3]int{{-1, 0, 1}, {0, 0, 1}, {0, 1, 1}, {0, 1, -2}} {
+		_, err := statement.NewMapping(columns[0], columns[1], columns[2])
+		require.ErrorIs(t, err, errs.ErrValueIsInvalid, columns)
+	}
+}
+
+func TestNewExchangeTable(t *testing.T) {
+	table, err := statement.NewExchangeTable(statement.FileFormatQif, [][]string{
+		{"2026-03-15", "-350.50", "Кофейня", "☕ Кафе"},
+		{"2026-03-16T10:00:00+05:00", "50000.00", "", "Зарплата"},
+	})
+	require.NoError(t, err)
+
+	m, ok := table.Mapping()
+	require.True(t, ok)
+	assert.Equal(t, "QIF", m.Preset())
+
+	loc := time.FixedZone("MSK", 3*60*60)
+	records, rowErrors := m.Parse(table, loc)
+
+	require.Empty(t, rowErrors)
+	require.Len(t, records, 2)
+	assert.True(t, time.Date(2026, 3, 15, 0, 0, 0, 0, loc).Equal(records[0].OccurredAt()))
+	assert.Equal(t, "☕ Кафе", records[0].BankCategory())
+	assert.True(t, time.Date(2026, 3, 16, 5, 0, 0, 0, time.UTC).Equal(records[1].OccurredAt()))
+
+	plain, err := statement.NewTable(table.Header(), table.Rows())
+	require.NoError(t, err)
+
+	_, ok = plain.Mapping()
+	assert.False(t, ok)
+}
@@ -0,0 +1,133 @@
+package statement
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+)
+
+// ImportItem операция выписки, подготовленная к импорту.
+type ImportItem struct {
+	record      Record
+	fingerprint string
+	category    *category.Category
+	duplicate   bool
+}
+
+func (i ImportItem) Record() Record {
+	return i.record
+}
+
+func (i ImportItem) Fingerprint() string {
+	return i.fingerprint
+}
+
+// Category возвращает подобранную категорию или nil, если подходящей категории нет.
+func (i ImportItem) Category() *category.Category {
+	return i.category
+}
+
+// Duplicate сообщает, что операция уже была импортирована раньше.
+func (i ImportItem) Duplicate() bool {
+	return i.duplicate
+}
+
+// Importable сообщает, что операцию можно сохранить как транзакцию.
+func (i ImportItem) Importable() bool {
+	return !i.duplicate && i.category != nil
+}
+
+// ImportPlan результат разбора выписки, который показывается пользователю перед импортом.
+type ImportPlan struct {
+	mapping   Mapping
+	items     []ImportItem
+	rowErrors []RowError
+}
+
+// NewImportPlan вычисляет отпечатки операций и подбирает им категории.
+func NewImportPlan(mapping Mapping, records []Record, rowErrors []RowError, categorizer *Categorizer) *ImportPlan {
+	occurrences := make(map[string]int, len(records))
+	items := make([]ImportItem, 0, len(records))
+
+	for _, r := range records {
+		key := fingerprintKey(r)
+
+		items = append(items, ImportItem{
+			record:      r,
+			fingerprint: Fingerprint(r, occurrences[key]),
+			category:    categorizer.Categorize(r),
+		})
+
+		occurrences[key]++
+	}
+
+	return &ImportPlan{mapping: mapping, items: items, rowErrors: rowErrors}
+}
+
+func (p *ImportPlan) Mapping() Mapping {
+	return p.mapping
+}
+
+func (p *ImportPlan) Items() []ImportItem {
+	return p.items
+}
+
+func (p *ImportPlan) RowErrors() []RowError {
+	return p.rowErrors
+}
+
+// Fingerprints возвращает отпечатки всех операций плана.
+func (p *ImportPlan) Fingerprints() []string {
+	fingerprints := make([]string, len(p.items))
+	for i, item := range p.items {
+		fingerprints[i] = item.fingerprint
+	}
+
+	return fingerprints
+}
+
+// MarkDuplicates отмечает операции, отпечатки которых уже есть среди existing.
+func (p *ImportPlan) MarkDuplicates(existing []string) {
+	known := make(map[string]bool, len(existing))
+	for _, f := range existing {
+		known[f] = true
+	}
+
+	for i := range p.items {
+		p.items[i].duplicate = known[p.items[i].fingerprint]
+	}
+}
+
+// Importable возвращает операции, которые будут сохранены при импорте.
+func (p *ImportPlan) Importable() []ImportItem {
+	var items []ImportItem
+	for _, item := range p.items {
+		if item.Importable() {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// DuplicateCount возвращает число операций, импортированных ранее.
+func (p *ImportPlan) DuplicateCount() int {
+	var n int
+	for _, item := range p.items {
+		if item.duplicate {
+			n++
+		}
+	}
+
+	return n
+}
+
+// UncategorizedCount возвращает число новых операций, для которых не нашлось категории.
+func (p *ImportPlan) UncategorizedCount() int {
+	var n int
+	for _, item := range p.items {
+		if !item.duplicate && item.category == nil {
+			n++
+		}
+	}
+
+	return n
+}
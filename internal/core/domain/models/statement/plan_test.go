@@ -0,0 +1,104 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+)
+
+func newCategory(t *testing.T, name string, categoryType category.Type) *category.Category {
+	t.Helper()
+
+	c, err := category.New(name, categoryType, shared.NewID(), nil)
+	require.NoError(t, err)
+
+	return c
+}
+
+func parseRecords(t *testing.T, rows [][]string) (statement.Mapping, []statement.Record) {
+	t.Helper()
+
+	header := []string{"Дата операции", "Статус", "Сумма операции", "Категория", "Описание"}
+	table, err := statement.NewTable(header, rows)
+	require.NoError(t, err)
+
+	m, ok := statement.DetectMapping(header)
+	require.True(t, ok)
+
+	records, rowErrors := m.Parse(table, time.UTC)
+	require.Empty(t, rowErrors)
+
+	return m, records
+}
+
+func TestCategorizer_Categorize(t *testing.T) {
+	food := newCategory(t, "🍎 Еда, продукты", category.TypeExpense)
+	cafe := newCategory(t, "☕ Кафе", category.TypeExpense)
+	car := newCategory(t, "🚙 Машина", category.TypeExpense)
+	other := newCategory(t, "🔹 Прочее", category.TypeExpense)
+	salary := newCategory(t, "Зарплата", category.TypeIncome)
+	cashback := newCategory(t, "Кешбек", category.TypeIncome)
+
+	categorizer := statement.NewCategorizer(
+		[]*category.Category{food, cafe, car, other},
+		[]*category.Category{salary, cashback},
+	)
+
+	_, records := parseRecords(t, [][]string{
+		{"15.03.2026", "OK", "-350", "Кафе", "Кофейня"},
+		{"15.03.2026", "OK", "-1200", "Супермаркеты", "ПЯТЕРОЧКА 1234"},
+		{"15.03.2026", "OK", "-2500", "", "АЗС Лукойл"},
+		{"15.03.2026", "OK", "-700", "Маркетплейсы", "Ozon"},
+		{"15.03.2026", "OK", "120", "Бонусы", "Кэшбэк за покупки"},
+		{"15.03.2026", "OK", "10000", "Переводы", "Перевод от Ивана"},
+	})
+
+	want := []*category.Category{cafe, food, car, other, cashback, salary}
+	for i, r := range records {
+		assert.Equal(t, want[i].Name(), categorizer.Categorize(r).Name(), r.Description())
+	}
+}
+
+func TestCategorizer_NoCategories(t *testing.T) {
+	categorizer := statement.NewCategorizer([]*category.Category{newCategory(t, "Еда", category.TypeExpense)}, nil)
+
+	_, records := parseRecords(t, [][]string{{"15.03.2026", "OK", "100", "", "Перевод"}})
+
+	assert.Nil(t, categorizer.Categorize(records[0]))
+}
+
+func TestImportPlan(t *testing.T) {
+	other := newCategory(t, "Прочее", category.TypeExpense)
+	categorizer := statement.NewCategorizer([]*category.Category{other}, nil)
+
+	rows := [][]string{
+		{"15.03.2026 09:00:00", "OK", "-150", "", "Кофе"},
+		{"15.03.2026 09:00:00", "OK", "-150", "", "кофе"},
+		{"16.03.2026 09:00:00", "OK", "-300", "", "Обед"},
+		{"16.03.2026 10:00:00", "OK", "500", "", "Перевод"},
+	}
+	m, records := parseRecords(t, rows)
+
+	plan := statement.NewImportPlan(m, records, nil, categorizer)
+	fingerprints := plan.Fingerprints()
+
+	require.Len(t, fingerprints, 4)
+	assert.NotEqual(t, fingerprints[0], fingerprints[1], "одинаковые операции в файле различаются порядковым номером")
+
+	_, again := parseRecords(t, rows)
+	assert.Equal(t, fingerprints, statement.NewImportPlan(m, again, nil, categorizer).Fingerprints())
+
+	plan.MarkDuplicates([]string{fingerprints[2]})
+
+	assert.Equal(t, 1, plan.DuplicateCount())
+	assert.Equal(t, 1, plan.UncategorizedCount())
+	require.Len(t, plan.Importable(), 2)
+	assert.Equal(t, fingerprints[0], plan.Importable()[0].Fingerprint())
+	assert.Equal(t, fingerprints[1], plan.Importable()[1].Fingerprint())
+}
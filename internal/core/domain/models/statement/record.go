@@ -0,0 +1,243 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+)
+
+var (
+	ErrInvalidDate   = errors.New("invalid date")
+	ErrInvalidAmount = errors.New("invalid amount")
+	ErrMissingColumn = errors.New("missing column")
+)
+
+// dateLayouts форматы дат, встречающиеся в выписках российских банков.
+var dateLayouts = []string{
+	time.RFC3339,
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"02.01.06",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/01/2006",
+}
+
+// failedStatuses статусы операций, которые не состоялись и не попадают в импорт.
+var failedStatuses = map[string]bool{
+	"failed":    true,
+	"declined":  true,
+	"отклонена": true,
+	"отклонено": true,
+	"отменена":  true,
+	"отменено":  true,
+	"ошибка":    true,
+}
+
+// incomeDirections признаки поступления в столбце типа операции. Остальные значения считаются списанием.
+var incomeDirections = []string{"пополнение", "зачисление", "поступление", "приход", "доход", "income", "credit"}
+
+// Record операция из выписки. Положительная сумма означает поступление, отрицательная — списание.
+type Record struct {
+	line         int
+	occurredAt   time.Time
+	amount       decimal.Decimal
+	description  string
+	bankCategory string
+}
+
+// Line возвращает номер строки в файле, считая заголовок первой строкой.
+func (r Record) Line() int {
+	return r.line
+}
+
+func (r Record) OccurredAt() time.Time {
+	return r.occurredAt
+}
+
+// Amount возвращает сумму операции без знака.
+func (r Record) Amount() decimal.Decimal {
+	return r.amount.Abs()
+}
+
+// SignedAmount возвращает сумму со знаком: списания отрицательны.
+func (r Record) SignedAmount() decimal.Decimal {
+	return r.amount
+}
+
+func (r Record) Type() category.Type {
+	if r.amount.IsPositive() {
+		return category.TypeIncome
+	}
+
+	return category.TypeExpense
+}
+
+func (r Record) Description() string {
+	return r.description
+}
+
+// BankCategory возвращает категорию, присвоенную операции банком, если она есть в выписке.
+func (r Record) BankCategory() string {
+	return r.bankCategory
+}
+
+// RowError ошибка разбора строки выписки.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// Parse разбирает строки выписки по разметке. Даты без часового пояса считаются временем в loc,
+// даты в формате RFC 3339 сохраняют указанное в них смещение.
+// Несостоявшиеся операции и операции с нулевой суммой пропускаются, строки с ошибками
+// возвращаются отдельно и не мешают разбору остальных.
+func (m Mapping) Parse(t Table, loc *time.Location) ([]Record, []RowError) {
+	var (
+		records   []Record
+		rowErrors []RowError
+	)
+
+	for i, row := range t.Rows() {
+		line := i + 2
+
+		r, ok, err := m.parseRow(row, loc)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: err})
+			continue
+		}
+
+		if ok {
+			r.line = line
+			records = append(records, r)
+		}
+	}
+
+	return records, rowErrors
+}
+
+func (m Mapping) parseRow(row []string, loc *time.Location) (Record, bool, error) {
+	cell := func(i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+
+		return strings.TrimSpace(row[i])
+	}
+
+	if m.date >= len(row) || m.amount >= len(row) {
+		return Record{}, false, ErrMissingColumn
+	}
+
+	if failedStatuses[strings.ToLower(cell(m.status))] {
+		return Record{}, false, nil
+	}
+
+	occurredAt, err := ParseDate(cell(m.date), loc)
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	amount, err := ParseAmount(cell(m.amount))
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	if amount.IsZero() {
+		return Record{}, false, nil
+	}
+
+	if m.direction != NoColumn {
+		amount = amount.Abs()
+		if !isIncomeDirection(cell(m.direction)) {
+			amount = amount.Neg()
+		}
+	}
+
+	return Record{
+		occurredAt:   occurredAt,
+		amount:       amount,
+		description:  strings.Join(strings.Fields(cell(m.description)), " "),
+		bankCategory: cell(m.category),
+	}, true, nil
+}
+
+func isIncomeDirection(s string) bool {
+	s = strings.ToLower(s)
+	for _, d := range incomeDirections {
+		if strings.Contains(s, d) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseDate разбирает дату операции в одном из форматов, принятых в выписках.
+func ParseDate(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, s)
+}
+
+// amountReplacer убирает разделители разрядов и обозначения валюты и приводит знак минуса к ASCII.
+var amountReplacer = strings.NewReplacer(
+	" ", "",
+	"\u00a0", "",
+	"\u202f", "",
+	"\u2009", "",
+	"'", "",
+	"\u2212", "-",
+	"\u2013", "-",
+	"₽", "",
+	"руб.", "",
+	"руб", "",
+	"RUB", "",
+	"р.", "",
+)
+
+// ParseAmount разбирает сумму вида "-1 234,56 ₽", "+500.00" или "1,234.56".
+func ParseAmount(s string) (decimal.Decimal, error) {
+	cleaned := amountReplacer.Replace(strings.TrimSpace(s))
+	cleaned = strings.TrimPrefix(cleaned, "+")
+
+	comma, dot := strings.LastIndex(cleaned, ","), strings.LastIndex(cleaned, ".")
+	switch {
+	case comma >= 0 && dot > comma:
+		cleaned = strings.ReplaceAll(cleaned, ",", "")
+	case comma >= 0 && dot >= 0:
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+		cleaned = strings.Replace(cleaned, ",", ".", 1)
+	case comma >= 0:
+		cleaned = strings.Replace(cleaned, ",", ".", 1)
+	}
+
+	amount, err := decimal.NewFromString(cleaned)
+	if err != nil || cleaned == "" {
+		return decimal.Decimal{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	return amount, nil
+}
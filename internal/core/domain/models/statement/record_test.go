@@ -0,0 +1,114 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/category"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := map[string]string{
+		"-350,00":          "-350",
+		"+500.50":          "500.5",
+		"1 234,56":         "1234.56",
+		"-1\u00a0234,56 ₽": "-1234.56",
+		"\u22121\u202f000": "-1000",
+		"1,234.56":         "1234.56",
+		"1.234,56":         "1234.56",
+		"99 руб.":          "99",
+	}
+
+	for input, want := range tests {
+		amount, err := statement.ParseAmount(input)
+
+		require.NoError(t, err, input)
+		assert.Equal(t, want, amount.String(), input)
+	}
+
+	for _, input := range []string{"", "abc", "₽"} {
+		_, err := statement.ParseAmount(input)
+		require.ErrorIs(t, err, statement.ErrInvalidAmount, input)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	want := time.Date(2026, 3, 15, 14, 30, 0, 0, loc)
+
+	for _, input := range []string{"15.03.2026 14:30:00", "15.03.2026 14:30", "2026-03-15 14:30:00", "2026-03-15T14:30:00"} {
+		got, err := statement.ParseDate(input, loc)
+
+		require.NoError(t, err, input)
+		assert.True(t, want.Equal(got), input)
+	}
+
+	got, err := statement.ParseDate("15.03.2026", loc)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2026, 3, 15, 0, 0, 0, 0, loc).Equal(got))
+
+	_, err = statement.ParseDate("вчера", loc)
+	require.ErrorIs(t, err, statement.ErrInvalidDate)
+}
+
+func TestMapping_Parse(t *testing.T) {
+	header := []string{"Дата операции", "Статус", "Сумма операции", "Категория", "Описание"}
+	table, err := statement.NewTable(header, [][]string{
+		{"15.03.2026 14:30:00", "OK", "-350,00", "Кафе", "  Кофейня   у дома "},
+		{"15.03.2026 15:00:00", "FAILED", "-1000,00", "Супермаркеты", "Пятерочка"},
+		{"16.03.2026 10:00:00", "OK", "50000,00", "Пополнения", "Зарплата"},
+		{"вчера", "OK", "-1,00", "", ""},
+		{"17.03.2026 10:00:00", "OK", "0,00", "", "Проверка карты"},
+		{"17.03.2026 10:00:00", "OK"},
+	})
+	require.NoError(t, err)
+
+	m, ok := statement.DetectMapping(header)
+	require.True(t, ok)
+
+	records, rowErrors := m.Parse(table, time.UTC)
+
+	require.Len(t, records, 2)
+	assert.Equal(t, 2, records[0].Line())
+	assert.Equal(t, category.TypeExpense, records[0].Type())
+	assert.True(t, decimal.NewFromInt(350).Equal(records[0].Amount()))
+	assert.Equal(t, "Кофейня у дома", records[0].Description())
+	assert.Equal(t, "Кафе", records[0].BankCategory())
+	assert.Equal(t, category.TypeIncome, records[1].Type())
+
+	require.Len(t, rowErrors, 2)
+	assert.Equal(t, 5, rowErrors[0].Line)
+	require.ErrorIs(t, rowErrors[0], statement.ErrInvalidDate)
+	assert.Equal(t, 7, rowErrors[1].Line)
+	require.ErrorIs(t, rowErrors[1], statement.ErrMissingColumn)
+}
+
+func TestMapping_Parse_Direction(t *testing.T) {
+	header := []string{"Дата операции", "Категория", "Описание", "Сумма", "Тип операции", "Статус"}
+	table, err := statement.NewTable(header, [][]string{
+		{"15.03.2026", "Продукты", "Магнит", "1 200,00", "Списание", "Выполнено"},
+		{"16.03.2026", "", "Перевод", "3 000,00", "Пополнение", "Выполнено"},
+	})
+	require.NoError(t, err)
+
+	m, ok := statement.DetectMapping(header)
+	require.True(t, ok)
+
+	records, rowErrors := m.Parse(table, time.UTC)
+
+	require.Empty(t, rowErrors)
+	require.Len(t, records, 2)
+	assert.Equal(t, "-1200", records[0].SignedAmount().String())
+	assert.Equal(t, "3000", records[1].SignedAmount().String())
+}
+
+func TestNewTable_Empty(t *testing.T) {
+	_, err := statement.NewTable([]string{"Дата", "Сумма"}, nil)
+
+	require.ErrorIs(t, err, statement.ErrEmptyStatement)
+}
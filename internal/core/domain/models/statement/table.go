@@ -0,0 +1,98 @@
+// Package statement описывает импорт операций из банковских выписок: разметку столбцов,
+// разбор строк, отпечатки для отсечения повторов и подбор категорий.
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrEmptyStatement = errors.New("statement has no rows")
+	ErrUnknownFormat  = errors.New("unknown statement format")
+
+	// ErrAlreadyImported сообщает, что операция выписки уже записана, например параллельным импортом.
+	ErrAlreadyImported = errors.New("statement operation is already imported")
+)
+
+// UnknownFormatError сообщает, что столбцы выписки не удалось сопоставить ни с одним известным форматом.
+// Header позволяет предложить пользователю разметить столбцы вручную.
+type UnknownFormatError struct {
+	Header []string
+}
+
+func NewUnknownFormatError(header []string) *UnknownFormatError {
+	return &UnknownFormatError{Header: header}
+}
+
+func (e *UnknownFormatError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnknownFormat, strings.Join(e.Header, ", "))
+}
+
+func (e *UnknownFormatError) Unwrap() error {
+	return ErrUnknownFormat
+}
+
+// Столбцы таблицы, в которую читаются файлы обмена с финансовыми программами (OFX, QIF).
+const (
+	ExchangeColumnDate = iota
+	ExchangeColumnAmount
+	ExchangeColumnDescription
+	ExchangeColumnCategory
+)
+
+var exchangeHeader = []string{"Дата", "Сумма", "Описание", "Категория"}
+
+// Table содержимое выписки: заголовок и строки с операциями в исходном текстовом виде.
+type Table struct {
+	header  []string
+	rows    [][]string
+	mapping *Mapping
+}
+
+func NewTable(header []string, rows [][]string) (Table, error) {
+	if len(header) == 0 || len(rows) == 0 {
+		return Table{}, ErrEmptyStatement
+	}
+
+	return Table{header: header, rows: rows}, nil
+}
+
+// NewExchangeTable создает таблицу из операций файла обмена format. Столбцы строк rows
+// идут в порядке ExchangeColumn*, разметка задается сразу и не требует определения по заголовку.
+func NewExchangeTable(format FileFormat, rows [][]string) (Table, error) {
+	t, err := NewTable(exchangeHeader, rows)
+	if err != nil {
+		return Table{}, err
+	}
+
+	t.mapping = &Mapping{
+		preset:      strings.ToUpper(format.String()),
+		date:        ExchangeColumnDate,
+		amount:      ExchangeColumnAmount,
+		description: ExchangeColumnDescription,
+		category:    ExchangeColumnCategory,
+		status:      NoColumn,
+		direction:   NoColumn,
+	}
+
+	return t, nil
+}
+
+func (t Table) Header() []string {
+	return t.header
+}
+
+func (t Table) Rows() [][]string {
+	return t.rows
+}
+
+// Mapping возвращает разметку, известную из формата файла, если она есть.
+func (t Table) Mapping() (Mapping, bool) {
+	if t.mapping == nil {
+		return Mapping{}, false
+	}
+
+	return *t.mapping, true
+}
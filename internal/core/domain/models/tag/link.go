@@ -0,0 +1,32 @@
+package tag
+
+import (
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+// Link связь метки с транзакцией.
+type Link struct {
+	transactionID shared.ID
+	tagID         shared.ID
+}
+
+func NewLink(transactionID shared.ID, tagID shared.ID) (Link, error) {
+	if transactionID.IsZero() {
+		return Link{}, errs.NewValueIsRequiredError("transactionID")
+	}
+
+	if tagID.IsZero() {
+		return Link{}, errs.NewValueIsRequiredError("tagID")
+	}
+
+	return Link{transactionID: transactionID, tagID: tagID}, nil
+}
+
+func (l Link) TransactionID() shared.ID {
+	return l.transactionID
+}
+
+func (l Link) TagID() shared.ID {
+	return l.tagID
+}
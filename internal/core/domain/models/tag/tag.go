@@ -0,0 +1,140 @@
+// Package tag описывает метки транзакций. Метки не зависят от категорий и позволяют
+// объединить траты разных категорий, например #отпуск2026 или #ремонт.
+package tag
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// MaxNameLength наибольшая длина названия метки в символах.
+	MaxNameLength = 32
+
+	// MaxPerTransaction наибольшее число меток одной транзакции.
+	MaxPerTransaction = 10
+
+	hashSign = "#"
+)
+
+var (
+	ErrInvalidName = errors.New("invalid tag name")
+	ErrTooMany     = errors.New("too many tags")
+)
+
+// Tag метка пользователя. Название уникально в пределах пользователя и хранится в нижнем регистре.
+type Tag struct {
+	baseAggregate *ddd.BaseAggregate[shared.ID]
+	userID        shared.ID
+	name          string
+	createdAt     time.Time
+}
+
+// New создает метку пользователя userID. Название можно передать со знаком #.
+func New(userID shared.ID, name string) (*Tag, error) {
+	if userID.IsZero() {
+		return nil, errs.NewValueIsRequiredError("userID")
+	}
+
+	name, err := NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tag{
+		baseAggregate: ddd.NewBaseAggregate(shared.NewID()),
+		userID:        userID,
+		name:          name,
+		createdAt:     time.Now(),
+	}, nil
+}
+
+func Restore(id shared.ID, userID shared.ID, name string, createdAt time.Time) *Tag {
+	return &Tag{
+		baseAggregate: ddd.NewBaseAggregate(id),
+		userID:        userID,
+		name:          name,
+		createdAt:     createdAt,
+	}
+}
+
+func (t *Tag) ID() shared.ID {
+	return t.baseAggregate.ID()
+}
+
+func (t *Tag) UserID() shared.ID {
+	return t.userID
+}
+
+// Name возвращает название метки без знака #.
+func (t *Tag) Name() string {
+	return t.name
+}
+
+func (t *Tag) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// String возвращает метку в том виде, в котором её пишут в сообщении: #название.
+func (t *Tag) String() string {
+	return hashSign + t.name
+}
+
+// NormalizeName убирает знак # и приводит название к нижнему регистру.
+// Название может состоять из букв, цифр и знака подчеркивания.
+func NormalizeName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), hashSign))
+
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", ErrInvalidName
+	}
+
+	for _, r := range name {
+		if !isNameRune(r) {
+			return "", ErrInvalidName
+		}
+	}
+
+	return name, nil
+}
+
+// ExtractHashtags находит в тексте метки вида #название и возвращает их названия без повторов
+// вместе с текстом, из которого метки убраны. Слова с # и недопустимыми символами остаются в тексте.
+func ExtractHashtags(text string) ([]string, string) {
+	var (
+		names []string
+		rest  []string
+		seen  = make(map[string]bool)
+	)
+
+	for _, word := range strings.Fields(text) {
+		if !strings.HasPrefix(word, hashSign) {
+			rest = append(rest, word)
+			continue
+		}
+
+		name, err := NormalizeName(word)
+		if err != nil {
+			rest = append(rest, word)
+			continue
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names, strings.Join(rest, " ")
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,65 @@
+package tag_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+)
+
+func TestNew(t *testing.T) {
+	userID := shared.NewID()
+
+	tg, err := tag.New(userID, "#Отпуск2026")
+	require.NoError(t, err)
+
+	assert.False(t, tg.ID().IsZero())
+	assert.Equal(t, userID, tg.UserID())
+	assert.Equal(t, "отпуск2026", tg.Name())
+	assert.Equal(t, "#отпуск2026", tg.String())
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := tag.New(shared.ID{}, "ремонт")
+	require.Error(t, err)
+
+	for _, name := range []string{"", "#", "два слова", "ремонт!", strings.Repeat("я", tag.MaxNameLength+1)} {
+		_, err := tag.New(shared.NewID(), name)
+		require.ErrorIs(t, err, tag.ErrInvalidName, name)
+	}
+}
+
+func TestExtractHashtags(t *testing.T) {
+	names, rest := tag.ExtractHashtags("билеты #Отпуск2026 в Сочи #отпуск2026 #семья #!")
+
+	assert.Equal(t, []string{"отпуск2026", "семья"}, names)
+	assert.Equal(t, "билеты в Сочи #!", rest)
+}
+
+func TestExtractHashtags_NoTags(t *testing.T) {
+	names, rest := tag.ExtractHashtags("кофе с собой")
+
+	assert.Empty(t, names)
+	assert.Equal(t, "кофе с собой", rest)
+}
+
+func TestNewLink(t *testing.T) {
+	transactionID := shared.NewID()
+	tagID := shared.NewID()
+
+	link, err := tag.NewLink(transactionID, tagID)
+	require.NoError(t, err)
+
+	assert.Equal(t, transactionID, link.TransactionID())
+	assert.Equal(t, tagID, link.TagID())
+
+	_, err = tag.NewLink(shared.ID{}, tagID)
+	require.Error(t, err)
+
+	_, err = tag.NewLink(transactionID, shared.ID{})
+	require.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// TransactionCreated событие записи новой транзакции.
+type TransactionCreated struct {
+	ID            uuid.UUID       `json:"id"`
+	TransactionID shared.ID       `json:"transaction_id"`
+	UserID        shared.ID       `json:"user_id"`
+	CategoryID    shared.ID       `json:"category_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+func (e TransactionCreated) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e TransactionCreated) GetName() string {
+	return "TransactionCreated"
+}
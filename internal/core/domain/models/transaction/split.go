@@ -0,0 +1,84 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// MaxSplitParts наибольшее число частей, на которые делится транзакция.
+const MaxSplitParts = 10
+
+var (
+	ErrTooFewSplitParts       = errors.New("split must have at least two parts")
+	ErrTooManySplitParts      = errors.New("too many split parts")
+	ErrDuplicateSplitCategory = errors.New("split parts must have different categories")
+	ErrSplitSumMismatch       = errors.New("split parts must add up to transaction amount")
+	ErrSplit                  = errors.New("transaction is split into parts")
+)
+
+// Split часть транзакции, отнесенная на свою категорию, например бытовая химия из чека супермаркета.
+type Split struct {
+	categoryID shared.ID
+	amount     Amount
+}
+
+func NewSplit(categoryID shared.ID, amount Amount) (Split, error) {
+	if categoryID.IsZero() {
+		return Split{}, fmt.Errorf("%w: %s", ErrInvalidCategoryID, categoryID)
+	}
+
+	if amount.Value().IsZero() {
+		return Split{}, ErrInvalidAmount
+	}
+
+	return Split{categoryID: categoryID, amount: amount}, nil
+}
+
+func (s Split) CategoryID() shared.ID {
+	return s.categoryID
+}
+
+func (s Split) Amount() Amount {
+	return s.amount
+}
+
+// validateSplits проверяет, что части относятся к разным категориям и в сумме дают total.
+func validateSplits(parts []Split, total Amount) error {
+	if len(parts) < 2 {
+		return ErrTooFewSplitParts
+	}
+
+	if len(parts) > MaxSplitParts {
+		return fmt.Errorf("%w: max %d", ErrTooManySplitParts, MaxSplitParts)
+	}
+
+	sum := decimal.Zero
+	seen := make(map[shared.ID]bool, len(parts))
+
+	for _, p := range parts {
+		if p.categoryID.IsZero() {
+			return fmt.Errorf("%w: %s", ErrInvalidCategoryID, p.categoryID)
+		}
+
+		if p.amount.Value().IsZero() {
+			return ErrInvalidAmount
+		}
+
+		if seen[p.categoryID] {
+			return fmt.Errorf("%w: %s", ErrDuplicateSplitCategory, p.categoryID)
+		}
+
+		seen[p.categoryID] = true
+		sum = sum.Add(p.amount.Value())
+	}
+
+	if !sum.Equal(total.Value()) {
+		return fmt.Errorf("%w: %s of %s", ErrSplitSumMismatch, sum.StringFixed(2), total)
+	}
+
+	return nil
+}
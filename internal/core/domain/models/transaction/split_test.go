@@ -0,0 +1,117 @@
+package transaction_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+)
+
+func newSplitTestAmount(t *testing.T, value int64) transaction.Amount {
+	t.Helper()
+
+	amount, err := transaction.NewAmount(decimal.NewFromInt(value))
+	require.NoError(t, err)
+
+	return amount
+}
+
+func newSplitTestPart(t *testing.T, categoryID shared.ID, value int64) transaction.Split {
+	t.Helper()
+
+	part, err := transaction.NewSplit(categoryID, newSplitTestAmount(t, value))
+	require.NoError(t, err)
+
+	return part
+}
+
+func TestNewSplit_Invalid(t *testing.T) {
+	_, err := transaction.NewSplit(shared.ID{}, newSplitTestAmount(t, 100))
+	require.ErrorIs(t, err, transaction.ErrInvalidCategoryID)
+
+	_, err = transaction.NewSplit(shared.NewID(), transaction.Amount{})
+	require.ErrorIs(t, err, transaction.ErrInvalidAmount)
+}
+
+func TestTransaction_SplitInto(t *testing.T) {
+	food, household := shared.NewID(), shared.NewID()
+
+	tx, err := transaction.New(shared.NewID(), newSplitTestAmount(t, 3000), shared.NewID())
+	require.NoError(t, err)
+
+	parts := []transaction.Split{newSplitTestPart(t, food, 2200), newSplitTestPart(t, household, 800)}
+
+	require.NoError(t, tx.SplitInto(parts))
+	assert.True(t, tx.IsSplit())
+	assert.Equal(t, parts, tx.Splits())
+	assert.Equal(t, food, tx.CategoryID())
+
+	require.ErrorIs(t, tx.SetAmount(newSplitTestAmount(t, 100)), transaction.ErrSplit)
+	require.ErrorIs(t, tx.SetCategoryID(shared.NewID()), transaction.ErrSplit)
+
+	tx.Unsplit()
+	assert.False(t, tx.IsSplit())
+	assert.Nil(t, tx.Splits())
+	assert.Equal(t, food, tx.CategoryID())
+	require.NoError(t, tx.SetAmount(newSplitTestAmount(t, 100)))
+}
+
+func TestTransaction_SplitInto_Invalid(t *testing.T) {
+	food, household := shared.NewID(), shared.NewID()
+
+	tests := []struct {
+		name  string
+		parts []transaction.Split
+		err   error
+	}{
+		{
+			name:  "one part",
+			parts: []transaction.Split{newSplitTestPart(t, food, 3000)},
+			err:   transaction.ErrTooFewSplitParts,
+		},
+		{
+			name:  "sum is less than amount",
+			parts: []transaction.Split{newSplitTestPart(t, food, 2000), newSplitTestPart(t, household, 800)},
+			err:   transaction.ErrSplitSumMismatch,
+		},
+		{
+			name:  "same category",
+			parts: []transaction.Split{newSplitTestPart(t, food, 2000), newSplitTestPart(t, food, 1000)},
+			err:   transaction.ErrDuplicateSplitCategory,
+		},
+		{
+			name:  "zero part",
+			parts: []transaction.Split{newSplitTestPart(t, food, 3000), {}},
+			err:   transaction.ErrInvalidCategoryID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categoryID := shared.NewID()
+
+			tx, err := transaction.New(shared.NewID(), newSplitTestAmount(t, 3000), categoryID)
+			require.NoError(t, err)
+
+			require.ErrorIs(t, tx.SplitInto(tt.parts), tt.err)
+			assert.False(t, tx.IsSplit())
+			assert.Equal(t, categoryID, tx.CategoryID())
+		})
+	}
+}
+
+func TestTransaction_SplitInto_TooManyParts(t *testing.T) {
+	tx, err := transaction.New(shared.NewID(), newSplitTestAmount(t, transaction.MaxSplitParts+1), shared.NewID())
+	require.NoError(t, err)
+
+	parts := make([]transaction.Split, 0, transaction.MaxSplitParts+1)
+	for range transaction.MaxSplitParts + 1 {
+		parts = append(parts, newSplitTestPart(t, shared.NewID(), 1))
+	}
+
+	require.ErrorIs(t, tx.SplitInto(parts), transaction.ErrTooManySplitParts)
+}
@@ -3,15 +3,25 @@ package transaction
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
 )
 
+// MaxNoteLength наибольшая длина комментария к транзакции в символах.
+const MaxNoteLength = 255
+
 var (
 	ErrInvalidUserID     = errors.New("invalid user id")
 	ErrInvalidCategoryID = errors.New("invalid category id")
+	ErrTooLongNote       = errors.New("note is too long")
+	ErrInvalidOccurredAt = errors.New("invalid occurrence time")
 )
 
 type Transaction struct {
@@ -19,7 +29,18 @@ type Transaction struct {
 	userID        shared.ID
 	amount        Amount
 	categoryID    shared.ID
+	note          string
+	occurredAt    time.Time
+	fingerprint   string
+	fiscalID      receipt.FiscalID
 	createdAt     time.Time
+
+	// splits части разделенной транзакции. Пусто, если транзакция целиком относится к categoryID.
+	splits []Split
+
+	// createdEventID идентификатор еще не сохраненного события TransactionCreated. Событие собирается
+	// при сохранении, а не в New: время операции, категорию и сумму задают уже после создания.
+	createdEventID uuid.UUID
 }
 
 func New(uID shared.ID, amount Amount, cID shared.ID) (*Transaction, error) {
@@ -31,19 +52,166 @@ func New(uID shared.ID, amount Amount, cID shared.ID) (*Transaction, error) {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidCategoryID, cID)
 	}
 
+	now := time.Now()
+
+	return &Transaction{
+		baseAggregate:  ddd.NewBaseAggregate(shared.NewID()),
+		userID:         uID,
+		amount:         amount,
+		categoryID:     cID,
+		occurredAt:     now,
+		createdAt:      now,
+		createdEventID: shared.NewID().Value(),
+	}, nil
+}
+
+func Restore(
+	id shared.ID,
+	uID shared.ID,
+	amount Amount,
+	cID shared.ID,
+	note string,
+	occurredAt time.Time,
+	fingerprint string,
+	fiscalID receipt.FiscalID,
+	createdAt time.Time,
+	splits ...Split,
+) *Transaction {
 	return &Transaction{
-		baseAggregate: ddd.NewBaseAggregate(shared.NewID()),
+		baseAggregate: ddd.NewBaseAggregate(id),
 		userID:        uID,
 		amount:        amount,
 		categoryID:    cID,
-		createdAt:     time.Now(),
-	}, nil
+		note:          note,
+		occurredAt:    occurredAt,
+		fingerprint:   fingerprint,
+		fiscalID:      fiscalID,
+		createdAt:     createdAt,
+		splits:        splits,
+	}
+}
+
+// SetAmount исправляет сумму транзакции. Сумму разделенной транзакции менять нельзя,
+// пока не отменено разделение: иначе части перестанут сходиться с суммой.
+func (t *Transaction) SetAmount(amount Amount) error {
+	if amount.Value().IsZero() {
+		return ErrInvalidAmount
+	}
+
+	if t.IsSplit() {
+		return ErrSplit
+	}
+
+	t.amount = amount
+
+	return nil
+}
+
+// SetCategoryID переносит транзакцию в другую категорию. Разделенную транзакцию перенести нельзя.
+func (t *Transaction) SetCategoryID(cID shared.ID) error {
+	if cID.IsZero() {
+		return fmt.Errorf("%w: %s", ErrInvalidCategoryID, cID)
+	}
+
+	if t.IsSplit() {
+		return ErrSplit
+	}
+
+	t.categoryID = cID
+
+	return nil
+}
+
+// SetNote задает произвольный комментарий к транзакции, пустая строка удаляет комментарий.
+func (t *Transaction) SetNote(note string) error {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxNoteLength {
+		return fmt.Errorf("%w: max %d characters", ErrTooLongNote, MaxNoteLength)
+	}
+
+	t.note = note
+
+	return nil
+}
+
+// SetOccurredAt задает время совершения операции, если оно отличается от времени записи,
+// например для операций из банковской выписки.
+func (t *Transaction) SetOccurredAt(occurredAt time.Time) error {
+	if occurredAt.IsZero() || occurredAt.After(t.createdAt.Add(24*time.Hour)) {
+		return fmt.Errorf("%w: %s", ErrInvalidOccurredAt, occurredAt)
+	}
+
+	t.occurredAt = occurredAt
+
+	return nil
+}
+
+// SplitInto делит транзакцию на части по категориям. Частей должно быть от двух до MaxSplitParts,
+// их категории не должны повторяться, а суммы должны в точности давать сумму транзакции.
+// Категорией транзакции становится категория первой части.
+func (t *Transaction) SplitInto(parts []Split) error {
+	if err := validateSplits(parts, t.amount); err != nil {
+		return err
+	}
+
+	t.splits = append([]Split(nil), parts...)
+	t.categoryID = parts[0].categoryID
+
+	return nil
+}
+
+// Unsplit отменяет разделение: транзакция целиком относится к категории первой части.
+func (t *Transaction) Unsplit() {
+	t.splits = nil
+}
+
+// SetFingerprint задает отпечаток источника транзакции, по которому отсекаются повторные загрузки
+// одной и той же операции.
+func (t *Transaction) SetFingerprint(fingerprint string) {
+	t.fingerprint = fingerprint
+}
+
+// SetFiscalID задает фискальные реквизиты чека, по которому записана транзакция.
+// Один чек нельзя записать дважды.
+func (t *Transaction) SetFiscalID(fiscalID receipt.FiscalID) {
+	t.fiscalID = fiscalID
+}
+
+func (t Transaction) Note() string {
+	return t.note
+}
+
+// OccurredAt возвращает время совершения операции, по которому транзакция попадает в отчеты.
+func (t Transaction) OccurredAt() time.Time {
+	return t.occurredAt
+}
+
+func (t Transaction) Fingerprint() string {
+	return t.fingerprint
+}
+
+// FiscalID возвращает реквизиты чека или нулевое значение, если транзакция записана не по чеку.
+func (t Transaction) FiscalID() receipt.FiscalID {
+	return t.fiscalID
 }
 
 func (t Transaction) CreatedAt() time.Time {
 	return t.createdAt
 }
 
+// Splits возвращает части разделенной транзакции в порядке ввода или nil, если транзакция не разделена.
+func (t Transaction) Splits() []Split {
+	if len(t.splits) == 0 {
+		return nil
+	}
+
+	return append([]Split(nil), t.splits...)
+}
+
+func (t Transaction) IsSplit() bool {
+	return len(t.splits) > 0
+}
+
 func (t Transaction) CategoryID() shared.ID {
 	return t.categoryID
 }
@@ -67,3 +235,31 @@ func (t Transaction) Equals(other *Transaction) bool {
 
 	return t.baseAggregate.Equal(other.baseAggregate)
 }
+
+func (t *Transaction) ClearDomainEvents() {
+	t.createdEventID = uuid.Nil
+	t.baseAggregate.ClearDomainEvents()
+}
+
+// GetDomainEvents возвращает события транзакции. Событие создания новой транзакции идет первым
+// и отражает ее состояние на момент вызова.
+func (t *Transaction) GetDomainEvents() []ddd.DomainEvent {
+	if t.createdEventID == uuid.Nil {
+		return t.baseAggregate.GetDomainEvents()
+	}
+
+	created := TransactionCreated{
+		ID:            t.createdEventID,
+		TransactionID: t.ID(),
+		UserID:        t.userID,
+		CategoryID:    t.categoryID,
+		Amount:        t.amount.Value(),
+		OccurredAt:    t.occurredAt,
+	}
+
+	return append([]ddd.DomainEvent{created}, t.baseAggregate.GetDomainEvents()...)
+}
+
+func (t *Transaction) RaiseDomainEvent(event ddd.DomainEvent) {
+	t.baseAggregate.RaiseDomainEvent(event)
+}
@@ -1,12 +1,15 @@
 package transaction_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	transaction2 "github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 )
@@ -173,3 +176,113 @@ func TestTransaction_Equals(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_SetNote(t *testing.T) {
+	tx, err := transaction2.New(shared.NewID(), transaction2.Amount{}, shared.NewID())
+	require.NoError(t, err)
+
+	require.NoError(t, tx.SetNote("  кофе с собой "))
+	assert.Equal(t, "кофе с собой", tx.Note())
+
+	require.NoError(t, tx.SetNote(strings.Repeat("я", transaction2.MaxNoteLength)))
+
+	err = tx.SetNote(strings.Repeat("я", transaction2.MaxNoteLength+1))
+	require.ErrorIs(t, err, transaction2.ErrTooLongNote)
+	assert.Equal(t, strings.Repeat("я", transaction2.MaxNoteLength), tx.Note())
+}
+
+func TestTransaction_SetOccurredAt(t *testing.T) {
+	tx, err := transaction2.New(shared.NewID(), transaction2.Amount{}, shared.NewID())
+	require.NoError(t, err)
+	assert.Equal(t, tx.CreatedAt(), tx.OccurredAt())
+
+	occurredAt := time.Date(2026, 1, 15, 12, 30, 0, 0, time.UTC)
+	require.NoError(t, tx.SetOccurredAt(occurredAt))
+	assert.Equal(t, occurredAt, tx.OccurredAt())
+
+	require.ErrorIs(t, tx.SetOccurredAt(time.Time{}), transaction2.ErrInvalidOccurredAt)
+	require.ErrorIs(t, tx.SetOccurredAt(time.Now().Add(48*time.Hour)), transaction2.ErrInvalidOccurredAt)
+	assert.Equal(t, occurredAt, tx.OccurredAt())
+}
+
+func TestTransaction_SetAmount(t *testing.T) {
+	tx, err := transaction2.New(shared.NewID(), transaction2.Amount{}, shared.NewID())
+	require.NoError(t, err)
+
+	amount, err := transaction2.NewAmountFromString("420.50")
+	require.NoError(t, err)
+
+	require.NoError(t, tx.SetAmount(amount))
+	assert.Equal(t, amount, tx.Amount())
+
+	require.ErrorIs(t, tx.SetAmount(transaction2.Amount{}), transaction2.ErrInvalidAmount)
+	assert.Equal(t, amount, tx.Amount())
+}
+
+func TestTransaction_SetCategoryID(t *testing.T) {
+	tx, err := transaction2.New(shared.NewID(), transaction2.Amount{}, shared.NewID())
+	require.NoError(t, err)
+
+	categoryID := shared.NewID()
+	require.NoError(t, tx.SetCategoryID(categoryID))
+	assert.Equal(t, categoryID, tx.CategoryID())
+
+	require.ErrorIs(t, tx.SetCategoryID(shared.ID{}), transaction2.ErrInvalidCategoryID)
+	assert.Equal(t, categoryID, tx.CategoryID())
+}
+
+func TestNewTransaction_RaisesTransactionCreated(t *testing.T) {
+	userID := shared.NewID()
+	categoryID := shared.NewID()
+
+	amount, err := transaction2.NewAmount(decimal.NewFromInt(1500))
+	require.NoError(t, err)
+
+	tx, err := transaction2.New(userID, amount, categoryID)
+	require.NoError(t, err)
+
+	require.Len(t, tx.GetDomainEvents(), 1)
+
+	event, ok := tx.GetDomainEvents()[0].(transaction2.TransactionCreated)
+	require.True(t, ok)
+	assert.Equal(t, tx.ID(), event.TransactionID)
+	assert.Equal(t, userID, event.UserID)
+	assert.Equal(t, categoryID, event.CategoryID)
+	assert.True(t, amount.Value().Equal(event.Amount))
+}
+
+func TestNewTransaction_TransactionCreatedReflectsBackdating(t *testing.T) {
+	amount, err := transaction2.NewAmount(decimal.NewFromInt(1500))
+	require.NoError(t, err)
+
+	tx, err := transaction2.New(shared.NewID(), amount, shared.NewID())
+	require.NoError(t, err)
+
+	before := tx.GetDomainEvents()[0].(transaction2.TransactionCreated)
+
+	// Операции из выписок, чеков и записанные задним числом получают время после создания.
+	occurredAt := time.Date(2026, 3, 15, 14, 30, 0, 0, time.UTC)
+	require.NoError(t, tx.SetOccurredAt(occurredAt))
+
+	categoryID := shared.NewID()
+	require.NoError(t, tx.SetCategoryID(categoryID))
+
+	require.Len(t, tx.GetDomainEvents(), 1)
+
+	event, ok := tx.GetDomainEvents()[0].(transaction2.TransactionCreated)
+	require.True(t, ok)
+	assert.Equal(t, before.ID, event.ID)
+	assert.True(t, occurredAt.Equal(event.OccurredAt))
+	assert.Equal(t, categoryID, event.CategoryID)
+
+	tx.ClearDomainEvents()
+	assert.Empty(t, tx.GetDomainEvents())
+}
+
+func TestRestoreTransaction_RaisesNoTransactionCreated(t *testing.T) {
+	amount, err := transaction2.NewAmount(decimal.NewFromInt(1500))
+	require.NoError(t, err)
+
+	tx := transaction2.Restore(shared.NewID(), shared.NewID(), amount, shared.NewID(), "", time.Now(), "", receipt.FiscalID{}, time.Now())
+	assert.Empty(t, tx.GetDomainEvents())
+}
@@ -0,0 +1,27 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// UserRegistered событие регистрации нового пользователя.
+type UserRegistered struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     shared.ID `json:"user_id"`
+	Name       string    `json:"name"`
+	Provider   Provider  `json:"provider"`
+	ChatID     string    `json:"chat_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e UserRegistered) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e UserRegistered) GetName() string {
+	return "UserRegistered"
+}
@@ -55,3 +55,19 @@ func NewExternalIdentity(userID shared.ID, provider Provider, externalID string)
 
 	return &ei, nil
 }
+
+func RestoreExternalIdentity(
+	id shared.ID,
+	userID shared.ID,
+	provider Provider,
+	externalID string,
+	createdAt time.Time,
+) *ExternalIdentity {
+	return &ExternalIdentity{
+		baseAggregate: ddd.NewBaseEntity(id),
+		userID:        userID,
+		provider:      provider,
+		externalID:    externalID,
+		createdAt:     createdAt,
+	}
+}
@@ -41,6 +41,15 @@ func New(name string, chatID string, provider Provider) (*User, error) {
 		return nil, err
 	}
 
+	u.RaiseDomainEvent(UserRegistered{
+		ID:         shared.NewID().Value(),
+		UserID:     u.ID(),
+		Name:       u.name,
+		Provider:   provider,
+		ChatID:     chatID,
+		OccurredAt: u.createdAt,
+	})
+
 	return &u, nil
 }
 
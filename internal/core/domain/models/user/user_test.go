@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -209,3 +210,23 @@ func TestUser_Restore(t *testing.T) {
 	assert.Equal(t, createdAt, u.CreatedAt())
 	assert.Nil(t, u.GetExternalIdentity()) // Restored user should have no external identity initially
 }
+
+func TestNewUser_RaisesUserRegistered(t *testing.T) {
+	u, err := user.New("Alice", "42", user.ProviderTelegram)
+	require.NoError(t, err)
+
+	require.Len(t, u.GetDomainEvents(), 1)
+
+	event, ok := u.GetDomainEvents()[0].(user.UserRegistered)
+	require.True(t, ok)
+	assert.NotEqual(t, uuid.Nil, event.GetID())
+	assert.Equal(t, u.ID(), event.UserID)
+	assert.Equal(t, "Alice", event.Name)
+	assert.Equal(t, user.ProviderTelegram, event.Provider)
+	assert.Equal(t, "42", event.ChatID)
+
+	u.ClearDomainEvents()
+	assert.Empty(t, u.GetDomainEvents())
+
+	assert.Empty(t, user.Restore(shared.NewID(), "Alice", time.Now()).GetDomainEvents())
+}
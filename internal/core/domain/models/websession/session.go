@@ -0,0 +1,160 @@
+// Package websession описывает сеансы веб-панели. Сеанс начинается с одноразовой ссылки для входа,
+// которую выдает бот. При входе секрет ссылки заменяется секретом сеанса, поэтому ссылка
+// срабатывает только один раз. Хранятся только хеши секретов.
+package websession
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/errs"
+)
+
+const (
+	// LinkTTL время, в течение которого действует ссылка для входа.
+	LinkTTL = 10 * time.Minute
+
+	// SessionTTL время жизни сеанса после входа.
+	SessionTTL = 7 * 24 * time.Hour
+
+	secretBytes = 32
+)
+
+var (
+	ErrLinkUsed    = errors.New("login link is already used")
+	ErrLinkExpired = errors.New("login link is expired")
+
+	// ErrInvalidSession сеанс неизвестен, истек или вход по ссылке еще не выполнен.
+	ErrInvalidSession = errors.New("invalid web session")
+)
+
+// Session сеанс веб-панели пользователя.
+type Session struct {
+	baseAggregate *ddd.BaseAggregate[shared.ID]
+	userID        shared.ID
+	hash          []byte
+	activatedAt   time.Time
+	expiresAt     time.Time
+	createdAt     time.Time
+}
+
+// New создает сеанс, ожидающий входа по ссылке, и возвращает его вместе с секретом ссылки.
+func New(userID shared.ID, now time.Time) (*Session, string, error) {
+	if userID.IsZero() {
+		return nil, "", errs.NewValueIsRequiredError("userID")
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &Session{
+		baseAggregate: ddd.NewBaseAggregate(shared.NewID()),
+		userID:        userID,
+		hash:          Hash(secret),
+		expiresAt:     now.Add(LinkTTL),
+		createdAt:     now,
+	}, secret, nil
+}
+
+func Restore(
+	id shared.ID,
+	userID shared.ID,
+	hash []byte,
+	activatedAt time.Time,
+	expiresAt time.Time,
+	createdAt time.Time,
+) *Session {
+	return &Session{
+		baseAggregate: ddd.NewBaseAggregate(id),
+		userID:        userID,
+		hash:          hash,
+		activatedAt:   activatedAt,
+		expiresAt:     expiresAt,
+		createdAt:     createdAt,
+	}
+}
+
+// Hash возвращает хеш секрета, по которому сеанс хранится и ищется.
+func Hash(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+
+	return sum[:]
+}
+
+// Activate выполняет вход по ссылке: заменяет секрет ссылки секретом сеанса и продлевает
+// сеанс на SessionTTL. Возвращает секрет сеанса, который нужно сохранить в cookie.
+func (s *Session) Activate(now time.Time) (string, error) {
+	if s.IsActivated() {
+		return "", ErrLinkUsed
+	}
+
+	if s.IsExpired(now) {
+		return "", ErrLinkExpired
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+
+	s.hash = Hash(secret)
+	s.activatedAt = now
+	s.expiresAt = now.Add(SessionTTL)
+
+	return secret, nil
+}
+
+// IsActive сообщает, что вход выполнен и сеанс не истек к моменту now.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.IsActivated() && !s.IsExpired(now)
+}
+
+func (s *Session) IsActivated() bool {
+	return !s.activatedAt.IsZero()
+}
+
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.expiresAt)
+}
+
+func (s *Session) ID() shared.ID {
+	return s.baseAggregate.ID()
+}
+
+func (s *Session) UserID() shared.ID {
+	return s.userID
+}
+
+func (s *Session) Hash() []byte {
+	return s.hash
+}
+
+// ActivatedAt возвращает момент входа или нулевое время, если по ссылке еще не входили.
+func (s *Session) ActivatedAt() time.Time {
+	return s.activatedAt
+}
+
+// ExpiresAt возвращает момент, когда истекает ссылка для входа, а после входа — сам сеанс.
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func newSecret() (string, error) {
+	raw := make([]byte, secretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
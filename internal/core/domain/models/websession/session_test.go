@@ -0,0 +1,74 @@
+package websession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+)
+
+func TestNew(t *testing.T) {
+	userID := shared.NewID()
+	now := time.Now()
+
+	s, secret, err := websession.New(userID, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, userID, s.UserID())
+	assert.Equal(t, websession.Hash(secret), s.Hash())
+	assert.Equal(t, now.Add(websession.LinkTTL), s.ExpiresAt())
+	assert.False(t, s.IsActivated())
+	assert.False(t, s.IsActive(now))
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, _, err := websession.New(shared.ID{}, time.Now())
+	require.Error(t, err)
+}
+
+func TestSession_Activate(t *testing.T) {
+	now := time.Now()
+
+	s, linkSecret, err := websession.New(shared.NewID(), now)
+	require.NoError(t, err)
+
+	loginAt := now.Add(time.Minute)
+
+	secret, err := s.Activate(loginAt)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, linkSecret, secret)
+	assert.Equal(t, websession.Hash(secret), s.Hash())
+	assert.Equal(t, loginAt, s.ActivatedAt())
+	assert.Equal(t, loginAt.Add(websession.SessionTTL), s.ExpiresAt())
+	assert.True(t, s.IsActive(loginAt))
+	assert.False(t, s.IsActive(loginAt.Add(websession.SessionTTL)))
+}
+
+func TestSession_Activate_Once(t *testing.T) {
+	now := time.Now()
+
+	s, _, err := websession.New(shared.NewID(), now)
+	require.NoError(t, err)
+
+	_, err = s.Activate(now)
+	require.NoError(t, err)
+
+	_, err = s.Activate(now)
+	require.ErrorIs(t, err, websession.ErrLinkUsed)
+}
+
+func TestSession_Activate_Expired(t *testing.T) {
+	now := time.Now()
+
+	s, _, err := websession.New(shared.NewID(), now)
+	require.NoError(t, err)
+
+	_, err = s.Activate(now.Add(websession.LinkTTL))
+	require.ErrorIs(t, err, websession.ErrLinkExpired)
+	assert.False(t, s.IsActivated())
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/apitoken"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// APITokenRepository определяет контракт хранилища персональных токенов доступа к HTTP API.
+type APITokenRepository interface {
+	Add(ctx context.Context, t *apitoken.Token) error
+
+	// Save сохраняет изменения токена: отзыв.
+	Save(ctx context.Context, t *apitoken.Token) error
+
+	// Get возвращает токен пользователя. Возвращает errs.ErrObjectNotFound, если у пользователя нет такого токена.
+	Get(ctx context.Context, userID shared.ID, id shared.ID) (*apitoken.Token, error)
+
+	// GetByHash возвращает токен по хешу секрета. Возвращает errs.ErrObjectNotFound, если такого токена нет.
+	GetByHash(ctx context.Context, hash []byte) (*apitoken.Token, error)
+
+	// FindByUserID возвращает неотозванные токены пользователя, включая истекшие, от старых к новым.
+	FindByUserID(ctx context.Context, userID shared.ID) ([]*apitoken.Token, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/attachment"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// AttachmentRepository определяет контракт хранилища вложений транзакций.
+type AttachmentRepository interface {
+	// Add добавляет вложение в хранилище.
+	Add(ctx context.Context, attachment *attachment.Attachment) error
+
+	// FindByTransactionID возвращает вложения транзакции в порядке добавления.
+	// Внутри транзакции UnitOfWork читает в её рамках, чтобы проверка и вставка были согласованы.
+	FindByTransactionID(ctx context.Context, transactionID shared.ID) ([]*attachment.Attachment, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"io"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/backup"
+)
+
+// BackupCodec определяет контракт записи и чтения резервной копии данных пользователя.
+type BackupCodec interface {
+	// Encode записывает снимок в w.
+	Encode(w io.Writer, snapshot *backup.Snapshot) error
+
+	// Decode читает снимок из r. Прочитанный снимок не проверяется, его нужно проверить методом Validate.
+	Decode(r io.Reader) (*backup.Snapshot, error)
+}
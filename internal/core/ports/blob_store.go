@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore определяет контракт хранилища копий файлов, например вложений транзакций.
+type BlobStore interface {
+	// Put сохраняет содержимое r под ключом key, заменяя прежнее содержимое.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Delete удаляет содержимое по ключу key. Отсутствие ключа не считается ошибкой.
+	Delete(ctx context.Context, key string) error
+}
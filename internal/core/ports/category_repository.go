@@ -12,4 +12,12 @@ type CategoryRepository interface {
 	GetIncomeByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error)
 	GetExpenseByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error)
 	HasCategoriesByUserID(ctx context.Context, userID shared.ID) (bool, error)
+
+	// GetAllByUserID возвращает все категории пользователя, включая родительские категории расходов.
+	GetAllByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error)
+
+	// Get возвращает категорию пользователя или errs.ErrObjectNotFound, если у пользователя ее нет.
+	Get(ctx context.Context, userID shared.ID, id shared.ID) (*category.Category, error)
+	// Save сохраняет изменения категории.
+	Save(ctx context.Context, category *category.Category) error
 }
@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+
+// EventCodec определяет контракт сериализации доменных событий для хранения в outbox.
+type EventCodec interface {
+	// Encode сериализует событие.
+	Encode(event ddd.DomainEvent) ([]byte, error)
+
+	// Decode восстанавливает событие по имени и сериализованному содержимому.
+	// Для неизвестного имени возвращает ошибку.
+	Decode(name string, payload []byte) (ddd.DomainEvent, error)
+}
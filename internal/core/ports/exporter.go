@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"io"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
+)
+
+// Exporter определяет контракт выгрузки транзакций в файл определенного формата.
+type Exporter interface {
+	// Format возвращает формат файла, который формирует выгрузка.
+	Format() report.ExportFormat
+
+	// NewWriter начинает запись выгрузки в w. Даты выводятся в часовом поясе loc.
+	NewWriter(w io.Writer, loc *time.Location) (ExportWriter, error)
+}
+
+// ExportWriter записывает транзакции в файл выгрузки по одной.
+type ExportWriter interface {
+	// Write добавляет транзакцию в выгрузку.
+	Write(line report.TransactionLine) error
+
+	// Close завершает выгрузку и дописывает в w оставшиеся данные.
+	Close() error
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/job"
+)
+
+// JobRepository определяет контракт хранилища фоновых задач планировщика.
+type JobRepository interface {
+	// Enqueue сохраняет новую задачу.
+	// Задача с уже существующим ключом повторно не добавляется.
+	Enqueue(ctx context.Context, job *job.Job) error
+
+	// ClaimDue захватывает до limit задач, время запуска которых наступило.
+	// Захваченные задачи переводятся в статус running и блокируются за workerID до lockedUntil.
+	// Задачи, уже захваченные другими экземплярами, пропускаются.
+	ClaimDue(ctx context.Context, workerID string, now time.Time, lockedUntil time.Time, limit int) ([]*job.Job, error)
+
+	// Save сохраняет состояние задачи после выполнения и снимает блокировку workerID.
+	// Если задача больше не заблокирована за workerID, возвращает job.ErrLeaseLost
+	// и ничего не меняет: результат выполнения принадлежит тому, кто захватил задачу последним.
+	Save(ctx context.Context, workerID string, job *job.Job) error
+
+	// ReleaseExpired возвращает в очередь задачи, блокировка которых истекла до now,
+	// например, после аварийной остановки экземпляра. Возвращает количество таких задач.
+	ReleaseExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// LeaderElector определяет контракт выбора ведущего экземпляра среди нескольких реплик бота.
+type LeaderElector interface {
+	// TryAcquire пытается получить или подтвердить лидерство.
+	// Возвращает true, пока текущий экземпляр остается ведущим.
+	TryAcquire(ctx context.Context) (bool, error)
+
+	// Release добровольно отказывается от лидерства.
+	Release(ctx context.Context) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import "time"
+
+// Metrics определяет контракт учета метрик работы бота для мониторинга.
+type Metrics interface {
+	// ObserveUpdate учитывает обработанное обновление Telegram типа updateType и время его обработки.
+	ObserveUpdate(updateType string, duration time.Duration)
+
+	// IncError учитывает ошибку обработки вида kind, например not_found или panic.
+	IncError(kind string)
+
+	// ObserveTelegramRequest учитывает запрос method к Telegram Bot API и время ответа.
+	ObserveTelegramRequest(method string, duration time.Duration, failed bool)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
+)
+
+// Notification сообщение, которое бот отправляет пользователю по собственной инициативе.
+type Notification struct {
+	Text string
+
+	// QuickAmounts суммы расхода, которые пользователь может записать одним нажатием.
+	QuickAmounts []transaction.Amount
+}
+
+// Notifier определяет контракт отправки уведомлений пользователю через внешний канал.
+type Notifier interface {
+	// Notify отправляет уведомление получателю, определяемому внешней учетной записью пользователя.
+	Notify(ctx context.Context, recipient *user.ExternalIdentity, notification Notification) error
+}
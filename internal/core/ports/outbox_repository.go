@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/outbox"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// OutboxRepository определяет контракт хранилища доменных событий, ожидающих доставки.
+type OutboxRepository interface {
+	// Add сохраняет сообщение в текущей транзакции, вместе с изменениями, породившими событие.
+	Add(ctx context.Context, message *outbox.Message) error
+
+	// ClaimDue захватывает до limit сообщений, время доставки которых наступило, в порядке их создания.
+	// Каждому захваченному сообщению засчитывается попытка доставки, а следующая попытка откладывается
+	// до lockedUntil: если экземпляр остановится, не сохранив результат, сообщение будет доставлено повторно.
+	// Сообщения, уже захваченные другими экземплярами, пропускаются. Захват помечается токеном claim,
+	// без которого результат доставки сохранить нельзя.
+	ClaimDue(ctx context.Context, claim shared.ID, now time.Time, lockedUntil time.Time, limit int) ([]*outbox.Message, error)
+
+	// Save сохраняет результат доставки сообщения, захваченного с токеном claim, и снимает захват.
+	// Возвращает outbox.ErrLeaseLost, если захват истек и сообщение захвачено повторно или уже сохранено.
+	Save(ctx context.Context, claim shared.ID, message *outbox.Message) error
+
+	// DeleteFinished удаляет сообщения в состоянии status, которые последний раз изменялись раньше before,
+	// и возвращает количество удаленных.
+	DeleteFinished(ctx context.Context, status outbox.Status, before time.Time) (int64, error)
+}
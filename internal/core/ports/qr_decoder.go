@@ -0,0 +1,10 @@
+package ports
+
+import "io"
+
+// QRDecoder определяет контракт распознавания QR-кода на изображении.
+type QRDecoder interface {
+	// Decode находит QR-код на изображении из r и возвращает его содержимое.
+	// Если кода на изображении нет, возвращает receipt.ErrCodeNotFound.
+	Decode(r io.Reader) (string, error)
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/refund"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// RefundRepository определяет контракт хранилища возвратов по расходам.
+type RefundRepository interface {
+	// Add добавляет возврат в хранилище.
+	Add(ctx context.Context, refund *refund.Refund) error
+
+	// FindByTransactionID возвращает возвраты по транзакции в порядке времени возврата.
+	// Внутри транзакции UnitOfWork читает в её рамках, чтобы проверка остатка и вставка были согласованы.
+	FindByTransactionID(ctx context.Context, transactionID shared.ID) ([]*refund.Refund, error)
+
+	// FindByUserID возвращает все возвраты пользователя в порядке времени возврата.
+	FindByUserID(ctx context.Context, userID shared.ID) ([]*refund.Refund, error)
+}
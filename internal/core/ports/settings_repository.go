@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/settings"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+)
+
+// SettingsRepository определяет контракт хранилища пользовательских настроек.
+type SettingsRepository interface {
+	// Get возвращает настройки пользователя.
+	// Если пользователь еще не менял настройки, возвращаются настройки по умолчанию.
+	Get(ctx context.Context, userID shared.ID) (*settings.Settings, error)
+
+	// Save создает или обновляет настройки пользователя.
+	Save(ctx context.Context, settings *settings.Settings) error
+
+	// FindDigestSubscribers возвращает настройки пользователей с включенной сводкой.
+	FindDigestSubscribers(ctx context.Context) ([]*settings.Settings, error)
+
+	// FindReminderSubscribers возвращает настройки пользователей с включенными напоминаниями о бездействии.
+	FindReminderSubscribers(ctx context.Context) ([]*settings.Settings, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"io"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/statement"
+)
+
+// StatementReader определяет контракт чтения банковской выписки из файла определенного формата.
+type StatementReader interface {
+	// Format возвращает формат файла, который читает reader.
+	Format() statement.FileFormat
+
+	// Read читает выписку из r целиком и возвращает её заголовок и строки.
+	Read(r io.Reader) (statement.Table, error)
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/tag"
+)
+
+// TagRepository определяет контракт хранилища меток и их связей с транзакциями.
+type TagRepository interface {
+	// Ensure сохраняет метки, которых у пользователя еще нет, и возвращает сохраненные метки
+	// с теми же названиями. Уже существующие метки сохраняют свои идентификаторы.
+	Ensure(ctx context.Context, userID shared.ID, tags []*tag.Tag) ([]*tag.Tag, error)
+
+	// Link связывает транзакцию с метками.
+	Link(ctx context.Context, transactionID shared.ID, tagIDs []shared.ID) error
+
+	// FindByUserID возвращает метки пользователя в алфавитном порядке.
+	FindByUserID(ctx context.Context, userID shared.ID) ([]*tag.Tag, error)
+
+	// FindByTransactionID возвращает метки транзакции в алфавитном порядке.
+	FindByTransactionID(ctx context.Context, transactionID shared.ID) ([]*tag.Tag, error)
+
+	// FindLinksByUserID возвращает связи меток пользователя с транзакциями.
+	FindLinksByUserID(ctx context.Context, userID shared.ID) ([]tag.Link, error)
+
+	// GetByName возвращает метку пользователя по названию без знака #.
+	// Возвращает errs.ErrObjectNotFound, если такой метки нет.
+	GetByName(ctx context.Context, userID shared.ID, name string) (*tag.Tag, error)
+}
@@ -4,7 +4,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 )
@@ -12,19 +15,86 @@ import (
 // TransactionRepository определяет контракт для работы с хранилищем транзакций.
 // Предоставляет методы для добавления, получения, обновления и удаления транзакций.
 type TransactionRepository interface {
-	// Add добавляет новую транзакцию в хранилище.
-	// Возвращает ошибку, если не удалось добавить транзакцию.
+	// Add добавляет новую транзакцию в хранилище вместе с частями, если транзакция разделена.
+	// Возвращает receipt.ErrAlreadyRecorded, если чек уже записан пользователем, и
+	// statement.ErrAlreadyImported, если операция выписки уже импортирована.
 	Add(ctx context.Context, transaction *transaction.Transaction) error
 
 	// Get возвращает транзакцию по её идентификатору.
-	// Возвращает nil, если транзакция не найдена.
+	// Возвращает errs.ErrObjectNotFound, если транзакция не найдена. Внутри транзакции UnitOfWork читает в её рамках
+	// и блокирует строку до её завершения, поэтому проверки сумм возвратов и частей не обгоняют друг друга.
 	Get(ctx context.Context, id shared.ID) (*transaction.Transaction, error)
 
-	// Update обновляет существующую транзакцию в хранилище.
+	// GetLine возвращает транзакцию пользователя userID вместе с данными категории.
+	// Возвращает errs.ErrObjectNotFound, если у пользователя нет такой транзакции.
+	GetLine(ctx context.Context, userID shared.ID, id shared.ID) (report.TransactionLine, error)
+
+	// Update обновляет существующую транзакцию в хранилище и заменяет её части.
 	// Возвращает ошибку, если транзакция не найдена или произошла ошибка при обновлении.
 	Update(ctx context.Context, transaction *transaction.Transaction) error
 
+	// FindParts возвращает части разделенной транзакции с данными категорий в порядке ввода.
+	// Для неразделенной транзакции возвращает пустой список.
+	FindParts(ctx context.Context, transactionID shared.ID) ([]report.CategoryTotal, error)
+
 	// Delete удаляет транзакцию с указанным идентификатором.
 	// Возвращает ошибку, если транзакция не найдена или произошла ошибка при удалении.
 	Delete(ctx context.Context, id shared.ID) error
+
+	// GetTotalsByCategory возвращает суммы транзакций пользователя по категориям
+	// за период [from, to) по времени совершения операции. Части разделенных транзакций учитываются
+	// в своих категориях, возвраты за период уменьшают сумму категории. Категории без транзакций не возвращаются.
+	GetTotalsByCategory(ctx context.Context, userID shared.ID, from time.Time, to time.Time) ([]report.CategoryTotal, error)
+
+	// GetLastCreatedAt возвращает время создания последней транзакции пользователя.
+	// Возвращает нулевое время, если у пользователя нет транзакций.
+	GetLastCreatedAt(ctx context.Context, userID shared.ID) (time.Time, error)
+
+	// GetTotalsByTag возвращает суммы транзакций пользователя с меткой tagID по категориям за все время.
+	// Части разделенных транзакций учитываются в своих категориях, возвраты уменьшают сумму категории.
+	GetTotalsByTag(ctx context.Context, userID shared.ID, tagID shared.ID) ([]report.CategoryTotal, error)
+
+	// StreamLines последовательно передает в fn транзакции пользователя за период [from, to)
+	// в порядке совершения операций, не загружая их в память целиком. Нулевые границы не ограничивают период,
+	// нулевой tagID не ограничивает выборку по меткам. Разделенная транзакция передается строкой
+	// на каждую часть с общим идентификатором, возврат — отдельной строкой с отрицательной суммой.
+	// Ошибка, возвращенная fn, прерывает чтение и возвращается вызывающему.
+	StreamLines(
+		ctx context.Context,
+		userID shared.ID,
+		from time.Time,
+		to time.Time,
+		tagID shared.ID,
+		fn func(line report.TransactionLine) error,
+	) error
+
+	// FindHistory возвращает не больше limit строк транзакций пользователя, отобранных фильтром,
+	// от новых к старым. Выборка начинается сразу после позиции after; нулевая позиция — начало истории.
+	// Разделенная транзакция подходит под фильтр по категории, если под него подходит любая её часть.
+	FindHistory(
+		ctx context.Context,
+		userID shared.ID,
+		filter report.HistoryFilter,
+		after report.HistoryCursor,
+		limit int,
+	) ([]report.TransactionLine, error)
+
+	// Search ищет транзакции пользователя по словам из комментария и названия категории с учетом
+	// русской морфологии. Возвращает не больше limit строк от новых к старым и итоги по всем совпадениям.
+	Search(ctx context.Context, userID shared.ID, text string, limit int) (*report.SearchResult, error)
+
+	// FindFingerprints возвращает те из отпечатков fingerprints, с которыми у пользователя уже есть транзакции.
+	// Внутри транзакции UnitOfWork читает в её рамках, чтобы проверка и вставка были согласованы.
+	FindFingerprints(ctx context.Context, userID shared.ID, fingerprints []string) ([]string, error)
+
+	// HasFiscalID сообщает, записана ли у пользователя транзакция по чеку с реквизитами fiscalID.
+	// Внутри транзакции UnitOfWork читает в её рамках.
+	HasFiscalID(ctx context.Context, userID shared.ID, fiscalID receipt.FiscalID) (bool, error)
+
+	// FindLinesByFiscalDrive возвращает не больше limit транзакций пользователя, записанных по чекам
+	// фискального накопителя fiscalDrive, от новых к старым. По ним ищется покупка, которую возвращает чек возврата.
+	FindLinesByFiscalDrive(ctx context.Context, userID shared.ID, fiscalDrive string, limit int) ([]report.TransactionLine, error)
+
+	// FindByUserID возвращает все транзакции пользователя в порядке совершения операций.
+	FindByUserID(ctx context.Context, userID shared.ID) ([]*transaction.Transaction, error)
 }
@@ -10,6 +10,14 @@ type UnitOfWork interface {
 	UserRepository() UserRepository
 	CategoryRepository() CategoryRepository
 	TransactionRepository() TransactionRepository
+	JobRepository() JobRepository
+	SettingsRepository() SettingsRepository
+	AttachmentRepository() AttachmentRepository
+	TagRepository() TagRepository
+	RefundRepository() RefundRepository
+	OutboxRepository() OutboxRepository
+	APITokenRepository() APITokenRepository
+	WebSessionRepository() WebSessionRepository
 
 	RollbackUnlessCommitted() error
 
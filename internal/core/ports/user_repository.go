@@ -3,10 +3,18 @@ package ports
 import (
 	"context"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *user.User) error
 	FindByExternalProvider(ctx context.Context, provider user.Provider, externalID string) (*user.User, error)
+	GetExternalIdentity(ctx context.Context, userID shared.ID, provider user.Provider) (*user.ExternalIdentity, error)
+
+	// Get возвращает пользователя по идентификатору.
+	Get(ctx context.Context, id shared.ID) (*user.User, error)
+
+	// FindExternalIdentities возвращает все внешние идентификаторы пользователя.
+	FindExternalIdentities(ctx context.Context, userID shared.ID) ([]*user.ExternalIdentity, error)
 }
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/websession"
+)
+
+// WebSessionRepository определяет контракт хранилища сеансов веб-панели.
+type WebSessionRepository interface {
+	Add(ctx context.Context, s *websession.Session) error
+
+	// Save сохраняет изменения сеанса: вход по ссылке.
+	Save(ctx context.Context, s *websession.Session) error
+
+	// GetByHash возвращает сеанс по хешу секрета ссылки или сеанса. Возвращает errs.ErrObjectNotFound,
+	// если такого сеанса нет. Внутри транзакции UnitOfWork блокирует строку до её завершения,
+	// поэтому два входа по одной ссылке не активируют сеанс оба.
+	GetByHash(ctx context.Context, hash []byte) (*websession.Session, error)
+
+	// Delete удаляет сеанс. Отсутствие сеанса ошибкой не считается.
+	Delete(ctx context.Context, id shared.ID) error
+
+	// DeleteExpired удаляет сеансы и неиспользованные ссылки, истекшие к моменту now.
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS jobs
+(
+    id               uuid PRIMARY KEY                     DEFAULT uuidv7(),
+    kind             text                        NOT NULL,
+    key              text,
+    payload          jsonb,
+    status           text                        NOT NULL,
+    attempts         integer                     NOT NULL DEFAULT 0,
+    max_attempts     integer                     NOT NULL,
+    interval_seconds bigint                      NOT NULL DEFAULT 0,
+    run_at           timestamp(0) with time zone NOT NULL,
+    locked_by        text,
+    locked_until     timestamp(0) with time zone,
+    last_error       text,
+    created_at       timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at       timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX ux_jobs_key
+    ON jobs (key);
+
+CREATE INDEX ix_jobs_due
+    ON jobs (run_at)
+    WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS jobs;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_settings
+(
+    user_id        uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    timezone       text                        NOT NULL DEFAULT 'Europe/Moscow',
+    digest_mode    text                        NOT NULL DEFAULT 'off',
+    digest_hour    smallint                    NOT NULL DEFAULT 9,
+    digest_sent_at timestamp(0) with time zone,
+    updated_at     timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS ix_transactions_user_created_at
+    ON transactions (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ix_transactions_user_created_at;
+DROP TABLE IF EXISTS user_settings;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS reminder_after_hours smallint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS quiet_from           smallint NOT NULL DEFAULT 22,
+    ADD COLUMN IF NOT EXISTS quiet_to             smallint NOT NULL DEFAULT 9,
+    ADD COLUMN IF NOT EXISTS reminded_at          timestamp(0) with time zone;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS reminded_at,
+    DROP COLUMN IF EXISTS quiet_to,
+    DROP COLUMN IF EXISTS quiet_from,
+    DROP COLUMN IF EXISTS reminder_after_hours;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS occurred_at timestamp(0) with time zone,
+    ADD COLUMN IF NOT EXISTS fingerprint text;
+
+UPDATE transactions
+SET occurred_at = created_at
+WHERE occurred_at IS NULL;
+
+ALTER TABLE transactions
+    ALTER COLUMN occurred_at SET NOT NULL,
+    ALTER COLUMN occurred_at SET DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS ix_transactions_user_occurred_at
+    ON transactions (user_id, occurred_at);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_transactions_user_fingerprint
+    ON transactions (user_id, fingerprint)
+    WHERE fingerprint IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ux_transactions_user_fingerprint;
+DROP INDEX IF EXISTS ix_transactions_user_occurred_at;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS fingerprint,
+    DROP COLUMN IF EXISTS occurred_at;
+-- +goose StatementEnd
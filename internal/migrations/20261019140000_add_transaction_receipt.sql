@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS fiscal_drive text,
+    ADD COLUMN IF NOT EXISTS fiscal_document text,
+    ADD COLUMN IF NOT EXISTS fiscal_sign text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_transactions_user_receipt
+    ON transactions (user_id, fiscal_drive, fiscal_document, fiscal_sign)
+    WHERE fiscal_sign IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ux_transactions_user_receipt;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS fiscal_sign,
+    DROP COLUMN IF EXISTS fiscal_document,
+    DROP COLUMN IF EXISTS fiscal_drive;
+-- +goose StatementEnd
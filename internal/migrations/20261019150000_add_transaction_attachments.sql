@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS transaction_attachments
+(
+    id             uuid PRIMARY KEY,
+    transaction_id uuid                        NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    user_id        uuid                        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind           text                        NOT NULL,
+    file_id        text                        NOT NULL,
+    file_unique_id text                        NOT NULL,
+    file_name      text                        NOT NULL DEFAULT '',
+    mime_type      text                        NOT NULL DEFAULT '',
+    size           bigint                      NOT NULL DEFAULT 0,
+    storage_key    text,
+    created_at     timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_transaction_attachments_file
+    ON transaction_attachments (transaction_id, file_unique_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS transaction_attachments;
+-- +goose StatementEnd
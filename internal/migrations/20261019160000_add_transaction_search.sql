@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS note_tsv tsvector GENERATED ALWAYS AS (to_tsvector('russian', note)) STORED;
+
+CREATE INDEX IF NOT EXISTS ix_transactions_note_tsv
+    ON transactions USING GIN (note_tsv);
+
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS name_tsv tsvector GENERATED ALWAYS AS (to_tsvector('russian', name)) STORED;
+
+CREATE INDEX IF NOT EXISTS ix_categories_name_tsv
+    ON categories USING GIN (name_tsv);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ix_categories_name_tsv;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS name_tsv;
+
+DROP INDEX IF EXISTS ix_transactions_note_tsv;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS note_tsv;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tags
+(
+    id         uuid PRIMARY KEY,
+    user_id    uuid                        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       text                        NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_tags_user_name
+    ON tags (user_id, name);
+
+CREATE TABLE IF NOT EXISTS transaction_tags
+(
+    transaction_id uuid NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    tag_id         uuid NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (transaction_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_transaction_tags_tag
+    ON transaction_tags (tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS transaction_tags;
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
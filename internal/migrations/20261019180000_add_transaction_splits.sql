@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS transaction_splits
+(
+    transaction_id uuid           NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    position       smallint       NOT NULL,
+    category_id    uuid           NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    amount         numeric(10, 2) NOT NULL,
+    PRIMARY KEY (transaction_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS ix_transaction_splits_category
+    ON transaction_splits (category_id);
+
+-- Части транзакций для отчетов: разделенная транзакция дает строку на каждую часть,
+-- неразделенная — одну строку со своей категорией и суммой.
+CREATE OR REPLACE VIEW transaction_parts AS
+SELECT t.id                                   AS transaction_id,
+       t.user_id,
+       t.occurred_at,
+       COALESCE(s.position, 0)                AS position,
+       COALESCE(s.category_id, t.category_id) AS category_id,
+       COALESCE(s.amount, t.amount)           AS amount,
+       t.note
+FROM transactions t
+         LEFT JOIN transaction_splits s ON s.transaction_id = t.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS transaction_parts;
+DROP TABLE IF EXISTS transaction_splits;
+-- +goose StatementEnd
@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS refunds
+(
+    id             uuid PRIMARY KEY,
+    user_id        uuid                        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    transaction_id uuid                        NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    category_id    uuid                        NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    amount         numeric(10, 2)              NOT NULL,
+    note           text                        NOT NULL DEFAULT '',
+    occurred_at    timestamp(0) with time zone NOT NULL,
+    created_at     timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS ix_refunds_transaction
+    ON refunds (transaction_id);
+
+CREATE INDEX IF NOT EXISTS ix_refunds_user_occurred
+    ON refunds (user_id, occurred_at);
+
+-- Возвраты попадают в отчеты отрицательной строкой в категории исходной транзакции и уменьшают расход.
+-- transaction_id указывает на исходную транзакцию, line_id — на саму строку: транзакцию или возврат.
+DROP VIEW IF EXISTS transaction_parts;
+CREATE VIEW transaction_parts AS
+SELECT t.id                                   AS transaction_id,
+       t.user_id,
+       t.occurred_at,
+       COALESCE(s.position, 0)                AS position,
+       COALESCE(s.category_id, t.category_id) AS category_id,
+       COALESCE(s.amount, t.amount)           AS amount,
+       t.note,
+       t.id                                   AS line_id
+FROM transactions t
+         LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+UNION ALL
+SELECT r.transaction_id,
+       r.user_id,
+       r.occurred_at,
+       0,
+       r.category_id,
+       -r.amount,
+       r.note,
+       r.id
+FROM refunds r;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS transaction_parts;
+CREATE VIEW transaction_parts AS
+SELECT t.id                                   AS transaction_id,
+       t.user_id,
+       t.occurred_at,
+       COALESCE(s.position, 0)                AS position,
+       COALESCE(s.category_id, t.category_id) AS category_id,
+       COALESCE(s.amount, t.amount)           AS amount,
+       t.note
+FROM transactions t
+         LEFT JOIN transaction_splits s ON s.transaction_id = t.id;
+
+DROP TABLE IF EXISTS refunds;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id              uuid PRIMARY KEY,
+    name            text                        NOT NULL,
+    payload         jsonb                       NOT NULL,
+    status          text                        NOT NULL,
+    attempts        integer                     NOT NULL DEFAULT 0,
+    max_attempts    integer                     NOT NULL,
+    next_attempt_at timestamp(0) with time zone NOT NULL,
+    last_error      text,
+    created_at      timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at      timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ix_outbox_due
+    ON outbox (next_attempt_at)
+    WHERE status = 'pending';
+
+CREATE INDEX ix_outbox_dead
+    ON outbox (updated_at)
+    WHERE status = 'dead';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
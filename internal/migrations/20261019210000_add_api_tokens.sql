@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS api_tokens
+(
+    id         uuid PRIMARY KEY,
+    user_id    uuid                        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       text                        NOT NULL,
+    -- SHA-256 секрета. Сам секрет показывается пользователю один раз и не хранится.
+    token_hash bytea                       NOT NULL,
+    hint       text                        NOT NULL,
+    -- Права через запятую: read, write.
+    scopes     text                        NOT NULL,
+    expires_at timestamp(0) with time zone,
+    revoked_at timestamp(0) with time zone,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_api_tokens_hash
+    ON api_tokens (token_hash);
+
+CREATE INDEX IF NOT EXISTS ix_api_tokens_user
+    ON api_tokens (user_id, created_at)
+    WHERE revoked_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_tokens;
+-- +goose StatementEnd
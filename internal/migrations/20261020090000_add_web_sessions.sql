@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS web_sessions
+(
+    id           uuid PRIMARY KEY,
+    user_id      uuid                        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    -- SHA-256 секрета ссылки для входа, а после входа — секрета сеанса.
+    token_hash   bytea                       NOT NULL,
+    activated_at timestamp(0) with time zone,
+    expires_at   timestamp(0) with time zone NOT NULL,
+    created_at   timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_web_sessions_hash
+    ON web_sessions (token_hash);
+
+CREATE INDEX IF NOT EXISTS ix_web_sessions_expires_at
+    ON web_sessions (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS web_sessions;
+-- +goose StatementEnd
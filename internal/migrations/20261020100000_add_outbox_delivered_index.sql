@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Индекс для удаления доставленных сообщений, срок хранения которых истек.
+CREATE INDEX IF NOT EXISTS ix_outbox_delivered
+    ON outbox (updated_at)
+    WHERE status = 'delivered';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ix_outbox_delivered;
+-- +goose StatementEnd
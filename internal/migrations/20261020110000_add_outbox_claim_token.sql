@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Токен захвата порции сообщений. Результат доставки сохраняется только с тем же токеном,
+-- поэтому запоздавший экземпляр не перезапишет состояние сообщения, захваченного повторно.
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS claim_token uuid;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS claim_token;
+-- +goose StatementEnd
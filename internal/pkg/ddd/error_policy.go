@@ -0,0 +1,10 @@
+// Package ddd содержит базовые структуры и интерфейсы для реализации предметно-ориентированного дизайна (DDD).
+// Определяет политики обработки ошибок обработчиков доменных событий.
+package ddd
+
+// ErrorPolicy определяет, как посредник поступает с ошибками обработчиков события.
+// fail-fast — остальные обработчики не вызываются (или отменяются), возвращается первая ошибка;
+// collect-all — вызываются все обработчики, возвращаются все ошибки;
+// log-and-continue — вызываются все обработчики, ошибки записываются в журнал и не возвращаются.
+// ENUM(fail-fast, collect-all, log-and-continue)
+type ErrorPolicy string
@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version: v0.9.2
+
+// Built By: go install
+
+package ddd
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ErrorPolicyFailFast is a ErrorPolicy of type fail-fast.
+	ErrorPolicyFailFast ErrorPolicy = "fail-fast"
+	// ErrorPolicyCollectAll is a ErrorPolicy of type collect-all.
+	ErrorPolicyCollectAll ErrorPolicy = "collect-all"
+	// ErrorPolicyLogAndContinue is a ErrorPolicy of type log-and-continue.
+	ErrorPolicyLogAndContinue ErrorPolicy = "log-and-continue"
+)
+
+var ErrInvalidErrorPolicy = errors.New("not a valid ErrorPolicy")
+
+// String implements the Stringer interface.
+func (x ErrorPolicy) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ErrorPolicy) IsValid() bool {
+	_, err := ParseErrorPolicy(string(x))
+	return err == nil
+}
+
+var _ErrorPolicyValue = map[string]ErrorPolicy{
+	"fail-fast":        ErrorPolicyFailFast,
+	"collect-all":      ErrorPolicyCollectAll,
+	"log-and-continue": ErrorPolicyLogAndContinue,
+}
+
+// ParseErrorPolicy attempts to convert a string to a ErrorPolicy.
+func ParseErrorPolicy(name string) (ErrorPolicy, error) {
+	if x, ok := _ErrorPolicyValue[name]; ok {
+		return x, nil
+	}
+	return ErrorPolicy(""), fmt.Errorf("%s is %w", name, ErrInvalidErrorPolicy)
+}
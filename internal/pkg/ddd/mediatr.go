@@ -2,7 +2,20 @@
 // Реализует паттерн Mediator для обработки доменных событий.
 package ddd
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrHandlerPanicked = errors.New("event handler panicked")
+	ErrUnexpectedEvent = errors.New("unexpected event type")
+)
 
 // EventHandler определяет контракт для обработчиков доменных событий.
 // Каждый обработчик должен реализовывать метод Handle для обработки конкретного типа события.
@@ -11,45 +24,237 @@ type EventHandler interface {
 	Handle(ctx context.Context, event DomainEvent) error
 }
 
+// EventHandlerFunc позволяет использовать функцию как обработчик событий.
+type EventHandlerFunc func(ctx context.Context, event DomainEvent) error
+
+func (f EventHandlerFunc) Handle(ctx context.Context, event DomainEvent) error {
+	return f(ctx, event)
+}
+
+// Logger журнал, в который посредник записывает ошибки обработчиков.
+type Logger interface {
+	Error(msg string, args ...any)
+}
+
 // Mediatr определяет интерфейс для посредника, управляющего подпиской и публикацией событий.
 // Позволяет подписывать обработчики на события и публиковать события для обработки.
+// Подписка и публикация безопасны для одновременного вызова из нескольких горутин.
 type Mediatr interface {
 	Subscribe(handler EventHandler, events ...DomainEvent)
 	Publish(ctx context.Context, event DomainEvent) error
 }
 
+// Subscribe подписывает типизированный обработчик на события типа E.
+// Имя события берется из нулевого значения E, поэтому GetName не должен зависеть от полей события.
+// E должен быть конкретным типом события, а не указателем или интерфейсом: события публикуются
+// по значению, а у нулевого указателя или интерфейса нельзя получить имя. Для таких E Subscribe паникует.
+func Subscribe[E DomainEvent](m Mediatr, handler func(ctx context.Context, event E) error) {
+	if kind := reflect.TypeFor[E]().Kind(); kind == reflect.Pointer || kind == reflect.Interface {
+		panic(fmt.Sprintf("ddd: subscribe to %s: event type must be a value type", reflect.TypeFor[E]()))
+	}
+
+	var zero E
+
+	m.Subscribe(EventHandlerFunc(func(ctx context.Context, event DomainEvent) error {
+		e, ok := event.(E)
+		if !ok {
+			return fmt.Errorf("%w: %s is %T, want %T", ErrUnexpectedEvent, event.GetName(), event, zero)
+		}
+
+		return handler(ctx, e)
+	}), zero)
+}
+
+type MediatrOptions struct {
+	// ErrorPolicy определяет, как Publish поступает с ошибками обработчиков. По умолчанию fail-fast.
+	ErrorPolicy ErrorPolicy
+	// Workers количество обработчиков, одновременно выполняемых во всех вызовах Publish.
+	// Ноль означает, что обработчики события вызываются по очереди в горутине Publish.
+	// В обоих случаях Publish возвращает управление после завершения всех обработчиков:
+	// пул ускоряет обработку одного события, но не делает публикацию фоновой, и ошибки
+	// обработчиков по-прежнему доходят до вызывающего, например до повторной доставки outbox.
+	Workers int
+	// Logger журнал ошибок и паник обработчиков. Обязателен для политики log-and-continue.
+	Logger Logger
+}
+
 // mediatr реализует интерфейс Mediatr.
 // Хранит отображение имен событий на список обработчиков.
 type mediatr struct {
+	mu       sync.RWMutex
 	handlers map[string][]EventHandler
+	opts     MediatrOptions
+
+	// pool ограничивает число одновременно выполняемых обработчиков. Пуст для последовательного вызова.
+	pool chan struct{}
 }
 
 // NewMediatr создает и возвращает новый экземпляр посредника.
-// Инициализирует внутреннюю карту для хранения обработчиков событий.
+// Обработчики вызываются по очереди, первая ошибка прерывает обработку события.
 func NewMediatr() Mediatr {
-	return &mediatr{handlers: make(map[string][]EventHandler)}
+	return &mediatr{
+		handlers: make(map[string][]EventHandler),
+		opts:     MediatrOptions{ErrorPolicy: ErrorPolicyFailFast},
+	}
+}
+
+// NewMediatrWithOptions создает посредника с заданной политикой ошибок и пулом обработчиков.
+func NewMediatrWithOptions(opts MediatrOptions) (Mediatr, error) {
+	if opts.ErrorPolicy == "" {
+		opts.ErrorPolicy = ErrorPolicyFailFast
+	}
+
+	if !opts.ErrorPolicy.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidErrorPolicy, opts.ErrorPolicy)
+	}
+
+	if opts.ErrorPolicy == ErrorPolicyLogAndContinue && opts.Logger == nil {
+		return nil, errors.New("logger is required for log-and-continue error policy")
+	}
+
+	if opts.Workers < 0 {
+		return nil, fmt.Errorf("workers must not be negative: %d", opts.Workers)
+	}
+
+	m := &mediatr{
+		handlers: make(map[string][]EventHandler),
+		opts:     opts,
+	}
+
+	if opts.Workers > 0 {
+		m.pool = make(chan struct{}, opts.Workers)
+	}
+
+	return m, nil
 }
 
 // Subscribe регистрирует обработчик для указанных типов событий.
 // Один обработчик может быть подписан на несколько типов событий.
 // При наступлении события вызываются все зарегистрированные для него обработчики.
 func (e *mediatr) Subscribe(handler EventHandler, events ...DomainEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	for _, event := range events {
-		handlers := e.handlers[event.GetName()]
-		handlers = append(handlers, handler)
-		e.handlers[event.GetName()] = handlers
+		e.handlers[event.GetName()] = append(e.handlers[event.GetName()], handler)
 	}
 }
 
 // Publish публикует событие для обработки.
-// Вызывает все обработчики, подписанные на данное событие.
-// Возвращает первую возникшую ошибку или nil, если обработка прошла успешно.
+// Вызывает все обработчики, подписанные на данное событие, и поступает с их ошибками
+// согласно политике ошибок. Паника обработчика возвращается как ошибка ErrHandlerPanicked
+// и не мешает работе остальных обработчиков.
+// При пуле обработчиков обработчик не должен сам вызывать Publish: при исчерпании пула вызов не завершится.
 func (e *mediatr) Publish(ctx context.Context, event DomainEvent) error {
-	for _, handler := range e.handlers[event.GetName()] {
-		err := handler.Handle(ctx, event)
-		if err != nil {
-			return err
+	e.mu.RLock()
+	handlers := slices.Clone(e.handlers[event.GetName()])
+	e.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	var errs []error
+	if e.pool == nil {
+		errs = e.publishSync(ctx, event, handlers)
+	} else {
+		errs = e.publishConcurrent(ctx, event, handlers)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	switch e.opts.ErrorPolicy {
+	case ErrorPolicyCollectAll:
+		return errors.Join(errs...)
+	case ErrorPolicyLogAndContinue:
+		for _, err := range errs {
+			e.opts.Logger.Error("event handler failed", "event", event.GetName(), "err", err)
+		}
+
+		return nil
+	default:
+		return errs[0]
+	}
+}
+
+func (e *mediatr) publishSync(ctx context.Context, event DomainEvent, handlers []EventHandler) []error {
+	var errs []error
+
+	for _, handler := range handlers {
+		if err := e.call(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+
+			if e.opts.ErrorPolicy == ErrorPolicyFailFast {
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// publishConcurrent выполняет обработчики одновременно в пуле и дожидается их завершения.
+// При политике fail-fast первая ошибка отменяет контекст остальных обработчиков;
+// она возвращается первой.
+func (e *mediatr) publishConcurrent(ctx context.Context, event DomainEvent, handlers []EventHandler) []error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = append(errs, err)
+
+		if e.opts.ErrorPolicy == ErrorPolicyFailFast {
+			cancel()
 		}
 	}
-	return nil
+
+	for _, handler := range handlers {
+		select {
+		case e.pool <- struct{}{}:
+		case <-ctx.Done():
+			fail(ctx.Err())
+			continue
+		}
+
+		wg.Go(func() {
+			defer func() { <-e.pool }()
+
+			if err := e.call(ctx, handler, event); err != nil {
+				fail(err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// call вызывает обработчик, превращая его панику в ошибку.
+func (e *mediatr) call(ctx context.Context, handler EventHandler, event DomainEvent) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err = fmt.Errorf("%w: %s: %v", ErrHandlerPanicked, event.GetName(), r)
+
+		if e.opts.Logger != nil {
+			e.opts.Logger.Error("event handler panicked", "event", event.GetName(), "panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+
+	return handler.Handle(ctx, event)
 }
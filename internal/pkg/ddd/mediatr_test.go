@@ -0,0 +1,342 @@
+package ddd_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nemizar/coin_tamer_bot/internal/pkg/ddd"
+)
+
+type orderPlaced struct {
+	ID     uuid.UUID
+	Amount int
+}
+
+func (e orderPlaced) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e orderPlaced) GetName() string {
+	return "order.placed"
+}
+
+// orderPlacedV2 совпадает по имени с orderPlaced, но имеет другой тип.
+type orderPlacedV2 struct {
+	ID uuid.UUID
+}
+
+func (e orderPlacedV2) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e orderPlacedV2) GetName() string {
+	return "order.placed"
+}
+
+// recordingLogger запоминает сообщения об ошибках.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Error(msg string, _ ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, msg)
+}
+
+func (l *recordingLogger) Messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.messages...)
+}
+
+func newMediatr(t *testing.T, opts ddd.MediatrOptions) ddd.Mediatr {
+	t.Helper()
+
+	m, err := ddd.NewMediatrWithOptions(opts)
+	require.NoError(t, err)
+
+	return m
+}
+
+func failing(err error) ddd.EventHandler {
+	return ddd.EventHandlerFunc(func(context.Context, ddd.DomainEvent) error {
+		return err
+	})
+}
+
+func counting(calls *atomic.Int32) ddd.EventHandler {
+	return ddd.EventHandlerFunc(func(context.Context, ddd.DomainEvent) error {
+		calls.Add(1)
+		return nil
+	})
+}
+
+func TestNewMediatrWithOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ddd.MediatrOptions
+	}{
+		{name: "unknown policy", opts: ddd.MediatrOptions{ErrorPolicy: "retry"}},
+		{name: "log-and-continue without logger", opts: ddd.MediatrOptions{ErrorPolicy: ddd.ErrorPolicyLogAndContinue}},
+		{name: "negative workers", opts: ddd.MediatrOptions{Workers: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ddd.NewMediatrWithOptions(tt.opts)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPublish_NoHandlers(t *testing.T) {
+	require.NoError(t, ddd.NewMediatr().Publish(context.Background(), orderPlaced{ID: uuid.New()}))
+}
+
+func TestSubscribe_Typed(t *testing.T) {
+	m := ddd.NewMediatr()
+
+	var got orderPlaced
+	ddd.Subscribe(m, func(_ context.Context, e orderPlaced) error {
+		got = e
+		return nil
+	})
+
+	event := orderPlaced{ID: uuid.New(), Amount: 42}
+	require.NoError(t, m.Publish(context.Background(), event))
+	assert.Equal(t, event, got)
+}
+
+func TestSubscribe_WrongType(t *testing.T) {
+	m := ddd.NewMediatr()
+
+	called := false
+	ddd.Subscribe(m, func(context.Context, orderPlaced) error {
+		called = true
+		return nil
+	})
+
+	err := m.Publish(context.Background(), orderPlacedV2{ID: uuid.New()})
+	require.ErrorIs(t, err, ddd.ErrUnexpectedEvent)
+	assert.False(t, called)
+}
+
+// orderShipped реализует DomainEvent методами со значением-получателем, поэтому им удовлетворяет и *orderShipped.
+type orderShipped struct {
+	ID uuid.UUID
+}
+
+func (e orderShipped) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e orderShipped) GetName() string {
+	return "order.shipped"
+}
+
+func TestSubscribe_RejectsPointerAndInterfaceEvents(t *testing.T) {
+	m := ddd.NewMediatr()
+
+	assert.PanicsWithValue(t, "ddd: subscribe to *ddd_test.orderShipped: event type must be a value type", func() {
+		ddd.Subscribe(m, func(context.Context, *orderShipped) error { return nil })
+	})
+
+	assert.PanicsWithValue(t, "ddd: subscribe to ddd.DomainEvent: event type must be a value type", func() {
+		ddd.Subscribe(m, func(context.Context, ddd.DomainEvent) error { return nil })
+	})
+
+	require.NoError(t, m.Publish(context.Background(), orderShipped{ID: uuid.New()}))
+}
+
+func TestPublish_ErrorPolicies(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	tests := []struct {
+		name    string
+		policy  ddd.ErrorPolicy
+		workers int
+		// wantErrs ошибки, которые должен вернуть Publish. Пусто, если ошибок быть не должно.
+		wantErrs []error
+		// wantCalls сколько раз вызывается обработчик без ошибки, который подписан после первого.
+		wantCalls int32
+		wantLogs  int
+	}{
+		{name: "fail-fast", policy: ddd.ErrorPolicyFailFast, wantErrs: []error{errFirst}, wantCalls: 0},
+		{name: "fail-fast in pool", policy: ddd.ErrorPolicyFailFast, workers: 1, wantErrs: []error{errFirst}},
+		{name: "collect-all", policy: ddd.ErrorPolicyCollectAll, wantErrs: []error{errFirst, errSecond}, wantCalls: 1},
+		{name: "collect-all in pool", policy: ddd.ErrorPolicyCollectAll, workers: 2, wantErrs: []error{errFirst, errSecond}, wantCalls: 1},
+		{name: "log-and-continue", policy: ddd.ErrorPolicyLogAndContinue, wantCalls: 1, wantLogs: 2},
+		{name: "log-and-continue in pool", policy: ddd.ErrorPolicyLogAndContinue, workers: 2, wantCalls: 1, wantLogs: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &recordingLogger{}
+			m := newMediatr(t, ddd.MediatrOptions{ErrorPolicy: tt.policy, Workers: tt.workers, Logger: logger})
+
+			var calls atomic.Int32
+			m.Subscribe(failing(errFirst), orderPlaced{})
+			m.Subscribe(counting(&calls), orderPlaced{})
+			m.Subscribe(failing(errSecond), orderPlaced{})
+
+			err := m.Publish(context.Background(), orderPlaced{ID: uuid.New()})
+
+			if len(tt.wantErrs) == 0 {
+				require.NoError(t, err)
+			}
+
+			for _, want := range tt.wantErrs {
+				require.ErrorIs(t, err, want)
+			}
+
+			// В пуле при fail-fast обработчики после первого могут успеть запуститься до отмены.
+			if tt.policy != ddd.ErrorPolicyFailFast || tt.workers == 0 {
+				assert.Equal(t, tt.wantCalls, calls.Load())
+			}
+
+			assert.Len(t, logger.Messages(), tt.wantLogs)
+		})
+	}
+}
+
+func TestPublish_FailFastCancelsPool(t *testing.T) {
+	errFirst := errors.New("first")
+	m := newMediatr(t, ddd.MediatrOptions{ErrorPolicy: ddd.ErrorPolicyFailFast, Workers: 2})
+
+	started := make(chan struct{})
+	m.Subscribe(ddd.EventHandlerFunc(func(ctx context.Context, _ ddd.DomainEvent) error {
+		close(started)
+		<-ctx.Done()
+
+		return ctx.Err()
+	}), orderPlaced{})
+	m.Subscribe(ddd.EventHandlerFunc(func(context.Context, ddd.DomainEvent) error {
+		<-started
+		return errFirst
+	}), orderPlaced{})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.Publish(context.Background(), orderPlaced{ID: uuid.New()})
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errFirst)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish did not cancel the remaining handlers")
+	}
+}
+
+func TestPublish_BoundedPool(t *testing.T) {
+	const (
+		workers    = 2
+		publishers = 3
+		handlers   = 4
+	)
+
+	m := newMediatr(t, ddd.MediatrOptions{ErrorPolicy: ddd.ErrorPolicyCollectAll, Workers: workers})
+
+	var active, peak, calls atomic.Int32
+	for range handlers {
+		m.Subscribe(ddd.EventHandlerFunc(func(context.Context, ddd.DomainEvent) error {
+			n := active.Add(1)
+			defer active.Add(-1)
+
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			calls.Add(1)
+
+			return nil
+		}), orderPlaced{})
+	}
+
+	// Пул общий для всех вызовов Publish, поэтому ограничение действует и при одновременной публикации.
+	var wg sync.WaitGroup
+	for range publishers {
+		wg.Go(func() {
+			assert.NoError(t, m.Publish(context.Background(), orderPlaced{ID: uuid.New()}))
+		})
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(publishers*handlers), calls.Load())
+	assert.LessOrEqual(t, peak.Load(), int32(workers))
+	assert.Positive(t, peak.Load())
+}
+
+func TestPublish_PanicIsolation(t *testing.T) {
+	for _, workers := range []int{0, 2} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			logger := &recordingLogger{}
+			m := newMediatr(t, ddd.MediatrOptions{ErrorPolicy: ddd.ErrorPolicyCollectAll, Workers: workers, Logger: logger})
+
+			var calls atomic.Int32
+			m.Subscribe(ddd.EventHandlerFunc(func(context.Context, ddd.DomainEvent) error {
+				panic("boom")
+			}), orderPlaced{})
+			m.Subscribe(counting(&calls), orderPlaced{})
+
+			err := m.Publish(context.Background(), orderPlaced{ID: uuid.New()})
+			require.ErrorIs(t, err, ddd.ErrHandlerPanicked)
+			assert.ErrorContains(t, err, "boom")
+
+			assert.Equal(t, int32(1), calls.Load())
+			assert.Contains(t, logger.Messages(), "event handler panicked")
+		})
+	}
+}
+
+// TestMediatr_ConcurrentSubscribeAndPublish проверяется с флагом -race.
+func TestMediatr_ConcurrentSubscribeAndPublish(t *testing.T) {
+	for _, workers := range []int{0, 4} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			m := newMediatr(t, ddd.MediatrOptions{Workers: workers})
+
+			var (
+				wg    sync.WaitGroup
+				calls atomic.Int32
+			)
+
+			for range 10 {
+				wg.Go(func() {
+					ddd.Subscribe(m, func(context.Context, orderPlaced) error {
+						calls.Add(1)
+						return nil
+					})
+				})
+
+				wg.Go(func() {
+					assert.NoError(t, m.Publish(context.Background(), orderPlaced{ID: uuid.New()}))
+				})
+			}
+
+			wg.Wait()
+
+			calls.Store(0)
+			require.NoError(t, m.Publish(context.Background(), orderPlaced{ID: uuid.New()}))
+			assert.Equal(t, int32(10), calls.Load())
+		})
+	}
+}
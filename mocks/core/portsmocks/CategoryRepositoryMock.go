@@ -96,6 +96,148 @@ func (_c *CategoryRepositoryMock_Create_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// Get provides a mock function for the type CategoryRepositoryMock
+func (_mock *CategoryRepositoryMock) Get(ctx context.Context, userID shared.ID, id shared.ID) (*category.Category, error) {
+	ret := _mock.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, shared.ID) (*category.Category, error)); ok {
+		return returnFunc(ctx, userID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, shared.ID) *category.Category); ok {
+		r0 = returnFunc(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, shared.ID) error); ok {
+		r1 = returnFunc(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CategoryRepositoryMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type CategoryRepositoryMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - id shared.ID
+func (_e *CategoryRepositoryMock_Expecter) Get(ctx interface{}, userID interface{}, id interface{}) *CategoryRepositoryMock_Get_Call {
+	return &CategoryRepositoryMock_Get_Call{Call: _e.mock.On("Get", ctx, userID, id)}
+}
+
+func (_c *CategoryRepositoryMock_Get_Call) Run(run func(ctx context.Context, userID shared.ID, id shared.ID)) *CategoryRepositoryMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 shared.ID
+		if args[2] != nil {
+			arg2 = args[2].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *CategoryRepositoryMock_Get_Call) Return(category1 *category.Category, err error) *CategoryRepositoryMock_Get_Call {
+	_c.Call.Return(category1, err)
+	return _c
+}
+
+func (_c *CategoryRepositoryMock_Get_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, id shared.ID) (*category.Category, error)) *CategoryRepositoryMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllByUserID provides a mock function for the type CategoryRepositoryMock
+func (_mock *CategoryRepositoryMock) GetAllByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllByUserID")
+	}
+
+	var r0 []*category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) ([]*category.Category, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) []*category.Category); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CategoryRepositoryMock_GetAllByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllByUserID'
+type CategoryRepositoryMock_GetAllByUserID_Call struct {
+	*mock.Call
+}
+
+// GetAllByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+func (_e *CategoryRepositoryMock_Expecter) GetAllByUserID(ctx interface{}, userID interface{}) *CategoryRepositoryMock_GetAllByUserID_Call {
+	return &CategoryRepositoryMock_GetAllByUserID_Call{Call: _e.mock.On("GetAllByUserID", ctx, userID)}
+}
+
+func (_c *CategoryRepositoryMock_GetAllByUserID_Call) Run(run func(ctx context.Context, userID shared.ID)) *CategoryRepositoryMock_GetAllByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CategoryRepositoryMock_GetAllByUserID_Call) Return(categorys []*category.Category, err error) *CategoryRepositoryMock_GetAllByUserID_Call {
+	_c.Call.Return(categorys, err)
+	return _c
+}
+
+func (_c *CategoryRepositoryMock_GetAllByUserID_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID) ([]*category.Category, error)) *CategoryRepositoryMock_GetAllByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetExpenseByUserID provides a mock function for the type CategoryRepositoryMock
 func (_mock *CategoryRepositoryMock) GetExpenseByUserID(ctx context.Context, userID shared.ID) ([]*category.Category, error) {
 	ret := _mock.Called(ctx, userID)
@@ -297,3 +439,60 @@ func (_c *CategoryRepositoryMock_HasCategoriesByUserID_Call) RunAndReturn(run fu
 	_c.Call.Return(run)
 	return _c
 }
+
+// Save provides a mock function for the type CategoryRepositoryMock
+func (_mock *CategoryRepositoryMock) Save(ctx context.Context, category1 *category.Category) error {
+	ret := _mock.Called(ctx, category1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *category.Category) error); ok {
+		r0 = returnFunc(ctx, category1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CategoryRepositoryMock_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type CategoryRepositoryMock_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category1 *category.Category
+func (_e *CategoryRepositoryMock_Expecter) Save(ctx interface{}, category1 interface{}) *CategoryRepositoryMock_Save_Call {
+	return &CategoryRepositoryMock_Save_Call{Call: _e.mock.On("Save", ctx, category1)}
+}
+
+func (_c *CategoryRepositoryMock_Save_Call) Run(run func(ctx context.Context, category1 *category.Category)) *CategoryRepositoryMock_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *category.Category
+		if args[1] != nil {
+			arg1 = args[1].(*category.Category)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CategoryRepositoryMock_Save_Call) Return(err error) *CategoryRepositoryMock_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CategoryRepositoryMock_Save_Call) RunAndReturn(run func(ctx context.Context, category1 *category.Category) error) *CategoryRepositoryMock_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,7 +6,10 @@ package portsmocks
 
 import (
 	"context"
+	"time"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/receipt"
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/report"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/transaction"
 	mock "github.com/stretchr/testify/mock"
@@ -153,6 +156,382 @@ func (_c *TransactionRepositoryMock_Delete_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// FindByUserID provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) FindByUserID(ctx context.Context, userID shared.ID) ([]*transaction.Transaction, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserID")
+	}
+
+	var r0 []*transaction.Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) ([]*transaction.Transaction, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) []*transaction.Transaction); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transaction.Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_FindByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUserID'
+type TransactionRepositoryMock_FindByUserID_Call struct {
+	*mock.Call
+}
+
+// FindByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+func (_e *TransactionRepositoryMock_Expecter) FindByUserID(ctx interface{}, userID interface{}) *TransactionRepositoryMock_FindByUserID_Call {
+	return &TransactionRepositoryMock_FindByUserID_Call{Call: _e.mock.On("FindByUserID", ctx, userID)}
+}
+
+func (_c *TransactionRepositoryMock_FindByUserID_Call) Run(run func(ctx context.Context, userID shared.ID)) *TransactionRepositoryMock_FindByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindByUserID_Call) Return(transactions []*transaction.Transaction, err error) *TransactionRepositoryMock_FindByUserID_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindByUserID_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID) ([]*transaction.Transaction, error)) *TransactionRepositoryMock_FindByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindFingerprints provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) FindFingerprints(ctx context.Context, userID shared.ID, fingerprints []string) ([]string, error) {
+	ret := _mock.Called(ctx, userID, fingerprints)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFingerprints")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, []string) ([]string, error)); ok {
+		return returnFunc(ctx, userID, fingerprints)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, []string) []string); ok {
+		r0 = returnFunc(ctx, userID, fingerprints)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, []string) error); ok {
+		r1 = returnFunc(ctx, userID, fingerprints)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_FindFingerprints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindFingerprints'
+type TransactionRepositoryMock_FindFingerprints_Call struct {
+	*mock.Call
+}
+
+// FindFingerprints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - fingerprints []string
+func (_e *TransactionRepositoryMock_Expecter) FindFingerprints(ctx interface{}, userID interface{}, fingerprints interface{}) *TransactionRepositoryMock_FindFingerprints_Call {
+	return &TransactionRepositoryMock_FindFingerprints_Call{Call: _e.mock.On("FindFingerprints", ctx, userID, fingerprints)}
+}
+
+func (_c *TransactionRepositoryMock_FindFingerprints_Call) Run(run func(ctx context.Context, userID shared.ID, fingerprints []string)) *TransactionRepositoryMock_FindFingerprints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindFingerprints_Call) Return(ss []string, err error) *TransactionRepositoryMock_FindFingerprints_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindFingerprints_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, fingerprints []string) ([]string, error)) *TransactionRepositoryMock_FindFingerprints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindHistory provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) FindHistory(ctx context.Context, userID shared.ID, filter report.HistoryFilter, after report.HistoryCursor, limit int) ([]report.TransactionLine, error) {
+	ret := _mock.Called(ctx, userID, filter, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindHistory")
+	}
+
+	var r0 []report.TransactionLine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, report.HistoryFilter, report.HistoryCursor, int) ([]report.TransactionLine, error)); ok {
+		return returnFunc(ctx, userID, filter, after, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, report.HistoryFilter, report.HistoryCursor, int) []report.TransactionLine); ok {
+		r0 = returnFunc(ctx, userID, filter, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.TransactionLine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, report.HistoryFilter, report.HistoryCursor, int) error); ok {
+		r1 = returnFunc(ctx, userID, filter, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_FindHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindHistory'
+type TransactionRepositoryMock_FindHistory_Call struct {
+	*mock.Call
+}
+
+// FindHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - filter report.HistoryFilter
+//   - after report.HistoryCursor
+//   - limit int
+func (_e *TransactionRepositoryMock_Expecter) FindHistory(ctx interface{}, userID interface{}, filter interface{}, after interface{}, limit interface{}) *TransactionRepositoryMock_FindHistory_Call {
+	return &TransactionRepositoryMock_FindHistory_Call{Call: _e.mock.On("FindHistory", ctx, userID, filter, after, limit)}
+}
+
+func (_c *TransactionRepositoryMock_FindHistory_Call) Run(run func(ctx context.Context, userID shared.ID, filter report.HistoryFilter, after report.HistoryCursor, limit int)) *TransactionRepositoryMock_FindHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 report.HistoryFilter
+		if args[2] != nil {
+			arg2 = args[2].(report.HistoryFilter)
+		}
+		var arg3 report.HistoryCursor
+		if args[3] != nil {
+			arg3 = args[3].(report.HistoryCursor)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindHistory_Call) Return(transactionLines []report.TransactionLine, err error) *TransactionRepositoryMock_FindHistory_Call {
+	_c.Call.Return(transactionLines, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindHistory_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, filter report.HistoryFilter, after report.HistoryCursor, limit int) ([]report.TransactionLine, error)) *TransactionRepositoryMock_FindHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindLinesByFiscalDrive provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) FindLinesByFiscalDrive(ctx context.Context, userID shared.ID, fiscalDrive string, limit int) ([]report.TransactionLine, error) {
+	ret := _mock.Called(ctx, userID, fiscalDrive, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLinesByFiscalDrive")
+	}
+
+	var r0 []report.TransactionLine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, string, int) ([]report.TransactionLine, error)); ok {
+		return returnFunc(ctx, userID, fiscalDrive, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, string, int) []report.TransactionLine); ok {
+		r0 = returnFunc(ctx, userID, fiscalDrive, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.TransactionLine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, string, int) error); ok {
+		r1 = returnFunc(ctx, userID, fiscalDrive, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_FindLinesByFiscalDrive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindLinesByFiscalDrive'
+type TransactionRepositoryMock_FindLinesByFiscalDrive_Call struct {
+	*mock.Call
+}
+
+// FindLinesByFiscalDrive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - fiscalDrive string
+//   - limit int
+func (_e *TransactionRepositoryMock_Expecter) FindLinesByFiscalDrive(ctx interface{}, userID interface{}, fiscalDrive interface{}, limit interface{}) *TransactionRepositoryMock_FindLinesByFiscalDrive_Call {
+	return &TransactionRepositoryMock_FindLinesByFiscalDrive_Call{Call: _e.mock.On("FindLinesByFiscalDrive", ctx, userID, fiscalDrive, limit)}
+}
+
+func (_c *TransactionRepositoryMock_FindLinesByFiscalDrive_Call) Run(run func(ctx context.Context, userID shared.ID, fiscalDrive string, limit int)) *TransactionRepositoryMock_FindLinesByFiscalDrive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindLinesByFiscalDrive_Call) Return(transactionLines []report.TransactionLine, err error) *TransactionRepositoryMock_FindLinesByFiscalDrive_Call {
+	_c.Call.Return(transactionLines, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindLinesByFiscalDrive_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, fiscalDrive string, limit int) ([]report.TransactionLine, error)) *TransactionRepositoryMock_FindLinesByFiscalDrive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindParts provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) FindParts(ctx context.Context, transactionID shared.ID) ([]report.CategoryTotal, error) {
+	ret := _mock.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindParts")
+	}
+
+	var r0 []report.CategoryTotal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) ([]report.CategoryTotal, error)); ok {
+		return returnFunc(ctx, transactionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) []report.CategoryTotal); ok {
+		r0 = returnFunc(ctx, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.CategoryTotal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID) error); ok {
+		r1 = returnFunc(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_FindParts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindParts'
+type TransactionRepositoryMock_FindParts_Call struct {
+	*mock.Call
+}
+
+// FindParts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionID shared.ID
+func (_e *TransactionRepositoryMock_Expecter) FindParts(ctx interface{}, transactionID interface{}) *TransactionRepositoryMock_FindParts_Call {
+	return &TransactionRepositoryMock_FindParts_Call{Call: _e.mock.On("FindParts", ctx, transactionID)}
+}
+
+func (_c *TransactionRepositoryMock_FindParts_Call) Run(run func(ctx context.Context, transactionID shared.ID)) *TransactionRepositoryMock_FindParts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindParts_Call) Return(categoryTotals []report.CategoryTotal, err error) *TransactionRepositoryMock_FindParts_Call {
+	_c.Call.Return(categoryTotals, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_FindParts_Call) RunAndReturn(run func(ctx context.Context, transactionID shared.ID) ([]report.CategoryTotal, error)) *TransactionRepositoryMock_FindParts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function for the type TransactionRepositoryMock
 func (_mock *TransactionRepositoryMock) Get(ctx context.Context, id shared.ID) (*transaction.Transaction, error) {
 	ret := _mock.Called(ctx, id)
@@ -221,6 +600,531 @@ func (_c *TransactionRepositoryMock_Get_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// GetLastCreatedAt provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) GetLastCreatedAt(ctx context.Context, userID shared.ID) (time.Time, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastCreatedAt")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) (time.Time, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) time.Time); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_GetLastCreatedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastCreatedAt'
+type TransactionRepositoryMock_GetLastCreatedAt_Call struct {
+	*mock.Call
+}
+
+// GetLastCreatedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+func (_e *TransactionRepositoryMock_Expecter) GetLastCreatedAt(ctx interface{}, userID interface{}) *TransactionRepositoryMock_GetLastCreatedAt_Call {
+	return &TransactionRepositoryMock_GetLastCreatedAt_Call{Call: _e.mock.On("GetLastCreatedAt", ctx, userID)}
+}
+
+func (_c *TransactionRepositoryMock_GetLastCreatedAt_Call) Run(run func(ctx context.Context, userID shared.ID)) *TransactionRepositoryMock_GetLastCreatedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetLastCreatedAt_Call) Return(time1 time.Time, err error) *TransactionRepositoryMock_GetLastCreatedAt_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetLastCreatedAt_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID) (time.Time, error)) *TransactionRepositoryMock_GetLastCreatedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLine provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) GetLine(ctx context.Context, userID shared.ID, id shared.ID) (report.TransactionLine, error) {
+	ret := _mock.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLine")
+	}
+
+	var r0 report.TransactionLine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, shared.ID) (report.TransactionLine, error)); ok {
+		return returnFunc(ctx, userID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, shared.ID) report.TransactionLine); ok {
+		r0 = returnFunc(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(report.TransactionLine)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, shared.ID) error); ok {
+		r1 = returnFunc(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_GetLine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLine'
+type TransactionRepositoryMock_GetLine_Call struct {
+	*mock.Call
+}
+
+// GetLine is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - id shared.ID
+func (_e *TransactionRepositoryMock_Expecter) GetLine(ctx interface{}, userID interface{}, id interface{}) *TransactionRepositoryMock_GetLine_Call {
+	return &TransactionRepositoryMock_GetLine_Call{Call: _e.mock.On("GetLine", ctx, userID, id)}
+}
+
+func (_c *TransactionRepositoryMock_GetLine_Call) Run(run func(ctx context.Context, userID shared.ID, id shared.ID)) *TransactionRepositoryMock_GetLine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 shared.ID
+		if args[2] != nil {
+			arg2 = args[2].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetLine_Call) Return(transactionLine report.TransactionLine, err error) *TransactionRepositoryMock_GetLine_Call {
+	_c.Call.Return(transactionLine, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetLine_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, id shared.ID) (report.TransactionLine, error)) *TransactionRepositoryMock_GetLine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTotalsByCategory provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) GetTotalsByCategory(ctx context.Context, userID shared.ID, from time.Time, to time.Time) ([]report.CategoryTotal, error) {
+	ret := _mock.Called(ctx, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotalsByCategory")
+	}
+
+	var r0 []report.CategoryTotal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, time.Time, time.Time) ([]report.CategoryTotal, error)); ok {
+		return returnFunc(ctx, userID, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, time.Time, time.Time) []report.CategoryTotal); ok {
+		r0 = returnFunc(ctx, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.CategoryTotal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_GetTotalsByCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTotalsByCategory'
+type TransactionRepositoryMock_GetTotalsByCategory_Call struct {
+	*mock.Call
+}
+
+// GetTotalsByCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - from time.Time
+//   - to time.Time
+func (_e *TransactionRepositoryMock_Expecter) GetTotalsByCategory(ctx interface{}, userID interface{}, from interface{}, to interface{}) *TransactionRepositoryMock_GetTotalsByCategory_Call {
+	return &TransactionRepositoryMock_GetTotalsByCategory_Call{Call: _e.mock.On("GetTotalsByCategory", ctx, userID, from, to)}
+}
+
+func (_c *TransactionRepositoryMock_GetTotalsByCategory_Call) Run(run func(ctx context.Context, userID shared.ID, from time.Time, to time.Time)) *TransactionRepositoryMock_GetTotalsByCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetTotalsByCategory_Call) Return(categoryTotals []report.CategoryTotal, err error) *TransactionRepositoryMock_GetTotalsByCategory_Call {
+	_c.Call.Return(categoryTotals, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetTotalsByCategory_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, from time.Time, to time.Time) ([]report.CategoryTotal, error)) *TransactionRepositoryMock_GetTotalsByCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTotalsByTag provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) GetTotalsByTag(ctx context.Context, userID shared.ID, tagID shared.ID) ([]report.CategoryTotal, error) {
+	ret := _mock.Called(ctx, userID, tagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotalsByTag")
+	}
+
+	var r0 []report.CategoryTotal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, shared.ID) ([]report.CategoryTotal, error)); ok {
+		return returnFunc(ctx, userID, tagID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, shared.ID) []report.CategoryTotal); ok {
+		r0 = returnFunc(ctx, userID, tagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.CategoryTotal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, shared.ID) error); ok {
+		r1 = returnFunc(ctx, userID, tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_GetTotalsByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTotalsByTag'
+type TransactionRepositoryMock_GetTotalsByTag_Call struct {
+	*mock.Call
+}
+
+// GetTotalsByTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - tagID shared.ID
+func (_e *TransactionRepositoryMock_Expecter) GetTotalsByTag(ctx interface{}, userID interface{}, tagID interface{}) *TransactionRepositoryMock_GetTotalsByTag_Call {
+	return &TransactionRepositoryMock_GetTotalsByTag_Call{Call: _e.mock.On("GetTotalsByTag", ctx, userID, tagID)}
+}
+
+func (_c *TransactionRepositoryMock_GetTotalsByTag_Call) Run(run func(ctx context.Context, userID shared.ID, tagID shared.ID)) *TransactionRepositoryMock_GetTotalsByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 shared.ID
+		if args[2] != nil {
+			arg2 = args[2].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetTotalsByTag_Call) Return(categoryTotals []report.CategoryTotal, err error) *TransactionRepositoryMock_GetTotalsByTag_Call {
+	_c.Call.Return(categoryTotals, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_GetTotalsByTag_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, tagID shared.ID) ([]report.CategoryTotal, error)) *TransactionRepositoryMock_GetTotalsByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasFiscalID provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) HasFiscalID(ctx context.Context, userID shared.ID, fiscalID receipt.FiscalID) (bool, error) {
+	ret := _mock.Called(ctx, userID, fiscalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasFiscalID")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, receipt.FiscalID) (bool, error)); ok {
+		return returnFunc(ctx, userID, fiscalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, receipt.FiscalID) bool); ok {
+		r0 = returnFunc(ctx, userID, fiscalID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, receipt.FiscalID) error); ok {
+		r1 = returnFunc(ctx, userID, fiscalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_HasFiscalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasFiscalID'
+type TransactionRepositoryMock_HasFiscalID_Call struct {
+	*mock.Call
+}
+
+// HasFiscalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - fiscalID receipt.FiscalID
+func (_e *TransactionRepositoryMock_Expecter) HasFiscalID(ctx interface{}, userID interface{}, fiscalID interface{}) *TransactionRepositoryMock_HasFiscalID_Call {
+	return &TransactionRepositoryMock_HasFiscalID_Call{Call: _e.mock.On("HasFiscalID", ctx, userID, fiscalID)}
+}
+
+func (_c *TransactionRepositoryMock_HasFiscalID_Call) Run(run func(ctx context.Context, userID shared.ID, fiscalID receipt.FiscalID)) *TransactionRepositoryMock_HasFiscalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 receipt.FiscalID
+		if args[2] != nil {
+			arg2 = args[2].(receipt.FiscalID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_HasFiscalID_Call) Return(b bool, err error) *TransactionRepositoryMock_HasFiscalID_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_HasFiscalID_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, fiscalID receipt.FiscalID) (bool, error)) *TransactionRepositoryMock_HasFiscalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) Search(ctx context.Context, userID shared.ID, text string, limit int) (*report.SearchResult, error) {
+	ret := _mock.Called(ctx, userID, text, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *report.SearchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, string, int) (*report.SearchResult, error)); ok {
+		return returnFunc(ctx, userID, text, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, string, int) *report.SearchResult); ok {
+		r0 = returnFunc(ctx, userID, text, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*report.SearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, string, int) error); ok {
+		r1 = returnFunc(ctx, userID, text, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TransactionRepositoryMock_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type TransactionRepositoryMock_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - text string
+//   - limit int
+func (_e *TransactionRepositoryMock_Expecter) Search(ctx interface{}, userID interface{}, text interface{}, limit interface{}) *TransactionRepositoryMock_Search_Call {
+	return &TransactionRepositoryMock_Search_Call{Call: _e.mock.On("Search", ctx, userID, text, limit)}
+}
+
+func (_c *TransactionRepositoryMock_Search_Call) Run(run func(ctx context.Context, userID shared.ID, text string, limit int)) *TransactionRepositoryMock_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_Search_Call) Return(searchResult *report.SearchResult, err error) *TransactionRepositoryMock_Search_Call {
+	_c.Call.Return(searchResult, err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_Search_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, text string, limit int) (*report.SearchResult, error)) *TransactionRepositoryMock_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamLines provides a mock function for the type TransactionRepositoryMock
+func (_mock *TransactionRepositoryMock) StreamLines(ctx context.Context, userID shared.ID, from time.Time, to time.Time, tagID shared.ID, fn func(line report.TransactionLine) error) error {
+	ret := _mock.Called(ctx, userID, from, to, tagID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamLines")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, time.Time, time.Time, shared.ID, func(line report.TransactionLine) error) error); ok {
+		r0 = returnFunc(ctx, userID, from, to, tagID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TransactionRepositoryMock_StreamLines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamLines'
+type TransactionRepositoryMock_StreamLines_Call struct {
+	*mock.Call
+}
+
+// StreamLines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - from time.Time
+//   - to time.Time
+//   - tagID shared.ID
+//   - fn func(line report.TransactionLine) error
+func (_e *TransactionRepositoryMock_Expecter) StreamLines(ctx interface{}, userID interface{}, from interface{}, to interface{}, tagID interface{}, fn interface{}) *TransactionRepositoryMock_StreamLines_Call {
+	return &TransactionRepositoryMock_StreamLines_Call{Call: _e.mock.On("StreamLines", ctx, userID, from, to, tagID, fn)}
+}
+
+func (_c *TransactionRepositoryMock_StreamLines_Call) Run(run func(ctx context.Context, userID shared.ID, from time.Time, to time.Time, tagID shared.ID, fn func(line report.TransactionLine) error)) *TransactionRepositoryMock_StreamLines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 shared.ID
+		if args[4] != nil {
+			arg4 = args[4].(shared.ID)
+		}
+		var arg5 func(line report.TransactionLine) error
+		if args[5] != nil {
+			arg5 = args[5].(func(line report.TransactionLine) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_StreamLines_Call) Return(err error) *TransactionRepositoryMock_StreamLines_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TransactionRepositoryMock_StreamLines_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, from time.Time, to time.Time, tagID shared.ID, fn func(line report.TransactionLine) error) error) *TransactionRepositoryMock_StreamLines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type TransactionRepositoryMock
 func (_mock *TransactionRepositoryMock) Update(ctx context.Context, transaction1 *transaction.Transaction) error {
 	ret := _mock.Called(ctx, transaction1)
@@ -38,6 +38,98 @@ func (_m *UnitOfWorkMock) EXPECT() *UnitOfWorkMock_Expecter {
 	return &UnitOfWorkMock_Expecter{mock: &_m.Mock}
 }
 
+// APITokenRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) APITokenRepository() ports.APITokenRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for APITokenRepository")
+	}
+
+	var r0 ports.APITokenRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.APITokenRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.APITokenRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_APITokenRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APITokenRepository'
+type UnitOfWorkMock_APITokenRepository_Call struct {
+	*mock.Call
+}
+
+// APITokenRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) APITokenRepository() *UnitOfWorkMock_APITokenRepository_Call {
+	return &UnitOfWorkMock_APITokenRepository_Call{Call: _e.mock.On("APITokenRepository")}
+}
+
+func (_c *UnitOfWorkMock_APITokenRepository_Call) Run(run func()) *UnitOfWorkMock_APITokenRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_APITokenRepository_Call) Return(aPITokenRepository ports.APITokenRepository) *UnitOfWorkMock_APITokenRepository_Call {
+	_c.Call.Return(aPITokenRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_APITokenRepository_Call) RunAndReturn(run func() ports.APITokenRepository) *UnitOfWorkMock_APITokenRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AttachmentRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) AttachmentRepository() ports.AttachmentRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachmentRepository")
+	}
+
+	var r0 ports.AttachmentRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.AttachmentRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.AttachmentRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_AttachmentRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AttachmentRepository'
+type UnitOfWorkMock_AttachmentRepository_Call struct {
+	*mock.Call
+}
+
+// AttachmentRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) AttachmentRepository() *UnitOfWorkMock_AttachmentRepository_Call {
+	return &UnitOfWorkMock_AttachmentRepository_Call{Call: _e.mock.On("AttachmentRepository")}
+}
+
+func (_c *UnitOfWorkMock_AttachmentRepository_Call) Run(run func()) *UnitOfWorkMock_AttachmentRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_AttachmentRepository_Call) Return(attachmentRepository ports.AttachmentRepository) *UnitOfWorkMock_AttachmentRepository_Call {
+	_c.Call.Return(attachmentRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_AttachmentRepository_Call) RunAndReturn(run func() ports.AttachmentRepository) *UnitOfWorkMock_AttachmentRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Begin provides a mock function for the type UnitOfWorkMock
 func (_mock *UnitOfWorkMock) Begin(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -186,6 +278,52 @@ func (_c *UnitOfWorkMock_Commit_Call) RunAndReturn(run func(ctx context.Context)
 	return _c
 }
 
+// JobRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) JobRepository() ports.JobRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobRepository")
+	}
+
+	var r0 ports.JobRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.JobRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.JobRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_JobRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JobRepository'
+type UnitOfWorkMock_JobRepository_Call struct {
+	*mock.Call
+}
+
+// JobRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) JobRepository() *UnitOfWorkMock_JobRepository_Call {
+	return &UnitOfWorkMock_JobRepository_Call{Call: _e.mock.On("JobRepository")}
+}
+
+func (_c *UnitOfWorkMock_JobRepository_Call) Run(run func()) *UnitOfWorkMock_JobRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_JobRepository_Call) Return(jobRepository ports.JobRepository) *UnitOfWorkMock_JobRepository_Call {
+	_c.Call.Return(jobRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_JobRepository_Call) RunAndReturn(run func() ports.JobRepository) *UnitOfWorkMock_JobRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Logger provides a mock function for the type UnitOfWorkMock
 func (_mock *UnitOfWorkMock) Logger() ports.Logger {
 	ret := _mock.Called()
@@ -232,6 +370,98 @@ func (_c *UnitOfWorkMock_Logger_Call) RunAndReturn(run func() ports.Logger) *Uni
 	return _c
 }
 
+// OutboxRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) OutboxRepository() ports.OutboxRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutboxRepository")
+	}
+
+	var r0 ports.OutboxRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.OutboxRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.OutboxRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_OutboxRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OutboxRepository'
+type UnitOfWorkMock_OutboxRepository_Call struct {
+	*mock.Call
+}
+
+// OutboxRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) OutboxRepository() *UnitOfWorkMock_OutboxRepository_Call {
+	return &UnitOfWorkMock_OutboxRepository_Call{Call: _e.mock.On("OutboxRepository")}
+}
+
+func (_c *UnitOfWorkMock_OutboxRepository_Call) Run(run func()) *UnitOfWorkMock_OutboxRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_OutboxRepository_Call) Return(outboxRepository ports.OutboxRepository) *UnitOfWorkMock_OutboxRepository_Call {
+	_c.Call.Return(outboxRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_OutboxRepository_Call) RunAndReturn(run func() ports.OutboxRepository) *UnitOfWorkMock_OutboxRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefundRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) RefundRepository() ports.RefundRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundRepository")
+	}
+
+	var r0 ports.RefundRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.RefundRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.RefundRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_RefundRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefundRepository'
+type UnitOfWorkMock_RefundRepository_Call struct {
+	*mock.Call
+}
+
+// RefundRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) RefundRepository() *UnitOfWorkMock_RefundRepository_Call {
+	return &UnitOfWorkMock_RefundRepository_Call{Call: _e.mock.On("RefundRepository")}
+}
+
+func (_c *UnitOfWorkMock_RefundRepository_Call) Run(run func()) *UnitOfWorkMock_RefundRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_RefundRepository_Call) Return(refundRepository ports.RefundRepository) *UnitOfWorkMock_RefundRepository_Call {
+	_c.Call.Return(refundRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_RefundRepository_Call) RunAndReturn(run func() ports.RefundRepository) *UnitOfWorkMock_RefundRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RollbackUnlessCommitted provides a mock function for the type UnitOfWorkMock
 func (_mock *UnitOfWorkMock) RollbackUnlessCommitted() error {
 	ret := _mock.Called()
@@ -276,6 +506,98 @@ func (_c *UnitOfWorkMock_RollbackUnlessCommitted_Call) RunAndReturn(run func() e
 	return _c
 }
 
+// SettingsRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) SettingsRepository() ports.SettingsRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettingsRepository")
+	}
+
+	var r0 ports.SettingsRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.SettingsRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.SettingsRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_SettingsRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettingsRepository'
+type UnitOfWorkMock_SettingsRepository_Call struct {
+	*mock.Call
+}
+
+// SettingsRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) SettingsRepository() *UnitOfWorkMock_SettingsRepository_Call {
+	return &UnitOfWorkMock_SettingsRepository_Call{Call: _e.mock.On("SettingsRepository")}
+}
+
+func (_c *UnitOfWorkMock_SettingsRepository_Call) Run(run func()) *UnitOfWorkMock_SettingsRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_SettingsRepository_Call) Return(settingsRepository ports.SettingsRepository) *UnitOfWorkMock_SettingsRepository_Call {
+	_c.Call.Return(settingsRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_SettingsRepository_Call) RunAndReturn(run func() ports.SettingsRepository) *UnitOfWorkMock_SettingsRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) TagRepository() ports.TagRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagRepository")
+	}
+
+	var r0 ports.TagRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.TagRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.TagRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_TagRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagRepository'
+type UnitOfWorkMock_TagRepository_Call struct {
+	*mock.Call
+}
+
+// TagRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) TagRepository() *UnitOfWorkMock_TagRepository_Call {
+	return &UnitOfWorkMock_TagRepository_Call{Call: _e.mock.On("TagRepository")}
+}
+
+func (_c *UnitOfWorkMock_TagRepository_Call) Run(run func()) *UnitOfWorkMock_TagRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_TagRepository_Call) Return(tagRepository ports.TagRepository) *UnitOfWorkMock_TagRepository_Call {
+	_c.Call.Return(tagRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_TagRepository_Call) RunAndReturn(run func() ports.TagRepository) *UnitOfWorkMock_TagRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransactionRepository provides a mock function for the type UnitOfWorkMock
 func (_mock *UnitOfWorkMock) TransactionRepository() ports.TransactionRepository {
 	ret := _mock.Called()
@@ -367,3 +689,49 @@ func (_c *UnitOfWorkMock_UserRepository_Call) RunAndReturn(run func() ports.User
 	_c.Call.Return(run)
 	return _c
 }
+
+// WebSessionRepository provides a mock function for the type UnitOfWorkMock
+func (_mock *UnitOfWorkMock) WebSessionRepository() ports.WebSessionRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebSessionRepository")
+	}
+
+	var r0 ports.WebSessionRepository
+	if returnFunc, ok := ret.Get(0).(func() ports.WebSessionRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.WebSessionRepository)
+		}
+	}
+	return r0
+}
+
+// UnitOfWorkMock_WebSessionRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebSessionRepository'
+type UnitOfWorkMock_WebSessionRepository_Call struct {
+	*mock.Call
+}
+
+// WebSessionRepository is a helper method to define mock.On call
+func (_e *UnitOfWorkMock_Expecter) WebSessionRepository() *UnitOfWorkMock_WebSessionRepository_Call {
+	return &UnitOfWorkMock_WebSessionRepository_Call{Call: _e.mock.On("WebSessionRepository")}
+}
+
+func (_c *UnitOfWorkMock_WebSessionRepository_Call) Run(run func()) *UnitOfWorkMock_WebSessionRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnitOfWorkMock_WebSessionRepository_Call) Return(webSessionRepository ports.WebSessionRepository) *UnitOfWorkMock_WebSessionRepository_Call {
+	_c.Call.Return(webSessionRepository)
+	return _c
+}
+
+func (_c *UnitOfWorkMock_WebSessionRepository_Call) RunAndReturn(run func() ports.WebSessionRepository) *UnitOfWorkMock_WebSessionRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,6 +7,7 @@ package portsmocks
 import (
 	"context"
 
+	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/shared"
 	"github.com/Nemizar/coin_tamer_bot/internal/core/domain/models/user"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -168,3 +169,213 @@ func (_c *UserRepositoryMock_FindByExternalProvider_Call) RunAndReturn(run func(
 	_c.Call.Return(run)
 	return _c
 }
+
+// FindExternalIdentities provides a mock function for the type UserRepositoryMock
+func (_mock *UserRepositoryMock) FindExternalIdentities(ctx context.Context, userID shared.ID) ([]*user.ExternalIdentity, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExternalIdentities")
+	}
+
+	var r0 []*user.ExternalIdentity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) ([]*user.ExternalIdentity, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) []*user.ExternalIdentity); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*user.ExternalIdentity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepositoryMock_FindExternalIdentities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExternalIdentities'
+type UserRepositoryMock_FindExternalIdentities_Call struct {
+	*mock.Call
+}
+
+// FindExternalIdentities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+func (_e *UserRepositoryMock_Expecter) FindExternalIdentities(ctx interface{}, userID interface{}) *UserRepositoryMock_FindExternalIdentities_Call {
+	return &UserRepositoryMock_FindExternalIdentities_Call{Call: _e.mock.On("FindExternalIdentities", ctx, userID)}
+}
+
+func (_c *UserRepositoryMock_FindExternalIdentities_Call) Run(run func(ctx context.Context, userID shared.ID)) *UserRepositoryMock_FindExternalIdentities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepositoryMock_FindExternalIdentities_Call) Return(externalIdentitys []*user.ExternalIdentity, err error) *UserRepositoryMock_FindExternalIdentities_Call {
+	_c.Call.Return(externalIdentitys, err)
+	return _c
+}
+
+func (_c *UserRepositoryMock_FindExternalIdentities_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID) ([]*user.ExternalIdentity, error)) *UserRepositoryMock_FindExternalIdentities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type UserRepositoryMock
+func (_mock *UserRepositoryMock) Get(ctx context.Context, id shared.ID) (*user.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) (*user.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID) *user.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepositoryMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type UserRepositoryMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id shared.ID
+func (_e *UserRepositoryMock_Expecter) Get(ctx interface{}, id interface{}) *UserRepositoryMock_Get_Call {
+	return &UserRepositoryMock_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *UserRepositoryMock_Get_Call) Run(run func(ctx context.Context, id shared.ID)) *UserRepositoryMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepositoryMock_Get_Call) Return(user1 *user.User, err error) *UserRepositoryMock_Get_Call {
+	_c.Call.Return(user1, err)
+	return _c
+}
+
+func (_c *UserRepositoryMock_Get_Call) RunAndReturn(run func(ctx context.Context, id shared.ID) (*user.User, error)) *UserRepositoryMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExternalIdentity provides a mock function for the type UserRepositoryMock
+func (_mock *UserRepositoryMock) GetExternalIdentity(ctx context.Context, userID shared.ID, provider user.Provider) (*user.ExternalIdentity, error) {
+	ret := _mock.Called(ctx, userID, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExternalIdentity")
+	}
+
+	var r0 *user.ExternalIdentity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, user.Provider) (*user.ExternalIdentity, error)); ok {
+		return returnFunc(ctx, userID, provider)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, shared.ID, user.Provider) *user.ExternalIdentity); ok {
+		r0 = returnFunc(ctx, userID, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.ExternalIdentity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, shared.ID, user.Provider) error); ok {
+		r1 = returnFunc(ctx, userID, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepositoryMock_GetExternalIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExternalIdentity'
+type UserRepositoryMock_GetExternalIdentity_Call struct {
+	*mock.Call
+}
+
+// GetExternalIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID shared.ID
+//   - provider user.Provider
+func (_e *UserRepositoryMock_Expecter) GetExternalIdentity(ctx interface{}, userID interface{}, provider interface{}) *UserRepositoryMock_GetExternalIdentity_Call {
+	return &UserRepositoryMock_GetExternalIdentity_Call{Call: _e.mock.On("GetExternalIdentity", ctx, userID, provider)}
+}
+
+func (_c *UserRepositoryMock_GetExternalIdentity_Call) Run(run func(ctx context.Context, userID shared.ID, provider user.Provider)) *UserRepositoryMock_GetExternalIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 shared.ID
+		if args[1] != nil {
+			arg1 = args[1].(shared.ID)
+		}
+		var arg2 user.Provider
+		if args[2] != nil {
+			arg2 = args[2].(user.Provider)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepositoryMock_GetExternalIdentity_Call) Return(externalIdentity *user.ExternalIdentity, err error) *UserRepositoryMock_GetExternalIdentity_Call {
+	_c.Call.Return(externalIdentity, err)
+	return _c
+}
+
+func (_c *UserRepositoryMock_GetExternalIdentity_Call) RunAndReturn(run func(ctx context.Context, userID shared.ID, provider user.Provider) (*user.ExternalIdentity, error)) *UserRepositoryMock_GetExternalIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}